@@ -91,6 +91,9 @@ export interface PaginationInfo {
   pageSize: number;
   total: number;
   totalPages: number;
+  nextCursor?: string; // 游标分页：下一页游标
+  prevCursor?: string; // 游标分页：上一页游标
+  hasMore: boolean;
 }
 
 // 登录请求
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/interfaces/http/handlers"
+	"github.com/next-ai-ventus/server/internal/service"
+)
+
+// runExport 将全站内容导出为备份归档。-o 为 "-" 时写到标准输出
+func runExport(args []string) int {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	contentPath := flags.String("content", getEnv("CONTENT_PATH", "./content"), "content directory")
+	uploadsPath := flags.String("uploads", handlers.UploadsPath, "uploads directory")
+	format := flags.String("format", service.ArchiveZip, "archive format: zip or tar.gz")
+	output := flags.String("o", "", "output file, \"-\" for stdout (default ventus-backup-<time>.<format>)")
+	flags.Parse(args)
+
+	if !service.IsValidArchiveFormat(*format) {
+		log.Printf("Unsupported archive format %q", *format)
+		return 2
+	}
+
+	repo, err := openRepository(*contentPath)
+	if err != nil {
+		log.Printf("Failed to open repository: %v", err)
+		return 2
+	}
+	uploads, err := openUploads(*uploadsPath)
+	if err != nil {
+		log.Printf("Failed to open uploads storage: %v", err)
+		return 2
+	}
+	backupService := service.NewBackupService(repo, *contentPath, uploads)
+
+	var out io.Writer = os.Stdout
+	if *output != "-" {
+		if *output == "" {
+			*output = service.BackupFileName(*format, time.Now())
+		}
+		f, err := os.Create(*output)
+		if err != nil {
+			log.Printf("Failed to create %s: %v", *output, err)
+			return 2
+		}
+		defer f.Close()
+		out = f
+	}
+
+	manifest, err := backupService.Export(out, *format)
+	if err != nil {
+		log.Printf("Export failed: %v", err)
+		return 2
+	}
+
+	if *output != "-" {
+		fmt.Printf("exported %d posts, %d uploads (revisions: %t) to %s\n",
+			manifest.Posts, manifest.Uploads, manifest.Revisions, *output)
+	}
+	return 0
+}
+
+// runImport 校验并恢复备份归档。
+// 命令行恢复会直接改写内容文件，请在服务停止时执行；服务运行中请改用管理端 site.import。
+func runImport(args []string) int {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	contentPath := flags.String("content", getEnv("CONTENT_PATH", "./content"), "content directory")
+	uploadsPath := flags.String("uploads", handlers.UploadsPath, "uploads directory")
+	mode := flags.String("mode", service.RestoreMerge, "restore mode: merge or replace")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "usage: %s import [flags] <archive>\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+	if !service.IsValidRestoreMode(*mode) {
+		log.Printf("Unsupported restore mode %q", *mode)
+		return 2
+	}
+
+	f, err := os.Open(flags.Arg(0))
+	if err != nil {
+		log.Printf("Failed to open archive: %v", err)
+		return 2
+	}
+	defer f.Close()
+
+	repo, err := openRepository(*contentPath)
+	if err != nil {
+		log.Printf("Failed to open repository: %v", err)
+		return 2
+	}
+	uploads, err := openUploads(*uploadsPath)
+	if err != nil {
+		log.Printf("Failed to open uploads storage: %v", err)
+		return 2
+	}
+	backupService := service.NewBackupService(repo, *contentPath, uploads)
+
+	report, err := backupService.Import(f, *mode)
+	if err != nil {
+		log.Printf("Import failed: %v", err)
+		return 1
+	}
+
+	fmt.Printf("%s: %d posts restored, %d skipped; %d uploads restored, %d skipped; revisions: %t\n",
+		report.Mode, report.PostsAdded, report.PostsSkipped, report.UploadsAdded, report.UploadsSkipped,
+		report.Revisions)
+	for _, conflict := range report.Conflicts {
+		fmt.Printf("conflict: %s\n", conflict)
+	}
+	return 0
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/next-ai-ventus/server/internal/interfaces/http/handlers"
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+// runCheck 执行内容完整性检查，返回进程退出码（存在未修复的错误时为 1）。
+// 默认只报告问题；-fix 会修改内容文件，请在服务停止时执行，或改用管理端 content.check。
+func runCheck(args []string) int {
+	flags := flag.NewFlagSet("check", flag.ExitOnError)
+	contentPath := flags.String("content", getEnv("CONTENT_PATH", "./content"), "content directory")
+	uploadsPath := flags.String("uploads", handlers.UploadsPath, "uploads directory, empty to skip upload checks")
+	fix := flags.Bool("fix", false, "apply safe fixes (default is a dry-run)")
+	asJSON := flags.Bool("json", false, "print the report as JSON")
+	flags.Parse(args)
+
+	repo, err := openRepository(*contentPath)
+	if err != nil {
+		log.Printf("Failed to open repository: %v", err)
+		return 2
+	}
+	checker, ok := repo.(repository.ContentChecker)
+	if !ok {
+		log.Printf("Repository does not support content checks")
+		return 2
+	}
+
+	opts := repository.CheckOptions{Fix: *fix}
+	if *uploadsPath != "" {
+		uploads, err := openUploads(*uploadsPath)
+		if err != nil {
+			log.Printf("Failed to open uploads storage: %v", err)
+			return 2
+		}
+		opts.Uploads = uploads
+	}
+
+	report, err := checker.CheckContent(opts)
+	if err != nil {
+		log.Printf("Content check failed: %v", err)
+		return 2
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(report)
+	} else {
+		printCheckReport(os.Stdout, report)
+	}
+
+	if report.Count(repository.SeverityError) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// printCheckReport 以表格形式输出检查报告
+func printCheckReport(out io.Writer, report *repository.CheckReport) {
+	fixable := 0
+	tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	for _, issue := range report.Issues {
+		note := ""
+		switch {
+		case issue.Fixed:
+			note = "fixed: " + issue.Fix
+		case issue.Fix != "":
+			note = "fix: " + issue.Fix
+			fixable++
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", issue.Severity, issue.Kind, issue.Path, issue.Message, note)
+	}
+	tw.Flush()
+
+	fmt.Fprintf(out, "\nchecked %d post directories: %d errors, %d warnings, %d fixed\n",
+		report.Posts, report.Count(repository.SeverityError), report.Count(repository.SeverityWarning), report.Fixed)
+	if report.DryRun && fixable > 0 {
+		fmt.Fprintf(out, "dry-run: run with -fix to apply %d fixes\n", fixable)
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/interfaces/bff"
+	"github.com/next-ai-ventus/server/internal/interfaces/http/handlers"
+	"github.com/next-ai-ventus/server/internal/interfaces/static"
+	"github.com/next-ai-ventus/server/internal/service"
+	"github.com/next-ai-ventus/server/internal/site"
+)
+
+// runExportStatic 将已发布的文章导出为静态站点。
+// 默认增量导出：只重新生成输入变化的页面，-full 时全部重新生成
+func runExportStatic(args []string) int {
+	flags := flag.NewFlagSet("export-static", flag.ExitOnError)
+	contentPath := flags.String("content", getEnv("CONTENT_PATH", "./content"), "content directory")
+	uploadsPath := flags.String("uploads", handlers.UploadsPath, "uploads directory")
+	output := flags.String("o", "./public", "output directory")
+	baseURL := flags.String("base-url", getEnv("SITE_URL", ""), "site URL used in feeds and sitemap")
+	siteName := flags.String("site-name", getEnv("SITE_NAME", ""), "site name shown in header and feeds")
+	timeZone := flags.String("timezone", getEnv("SITE_TIMEZONE", ""), "site time zone used for archives (IANA name, default UTC)")
+	full := flags.Bool("full", false, "regenerate every page")
+	flags.Parse(args)
+
+	repo, err := openRepository(*contentPath)
+	if err != nil {
+		log.Printf("Failed to open repository: %v", err)
+		return 2
+	}
+	slugService := service.NewSlugService(repo)
+	postService := service.NewPostService(repo, slugService)
+	settings := site.Settings{Name: *siteName, TimeZone: *timeZone}
+	if _, err := time.LoadLocation(settings.TimeZone); err != nil {
+		log.Printf("Invalid time zone %q", settings.TimeZone)
+		return 2
+	}
+	indexService := service.NewIndexService(repo, settings.Location())
+	if err := indexService.Rebuild(); err != nil {
+		log.Printf("Failed to build post index: %v", err)
+		return 2
+	}
+	bffHandler := bff.NewHandler(postService, indexService, service.NewSearchService(repo), nil, nil, nil, nil, settings)
+
+	uploads, err := openUploads(*uploadsPath)
+	if err != nil {
+		log.Printf("Failed to open uploads storage: %v", err)
+		return 2
+	}
+
+	report, err := static.NewExporter(postService, indexService, bffHandler).Export(static.Options{
+		OutputDir: *output,
+		Uploads:   uploads,
+		BaseURL:   *baseURL,
+		Full:      *full,
+	})
+	if err != nil {
+		log.Printf("Export failed: %v", err)
+		return 2
+	}
+
+	for _, note := range report.Notes {
+		fmt.Printf("note: %s\n", note)
+	}
+	fmt.Printf("exported %d posts to %s (%d files written, %d unchanged, %d removed)\n",
+		report.Posts, *output, report.Written, report.Unchanged, report.Removed)
+	return 0
+}
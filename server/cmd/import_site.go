@@ -0,0 +1,100 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/interfaces/http/handlers"
+	"github.com/next-ai-ventus/server/internal/service"
+)
+
+// runImportSite 导入 Hugo、Hexo 或 Jekyll 站点目录中的文章，并写出 JSON 导入报告。
+// 导入直接写入内容目录，服务运行中执行时需随后调用管理端 index.rebuild（或重启服务）。
+func runImportSite(args []string) int {
+	flags := flag.NewFlagSet("import-site", flag.ExitOnError)
+	contentPath := flags.String("content", getEnv("CONTENT_PATH", "./content"), "content directory")
+	uploadsPath := flags.String("uploads", handlers.UploadsPath, "uploads directory")
+	format := flags.String("format", "", "site format: hugo, hexo or jekyll (default: detect)")
+	dryRun := flags.Bool("dry-run", false, "only report what would be imported")
+	reportPath := flags.String("report", "", "report file (default import-report-<time>.json)")
+	editor := flags.String("editor", "", "commit author when CONTENT_GIT is enabled")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "usage: %s import-site [flags] <site directory>\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+	if !service.IsValidSiteFormat(*format) {
+		log.Printf("Unsupported site format %q", *format)
+		return 2
+	}
+
+	repo, err := openRepository(*contentPath)
+	if err != nil {
+		log.Printf("Failed to open repository: %v", err)
+		return 2
+	}
+	slugService := service.NewSlugService(repo)
+	postService := service.NewPostService(repo, slugService)
+	uploads, err := openUploads(*uploadsPath)
+	if err != nil {
+		log.Printf("Failed to open uploads storage: %v", err)
+		return 2
+	}
+	importService := service.NewImportService(postService, slugService, nil, uploads)
+
+	report, err := importService.ImportMarkdownSite(flags.Arg(0), service.ImportOptions{
+		Format: *format,
+		DryRun: *dryRun,
+		Editor: *editor,
+	})
+	if err != nil {
+		log.Printf("Import failed: %v", err)
+		return 2
+	}
+
+	printImportReport(os.Stdout, report)
+
+	if *reportPath == "" {
+		*reportPath = fmt.Sprintf("import-report-%s.json", time.Now().Format("20060102-150405"))
+	}
+	if err := service.WriteReport(*reportPath, report); err != nil {
+		log.Printf("Failed to write report: %v", err)
+		return 2
+	}
+	fmt.Printf("report written to %s\n", *reportPath)
+
+	if report.Failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// printImportReport 以表格形式输出导入报告
+func printImportReport(out io.Writer, report *service.ImportReport) {
+	tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	for _, item := range report.Items {
+		detail := item.Error
+		if detail == "" && len(item.Warnings) > 0 {
+			detail = fmt.Sprintf("%d warnings", len(item.Warnings))
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", item.Result, item.Status, item.Source, item.Slug, detail)
+	}
+	tw.Flush()
+
+	prefix := ""
+	if report.DryRun {
+		prefix = "dry-run: "
+	}
+	fmt.Fprintf(out, "\n%simported %d posts from %s site (%d skipped, %d failed, %d images)\n",
+		prefix, report.Created, report.Format, report.Skipped, report.Failed, report.Images)
+}
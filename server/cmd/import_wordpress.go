@@ -0,0 +1,95 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/interfaces/http/handlers"
+	"github.com/next-ai-ventus/server/internal/repository/file"
+	"github.com/next-ai-ventus/server/internal/service"
+)
+
+// runImportWordPress 导入 WordPress 导出文件（WXR），旧固定链接保存为重定向。
+// 与 import-site 相同，服务运行中执行时需随后调用管理端 index.rebuild（或重启服务）。
+func runImportWordPress(args []string) int {
+	flags := flag.NewFlagSet("import-wordpress", flag.ExitOnError)
+	contentPath := flags.String("content", getEnv("CONTENT_PATH", "./content"), "content directory")
+	uploadsPath := flags.String("uploads", handlers.UploadsPath, "uploads directory")
+	includePages := flags.Bool("pages", false, "also import pages (tagged \"page\")")
+	dryRun := flags.Bool("dry-run", false, "only report what would be imported")
+	reportPath := flags.String("report", "", "report file (default import-report-<time>.json)")
+	editor := flags.String("editor", "", "commit author for posts without an author when CONTENT_GIT is enabled")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "usage: %s import-wordpress [flags] <export.xml>\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+
+	f, err := os.Open(flags.Arg(0))
+	if err != nil {
+		log.Printf("Failed to open export file: %v", err)
+		return 2
+	}
+	defer f.Close()
+
+	repo, err := openRepository(*contentPath)
+	if err != nil {
+		log.Printf("Failed to open repository: %v", err)
+		return 2
+	}
+	redirectRepo, err := file.NewFileRedirectRepository(*contentPath)
+	if err != nil {
+		log.Printf("Failed to load redirects: %v", err)
+		return 2
+	}
+	slugService := service.NewSlugService(repo)
+	postService := service.NewPostService(repo, slugService)
+	redirectService := service.NewRedirectService(redirectRepo, repo)
+	uploads, err := openUploads(*uploadsPath)
+	if err != nil {
+		log.Printf("Failed to open uploads storage: %v", err)
+		return 2
+	}
+	importService := service.NewImportService(postService, slugService, redirectService, uploads)
+
+	report, err := importService.ImportWordPress(f, filepath.Base(flags.Arg(0)), service.ImportOptions{
+		DryRun:       *dryRun,
+		Editor:       *editor,
+		IncludePages: *includePages,
+		Progress: func(done, total int) {
+			fmt.Fprintf(os.Stderr, "\rimporting %d/%d", done, total)
+			if done == total {
+				fmt.Fprintln(os.Stderr)
+			}
+		},
+	})
+	if err != nil {
+		log.Printf("Import failed: %v", err)
+		return 2
+	}
+
+	printImportReport(os.Stdout, report)
+
+	if *reportPath == "" {
+		*reportPath = fmt.Sprintf("import-report-%s.json", time.Now().Format("20060102-150405"))
+	}
+	if err := service.WriteReport(*reportPath, report); err != nil {
+		log.Printf("Failed to write report: %v", err)
+		return 2
+	}
+	fmt.Printf("report written to %s\n", *reportPath)
+
+	if report.Failed > 0 {
+		return 1
+	}
+	return 0
+}
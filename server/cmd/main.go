@@ -1,49 +1,119 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
-	"github.com/next-ai-ventus/server/internal/interfaces/bff"
 	httpInterface "github.com/next-ai-ventus/server/internal/interfaces/http"
+	"github.com/next-ai-ventus/server/internal/repository"
 	"github.com/next-ai-ventus/server/internal/repository/file"
-	"github.com/next-ai-ventus/server/internal/service"
+	"github.com/next-ai-ventus/server/internal/repository/git"
+	"github.com/next-ai-ventus/server/internal/site"
 )
 
+// commands 子命令，返回进程退出码
+var commands = map[string]func(args []string) int{
+	"check":            runCheck,
+	"export":           runExport,
+	"export-static":    runExportStatic,
+	"import":           runImport,
+	"import-site":      runImportSite,
+	"import-wordpress": runImportWordPress,
+}
+
 func main() {
+	// 子命令
+	if len(os.Args) > 1 {
+		if run, ok := commands[os.Args[1]]; ok {
+			os.Exit(run(os.Args[2:]))
+		}
+	}
+
 	// 设置 Gin 模式
 	gin.SetMode(gin.ReleaseMode)
 
 	// 获取配置
-	contentPath := getEnv("CONTENT_PATH", "./content")
 	jwtSecret := getEnv("JWT_SECRET", "your-secret-key")
 	port := getEnv("PORT", "8080")
 
-	// 初始化仓库
-	repo, err := file.NewFilePostRepository(contentPath)
+	// 加载站点配置（SITES_CONFIG），每个站点拥有独立的内容、上传目录与管理员
+	sites, err := loadSites(jwtSecret)
 	if err != nil {
-		log.Fatalf("Failed to initialize repository: %v", err)
+		log.Fatalf("Failed to load sites: %v", err)
 	}
 
-	// 初始化服务
-	slugService := service.NewSlugService(repo)
-	postService := service.NewPostService(repo, slugService)
-	indexService := service.NewIndexService(repo)
-	authService := service.NewAuthService(jwtSecret)
+	// 各站点退出前的清理（写入缓存的浏览量等），初始化失败时同样执行已启动站点的清理
+	var shutdownHooks []func() error
+	router, err := httpInterface.NewSiteRouter(sites, func(def *site.Definition) (http.Handler, error) {
+		handler, hooks, err := buildSite(def, sites.TrustedProxies)
+		shutdownHooks = append(shutdownHooks, hooks...)
+		return handler, err
+	})
+	if err != nil {
+		runShutdownHooks(shutdownHooks)
+		log.Fatalf("Failed to initialize sites: %v", err)
+	}
 
-	// 初始化 BFF 处理器
-	bffHandler := bff.NewHandler(postService, indexService)
+	// 启动服务器，收到 SIGINT/SIGTERM 后停止接收请求并执行退出前的清理
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// 设置路由
-	router := httpInterface.SetupRouter(postService, authService, bffHandler)
+	server := &http.Server{Addr: ":" + port, Handler: router}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Server shutdown: %v", err)
+		}
+	}()
 
-	// 启动服务器
-	log.Printf("Server starting on port %s...", port)
-	if err := router.Run(":" + port); err != nil {
+	log.Printf("Server starting on port %s with %d site(s)...", port, len(sites.Sites))
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		log.Fatalf("Failed to start server: %v", err)
 	}
+
+	runShutdownHooks(shutdownHooks)
+	log.Printf("Server stopped")
+}
+
+// runShutdownHooks 依次执行退出前的清理，失败时记录日志并继续
+func runShutdownHooks(hooks []func() error) {
+	for _, hook := range hooks {
+		if err := hook(); err != nil {
+			log.Printf("Shutdown: %v", err)
+		}
+	}
+}
+
+// openRepository 创建文章仓库：
+//   - 内存只保留元数据，正文按需加载并缓存最近使用的 CONTENT_CACHE_SIZE 篇
+//   - 开启 CONTENT_GIT 后每次保存/删除都会在内容目录的 git 仓库中提交
+func openRepository(contentPath string) (repository.PostRepository, error) {
+	cacheSize, _ := strconv.Atoi(getEnv("CONTENT_CACHE_SIZE", ""))
+	fileRepo, err := file.NewFilePostRepositoryWithCacheSize(contentPath, cacheSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if getEnv("CONTENT_GIT", "") != "true" {
+		return fileRepo, nil
+	}
+	repo, err := git.NewGitPostRepository(fileRepo, contentPath)
+	if err != nil {
+		return nil, fmt.Errorf("initialize git repository: %w", err)
+	}
+	return repo, nil
 }
 
 func getEnv(key, defaultValue string) string {
@@ -0,0 +1,257 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/interfaces/bff"
+	httpInterface "github.com/next-ai-ventus/server/internal/interfaces/http"
+	"github.com/next-ai-ventus/server/internal/interfaces/http/handlers"
+	"github.com/next-ai-ventus/server/internal/mail"
+	"github.com/next-ai-ventus/server/internal/repository/file"
+	"github.com/next-ai-ventus/server/internal/service"
+	"github.com/next-ai-ventus/server/internal/site"
+	"github.com/next-ai-ventus/server/internal/storage"
+)
+
+// loadSites 读取 SITES_CONFIG；未配置时由环境变量组成单站点配置（可信代理取自 TRUSTED_PROXIES，逗号分隔）
+func loadSites(jwtSecret string) (*site.Config, error) {
+	if path := getEnv("SITES_CONFIG", ""); path != "" {
+		return site.LoadConfig(path, jwtSecret)
+	}
+
+	cfg := &site.Config{Sites: []*site.Definition{{
+		ID:          "default",
+		URL:         getEnv("SITE_URL", ""),
+		ContentPath: getEnv("CONTENT_PATH", "./content"),
+		UploadsPath: handlers.UploadsPath,
+		Storage:     storageFromEnv(),
+		Mail:        mailFromEnv(),
+		Users:       []site.User{{Username: "admin", Password: "admin"}},
+		Settings:    site.Settings{Name: getEnv("SITE_NAME", ""), TimeZone: getEnv("SITE_TIMEZONE", "")},
+	}}}
+	for _, proxy := range strings.Split(getEnv("TRUSTED_PROXIES", ""), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			cfg.TrustedProxies = append(cfg.TrustedProxies, proxy)
+		}
+	}
+	if err := cfg.Validate(jwtSecret); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// storageFromEnv 由环境变量组成单站点模式与命令行工具使用的上传存储配置
+func storageFromEnv() storage.Config {
+	return storage.Config{
+		Type: getEnv("UPLOADS_STORAGE", storage.TypeLocal),
+		S3: storage.S3Options{
+			Endpoint:  getEnv("S3_ENDPOINT", ""),
+			Region:    getEnv("S3_REGION", ""),
+			Bucket:    getEnv("S3_BUCKET", ""),
+			Prefix:    getEnv("S3_PREFIX", ""),
+			AccessKey: getEnv("S3_ACCESS_KEY", ""),
+			SecretKey: getEnv("S3_SECRET_KEY", ""),
+			PathStyle: getEnv("S3_PATH_STYLE", "") == "true",
+			Redirect:  getEnv("S3_REDIRECT", "") == "true",
+		},
+	}
+}
+
+// mailFromEnv 由环境变量组成单站点模式的邮件发送配置，未设置 MAIL_TYPE 时不发送邮件
+func mailFromEnv() mail.Config {
+	port, _ := strconv.Atoi(getEnv("SMTP_PORT", ""))
+	return mail.Config{
+		Type: getEnv("MAIL_TYPE", ""),
+		From: getEnv("MAIL_FROM", ""),
+		Dir:  getEnv("MAIL_DIR", ""),
+		SMTP: mail.SMTPOptions{
+			Host:     getEnv("SMTP_HOST", ""),
+			Port:     port,
+			Username: getEnv("SMTP_USERNAME", ""),
+			Password: getEnv("SMTP_PASSWORD", ""),
+		},
+	}
+}
+
+// openUploads 打开命令行工具使用的上传存储，本地存储时使用 dir 目录
+func openUploads(dir string) (storage.Blob, error) {
+	return storage.New(storageFromEnv(), dir)
+}
+
+// viewFlushInterval 浏览量写入内容目录的间隔
+const viewFlushInterval = time.Minute
+
+// webhookDeliveryInterval 检查到期重试的 Webhook 投递的间隔
+const webhookDeliveryInterval = 30 * time.Second
+
+// newsletterSendInterval 新文章邮件的发送间隔，每个间隔最多发送一批（service.DefaultNewsletterBatch 封）
+const newsletterSendInterval = time.Minute
+
+// buildSite 为站点创建独立的仓库、服务与路由（trustedProxies 见 site.Config），
+// 同时返回服务器退出前需依次执行的清理（写入缓存的浏览量等；出错时为已启动部分的清理）
+func buildSite(def *site.Definition, trustedProxies []string) (http.Handler, []func() error, error) {
+	var hooks []func() error
+
+	// 初始化仓库
+	repo, err := openRepository(def.ContentPath)
+	if err != nil {
+		return nil, hooks, fmt.Errorf("initialize repository: %w", err)
+	}
+
+	// 初始化服务
+	slugService := service.NewSlugService(repo)
+	postService := service.NewPostService(repo, slugService)
+	indexService := service.NewIndexService(repo, def.Settings.Location())
+	authService := service.NewSiteAuthService(def)
+
+	// 初始化上传存储
+	uploads, err := storage.New(def.Storage, def.UploadsPath)
+	if err != nil {
+		return nil, hooks, fmt.Errorf("initialize uploads storage: %w", err)
+	}
+	backupService := service.NewBackupService(repo, def.ContentPath, uploads)
+
+	// 初始化重定向（导入文章的旧地址）
+	redirectRepo, err := file.NewFileRedirectRepository(def.ContentPath)
+	if err != nil {
+		return nil, hooks, fmt.Errorf("load redirects: %w", err)
+	}
+	redirectService := service.NewRedirectService(redirectRepo, repo)
+	importService := service.NewImportService(postService, slugService, redirectService, uploads)
+
+	// 初始化搜索索引，并在文章变更时增量更新
+	searchService := service.NewSearchService(repo)
+	if err := searchService.Rebuild(); err != nil {
+		return nil, hooks, fmt.Errorf("build search index: %w", err)
+	}
+	postService.AddObserver(searchService)
+
+	// 初始化站内链接图（反向链接与失效链接检查），同样增量更新
+	linkService := service.NewLinkService(repo, uploads, service.LinkOptions{SiteURL: def.URL, PathPrefix: def.PathPrefix})
+	if err := linkService.Rebuild(); err != nil {
+		return nil, hooks, fmt.Errorf("build link graph: %w", err)
+	}
+	postService.AddObserver(linkService)
+
+	// 初始化文章索引（标签、归档等模块读取），同样增量更新
+	if err := indexService.Rebuild(); err != nil {
+		return nil, hooks, fmt.Errorf("build post index: %w", err)
+	}
+	postService.AddObserver(indexService)
+
+	// 初始化评论，删除文章时一并删除其评论
+	commentRepo, err := file.NewFileCommentRepository(def.ContentPath)
+	if err != nil {
+		return nil, hooks, fmt.Errorf("load comments: %w", err)
+	}
+	commentService := service.NewCommentService(commentRepo, repo)
+	postService.AddObserver(commentService)
+
+	// 初始化表情回应，访客标识的密钥由站点的 JWT 密钥派生，重启后仍能去重
+	reactionRepo, err := file.NewFileReactionRepository(def.ContentPath)
+	if err != nil {
+		return nil, hooks, fmt.Errorf("initialize reactions: %w", err)
+	}
+	reactionService, err := service.NewReactionService(reactionRepo, []byte(def.JWTSecret))
+	if err != nil {
+		return nil, hooks, fmt.Errorf("load reactions: %w", err)
+	}
+	postService.AddObserver(reactionService)
+
+	// 初始化公开提交的反垃圾检查，禁止列表与判定记录保存在内容目录；
+	// 表单令牌与记录中 IP 哈希的密钥由站点的 JWT 密钥派生，重启后仍有效
+	spamRepo, err := file.NewFileSpamRepository(def.ContentPath)
+	if err != nil {
+		return nil, hooks, fmt.Errorf("load spam data: %w", err)
+	}
+	spamService, err := service.NewSpamService(spamRepo, []byte(def.JWTSecret))
+	if err != nil {
+		return nil, hooks, fmt.Errorf("initialize spam checks: %w", err)
+	}
+
+	// 初始化浏览量统计：计数缓存在内存中，定期及退出时写入内容目录
+	viewRepo, err := file.NewFileViewRepository(def.ContentPath)
+	if err != nil {
+		return nil, hooks, fmt.Errorf("load views: %w", err)
+	}
+	viewService, err := service.NewViewService(viewRepo, def.Settings.Location(), service.DefaultViewWindow)
+	if err != nil {
+		return nil, hooks, fmt.Errorf("initialize view counter: %w", err)
+	}
+	viewService.Start(viewFlushInterval, func(err error) {
+		log.Printf("Site %s: failed to save views: %v", def.ID, err)
+	})
+	hooks = append(hooks, viewService.Close)
+
+	// 初始化 Webhook：文章与评论事件写入投递记录，由后台任务签名投递并按退避重试
+	webhookRepo, err := file.NewFileWebhookRepository(def.ContentPath)
+	if err != nil {
+		return nil, hooks, fmt.Errorf("load webhooks: %w", err)
+	}
+	webhookService, err := service.NewWebhookService(webhookRepo, repo)
+	if err != nil {
+		return nil, hooks, fmt.Errorf("initialize webhooks: %w", err)
+	}
+	postService.AddObserver(webhookService)
+	commentService.AddObserver(webhookService)
+	webhookService.Start(webhookDeliveryInterval, func(err error) {
+		log.Printf("Site %s: failed to deliver webhooks: %v", def.ID, err)
+	})
+	hooks = append(hooks, webhookService.Close)
+
+	// 初始化新文章邮件：未配置邮件发送时不接受订阅
+	newsletterRepo, err := file.NewFileNewsletterRepository(def.ContentPath)
+	if err != nil {
+		return nil, hooks, fmt.Errorf("load newsletter: %w", err)
+	}
+	mailer, err := mail.New(def.Mail)
+	if err != nil {
+		return nil, hooks, fmt.Errorf("initialize mail: %w", err)
+	}
+	newsletterService, err := service.NewNewsletterService(newsletterRepo, repo, mailer, service.NewsletterOptions{
+		SiteName: def.Settings.Name,
+		SiteURL:  def.URL,
+	})
+	if err != nil {
+		return nil, hooks, fmt.Errorf("initialize newsletter: %w", err)
+	}
+	postService.AddObserver(newsletterService)
+	newsletterService.Start(newsletterSendInterval, func(err error) {
+		log.Printf("Site %s: failed to send newsletter: %v", def.ID, err)
+	})
+	hooks = append(hooks, newsletterService.Close)
+
+	// 备份与恢复时与文章一同锁定内容目录下的站点数据
+	backupService.AddStores(redirectService, commentService, reactionService, spamService, viewService, webhookService, newsletterService)
+
+	// 初始化 BFF 处理器
+	bffHandler := bff.NewHandler(postService, indexService, searchService, viewService, commentService, reactionService, linkService, def.Settings)
+
+	router, err := httpInterface.SetupRouter(&handlers.Services{
+		PostService:       postService,
+		SearchService:     searchService,
+		IndexService:      indexService,
+		AuthService:       authService,
+		BackupService:     backupService,
+		ImportService:     importService,
+		RedirectService:   redirectService,
+		ViewService:       viewService,
+		CommentService:    commentService,
+		SpamService:       spamService,
+		ReactionService:   reactionService,
+		WebhookService:    webhookService,
+		NewsletterService: newsletterService,
+		LinkService:       linkService,
+		BFFHandler:        bffHandler,
+		Uploads:           uploads,
+	}, trustedProxies)
+	if err != nil {
+		return nil, hooks, fmt.Errorf("initialize router: %w", err)
+	}
+	return router, hooks, nil
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/next-ai-ventus/server/internal/domain/valueobject"
+)
+
+var (
+	ErrEmptyCommentAuthor   = errors.New("comment author cannot be empty")
+	ErrEmptyCommentBody     = errors.New("comment body cannot be empty")
+	ErrCommentAuthorTooLong = errors.New("comment author is too long")
+	ErrCommentBodyTooLong   = errors.New("comment body is too long")
+	ErrInvalidCommentEmail  = errors.New("invalid comment email")
+)
+
+// 评论长度上限（字符数）
+const (
+	MaxCommentAuthorLength = 50
+	MaxCommentBodyLength   = 5000
+)
+
+// Comment 文章评论。ParentID 非空时为对另一条评论的回复；
+// 只保存邮箱的哈希（可用于显示头像），不保存邮箱本身
+type Comment struct {
+	ID         string
+	PostID     string
+	ParentID   string
+	AuthorName string
+	EmailHash  string
+	Body       string // Markdown，显示时渲染为安全的 HTML
+	Status     valueobject.CommentStatus
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// NewComment 创建待审核的评论，email 可为空
+func NewComment(id, postID, parentID, authorName, email, body string) (*Comment, error) {
+	authorName = strings.TrimSpace(authorName)
+	if authorName == "" {
+		return nil, ErrEmptyCommentAuthor
+	}
+	if utf8.RuneCountInString(authorName) > MaxCommentAuthorLength {
+		return nil, ErrCommentAuthorTooLong
+	}
+
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, ErrEmptyCommentBody
+	}
+	if utf8.RuneCountInString(body) > MaxCommentBodyLength {
+		return nil, ErrCommentBodyTooLong
+	}
+
+	email = strings.TrimSpace(email)
+	if email != "" && !validEmail(email) {
+		return nil, ErrInvalidCommentEmail
+	}
+
+	now := time.Now()
+	return &Comment{
+		ID:         id,
+		PostID:     postID,
+		ParentID:   parentID,
+		AuthorName: authorName,
+		EmailHash:  HashEmail(email),
+		Body:       body,
+		Status:     valueobject.CommentPending,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}, nil
+}
+
+// SetStatus 修改审核状态
+func (c *Comment) SetStatus(status valueobject.CommentStatus) {
+	c.Status = status
+	c.UpdatedAt = time.Now()
+}
+
+// IsApproved 检查评论是否公开显示
+func (c *Comment) IsApproved() bool {
+	return c.Status.IsApproved()
+}
+
+// HashEmail 返回邮箱（去空白、转小写后）的 SHA-256，空邮箱返回空字符串
+func HashEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(email))
+	return hex.EncodeToString(sum[:])
+}
+
+// validEmail 简单检查邮箱格式：local@domain，domain 中含 "."
+func validEmail(email string) bool {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 || at == len(email)-1 || strings.ContainsAny(email, " \t\r\n<>") {
+		return false
+	}
+	domain := email[at+1:]
+	return strings.Contains(domain, ".") && !strings.HasPrefix(domain, ".") && !strings.HasSuffix(domain, ".")
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/next-ai-ventus/server/internal/domain/valueobject"
+)
+
+func TestNewComment(t *testing.T) {
+	comment, err := NewComment("c1", "p1", "", "  Alice ", " Alice@Example.com ", " Hello **world** ")
+	if err != nil {
+		t.Fatalf("NewComment() error = %v", err)
+	}
+	if comment.AuthorName != "Alice" || comment.Body != "Hello **world**" {
+		t.Errorf("NewComment() author = %q, body = %q, want trimmed", comment.AuthorName, comment.Body)
+	}
+	if comment.Status != valueobject.CommentPending {
+		t.Errorf("NewComment() status = %v, want pending", comment.Status)
+	}
+	if comment.EmailHash != HashEmail("alice@example.com") || strings.Contains(comment.EmailHash, "@") {
+		t.Errorf("NewComment() email hash = %q, want hash of normalized email", comment.EmailHash)
+	}
+
+	anonymous, err := NewComment("c2", "p1", "c1", "Bob", "", "Reply")
+	if err != nil || anonymous.EmailHash != "" {
+		t.Errorf("NewComment() without email = %v, %v, want empty hash", anonymous, err)
+	}
+}
+
+func TestNewCommentValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		author string
+		email  string
+		body   string
+		want   error
+	}{
+		{"empty author", " ", "", "body", ErrEmptyCommentAuthor},
+		{"empty body", "Alice", "", "\n", ErrEmptyCommentBody},
+		{"long author", strings.Repeat("名", MaxCommentAuthorLength+1), "", "body", ErrCommentAuthorTooLong},
+		{"long body", "Alice", "", strings.Repeat("a", MaxCommentBodyLength+1), ErrCommentBodyTooLong},
+		{"email without domain", "Alice", "alice@", "body", ErrInvalidCommentEmail},
+		{"email without dot", "Alice", "alice@localhost", "body", ErrInvalidCommentEmail},
+		{"email with markup", "Alice", "<a>@example.com", "body", ErrInvalidCommentEmail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewComment("c1", "p1", "", tt.author, tt.email, tt.body); err != tt.want {
+				t.Errorf("NewComment() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/next-ai-ventus/server/internal/domain/valueobject"
@@ -14,20 +15,25 @@ var (
 	ErrNotPublished     = errors.New("post is not published")
 )
 
+// ExcerptLength 自动生成摘要的最大字符数
+const ExcerptLength = 200
+
 // Post 是博客文章实体
 type Post struct {
-	ID          string
-	Title       string
-	Slug        valueobject.Slug
-	Content     string
-	Excerpt     string
-	Tags        []valueobject.Tag
-	Status      valueobject.PostStatus
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	PublishedAt *time.Time
-	Version     int
-	Cover       string
+	ID             string
+	Title          string
+	Slug           valueobject.Slug
+	Content        string
+	Excerpt        string
+	Tags           []valueobject.Tag
+	Status         valueobject.PostStatus
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	PublishedAt    *time.Time
+	Version        int
+	Cover          string
+	Category       string // 分类（可为空），与标签不同，每篇文章最多一个
+	CommentsClosed bool   // 关闭评论（已有评论仍然显示）
 }
 
 // NewPost 创建新文章
@@ -52,7 +58,7 @@ func NewPost(id, title string, slug valueobject.Slug, content string, tags []val
 		Version:   1,
 	}
 
-	post.GenerateExcerpt(200)
+	post.GenerateExcerpt(ExcerptLength)
 	return post, nil
 }
 
@@ -90,7 +96,7 @@ func (p *Post) UpdateContent(content string) error {
 	}
 
 	p.Content = content
-	p.GenerateExcerpt(200)
+	p.GenerateExcerpt(ExcerptLength)
 	p.UpdatedAt = time.Now()
 	p.Version++
 	return nil
@@ -115,6 +121,20 @@ func (p *Post) UpdateTags(tags []valueobject.Tag) {
 	p.Version++
 }
 
+// SetCategory 设置分类，空字符串表示清除
+func (p *Post) SetCategory(category string) {
+	p.Category = strings.TrimSpace(category)
+	p.UpdatedAt = time.Now()
+	p.Version++
+}
+
+// SetCommentsClosed 开启或关闭评论
+func (p *Post) SetCommentsClosed(closed bool) {
+	p.CommentsClosed = closed
+	p.UpdatedAt = time.Now()
+	p.Version++
+}
+
 // GenerateExcerpt 从内容生成摘要
 func (p *Post) GenerateExcerpt(maxLen int) {
 	if p.Content == "" {
@@ -125,8 +145,9 @@ func (p *Post) GenerateExcerpt(maxLen int) {
 	// 简单的纯文本提取（移除 Markdown 语法）
 	excerpt := extractPlainText(p.Content)
 	
-	if len(excerpt) > maxLen {
-		p.Excerpt = excerpt[:maxLen] + "..."
+	// 按字符截断，避免截断多字节字符
+	if runes := []rune(excerpt); len(runes) > maxLen {
+		p.Excerpt = string(runes[:maxLen]) + "..."
 	} else {
 		p.Excerpt = excerpt
 	}
@@ -186,29 +207,17 @@ func extractPlainText(markdown string) string {
 	return result
 }
 
+// removeAll 反复移除子串，直到不再出现（移除后可能拼出新的子串）
 func removeAll(s, substr string) string {
-	result := s
-	for {
-		newResult := ""
-		found := false
-		for i := 0; i < len(result); i++ {
-			if i+len(substr) <= len(result) && result[i:i+len(substr)] == substr {
-				found = true
-				i += len(substr) - 1
-				continue
-			}
-			newResult += string(result[i])
-		}
-		result = newResult
-		if !found {
-			break
-		}
+	for strings.Contains(s, substr) {
+		s = strings.ReplaceAll(s, substr, "")
 	}
-	return result
+	return s
 }
 
+// removeLinks 移除链接标记，只保留链接文本（按字节处理，保持 UTF-8 完整）
 func removeLinks(s string) string {
-	result := ""
+	var result strings.Builder
 	i := 0
 	for i < len(s) {
 		if s[i] == '[' {
@@ -230,13 +239,13 @@ func removeLinks(s string) string {
 			
 			if closeBracket != -1 && openParen != -1 && closeParen != -1 {
 				// 提取链接文本
-				result += s[i+1 : closeBracket]
+				result.WriteString(s[i+1 : closeBracket])
 				i = closeParen + 1
 				continue
 			}
 		}
-		result += string(s[i])
+		result.WriteByte(s[i])
 		i++
 	}
-	return result
+	return result.String()
 }
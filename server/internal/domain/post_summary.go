@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/domain/valueobject"
+)
+
+// PostSummary 是文章的摘要视图（不含正文），用于列表查询
+type PostSummary struct {
+	ID             string
+	Title          string
+	Slug           valueobject.Slug
+	Excerpt        string
+	Tags           []valueobject.Tag
+	Status         valueobject.PostStatus
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	PublishedAt    *time.Time
+	Version        int
+	Cover          string
+	Category       string
+	CommentsClosed bool
+}
+
+// Summary 生成文章的摘要视图（标签为副本）
+func (p *Post) Summary() *PostSummary {
+	tags := make([]valueobject.Tag, len(p.Tags))
+	copy(tags, p.Tags)
+
+	return &PostSummary{
+		ID:             p.ID,
+		Title:          p.Title,
+		Slug:           p.Slug,
+		Excerpt:        p.Excerpt,
+		Tags:           tags,
+		Status:         p.Status,
+		CreatedAt:      p.CreatedAt,
+		UpdatedAt:      p.UpdatedAt,
+		PublishedAt:    p.PublishedAt,
+		Version:        p.Version,
+		Cover:          p.Cover,
+		Category:       p.Category,
+		CommentsClosed: p.CommentsClosed,
+	}
+}
+
+// Clone 创建摘要的深拷贝
+func (s *PostSummary) Clone() *PostSummary {
+	clone := *s
+	clone.Tags = make([]valueobject.Tag, len(s.Tags))
+	copy(clone.Tags, s.Tags)
+	return &clone
+}
+
+// WithContent 结合正文还原完整文章
+func (s *PostSummary) WithContent(content string) *Post {
+	tags := make([]valueobject.Tag, len(s.Tags))
+	copy(tags, s.Tags)
+
+	return &Post{
+		ID:             s.ID,
+		Title:          s.Title,
+		Slug:           s.Slug,
+		Content:        content,
+		Excerpt:        s.Excerpt,
+		Tags:           tags,
+		Status:         s.Status,
+		CreatedAt:      s.CreatedAt,
+		UpdatedAt:      s.UpdatedAt,
+		PublishedAt:    s.PublishedAt,
+		Version:        s.Version,
+		Cover:          s.Cover,
+		Category:       s.Category,
+		CommentsClosed: s.CommentsClosed,
+	}
+}
+
+// GetTagNames 获取标签名称列表
+func (s *PostSummary) GetTagNames() []string {
+	names := make([]string, len(s.Tags))
+	for i, tag := range s.Tags {
+		names[i] = tag.String()
+	}
+	return names
+}
+
+// HasTag 检查是否有指定标签
+func (s *PostSummary) HasTag(tagName string) bool {
+	for _, tag := range s.Tags {
+		if tag.String() == tagName {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPublished 检查文章是否已发布
+func (s *PostSummary) IsPublished() bool {
+	return s.Status.IsPublished()
+}
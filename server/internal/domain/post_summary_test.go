@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/next-ai-ventus/server/internal/domain/valueobject"
+)
+
+func TestPostSummary(t *testing.T) {
+	slug, _ := valueobject.NewSlug("test-post")
+	tag, _ := valueobject.NewTag("go")
+	post, _ := NewPost("1", "Test", slug, "Content", []valueobject.Tag{tag})
+	post.Publish()
+
+	summary := post.Summary()
+	if summary.ID != post.ID || summary.Title != post.Title || summary.Excerpt != post.Excerpt {
+		t.Errorf("Summary() = %+v, want fields of %+v", summary, post)
+	}
+	if !summary.IsPublished() || !summary.HasTag("go") {
+		t.Error("Summary() should keep status and tags")
+	}
+
+	// 标签为副本，修改摘要不影响原文章
+	other, _ := valueobject.NewTag("rust")
+	summary.Tags[0] = other
+	if !post.HasTag("go") {
+		t.Error("Summary() should copy tags")
+	}
+
+	restored := summary.WithContent("Body")
+	if restored.Content != "Body" || restored.Version != post.Version {
+		t.Errorf("WithContent() = %+v", restored)
+	}
+	if names := restored.GetTagNames(); len(names) != 1 || names[0] != "rust" {
+		t.Errorf("WithContent() tags = %v, want [rust]", names)
+	}
+}
+
+func TestPostSummaryClone(t *testing.T) {
+	slug, _ := valueobject.NewSlug("test-post")
+	tag, _ := valueobject.NewTag("go")
+	post, _ := NewPost("1", "Test", slug, "Content", []valueobject.Tag{tag})
+
+	summary := post.Summary()
+	clone := summary.Clone()
+	clone.Title = "Changed"
+	clone.Tags[0], _ = valueobject.NewTag("rust")
+
+	if summary.Title != "Test" || !summary.HasTag("go") {
+		t.Errorf("Clone() should not share state, got %+v", summary)
+	}
+}
@@ -257,6 +257,12 @@ func TestPostGenerateExcerpt(t *testing.T) {
 			maxLen:   100,
 			expected: "Title\n\nBold text", // 简化实现保留换行
 		},
+		{
+			name:     "chinese content truncated by rune",
+			content:  "# 测试\n\n" + strings.Repeat("中文", 60),
+			maxLen:   5,
+			expected: "测试\n\n中...",
+		},
 		{
 			name:     "content with link",
 			content:  "Check [this link](http://example.com) out",
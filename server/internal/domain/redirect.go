@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var ErrInvalidRedirectPath = errors.New("invalid redirect path")
+
+// redirectQueryKeys 需要保留的查询参数（WordPress 短链接 /?p=123、/?page_id=7）
+var redirectQueryKeys = []string{"p", "page_id"}
+
+// Redirect 旧地址到文章的永久重定向（如导入的 WordPress 固定链接）
+type Redirect struct {
+	From      string // 规范化后的路径
+	PostID    string
+	CreatedAt time.Time
+}
+
+// NormalizeRedirectPath 将 URL 或路径规范化为重定向键：
+// 去掉协议、主机与末尾的 "/"，只保留 WordPress 短链接使用的查询参数
+func NormalizeRedirectPath(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", ErrInvalidRedirectPath
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", ErrInvalidRedirectPath
+	}
+
+	path := u.Path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	if len(path) > 1 {
+		path = strings.TrimRight(path, "/")
+	}
+
+	query := u.Query()
+	for _, key := range redirectQueryKeys {
+		if value := query.Get(key); value != "" {
+			return path + "?" + key + "=" + url.QueryEscape(value), nil
+		}
+	}
+
+	if path == "/" {
+		return "", ErrInvalidRedirectPath
+	}
+	return path, nil
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "testing"
+
+func TestNormalizeRedirectPath(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{raw: "https://old.example.com/2015/03/hello-world/", want: "/2015/03/hello-world"},
+		{raw: "/2015/03/hello-world", want: "/2015/03/hello-world"},
+		{raw: "hello-world", want: "/hello-world"},
+		{raw: "https://old.example.com/?p=123", want: "/?p=123"},
+		{raw: "/about/?utm_source=x", want: "/about"},
+		{raw: "https://old.example.com/%E4%B8%AD%E6%96%87/", want: "/中文"},
+		{raw: "https://old.example.com/", wantErr: true},
+		{raw: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, err := NormalizeRedirectPath(tt.raw)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("NormalizeRedirectPath(%q) = %q, want error", tt.raw, got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("NormalizeRedirectPath(%q) = %q, %v, want %q", tt.raw, got, err, tt.want)
+			}
+		})
+	}
+}
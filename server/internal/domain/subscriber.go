@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var ErrInvalidSubscriberEmail = errors.New("invalid subscriber email")
+
+// SubscriberStatus 订阅状态
+type SubscriberStatus string
+
+const (
+	SubscriberPending   SubscriberStatus = "pending"   // 已提交，等待点击确认邮件中的链接
+	SubscriberConfirmed SubscriberStatus = "confirmed" // 已确认，发布新文章时收到邮件
+)
+
+// Subscriber 新文章邮件的订阅者（双重确认）。
+// ConfirmToken 只在确认前有效；UnsubscribeToken 用于每封邮件中的一键退订链接
+type Subscriber struct {
+	Email            string // 去空白并转为小写
+	Status           SubscriberStatus
+	ConfirmToken     string
+	UnsubscribeToken string
+	CreatedAt        time.Time
+	ConfirmedAt      time.Time
+}
+
+// NewSubscriber 创建待确认的订阅者
+func NewSubscriber(email, confirmToken, unsubscribeToken string) (*Subscriber, error) {
+	email = NormalizeEmail(email)
+	if !validEmail(email) {
+		return nil, ErrInvalidSubscriberEmail
+	}
+	return &Subscriber{
+		Email:            email,
+		Status:           SubscriberPending,
+		ConfirmToken:     confirmToken,
+		UnsubscribeToken: unsubscribeToken,
+		CreatedAt:        time.Now(),
+	}, nil
+}
+
+// Confirm 确认订阅，确认令牌随即失效
+func (s *Subscriber) Confirm(now time.Time) {
+	s.Status = SubscriberConfirmed
+	s.ConfirmToken = ""
+	s.ConfirmedAt = now
+}
+
+// IsConfirmed 检查是否已确认订阅
+func (s *Subscriber) IsConfirmed() bool {
+	return s.Status == SubscriberConfirmed
+}
+
+// NormalizeEmail 去掉邮箱两端空白并转为小写
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// NewsletterIssue 一篇新文章的通知邮件及其发送进度
+type NewsletterIssue struct {
+	PostID      string
+	Title       string
+	Pending     []string // 尚未发送的订阅者邮箱（按顺序发送）
+	Sent        int
+	Failed      int
+	LastError   string
+	Canceled    bool // 发送完成前文章被删除
+	CreatedAt   time.Time
+	CompletedAt time.Time
+}
+
+// Done 检查是否已发送完毕（或已取消）
+func (i *NewsletterIssue) Done() bool {
+	return len(i.Pending) == 0
+}
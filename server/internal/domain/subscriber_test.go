@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewSubscriber(t *testing.T) {
+	s, err := NewSubscriber("  Reader@Example.COM ", "confirm", "unsubscribe")
+	if err != nil {
+		t.Fatalf("NewSubscriber() error = %v", err)
+	}
+	if s.Email != "reader@example.com" || s.Status != SubscriberPending || s.IsConfirmed() {
+		t.Errorf("NewSubscriber() = %+v, want pending with normalized email", s)
+	}
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.Confirm(now)
+	if !s.IsConfirmed() || s.ConfirmToken != "" || !s.ConfirmedAt.Equal(now) {
+		t.Errorf("after Confirm() = %+v, want confirmed with the token cleared", s)
+	}
+
+	for _, email := range []string{"", "reader", "reader@localhost", "a b@example.com"} {
+		if _, err := NewSubscriber(email, "c", "u"); err != ErrInvalidSubscriberEmail {
+			t.Errorf("NewSubscriber(%q) error = %v, want ErrInvalidSubscriberEmail", email, err)
+		}
+	}
+}
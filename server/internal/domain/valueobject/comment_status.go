@@ -0,0 +1,43 @@
+package valueobject
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CommentStatus 表示评论审核状态
+type CommentStatus string
+
+const (
+	CommentPending  CommentStatus = "pending"  // 待审核（新评论的默认状态）
+	CommentApproved CommentStatus = "approved" // 已通过，公开显示
+	CommentSpam     CommentStatus = "spam"     // 垃圾评论
+	CommentDeleted  CommentStatus = "deleted"  // 已删除（保留记录，回复下显示为已删除）
+)
+
+var (
+	ValidCommentStatuses    = []CommentStatus{CommentPending, CommentApproved, CommentSpam, CommentDeleted}
+	ErrInvalidCommentStatus = errors.New("invalid comment status")
+)
+
+// NewCommentStatus 从字符串创建 CommentStatus
+func NewCommentStatus(raw string) (CommentStatus, error) {
+	switch CommentStatus(raw) {
+	case CommentPending, "":
+		return CommentPending, nil
+	case CommentApproved, CommentSpam, CommentDeleted:
+		return CommentStatus(raw), nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidCommentStatus, raw)
+	}
+}
+
+// String 返回状态字符串
+func (s CommentStatus) String() string {
+	return string(s)
+}
+
+// IsApproved 检查是否已通过审核
+func (s CommentStatus) IsApproved() bool {
+	return s == CommentApproved
+}
@@ -0,0 +1,38 @@
+package valueobject
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewCommentStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    CommentStatus
+		wantErr bool
+	}{
+		{"pending", "pending", CommentPending, false},
+		{"approved", "approved", CommentApproved, false},
+		{"spam", "spam", CommentSpam, false},
+		{"deleted", "deleted", CommentDeleted, false},
+		{"empty string defaults to pending", "", CommentPending, false},
+		{"unknown status", "published", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewCommentStatus(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewCommentStatus(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && !errors.Is(err, ErrInvalidCommentStatus) {
+				t.Errorf("NewCommentStatus(%q) error = %v, want ErrInvalidCommentStatus", tt.raw, err)
+			}
+			if got != tt.want {
+				t.Errorf("NewCommentStatus(%q) = %v, want %v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package valueobject
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Reaction 表示读者对文章的表情回应（固定的几种）
+type Reaction string
+
+const (
+	ReactionLike  Reaction = "like"
+	ReactionLove  Reaction = "love"
+	ReactionLaugh Reaction = "laugh"
+	ReactionWow   Reaction = "wow"
+	ReactionClap  Reaction = "clap"
+)
+
+var (
+	ValidReactions     = []Reaction{ReactionLike, ReactionLove, ReactionLaugh, ReactionWow, ReactionClap}
+	ErrInvalidReaction = errors.New("invalid reaction")
+)
+
+// reactionEmoji 表情回应对应的 emoji
+var reactionEmoji = map[Reaction]string{
+	ReactionLike:  "👍",
+	ReactionLove:  "❤️",
+	ReactionLaugh: "😂",
+	ReactionWow:   "😮",
+	ReactionClap:  "👏",
+}
+
+// NewReaction 从字符串创建 Reaction
+func NewReaction(raw string) (Reaction, error) {
+	reaction := Reaction(raw)
+	if _, ok := reactionEmoji[reaction]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrInvalidReaction, raw)
+	}
+	return reaction, nil
+}
+
+// String 返回表情回应的名称
+func (r Reaction) String() string {
+	return string(r)
+}
+
+// Emoji 返回表情回应对应的 emoji
+func (r Reaction) Emoji() string {
+	return reactionEmoji[r]
+}
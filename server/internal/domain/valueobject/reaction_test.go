@@ -0,0 +1,24 @@
+package valueobject
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewReaction(t *testing.T) {
+	for _, reaction := range ValidReactions {
+		got, err := NewReaction(reaction.String())
+		if err != nil || got != reaction {
+			t.Errorf("NewReaction(%q) = %v, %v", reaction, got, err)
+		}
+		if got.Emoji() == "" {
+			t.Errorf("Reaction %q has no emoji", reaction)
+		}
+	}
+
+	for _, raw := range []string{"", "LIKE", "👍", "dislike"} {
+		if _, err := NewReaction(raw); !errors.Is(err, ErrInvalidReaction) {
+			t.Errorf("NewReaction(%q) error = %v, want ErrInvalidReaction", raw, err)
+		}
+	}
+}
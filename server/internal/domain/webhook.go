@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidWebhookURL   = errors.New("webhook url must be an absolute http or https url")
+	ErrInvalidWebhookEvent = errors.New("invalid webhook event")
+	ErrNoWebhookEvents     = errors.New("webhook must subscribe to at least one event")
+)
+
+// WebhookEvent 可订阅的内容变更事件
+type WebhookEvent string
+
+const (
+	EventPostPublished  WebhookEvent = "post.published"  // 文章发布
+	EventPostUpdated    WebhookEvent = "post.updated"    // 已发布的文章被修改（包括取消发布）
+	EventPostDeleted    WebhookEvent = "post.deleted"    // 已发布的文章被删除
+	EventCommentCreated WebhookEvent = "comment.created" // 收到新评论
+)
+
+// ValidWebhookEvents 全部可订阅的事件
+var ValidWebhookEvents = []WebhookEvent{EventPostPublished, EventPostUpdated, EventPostDeleted, EventCommentCreated}
+
+// DeliveryStatus 投递状态
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"   // 等待（重新）投递
+	DeliverySucceeded DeliveryStatus = "succeeded" // 接收方返回 2xx
+	DeliveryFailed    DeliveryStatus = "failed"    // 重试次数用尽
+)
+
+// Webhook 管理员注册的事件接收地址，请求体使用 Secret 做 HMAC-SHA256 签名
+type Webhook struct {
+	ID        string
+	URL       string
+	Secret    string
+	Events    []WebhookEvent
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewWebhook 创建启用状态的 Webhook
+func NewWebhook(id, rawURL, secret string, events []WebhookEvent) (*Webhook, error) {
+	webhook := &Webhook{ID: id, Secret: secret, Active: true}
+	if err := webhook.SetURL(rawURL); err != nil {
+		return nil, err
+	}
+	if err := webhook.SetEvents(events); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	webhook.CreatedAt = now
+	webhook.UpdatedAt = now
+	return webhook, nil
+}
+
+// SetURL 修改接收地址
+func (w *Webhook) SetURL(rawURL string) error {
+	rawURL = strings.TrimSpace(rawURL)
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+	w.URL = rawURL
+	w.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetEvents 修改订阅的事件（去重并按 ValidWebhookEvents 的顺序保存）
+func (w *Webhook) SetEvents(events []WebhookEvent) error {
+	selected := make(map[WebhookEvent]bool, len(events))
+	for _, event := range events {
+		if !event.IsValid() {
+			return ErrInvalidWebhookEvent
+		}
+		selected[event] = true
+	}
+	if len(selected) == 0 {
+		return ErrNoWebhookEvents
+	}
+
+	ordered := make([]WebhookEvent, 0, len(selected))
+	for _, event := range ValidWebhookEvents {
+		if selected[event] {
+			ordered = append(ordered, event)
+		}
+	}
+	w.Events = ordered
+	w.UpdatedAt = time.Now()
+	return nil
+}
+
+// Subscribes 检查是否启用并订阅了事件
+func (w *Webhook) Subscribes(event WebhookEvent) bool {
+	if !w.Active {
+		return false
+	}
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValid 检查是否为可订阅的事件
+func (e WebhookEvent) IsValid() bool {
+	for _, valid := range ValidWebhookEvents {
+		if e == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery 一次事件投递及其重试状态
+type WebhookDelivery struct {
+	ID            string
+	WebhookID     string
+	Event         WebhookEvent
+	Payload       string // 请求体（JSON）
+	Status        DeliveryStatus
+	Attempts      int
+	ResponseCode  int    // 最近一次请求的 HTTP 状态码，请求失败时为 0
+	LastError     string // 最近一次失败的原因
+	RedeliveryOf  string // 手动重新投递时为原投递的 ID
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	NextAttemptAt time.Time // 状态为 pending 时下一次投递的时间
+}
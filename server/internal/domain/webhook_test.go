@@ -0,0 +1,41 @@
+package domain
+
+import "testing"
+
+func TestNewWebhook(t *testing.T) {
+	webhook, err := NewWebhook("w1", " https://hooks.example.com/blog ", "secret",
+		[]WebhookEvent{EventCommentCreated, EventPostPublished, EventPostPublished})
+	if err != nil {
+		t.Fatalf("NewWebhook() error = %v", err)
+	}
+	if webhook.URL != "https://hooks.example.com/blog" || !webhook.Active {
+		t.Errorf("NewWebhook() = %+v", webhook)
+	}
+	if len(webhook.Events) != 2 || webhook.Events[0] != EventPostPublished || webhook.Events[1] != EventCommentCreated {
+		t.Errorf("Events = %v, want deduplicated in canonical order", webhook.Events)
+	}
+	if !webhook.Subscribes(EventPostPublished) || webhook.Subscribes(EventPostDeleted) {
+		t.Error("Subscribes() should follow the event filter")
+	}
+	webhook.Active = false
+	if webhook.Subscribes(EventPostPublished) {
+		t.Error("inactive webhook should not subscribe to any event")
+	}
+
+	tests := []struct {
+		name   string
+		url    string
+		events []WebhookEvent
+		want   error
+	}{
+		{"relative url", "/hooks", []WebhookEvent{EventPostPublished}, ErrInvalidWebhookURL},
+		{"unsupported scheme", "ftp://hooks.example.com", []WebhookEvent{EventPostPublished}, ErrInvalidWebhookURL},
+		{"no events", "https://hooks.example.com", nil, ErrNoWebhookEvents},
+		{"unknown event", "https://hooks.example.com", []WebhookEvent{"post.viewed"}, ErrInvalidWebhookEvent},
+	}
+	for _, tt := range tests {
+		if _, err := NewWebhook("w2", tt.url, "secret", tt.events); err != tt.want {
+			t.Errorf("%s: NewWebhook() error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
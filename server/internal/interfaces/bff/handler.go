@@ -2,12 +2,14 @@ package bff
 
 import (
 	"net/http"
+	"strings"
 	"sync"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/next-ai-ventus/server/internal/interfaces/bff/modules"
 	"github.com/next-ai-ventus/server/internal/service"
+	"github.com/next-ai-ventus/server/internal/site"
 )
 
 // Handler BFF 处理器
@@ -17,25 +19,49 @@ type Handler struct {
 }
 
 // NewHandler 创建 BFF 处理器
-func NewHandler(postService *service.PostService, indexService *service.IndexService) *Handler {
+func NewHandler(
+	postService *service.PostService,
+	indexService *service.IndexService,
+	searchService *service.SearchService,
+	viewService *service.ViewService,
+	commentService *service.CommentService,
+	reactionService *service.ReactionService,
+	linkService *service.LinkService,
+	settings site.Settings,
+) *Handler {
 	services := &modules.Services{
-		PostService:  postService,
-		IndexService: indexService,
+		PostService:     postService,
+		IndexService:    indexService,
+		SearchService:   searchService,
+		ViewService:     viewService,
+		CommentService:  commentService,
+		ReactionService: reactionService,
+		LinkService:     linkService,
+		Site:            settings,
 	}
 
 	return &Handler{
 		services: services,
 		registry: map[string]modules.ModuleHandler{
 			// ===== C 端 Home 页面模块（按前端组件粒度）=====
-			"Logo":       modules.HandleLogo,
-			"Nav":        modules.HandleNav,
-			"UserAction": modules.HandleUserAction,
-			"PostList":   modules.HandlePostList,
-			"TagCloud":   modules.HandleTagCloud,
-			"Footer":     modules.HandleFooter,
+			"Logo":         modules.HandleLogo,
+			"Nav":          modules.HandleNav,
+			"UserAction":   modules.HandleUserAction,
+			"PostList":     modules.HandlePostList,
+			"TagCloud":     modules.HandleTagCloud,
+			"Archive":      modules.HandleArchive,
+			"PopularPosts": modules.HandlePopularPosts,
+			"Footer":       modules.HandleFooter,
 
 			// ===== C 端 Post 页面模块 =====
-			"Article": modules.HandleArticle,
+			"Article":   modules.HandleArticle,
+			"Backlinks": modules.HandleBacklinks,
+
+			// ===== C 端 Search 页面模块 =====
+			"SearchResults": modules.HandleSearchResults,
+
+			// ===== C 端 Archive 页面模块 =====
+			"ArchiveList": modules.HandleArchiveList,
 
 			// ===== B 端 Admin 页面模块 =====
 			"adminSidebar":   modules.HandleAdminSidebar,
@@ -81,7 +107,8 @@ func (h *Handler) Handle(c *gin.Context) {
 	}
 
 	// 并行执行模块
-	results := h.ExecuteModules(req.Page, req.Modules, req.Params)
+	prefix := strings.TrimRight(c.GetHeader(site.ForwardedPrefixHeader), "/")
+	results := h.ExecuteModules(prefix, req.Page, req.Modules, req.Params)
 
 	c.JSON(http.StatusOK, PageResponse{
 		Page:    req.Page,
@@ -89,8 +116,8 @@ func (h *Handler) Handle(c *gin.Context) {
 	})
 }
 
-// ExecuteModules 并行执行模块（导出供 APIHandler 使用）
-func (h *Handler) ExecuteModules(page string, moduleNames []string, params map[string]interface{}) map[string]ModuleResult {
+// ExecuteModules 并行执行模块（导出供 APIHandler 使用），prefix 为站点路径前缀，模块返回的站内链接会带上它
+func (h *Handler) ExecuteModules(prefix, page string, moduleNames []string, params map[string]interface{}) map[string]ModuleResult {
 	results := make(map[string]ModuleResult)
 	var mu sync.Mutex
 	var wg sync.WaitGroup
@@ -113,6 +140,7 @@ func (h *Handler) ExecuteModules(page string, moduleNames []string, params map[s
 				Page:     page,
 				Params:   params,
 				Services: h.services,
+				Prefix:   prefix,
 			}
 
 			data, err := handler(ctx)
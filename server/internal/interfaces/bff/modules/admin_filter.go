@@ -2,8 +2,11 @@ package modules
 
 // AdminFilterData AdminFilter 模块数据
 type AdminFilterData struct {
-	StatusOptions []FilterOption `json:"statusOptions"`
-	AllTags       []string       `json:"allTags"`
+	StatusOptions  []FilterOption `json:"statusOptions"`
+	AllTags        []string       `json:"allTags"`
+	TagModeOptions []FilterOption `json:"tagModeOptions"`
+	OrderOptions   []FilterOption `json:"orderOptions"`
+	DateFields     []FilterOption `json:"dateFields"` // 可按范围筛选的时间字段（参数名为 <value>From/<value>To）
 }
 
 // FilterOption 筛选选项
@@ -27,5 +30,24 @@ func HandleAdminFilter(ctx *ModuleContext) (interface{}, error) {
 			{Value: "draft", Label: "草稿"},
 		},
 		AllTags: tags,
+		TagModeOptions: []FilterOption{
+			{Value: "and", Label: "包含全部标签"},
+			{Value: "or", Label: "包含任一标签"},
+		},
+		OrderOptions: []FilterOption{
+			{Value: "date_desc", Label: "创建时间（新→旧）"},
+			{Value: "date_asc", Label: "创建时间（旧→新）"},
+			{Value: "updated_desc", Label: "更新时间（新→旧）"},
+			{Value: "updated_asc", Label: "更新时间（旧→新）"},
+			{Value: "published_desc", Label: "发布时间（新→旧）"},
+			{Value: "published_asc", Label: "发布时间（旧→新）"},
+			{Value: "title_asc", Label: "标题（A→Z）"},
+			{Value: "title_desc", Label: "标题（Z→A）"},
+		},
+		DateFields: []FilterOption{
+			{Value: "created", Label: "创建时间"},
+			{Value: "published", Label: "发布时间"},
+			{Value: "updated", Label: "更新时间"},
+		},
 	}, nil
 }
@@ -20,14 +20,16 @@ type AdminPostListData struct {
 
 // AdminPostItem 管理端文章列表项
 type AdminPostItem struct {
-	ID        string   `json:"id"`
-	Title     string   `json:"title"`
-	Slug      string   `json:"slug"`
-	Status    string   `json:"status"`
-	Tags      []string `json:"tags"`
-	CreatedAt string   `json:"createdAt"`
-	UpdatedAt string   `json:"updatedAt"`
-	Href      string   `json:"href"`
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Slug        string   `json:"slug"`
+	Status      string   `json:"status"`
+	Tags        []string `json:"tags"`
+	CreatedAt   string   `json:"createdAt"`
+	UpdatedAt   string   `json:"updatedAt"`
+	PublishedAt string   `json:"publishedAt,omitempty"`
+	Views       int      `json:"views"`
+	Href        string   `json:"href"`
 }
 
 // AdminPaginationInfo 分页信息
@@ -41,19 +43,13 @@ type AdminPaginationInfo struct {
 // HandleAdminPostList 处理 AdminPostList 模块
 func HandleAdminPostList(ctx *ModuleContext) (interface{}, error) {
 	// 解析参数
-	page := 1
-	if p, ok := ctx.Params["page"].(float64); ok {
-		page = int(p)
+	opts := repository.ListOptions{
+		Page:     1,
+		PageSize: 20,
+		OrderBy:  "date_desc",
 	}
-
-	status := ""
-	if s, ok := ctx.Params["status"].(string); ok {
-		status = s
-	}
-
-	tag := ""
-	if t, ok := ctx.Params["tag"].(string); ok {
-		tag = t
+	if err := repository.ParseListParams(ctx.Params, &opts); err != nil {
+		return nil, err
 	}
 
 	// 获取统计信息
@@ -63,29 +59,30 @@ func HandleAdminPostList(ctx *ModuleContext) (interface{}, error) {
 	}
 
 	// 查询文章列表
-	result, err := ctx.Services.PostService.ListPosts(repository.ListOptions{
-		Page:       page,
-		PageSize:   20,
-		Tag:        tag,
-		Status:     status,
-		OrderBy:    "date_desc",
-	})
+	result, err := ctx.Services.PostService.ListPosts(opts)
 	if err != nil {
 		return nil, err
 	}
 
-	// 转换为响应格式
+	// 转换为响应格式（浏览量包含尚未写入存储的部分）
+	views := ctx.Services.ViewService
 	items := make([]AdminPostItem, 0, len(result.Items))
 	for _, post := range result.Items {
+		publishedAt := ""
+		if post.PublishedAt != nil {
+			publishedAt = post.PublishedAt.Format("2006-01-02 15:04")
+		}
 		items = append(items, AdminPostItem{
-			ID:        post.ID,
-			Title:     post.Title,
-			Slug:      post.Slug.String(),
-			Status:    post.Status.String(),
-			Tags:      post.GetTagNames(),
-			CreatedAt: post.CreatedAt.Format("2006-01-02 15:04"),
-			UpdatedAt: post.UpdatedAt.Format("2006-01-02 15:04"),
-			Href:      fmt.Sprintf("/pages/admin-editor/index.html?id=%s", post.ID),
+			ID:          post.ID,
+			Title:       post.Title,
+			Slug:        post.Slug.String(),
+			Status:      post.Status.String(),
+			Tags:        post.GetTagNames(),
+			CreatedAt:   post.CreatedAt.Format("2006-01-02 15:04"),
+			UpdatedAt:   post.UpdatedAt.Format("2006-01-02 15:04"),
+			PublishedAt: publishedAt,
+			Views:       postViews(views, post.ID),
+			Href:        ctx.Href(fmt.Sprintf("/pages/admin-editor/index.html?id=%s", post.ID)),
 		})
 	}
 
@@ -106,6 +103,6 @@ func HandleAdminPostList(ctx *ModuleContext) (interface{}, error) {
 			Total:      result.Total,
 			TotalPages: result.TotalPages,
 		},
-		NewPostHref: "/pages/admin-editor/index.html",
+		NewPostHref: ctx.Href("/pages/admin-editor/index.html"),
 	}, nil
 }
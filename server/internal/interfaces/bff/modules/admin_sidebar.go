@@ -26,19 +26,19 @@ func HandleAdminSidebar(ctx *ModuleContext) (interface{}, error) {
 		{
 			Name:   "仪表盘",
 			Icon:   "dashboard",
-			Href:   "/pages/admin/index.html",
+			Href:   ctx.Href("/pages/admin/index.html"),
 			Active: currentPage == "admin",
 		},
 		{
 			Name:   "文章管理",
 			Icon:   "file-text",
-			Href:   "/pages/admin-posts/index.html",
+			Href:   ctx.Href("/pages/admin-posts/index.html"),
 			Active: currentPage == "adminPosts",
 		},
 		{
 			Name:   "图片管理",
 			Icon:   "image",
-			Href:   "/pages/admin-images/index.html",
+			Href:   ctx.Href("/pages/admin-images/index.html"),
 			Active: currentPage == "adminImages",
 		},
 	}
@@ -49,7 +49,7 @@ func HandleAdminSidebar(ctx *ModuleContext) (interface{}, error) {
 			Avatar string `json:"avatar"`
 		}{
 			Name:   "Admin",
-			Avatar: "/avatar.png",
+			Avatar: ctx.Href("/avatar.png"),
 		},
 		Menu: menu,
 	}, nil
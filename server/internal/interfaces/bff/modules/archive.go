@@ -0,0 +1,146 @@
+package modules
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/next-ai-ventus/server/internal/service"
+)
+
+// archiveHref 归档页地址
+const archiveHref = "/pages/archive/index.html"
+
+// ArchiveList 分页上限：每页最多文章数与最大页码（保证偏移量不溢出）
+const (
+	maxArchivePageSize = 50
+	maxArchivePage     = 10000
+)
+
+// ArchiveData Archive 模块数据（侧栏的 年 → 月 → 文章数）
+type ArchiveData struct {
+	Years []ArchiveYearItem `json:"years"`
+}
+
+// ArchiveYearItem 归档年份
+type ArchiveYearItem struct {
+	Year   int                `json:"year"`
+	Count  int                `json:"count"`
+	Href   string             `json:"href"`
+	Months []ArchiveMonthItem `json:"months"`
+}
+
+// ArchiveMonthItem 归档月份
+type ArchiveMonthItem struct {
+	Month int    `json:"month"`
+	Key   string `json:"key"` // "2024-06"
+	Count int    `json:"count"`
+	Href  string `json:"href"`
+}
+
+// ArchiveListData ArchiveList 模块数据
+type ArchiveListData struct {
+	Year       int            `json:"year"`
+	Month      int            `json:"month,omitempty"`
+	Items      []PostItem     `json:"items"`
+	Pagination PaginationInfo `json:"pagination"`
+}
+
+// HandleArchive 处理 Archive 模块（按站点时区的发布月份统计已发布文章）
+func HandleArchive(ctx *ModuleContext) (interface{}, error) {
+	archive := ctx.Services.IndexService.GetArchive()
+
+	years := make([]ArchiveYearItem, 0, len(archive))
+	for _, year := range archive {
+		item := ArchiveYearItem{
+			Year:   year.Year,
+			Count:  year.Count,
+			Href:   ctx.Href(fmt.Sprintf("%s?year=%d", archiveHref, year.Year)),
+			Months: make([]ArchiveMonthItem, 0, len(year.Months)),
+		}
+		for _, month := range year.Months {
+			item.Months = append(item.Months, ArchiveMonthItem{
+				Month: month.Month,
+				Key:   fmt.Sprintf("%d-%02d", year.Year, month.Month),
+				Count: month.Count,
+				Href:  ctx.Href(fmt.Sprintf("%s?year=%d&month=%d", archiveHref, year.Year, month.Month)),
+			})
+		}
+		years = append(years, item)
+	}
+
+	return ArchiveData{Years: years}, nil
+}
+
+// HandleArchiveList 处理 ArchiveList 模块：分页列出某年或某月发布的文章
+func HandleArchiveList(ctx *ModuleContext) (interface{}, error) {
+	year, ok := intParam(ctx.Params, "year")
+	if !ok || year < 1 || year > 9999 {
+		return nil, errors.New("year is required")
+	}
+	month, _ := intParam(ctx.Params, "month")
+	if month < 0 || month > 12 {
+		return nil, fmt.Errorf("invalid month %d", month)
+	}
+
+	page := 1
+	if p, ok := intParam(ctx.Params, "page"); ok && p > 0 {
+		page = p
+	}
+	if page > maxArchivePage {
+		page = maxArchivePage
+	}
+	pageSize := 10
+	if ps, ok := intParam(ctx.Params, "pageSize"); ok && ps > 0 {
+		pageSize = ps
+	}
+	if pageSize > maxArchivePageSize {
+		pageSize = maxArchivePageSize
+	}
+
+	index := ctx.Services.IndexService
+	posts, total := index.ListArchive(year, month, (page-1)*pageSize, pageSize)
+
+	// 日期按站点时区显示，与归档月份一致
+	items := make([]PostItem, 0, len(posts))
+	for _, post := range posts {
+		items = append(items, PostItem{
+			ID:      post.ID,
+			Title:   post.Title,
+			Slug:    post.Slug.String(),
+			Excerpt: post.Excerpt,
+			Tags:    post.GetTagNames(),
+			Date:    post.PublishedAt.In(index.Location()).Format("2006-01-02"),
+			Href:    ctx.Href(service.PostPath(post.Slug.String())),
+		})
+	}
+
+	totalPages := (total + pageSize - 1) / pageSize
+	if totalPages < 1 {
+		totalPages = 1
+	}
+	return ArchiveListData{
+		Year:  year,
+		Month: month,
+		Items: items,
+		Pagination: PaginationInfo{
+			Page:       page,
+			PageSize:   pageSize,
+			Total:      total,
+			TotalPages: totalPages,
+			HasMore:    page*pageSize < total,
+		},
+	}, nil
+}
+
+// intParam 解析整数参数（JSON 数字或查询字符串）
+func intParam(params map[string]interface{}, key string) (int, bool) {
+	switch v := params[key].(type) {
+	case float64:
+		return int(v), true
+	case string:
+		n, err := strconv.Atoi(v)
+		return n, err == nil
+	}
+	return 0, false
+}
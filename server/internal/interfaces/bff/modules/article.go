@@ -3,22 +3,25 @@ package modules
 import (
 	"errors"
 
+	"github.com/next-ai-ventus/server/internal/service"
 	"github.com/next-ai-ventus/server/pkg/markdown"
 )
 
 // ArticleData Article 模块数据
 type ArticleData struct {
-	ID          string   `json:"id"`
-	Title       string   `json:"title"`
-	Slug        string   `json:"slug"`
-	Content     string   `json:"content"`
-	HTML        string   `json:"html"`
-	Tags        []string `json:"tags"`
-	Status      string   `json:"status"`
-	CreatedAt   string   `json:"createdAt"`
-	UpdatedAt   string   `json:"updatedAt"`
-	PublishedAt *string  `json:"publishedAt,omitempty"`
-	WordCount   int      `json:"wordCount"`
+	ID             string                  `json:"id"`
+	Title          string                  `json:"title"`
+	Slug           string                  `json:"slug"`
+	Content        string                  `json:"content"`
+	HTML           string                  `json:"html"`
+	Tags           []string                `json:"tags"`
+	Status         string                  `json:"status"`
+	CreatedAt      string                  `json:"createdAt"`
+	UpdatedAt      string                  `json:"updatedAt"`
+	PublishedAt    *string                 `json:"publishedAt,omitempty"`
+	WordCount      int                     `json:"wordCount"`
+	CommentsClosed bool                    `json:"commentsClosed"`
+	Reactions      []service.ReactionCount `json:"reactions,omitempty"` // 静态导出时为空
 }
 
 // HandleArticle 处理 Article 模块
@@ -45,16 +48,18 @@ func HandleArticle(ctx *ModuleContext) (interface{}, error) {
 	}
 
 	return ArticleData{
-		ID:          post.ID,
-		Title:       post.Title,
-		Slug:        post.Slug.String(),
-		Content:     post.Content,
-		HTML:        mdResult.HTML,
-		Tags:        post.GetTagNames(),
-		Status:      post.Status.String(),
-		CreatedAt:   post.CreatedAt.Format("2006-01-02"),
-		UpdatedAt:   post.UpdatedAt.Format("2006-01-02"),
-		PublishedAt: publishedAt,
-		WordCount:   mdResult.WordCount,
+		ID:             post.ID,
+		Title:          post.Title,
+		Slug:           post.Slug.String(),
+		Content:        post.Content,
+		HTML:           mdResult.HTML,
+		Tags:           post.GetTagNames(),
+		Status:         post.Status.String(),
+		CreatedAt:      post.CreatedAt.Format("2006-01-02"),
+		UpdatedAt:      post.UpdatedAt.Format("2006-01-02"),
+		PublishedAt:    publishedAt,
+		WordCount:      mdResult.WordCount,
+		CommentsClosed: post.CommentsClosed,
+		Reactions:      postReactions(ctx, post.ID),
 	}, nil
 }
@@ -0,0 +1,49 @@
+package modules
+
+import (
+	"errors"
+
+	"github.com/next-ai-ventus/server/internal/service"
+)
+
+// BacklinksData Backlinks 模块数据
+type BacklinksData struct {
+	Items []BacklinkItem `json:"items"`
+}
+
+// BacklinkItem 链接到当前文章的文章
+type BacklinkItem struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Slug  string `json:"slug"`
+	Href  string `json:"href"`
+}
+
+// HandleBacklinks 处理反向链接模块（列出正文中链接到当前文章的已发布文章）。
+// 参数：slug
+func HandleBacklinks(ctx *ModuleContext) (interface{}, error) {
+	slug, ok := ctx.Params["slug"].(string)
+	if !ok || slug == "" {
+		return nil, errors.New("slug is required")
+	}
+
+	items := []BacklinkItem{}
+	if ctx.Services.LinkService == nil {
+		return BacklinksData{Items: items}, nil
+	}
+
+	post, err := ctx.Services.PostService.GetPostBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+	for _, source := range ctx.Services.LinkService.Backlinks(post.ID) {
+		items = append(items, BacklinkItem{
+			ID:    source.ID,
+			Title: source.Title,
+			Slug:  source.Slug,
+			Href:  ctx.Href(service.PostPath(source.Slug)),
+		})
+	}
+
+	return BacklinksData{Items: items}, nil
+}
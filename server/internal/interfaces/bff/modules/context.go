@@ -1,18 +1,46 @@
 package modules
 
-import "github.com/next-ai-ventus/server/internal/service"
+import (
+	"strings"
+
+	"github.com/next-ai-ventus/server/internal/service"
+	"github.com/next-ai-ventus/server/internal/site"
+)
 
 // ModuleContext BFF 模块上下文
 type ModuleContext struct {
 	Page     string
 	Params   map[string]interface{}
 	Services *Services
+	Prefix   string // 站点按路径前缀路由时的前缀（见 SiteRouter），站内链接需带上
 }
 
 // Services 包含所有应用服务
 type Services struct {
-	PostService  *service.PostService
-	IndexService *service.IndexService
+	PostService     *service.PostService
+	IndexService    *service.IndexService
+	SearchService   *service.SearchService
+	ViewService     *service.ViewService     // 浏览量统计（静态导出时为 nil）
+	CommentService  *service.CommentService  // 评论（为 nil 时不显示评论数）
+	ReactionService *service.ReactionService // 表情回应（静态导出时为 nil）
+	LinkService     *service.LinkService     // 站内链接图（静态导出时为 nil）
+	Site            site.Settings            // 当前站点的设置
+}
+
+// SiteName 返回当前站点名称（未配置时为默认名称）
+func (ctx *ModuleContext) SiteName() string {
+	if ctx.Services.Site.Name == "" {
+		return site.DefaultSiteName
+	}
+	return ctx.Services.Site.Name
+}
+
+// Href 返回带站点路径前缀的站内地址；绝对地址与协议相对地址原样返回
+func (ctx *ModuleContext) Href(path string) string {
+	if !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "//") {
+		return path
+	}
+	return ctx.Prefix + path
 }
 
 // ModuleHandler BFF 模块处理函数类型
@@ -4,14 +4,16 @@ package modules
 
 // EditorData Editor 模块数据
 type EditorData struct {
-	ID          string   `json:"id,omitempty"`
-	Title       string   `json:"title"`
-	Content     string   `json:"content"`
-	Tags        []string `json:"tags"`
-	Status      string   `json:"status"`
-	Cover       string   `json:"cover"`
-	Version     int      `json:"version"`
-	IsNew       bool     `json:"isNew"`
+	ID             string   `json:"id,omitempty"`
+	Title          string   `json:"title"`
+	Content        string   `json:"content"`
+	Tags           []string `json:"tags"`
+	Status         string   `json:"status"`
+	Cover          string   `json:"cover"`
+	Category       string   `json:"category"`
+	CommentsClosed bool     `json:"commentsClosed"`
+	Version        int      `json:"version"`
+	IsNew          bool     `json:"isNew"`
 }
 
 // HandleEditor 处理 Editor 模块（获取文章编辑数据）
@@ -24,14 +26,16 @@ func HandleEditor(ctx *ModuleContext) (interface{}, error) {
 		}
 
 		return EditorData{
-			ID:      post.ID,
-			Title:   post.Title,
-			Content: post.Content,
-			Tags:    post.GetTagNames(),
-			Status:  post.Status.String(),
-			Cover:   post.Cover,
-			Version: post.Version,
-			IsNew:   false,
+			ID:             post.ID,
+			Title:          post.Title,
+			Content:        post.Content,
+			Tags:           post.GetTagNames(),
+			Status:         post.Status.String(),
+			Cover:          post.Cover,
+			Category:       post.Category,
+			CommentsClosed: post.CommentsClosed,
+			Version:        post.Version,
+			IsNew:          false,
 		}, nil
 	}
 
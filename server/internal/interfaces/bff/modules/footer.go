@@ -9,7 +9,7 @@ type FooterData struct {
 // HandleFooter 处理 Footer 模块
 func HandleFooter(ctx *ModuleContext) (interface{}, error) {
 	return FooterData{
-		Copyright: "© 2024 Ventus Blog",
+		Copyright: "© 2024 " + ctx.SiteName(),
 		PoweredBy: "Powered by Ventus",
 	}, nil
 }
@@ -17,12 +17,12 @@ type NavLink struct {
 // HandleHeader 处理 Header 模块
 func HandleHeader(ctx *ModuleContext) (interface{}, error) {
 	return HeaderData{
-		SiteName:  "Ventus Blog",
-		Logo:      "/logo.png",
+		SiteName:  ctx.SiteName(),
+		Logo:      ctx.Href("/logo.png"),
 		NavLinks: []NavLink{
-			{Name: "首页", Href: "/pages/home/index.html"},
-			{Name: "关于", Href: "/pages/about/index.html"},
+			{Name: "首页", Href: ctx.Href("/pages/home/index.html")},
+			{Name: "关于", Href: ctx.Href("/pages/about/index.html")},
 		},
-		LoginHref: "/pages/login/index.html",
+		LoginHref: ctx.Href("/pages/login/index.html"),
 	}, nil
 }
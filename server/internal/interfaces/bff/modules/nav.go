@@ -5,9 +5,9 @@ func HandleNav(ctx *ModuleContext) (interface{}, error) {
 	// 返回导航链接列表
 	return map[string]interface{}{
 		"links": []map[string]string{
-			{"name": "首页", "href": "/"},
-			{"name": "技术", "href": "/?tag=tech"},
-			{"name": "生活", "href": "/?tag=life"},
+			{"name": "首页", "href": ctx.Href("/")},
+			{"name": "技术", "href": ctx.Href("/?tag=tech")},
+			{"name": "生活", "href": ctx.Href("/?tag=life")},
 		},
 	}, nil
 }
@@ -0,0 +1,77 @@
+package modules
+
+import (
+	"github.com/next-ai-ventus/server/internal/service"
+)
+
+// 热门文章默认参数
+const (
+	defaultPopularDays  = 30
+	defaultPopularLimit = 5
+	maxPopularLimit     = 20
+)
+
+// PopularPostsData PopularPosts 模块数据
+type PopularPostsData struct {
+	Days  int               `json:"days"` // 统计天数，0 表示全部
+	Items []PopularPostItem `json:"items"`
+}
+
+// PopularPostItem 热门文章
+type PopularPostItem struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Slug  string `json:"slug"`
+	Views int    `json:"views"`
+	Href  string `json:"href"`
+}
+
+// HandlePopularPosts 处理热门文章模块（按最近 days 天的浏览量排序，只列出已发布文章）。
+// 参数：days（默认 30，0 表示全部）、limit（默认 5）
+func HandlePopularPosts(ctx *ModuleContext) (interface{}, error) {
+	days := defaultPopularDays
+	if d, ok := intParam(ctx.Params, "days"); ok && d >= 0 {
+		days = d
+	}
+	limit := defaultPopularLimit
+	if l, ok := intParam(ctx.Params, "limit"); ok && l > 0 {
+		limit = l
+	}
+	if limit > maxPopularLimit {
+		limit = maxPopularLimit
+	}
+
+	items := []PopularPostItem{}
+	if ctx.Services.ViewService == nil {
+		return PopularPostsData{Days: days, Items: items}, nil
+	}
+
+	index := ctx.Services.IndexService
+	published := func(id string) bool {
+		post, ok := index.GetPost(id)
+		return ok && post.IsPublished()
+	}
+	for _, pv := range ctx.Services.ViewService.Popular(days, limit, published) {
+		post, ok := index.GetPost(pv.PostID)
+		if !ok {
+			continue // 统计期间被删除
+		}
+		items = append(items, PopularPostItem{
+			ID:    post.ID,
+			Title: post.Title,
+			Slug:  post.Slug.String(),
+			Views: pv.Views,
+			Href:  ctx.Href(service.PostPath(post.Slug.String())),
+		})
+	}
+
+	return PopularPostsData{Days: days, Items: items}, nil
+}
+
+// postViews 返回文章的总浏览量（未启用浏览量统计时为 0）
+func postViews(views *service.ViewService, postID string) int {
+	if views == nil {
+		return 0
+	}
+	return views.Views(postID)
+}
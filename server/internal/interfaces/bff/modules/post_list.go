@@ -1,9 +1,8 @@
 package modules
 
 import (
-	"fmt"
-
 	"github.com/next-ai-ventus/server/internal/repository"
+	"github.com/next-ai-ventus/server/internal/service"
 )
 
 // PostListData PostList 模块数据
@@ -14,21 +13,26 @@ type PostListData struct {
 
 // PostItem 文章列表项
 type PostItem struct {
-	ID        string   `json:"id"`
-	Title     string   `json:"title"`
-	Slug      string   `json:"slug"`
-	Excerpt   string   `json:"excerpt"`
-	Tags      []string `json:"tags"`
-	Date      string   `json:"date"`
-	Href      string   `json:"href"`
+	ID        string                  `json:"id"`
+	Title     string                  `json:"title"`
+	Slug      string                  `json:"slug"`
+	Excerpt   string                  `json:"excerpt"`
+	Tags      []string                `json:"tags"`
+	Date      string                  `json:"date"`
+	Href      string                  `json:"href"`
+	Comments  int                     `json:"comments"` // 已通过审核的评论数
+	Reactions []service.ReactionCount `json:"reactions,omitempty"`
 }
 
 // PaginationInfo 分页信息
 type PaginationInfo struct {
-	Page       int `json:"page"`
-	PageSize   int `json:"pageSize"`
-	Total      int `json:"total"`
-	TotalPages int `json:"totalPages"`
+	Page       int    `json:"page"`
+	PageSize   int    `json:"pageSize"`
+	Total      int    `json:"total"`
+	TotalPages int    `json:"totalPages"`
+	NextCursor string `json:"nextCursor,omitempty"`
+	PrevCursor string `json:"prevCursor,omitempty"`
+	HasMore    bool   `json:"hasMore"`
 }
 
 // HandlePostList 处理 PostList 模块
@@ -44,6 +48,12 @@ func HandlePostList(ctx *ModuleContext) (interface{}, error) {
 		tag = t
 	}
 
+	// 无限滚动使用游标分页，避免新文章发布导致翻页错位
+	cursor := ""
+	if c, ok := ctx.Params["cursor"].(string); ok {
+		cursor = c
+	}
+
 	// 查询文章列表
 	result, err := ctx.Services.PostService.ListPosts(repository.ListOptions{
 		Page:       page,
@@ -51,22 +61,31 @@ func HandlePostList(ctx *ModuleContext) (interface{}, error) {
 		Tag:        tag,
 		Status:     "published", // 只显示已发布的文章
 		OrderBy:    "date_desc",
+		Cursor:     cursor,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	// 评论数（未启用评论时为空）
+	commentCounts, err := approvedCommentCounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// 转换为响应格式
 	items := make([]PostItem, 0, len(result.Items))
 	for _, post := range result.Items {
 		items = append(items, PostItem{
-			ID:      post.ID,
-			Title:   post.Title,
-			Slug:    post.Slug.String(),
-			Excerpt: post.Excerpt,
-			Tags:    post.GetTagNames(),
-			Date:    post.CreatedAt.Format("2006-01-02"),
-			Href:    fmt.Sprintf("/pages/post/index.html?slug=%s", post.Slug.String()),
+			ID:        post.ID,
+			Title:     post.Title,
+			Slug:      post.Slug.String(),
+			Excerpt:   post.Excerpt,
+			Tags:      post.GetTagNames(),
+			Date:      post.CreatedAt.Format("2006-01-02"),
+			Href:      ctx.Href(service.PostPath(post.Slug.String())),
+			Comments:  commentCounts[post.ID],
+			Reactions: postReactions(ctx, post.ID),
 		})
 	}
 
@@ -77,6 +96,25 @@ func HandlePostList(ctx *ModuleContext) (interface{}, error) {
 			PageSize:   result.PageSize,
 			Total:      result.Total,
 			TotalPages: result.TotalPages,
+			NextCursor: result.NextCursor,
+			PrevCursor: result.PrevCursor,
+			HasMore:    result.HasMore,
 		},
 	}, nil
 }
+
+// approvedCommentCounts 返回每篇文章已通过审核的评论数，未启用评论时为空
+func approvedCommentCounts(ctx *ModuleContext) (map[string]int, error) {
+	if ctx.Services.CommentService == nil {
+		return map[string]int{}, nil
+	}
+	return ctx.Services.CommentService.ApprovedCounts()
+}
+
+// postReactions 返回文章的表情回应统计，未启用表情回应时为空
+func postReactions(ctx *ModuleContext, postID string) []service.ReactionCount {
+	if ctx.Services.ReactionService == nil {
+		return nil
+	}
+	return ctx.Services.ReactionService.Counts(postID)
+}
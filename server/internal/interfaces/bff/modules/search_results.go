@@ -0,0 +1,69 @@
+package modules
+
+import (
+	"github.com/next-ai-ventus/server/internal/service"
+)
+
+// SearchResultsData SearchResults 模块数据
+type SearchResultsData struct {
+	Query      string             `json:"query"`
+	Items      []SearchResultItem `json:"items"`
+	Pagination PaginationInfo     `json:"pagination"`
+}
+
+// SearchResultItem 搜索结果项
+type SearchResultItem struct {
+	ID        string   `json:"id"`
+	Title     string   `json:"title"`
+	Highlight string   `json:"highlight"`
+	Snippet   string   `json:"snippet"`
+	Tags      []string `json:"tags"`
+	Date      string   `json:"date"`
+	Href      string   `json:"href"`
+}
+
+// HandleSearchResults 处理 SearchResults 模块（仅搜索已发布文章）
+func HandleSearchResults(ctx *ModuleContext) (interface{}, error) {
+	// 解析参数
+	query, _ := ctx.Params["q"].(string)
+
+	page := 1
+	if p, ok := ctx.Params["page"].(float64); ok {
+		page = int(p)
+	}
+
+	result := ctx.Services.SearchService.Search(service.SearchOptions{
+		Query:    query,
+		Page:     page,
+		PageSize: 10,
+	})
+
+	// 转换为响应格式
+	items := make([]SearchResultItem, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		date := hit.CreatedAt
+		if hit.PublishedAt != nil {
+			date = *hit.PublishedAt
+		}
+		items = append(items, SearchResultItem{
+			ID:        hit.ID,
+			Title:     hit.Title,
+			Highlight: hit.Highlight,
+			Snippet:   hit.Snippet,
+			Tags:      hit.Tags,
+			Date:      date.Format("2006-01-02"),
+			Href:      ctx.Href(service.PostPath(hit.Slug)),
+		})
+	}
+
+	return SearchResultsData{
+		Query: result.Query,
+		Items: items,
+		Pagination: PaginationInfo{
+			Page:       result.Page,
+			PageSize:   result.PageSize,
+			Total:      result.Total,
+			TotalPages: result.TotalPages,
+		},
+	}, nil
+}
@@ -1,15 +1,65 @@
 package modules
 
-// HandleTagCloud 处理标签云模块（P1 功能，MVP 返回空）
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/next-ai-ventus/server/internal/service"
+)
+
+// 标签云默认参数
+const (
+	defaultTagCloudLimit = 30
+	maxTagCloudLimit     = 200
+)
+
+// TagCloudData TagCloud 模块数据
+type TagCloudData struct {
+	Tags []TagCloudItem `json:"tags"`
+}
+
+// TagCloudItem 标签云中的标签
+type TagCloudItem struct {
+	Name   string `json:"name"`
+	Count  int    `json:"count"`
+	Weight int    `json:"weight"` // 字号等级 1..5
+	Href   string `json:"href"`
+}
+
+// HandleTagCloud 处理标签云模块（只统计已发布文章，计数由 IndexService 维护）。
+// 参数：limit（默认 30）、minCount（默认 1）、sort（"count" 默认，或 "name"）
 func HandleTagCloud(ctx *ModuleContext) (interface{}, error) {
-	// MVP 版本返回空数据或热门标签
-	// 实际应该从 IndexService 获取标签统计
-	
-	return map[string]interface{}{
-		"tags": []map[string]interface{}{
-			{"name": "Go", "count": 5, "href": "/?tag=go"},
-			{"name": "React", "count": 3, "href": "/?tag=react"},
-			{"name": "架构", "count": 2, "href": "/?tag=架构"},
-		},
-	}, nil
+	opts := service.TagCloudOptions{
+		Limit:    defaultTagCloudLimit,
+		MinCount: 1,
+		SortBy:   service.TagCloudSortCount,
+	}
+	if limit, ok := intParam(ctx.Params, "limit"); ok && limit > 0 {
+		opts.Limit = limit
+	}
+	if opts.Limit > maxTagCloudLimit {
+		opts.Limit = maxTagCloudLimit
+	}
+	if minCount, ok := intParam(ctx.Params, "minCount"); ok && minCount > 0 {
+		opts.MinCount = minCount
+	}
+	if sortBy, ok := ctx.Params["sort"].(string); ok && sortBy != "" {
+		if sortBy != service.TagCloudSortCount && sortBy != service.TagCloudSortName {
+			return nil, fmt.Errorf("invalid sort %q", sortBy)
+		}
+		opts.SortBy = sortBy
+	}
+
+	cloud := ctx.Services.IndexService.TagCloud(opts)
+	tags := make([]TagCloudItem, 0, len(cloud))
+	for _, item := range cloud {
+		tags = append(tags, TagCloudItem{
+			Name:   item.Name,
+			Count:  item.Count,
+			Weight: item.Weight,
+			Href:   ctx.Href("/?tag=" + url.QueryEscape(item.Name)),
+		})
+	}
+
+	return TagCloudData{Tags: tags}, nil
 }
@@ -7,7 +7,7 @@ func HandleUserAction(ctx *ModuleContext) (interface{}, error) {
 	
 	return map[string]interface{}{
 		"isLoggedIn": false,
-		"loginHref":  "/pages/login/index.html",
+		"loginHref":  ctx.Href("/pages/login/index.html"),
 		"user":       nil,
 	}, nil
 }
@@ -1,13 +1,27 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
 	"github.com/gin-gonic/gin"
 
 	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/domain/valueobject"
 	"github.com/next-ai-ventus/server/internal/interfaces/bff"
 	"github.com/next-ai-ventus/server/internal/interfaces/http/response"
+	"github.com/next-ai-ventus/server/internal/mail"
 	"github.com/next-ai-ventus/server/internal/repository"
 	"github.com/next-ai-ventus/server/internal/service"
+	"github.com/next-ai-ventus/server/internal/site"
+	"github.com/next-ai-ventus/server/internal/storage"
+	"github.com/next-ai-ventus/server/pkg/markdown"
 )
 
 // APIRequest 统一 API 请求
@@ -16,23 +30,37 @@ type APIRequest struct {
 	Data      map[string]interface{} `json:"data"`
 }
 
+// Services 站点的应用服务，由路由与统一 API 处理器使用
+type Services struct {
+	PostService       *service.PostService
+	SearchService     *service.SearchService
+	IndexService      *service.IndexService
+	AuthService       *service.AuthService
+	BackupService     *service.BackupService
+	ImportService     *service.ImportService
+	RedirectService   *service.RedirectService
+	ViewService       *service.ViewService
+	CommentService    *service.CommentService
+	SpamService       *service.SpamService
+	ReactionService   *service.ReactionService
+	WebhookService    *service.WebhookService
+	NewsletterService *service.NewsletterService
+	LinkService       *service.LinkService
+	BFFHandler        *bff.Handler
+	Uploads           storage.Blob
+}
+
 // APIHandler 统一 API 处理器
 type APIHandler struct {
-	postService *service.PostService
-	authService *service.AuthService
-	bffHandler  *bff.Handler
+	services   *Services
+	importJobs *service.ImportJobs
 }
 
 // NewAPIHandler 创建统一 API 处理器
-func NewAPIHandler(
-	postService *service.PostService,
-	authService *service.AuthService,
-	bffHandler *bff.Handler,
-) *APIHandler {
+func NewAPIHandler(services *Services) *APIHandler {
 	return &APIHandler{
-		postService: postService,
-		authService: authService,
-		bffHandler:  bffHandler,
+		services:   services,
+		importJobs: service.NewImportJobs(),
 	}
 }
 
@@ -51,6 +79,24 @@ func (h *APIHandler) HandlePublic(c *gin.Context) {
 		h.handlePageGet(c, req.Data)
 	case "post.recordView":
 		h.handleRecordView(c, req.Data)
+	case "post.react":
+		h.handlePostReact(c, req.Data)
+	case "search.query":
+		h.handleSearch(c, req.Data, false)
+	case "redirect.resolve":
+		h.handleRedirectResolve(c, req.Data)
+	case "comment.list":
+		h.handleCommentList(c, req.Data)
+	case "comment.create":
+		h.handleCommentCreate(c, req.Data)
+	case "spam.token":
+		h.handleSpamToken(c)
+	case "newsletter.subscribe":
+		h.handleNewsletterSubscribe(c, req.Data)
+	case "newsletter.confirm":
+		h.handleNewsletterConfirm(c, req.Data)
+	case "newsletter.unsubscribe":
+		h.handleNewsletterUnsubscribe(c, req.Data)
 	default:
 		response.Error(c, response.CodeInvalidParam)
 	}
@@ -58,8 +104,8 @@ func (h *APIHandler) HandlePublic(c *gin.Context) {
 
 // HandleAdmin 处理管理 API（需要认证）
 func (h *APIHandler) HandleAdmin(c *gin.Context) {
-	var req APIRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	req, err := bindAdminRequest(c)
+	if err != nil {
 		response.Error(c, response.CodeInvalidParam)
 		return
 	}
@@ -75,8 +121,66 @@ func (h *APIHandler) HandleAdmin(c *gin.Context) {
 		h.handlePostGet(c, req.Data)
 	case "post.list":
 		h.handlePostList(c, req.Data)
+	case "post.bulk":
+		h.handlePostBulk(c, req.Data)
+	case "post.history":
+		h.handlePostHistory(c, req.Data)
+	case "post.revision":
+		h.handlePostRevision(c, req.Data)
+	case "post.links":
+		h.handlePostLinks(c, req.Data)
+	case "search.query":
+		h.handleSearch(c, req.Data, true)
+	case "index.rebuild":
+		h.handleIndexRebuild(c)
+	case "content.check":
+		h.handleContentCheck(c, req.Data)
+	case "links.report":
+		h.handleLinksReport(c)
 	case "file.upload":
 		h.handleFileUpload(c)
+	case "media.list":
+		h.handleMediaList(c, req.Data)
+	case "media.delete":
+		h.handleMediaDelete(c, req.Data)
+	case "site.export":
+		h.handleSiteExport(c, req.Data)
+	case "site.import":
+		h.handleSiteImport(c, req.Data)
+	case "import.wordpress":
+		h.handleImportWordPress(c, req.Data)
+	case "import.status":
+		h.handleImportStatus(c, req.Data)
+	case "comment.list":
+		h.handleCommentQueue(c, req.Data)
+	case "comment.moderate":
+		h.handleCommentModerate(c, req.Data)
+	case "comment.purge":
+		h.handleCommentPurge(c, req.Data)
+	case "spam.disallow.get":
+		h.handleDisallowGet(c)
+	case "spam.disallow.save":
+		h.handleDisallowSave(c, req.Data)
+	case "spam.log":
+		h.handleSpamLog(c, req.Data)
+	case "webhook.list":
+		h.handleWebhookList(c)
+	case "webhook.create":
+		h.handleWebhookCreate(c, req.Data)
+	case "webhook.update":
+		h.handleWebhookUpdate(c, req.Data)
+	case "webhook.delete":
+		h.handleWebhookDelete(c, req.Data)
+	case "webhook.deliveries":
+		h.handleWebhookDeliveries(c, req.Data)
+	case "webhook.redeliver":
+		h.handleWebhookRedeliver(c, req.Data)
+	case "newsletter.subscribers":
+		h.handleNewsletterSubscribers(c)
+	case "newsletter.issues":
+		h.handleNewsletterIssues(c)
+	case "newsletter.remove":
+		h.handleNewsletterRemove(c, req.Data)
 	default:
 		response.Error(c, response.CodeInvalidParam)
 	}
@@ -93,19 +197,19 @@ func (h *APIHandler) handleLogin(c *gin.Context, data map[string]interface{}) {
 		return
 	}
 
-	if !h.authService.ValidateCredentials(username, password) {
+	if !h.services.AuthService.ValidateCredentials(username, password) {
 		response.Error(c, response.CodeInvalidCredentials)
 		return
 	}
 
-	token, err := h.authService.GenerateToken(username)
+	token, err := h.services.AuthService.GenerateToken(username)
 	if err != nil {
 		response.Error(c, response.CodeInternalError)
 		return
 	}
 
-	// 设置 Cookie
-	c.SetCookie("token", token, 86400, "/", "", false, true)
+	// 设置 Cookie（按路径前缀区分的站点各自使用自己的 Cookie 路径）
+	c.SetCookie("token", token, 86400, sitePrefix(c)+"/", "", false, true)
 
 	response.Success(c, gin.H{
 		"token": token,
@@ -133,10 +237,11 @@ func (h *APIHandler) handlePostCreate(c *gin.Context, data map[string]interface{
 		}
 	}
 
-	post, err := h.postService.CreatePost(service.CreatePostInput{
+	post, err := h.services.PostService.CreatePost(service.CreatePostInput{
 		Title:   title,
 		Content: content,
 		Tags:    tags,
+		Editor:  c.GetString("username"),
 	})
 	if err != nil {
 		mapErrorAndRespond(c, err)
@@ -162,7 +267,9 @@ func (h *APIHandler) handlePostUpdate(c *gin.Context, data map[string]interface{
 	versionFloat, _ := data["version"].(float64)
 	version := int(versionFloat)
 
-	var input service.UpdatePostInput
+	input := service.UpdatePostInput{
+		Editor: c.GetString("username"),
+	}
 
 	if title, ok := data["title"].(string); ok {
 		input.Title = &title
@@ -173,6 +280,12 @@ func (h *APIHandler) handlePostUpdate(c *gin.Context, data map[string]interface{
 	if status, ok := data["status"].(string); ok {
 		input.Status = &status
 	}
+	if category, ok := data["category"].(string); ok {
+		input.Category = &category
+	}
+	if commentsClosed, ok := data["commentsClosed"].(bool); ok {
+		input.CommentsClosed = &commentsClosed
+	}
 	if tagList, ok := data["tags"].([]interface{}); ok {
 		for _, t := range tagList {
 			if tag, ok := t.(string); ok {
@@ -181,18 +294,30 @@ func (h *APIHandler) handlePostUpdate(c *gin.Context, data map[string]interface{
 		}
 	}
 
-	post, err := h.postService.UpdatePost(id, input, version)
+	// 记录修改前的 slug，slug 变化时提示仍指向旧地址的链接
+	oldSlug := ""
+	if before, ok := h.services.IndexService.GetPost(id); ok {
+		oldSlug = before.Slug.String()
+	}
+
+	post, err := h.services.PostService.UpdatePost(id, input, version)
 	if err != nil {
 		mapErrorAndRespond(c, err)
 		return
 	}
 
+	inbound := []*service.InboundLink{}
+	if oldSlug != "" && oldSlug != post.Slug.String() {
+		inbound = h.services.LinkService.Inbound(oldSlug)
+	}
+
 	response.Success(c, gin.H{
-		"id":      post.ID,
-		"title":   post.Title,
-		"slug":    post.Slug.String(),
-		"status":  post.Status.String(),
-		"version": post.Version,
+		"id":           post.ID,
+		"title":        post.Title,
+		"slug":         post.Slug.String(),
+		"status":       post.Status.String(),
+		"version":      post.Version,
+		"inboundLinks": inbound,
 	})
 }
 
@@ -203,12 +328,101 @@ func (h *APIHandler) handlePostDelete(c *gin.Context, data map[string]interface{
 		return
 	}
 
-	if err := h.postService.DeletePost(id); err != nil {
+	// 删除前记录指向该文章的链接，删除后这些链接将失效
+	inbound := []*service.InboundLink{}
+	if post, ok := h.services.IndexService.GetPost(id); ok {
+		inbound = h.services.LinkService.Inbound(post.Slug.String())
+	}
+
+	if err := h.services.PostService.DeletePostAs(id, c.GetString("username")); err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"inboundLinks": inbound})
+}
+
+func (h *APIHandler) handlePostBulk(c *gin.Context, data map[string]interface{}) {
+	action, _ := data["action"].(string)
+	atomic, _ := data["atomic"].(bool)
+	category, _ := data["category"].(string)
+
+	input := service.BulkInput{
+		Action:   service.BulkAction(action),
+		Category: category,
+		Atomic:   atomic,
+		Editor:   c.GetString("username"),
+	}
+	if tagList, ok := data["tags"].([]interface{}); ok {
+		for _, t := range tagList {
+			if tag, ok := t.(string); ok {
+				input.Tags = append(input.Tags, tag)
+			}
+		}
+	}
+	items, _ := data["items"].([]interface{})
+	for _, raw := range items {
+		item, _ := raw.(map[string]interface{})
+		id, _ := item["id"].(string)
+		version, _ := item["version"].(float64)
+		input.Items = append(input.Items, service.BulkItem{ID: id, Version: int(version)})
+	}
+
+	// 批量删除前记录指向各文章的链接，删除后这些链接将失效
+	inbound := make(map[string][]*service.InboundLink)
+	if input.Action == service.BulkDelete {
+		for _, item := range input.Items {
+			if post, ok := h.services.IndexService.GetPost(item.ID); ok {
+				inbound[item.ID] = h.services.LinkService.Inbound(post.Slug.String())
+			}
+		}
+	}
+
+	result, err := h.services.PostService.BulkUpdate(input)
+	if err != nil {
 		mapErrorAndRespond(c, err)
 		return
 	}
 
-	response.Success(c, nil)
+	deleted := make(map[string]bool)
+	if input.Action == service.BulkDelete {
+		for _, item := range result.Items {
+			if item.Err == nil {
+				deleted[item.ID] = true
+			}
+		}
+	}
+
+	results := make([]gin.H, len(result.Items))
+	for i, item := range result.Items {
+		entry := gin.H{"id": item.ID, "success": item.Err == nil}
+		switch {
+		case item.Err != nil:
+			entry["code"], entry["message"] = errorCode(item.Err)
+		case deleted[item.ID]:
+			entry["inboundLinks"] = survivingInbound(inbound[item.ID], deleted)
+		case item.Version > 0:
+			entry["version"] = item.Version
+		}
+		results[i] = entry
+	}
+
+	response.Success(c, gin.H{
+		"items":     results,
+		"succeeded": result.Succeeded,
+		"failed":    result.Failed,
+	})
+}
+
+// survivingInbound 过滤掉来自同批已删除文章的链接，只保留删除后仍会失效的链接
+func survivingInbound(links []*service.InboundLink, deleted map[string]bool) []*service.InboundLink {
+	result := make([]*service.InboundLink, 0, len(links))
+	for _, link := range links {
+		if !deleted[link.Source.ID] {
+			result = append(result, link)
+		}
+	}
+	return result
 }
 
 func (h *APIHandler) handlePostGet(c *gin.Context, data map[string]interface{}) {
@@ -219,9 +433,9 @@ func (h *APIHandler) handlePostGet(c *gin.Context, data map[string]interface{})
 	var err error
 
 	if id != "" {
-		post, err = h.postService.GetPost(id)
+		post, err = h.services.PostService.GetPost(id)
 	} else if slug != "" {
-		post, err = h.postService.GetPostBySlug(slug)
+		post, err = h.services.PostService.GetPostBySlug(slug)
 	} else {
 		response.Error(c, response.CodeInvalidParam)
 		return
@@ -242,88 +456,1258 @@ func (h *APIHandler) handlePostList(c *gin.Context, data map[string]interface{})
 		OrderBy:  "date_desc",
 	}
 
-	if page, ok := data["page"].(float64); ok {
-		opts.Page = int(page)
+	if err := repository.ParseListParams(data, &opts); err != nil {
+		response.ErrorWithMessage(c, response.CodeInvalidParam, err.Error())
+		return
 	}
-	if pageSize, ok := data["pageSize"].(float64); ok {
-		opts.PageSize = int(pageSize)
+
+	result, err := h.services.PostService.ListPosts(opts)
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
 	}
-	if status, ok := data["status"].(string); ok {
-		opts.Status = status
+
+	response.Success(c, result)
+}
+
+func (h *APIHandler) handlePostHistory(c *gin.Context, data map[string]interface{}) {
+	id, _ := data["id"].(string)
+	if id == "" {
+		response.Error(c, response.CodeInvalidParam)
+		return
+	}
+
+	revisions, err := h.services.PostService.GetHistory(id)
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
 	}
-	if tag, ok := data["tag"].(string); ok {
-		opts.Tag = tag
+
+	response.Success(c, gin.H{
+		"id":        id,
+		"revisions": revisions,
+	})
+}
+
+func (h *APIHandler) handlePostRevision(c *gin.Context, data map[string]interface{}) {
+	id, _ := data["id"].(string)
+	hash, _ := data["hash"].(string)
+	if id == "" || hash == "" {
+		response.Error(c, response.CodeInvalidParam)
+		return
 	}
 
-	result, err := h.postService.ListPosts(opts)
+	post, err := h.services.PostService.GetRevision(id, hash)
 	if err != nil {
-		response.Error(c, response.CodeInternalError)
+		mapErrorAndRespond(c, err)
 		return
 	}
 
-	response.Success(c, result)
+	response.Success(c, post)
+}
+
+func (h *APIHandler) handleIndexRebuild(c *gin.Context) {
+	if err := h.services.PostService.RebuildIndex(); err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+	if err := h.rebuildIndexes(); err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	total, published, draft, err := h.services.PostService.GetStats()
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"total":     total,
+		"published": published,
+		"draft":     draft,
+	})
+}
+
+func (h *APIHandler) handleContentCheck(c *gin.Context, data map[string]interface{}) {
+	// fix 为 false（默认）时只报告问题，不修改任何文件
+	fix, _ := data["fix"].(bool)
+
+	report, err := h.services.PostService.CheckContent(repository.CheckOptions{
+		Uploads: h.services.Uploads,
+		Fix:     fix,
+	})
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	// 修复会改写文章元数据，同步重建搜索与文章索引
+	if report.Fixed > 0 {
+		if err := h.rebuildIndexes(); err != nil {
+			mapErrorAndRespond(c, err)
+			return
+		}
+	}
+
+	response.Success(c, report)
 }
 
 func (h *APIHandler) handleRecordView(c *gin.Context, data map[string]interface{}) {
-	// MVP 版本简化处理
-	response.Success(c, gin.H{"success": true})
+	id, _ := data["id"].(string)
+	if id == "" {
+		response.Error(c, response.CodeInvalidParam)
+		return
+	}
+
+	// 只统计已发布的文章
+	post, ok := h.services.IndexService.GetPost(id)
+	if !ok || !post.IsPublished() {
+		response.Error(c, response.CodePostNotFound)
+		return
+	}
+
+	counted := false
+	if h.services.ViewService != nil {
+		counted = h.services.ViewService.Record(id, c.ClientIP(), c.Request.UserAgent())
+	}
+	response.Success(c, gin.H{"success": true, "counted": counted})
 }
 
-// ==================== BFF Handler ====================
+func (h *APIHandler) handlePostReact(c *gin.Context, data map[string]interface{}) {
+	id, _ := data["id"].(string)
+	raw, _ := data["reaction"].(string)
+	if id == "" {
+		response.Error(c, response.CodeInvalidParam)
+		return
+	}
+	reaction, err := valueobject.NewReaction(raw)
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
 
-func (h *APIHandler) handlePageGet(c *gin.Context, data map[string]interface{}) {
-	page, _ := data["page"].(string)
-	modules, _ := data["modules"].([]interface{})
-	params, _ := data["params"].(map[string]interface{})
+	// 只能回应已发布的文章
+	post, ok := h.services.IndexService.GetPost(id)
+	if !ok || !post.IsPublished() {
+		response.Error(c, response.CodePostNotFound)
+		return
+	}
 
-	if page == "" || len(modules) == 0 {
+	reactions, counted, err := h.services.ReactionService.React(id, reaction, c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+	response.Success(c, gin.H{"counted": counted, "reactions": reactions})
+}
+
+// ==================== Comment Handlers ====================
+
+// commentItem 公开评论（含回复）
+type commentItem struct {
+	ID         string        `json:"id"`
+	ParentID   string        `json:"parentId,omitempty"`
+	AuthorName string        `json:"authorName"`
+	EmailHash  string        `json:"emailHash,omitempty"`
+	HTML       string        `json:"html"`
+	CreatedAt  string        `json:"createdAt"`
+	Deleted    bool          `json:"deleted,omitempty"`
+	Replies    []commentItem `json:"replies"`
+}
+
+// adminCommentItem 审核队列中的评论
+type adminCommentItem struct {
+	ID         string `json:"id"`
+	PostID     string `json:"postId"`
+	PostTitle  string `json:"postTitle"`
+	ParentID   string `json:"parentId,omitempty"`
+	AuthorName string `json:"authorName"`
+	EmailHash  string `json:"emailHash,omitempty"`
+	Body       string `json:"body"`
+	HTML       string `json:"html"`
+	Status     string `json:"status"`
+	CreatedAt  string `json:"createdAt"`
+}
+
+func (h *APIHandler) handleCommentList(c *gin.Context, data map[string]interface{}) {
+	postID, _ := data["postId"].(string)
+	if postID == "" {
 		response.Error(c, response.CodeInvalidParam)
 		return
 	}
 
-	// 转换 modules 为字符串切片
-	moduleNames := make([]string, 0, len(modules))
-	for _, m := range modules {
-		if name, ok := m.(string); ok {
-			moduleNames = append(moduleNames, name)
+	post, ok := h.services.IndexService.GetPost(postID)
+	if !ok || !post.IsPublished() {
+		response.Error(c, response.CodePostNotFound)
+		return
+	}
+
+	threads, total, err := h.services.CommentService.Threads(postID)
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"items":          toCommentItems(threads),
+		"total":          total,
+		"commentsClosed": post.CommentsClosed,
+	})
+}
+
+func (h *APIHandler) handleCommentCreate(c *gin.Context, data map[string]interface{}) {
+	postID, _ := data["postId"].(string)
+	if postID == "" {
+		response.Error(c, response.CodeInvalidParam)
+		return
+	}
+
+	input := service.CreateCommentInput{PostID: postID}
+	input.ParentID, _ = data["parentId"].(string)
+	input.AuthorName, _ = data["authorName"].(string)
+	input.Email, _ = data["email"].(string)
+	input.Body, _ = data["body"].(string)
+
+	// 反垃圾检查：拒绝时不保存，可疑时放入垃圾评论队列等待复查
+	held, ok := h.checkSubmission(c, "comment", data, input.AuthorName, input.Email, input.Body)
+	if !ok {
+		return
+	}
+	input.Held = held
+
+	comment, err := h.services.CommentService.Create(input)
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"id":     comment.ID,
+		"status": comment.Status.String(),
+	})
+}
+
+func (h *APIHandler) handleCommentQueue(c *gin.Context, data map[string]interface{}) {
+	opts := repository.CommentListOptions{Page: 1, PageSize: 20}
+	opts.PostID, _ = data["postId"].(string)
+	if raw, ok := data["status"].(string); ok && raw != "" {
+		status, err := valueobject.NewCommentStatus(raw)
+		if err != nil {
+			mapErrorAndRespond(c, err)
+			return
+		}
+		opts.Status = status
+	}
+	if page, ok := data["page"].(float64); ok && page >= 1 {
+		opts.Page = int(page)
+	}
+	if pageSize, ok := data["pageSize"].(float64); ok && pageSize >= 1 {
+		opts.PageSize = int(pageSize)
+		if opts.PageSize > 100 {
+			opts.PageSize = 100
 		}
 	}
 
-	// 调用 BFF handler 内部方法
-	results := h.bffHandler.ExecuteModules(page, moduleNames, params)
+	comments, total, err := h.services.CommentService.List(opts)
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	items := make([]adminCommentItem, 0, len(comments))
+	for _, comment := range comments {
+		item := adminCommentItem{
+			ID:         comment.ID,
+			PostID:     comment.PostID,
+			ParentID:   comment.ParentID,
+			AuthorName: comment.AuthorName,
+			EmailHash:  comment.EmailHash,
+			Body:       comment.Body,
+			HTML:       markdown.RenderSafe(comment.Body),
+			Status:     comment.Status.String(),
+			CreatedAt:  comment.CreatedAt.Format(time.RFC3339),
+		}
+		if post, ok := h.services.IndexService.GetPost(comment.PostID); ok {
+			item.PostTitle = post.Title
+		}
+		items = append(items, item)
+	}
+
 	response.Success(c, gin.H{
-		"page":    page,
-		"modules": results,
+		"items":    items,
+		"total":    total,
+		"page":     opts.Page,
+		"pageSize": opts.PageSize,
 	})
 }
 
-// ==================== File Handler ====================
+func (h *APIHandler) handleCommentModerate(c *gin.Context, data map[string]interface{}) {
+	ids := commentIDs(data)
+	raw, _ := data["status"].(string)
+	if len(ids) == 0 || raw == "" {
+		response.Error(c, response.CodeInvalidParam)
+		return
+	}
+	status, err := valueobject.NewCommentStatus(raw)
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
 
-func (h *APIHandler) handleFileUpload(c *gin.Context) {
-	// 复用原有的上传逻辑
-	handler := NewUploadHandler()
-	handler.Upload(c)
+	comments, err := h.services.CommentService.Moderate(ids, status)
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"updated": len(comments),
+		"status":  status.String(),
+	})
 }
 
-// ==================== Helper Functions ====================
+func (h *APIHandler) handleCommentPurge(c *gin.Context, data map[string]interface{}) {
+	ids := commentIDs(data)
+	if len(ids) == 0 {
+		response.Error(c, response.CodeInvalidParam)
+		return
+	}
 
-func mapErrorAndRespond(c *gin.Context, err error) {
-	switch err {
-	case service.ErrVersionConflict:
-		response.Error(c, response.CodeVersionConflict)
-	case repository.ErrPostNotFound:
-		response.Error(c, response.CodePostNotFound)
+	purged, err := h.services.CommentService.Purge(ids)
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"purged": purged})
+}
+
+// commentIDs 读取 id 或 ids 参数
+func commentIDs(data map[string]interface{}) []string {
+	if id, ok := data["id"].(string); ok && id != "" {
+		return []string{id}
+	}
+	var ids []string
+	list, _ := data["ids"].([]interface{})
+	for _, v := range list {
+		if id, ok := v.(string); ok && id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// toCommentItems 将评论树转换为响应格式，正文以安全模式渲染
+func toCommentItems(threads []*service.CommentThread) []commentItem {
+	items := make([]commentItem, 0, len(threads))
+	for _, thread := range threads {
+		comment := thread.Comment
+		item := commentItem{
+			ID:         comment.ID,
+			ParentID:   comment.ParentID,
+			AuthorName: comment.AuthorName,
+			EmailHash:  comment.EmailHash,
+			CreatedAt:  comment.CreatedAt.Format(time.RFC3339),
+			Deleted:    !comment.IsApproved(),
+			Replies:    toCommentItems(thread.Replies),
+		}
+		if !item.Deleted {
+			item.HTML = markdown.RenderSafe(comment.Body)
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// ==================== Spam Handlers ====================
+
+// checkSubmission 对公开提交执行反垃圾检查，返回是否需要人工复查；拒绝时直接响应错误并返回 ok = false。
+// 表单需带上 formToken（由 spam.token 获取）与保持为空的蜜罐字段 website
+func (h *APIHandler) checkSubmission(c *gin.Context, kind string, data map[string]interface{}, authorName, email, body string) (held bool, ok bool) {
+	sub := service.SpamSubmission{
+		Kind:       kind,
+		IP:         c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+		AuthorName: authorName,
+		Email:      email,
+		Body:       body,
+	}
+	sub.Honeypot, _ = data["website"].(string)
+	sub.Token, _ = data["formToken"].(string)
+
+	decision := h.services.SpamService.Evaluate(sub)
+	if decision.Verdict == service.SpamReject {
+		response.Error(c, response.CodeSpamRejected)
+		return false, false
+	}
+	return decision.Verdict == service.SpamModerate, true
+}
+
+func (h *APIHandler) handleSpamToken(c *gin.Context) {
+	response.Success(c, gin.H{"token": h.services.SpamService.IssueToken()})
+}
+
+func (h *APIHandler) handleDisallowGet(c *gin.Context) {
+	response.Success(c, gin.H{"entries": h.services.SpamService.DisallowList()})
+}
+
+func (h *APIHandler) handleDisallowSave(c *gin.Context, data map[string]interface{}) {
+	list, ok := data["entries"].([]interface{})
+	if !ok {
+		response.Error(c, response.CodeInvalidParam)
+		return
+	}
+	entries := make([]string, 0, len(list))
+	for _, v := range list {
+		if entry, ok := v.(string); ok {
+			entries = append(entries, entry)
+		}
+	}
+
+	saved, err := h.services.SpamService.SetDisallowList(entries)
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"entries": saved})
+}
+
+func (h *APIHandler) handleSpamLog(c *gin.Context, data map[string]interface{}) {
+	limit := 100
+	if v, ok := data["limit"].(float64); ok && v >= 1 {
+		limit = int(v)
+	}
+
+	entries, err := h.services.SpamService.Log(limit)
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"items": entries})
+}
+
+// ==================== Webhook Handlers ====================
+
+// webhookItem 管理端的 Webhook
+type webhookItem struct {
+	ID        string   `json:"id"`
+	URL       string   `json:"url"`
+	Secret    string   `json:"secret"`
+	Events    []string `json:"events"`
+	Active    bool     `json:"active"`
+	CreatedAt string   `json:"createdAt"`
+	UpdatedAt string   `json:"updatedAt"`
+}
+
+// deliveryItem 管理端的投递记录
+type deliveryItem struct {
+	ID            string `json:"id"`
+	WebhookID     string `json:"webhookId"`
+	Event         string `json:"event"`
+	Payload       string `json:"payload"`
+	Status        string `json:"status"`
+	Attempts      int    `json:"attempts"`
+	ResponseCode  int    `json:"responseCode,omitempty"`
+	LastError     string `json:"lastError,omitempty"`
+	RedeliveryOf  string `json:"redeliveryOf,omitempty"`
+	CreatedAt     string `json:"createdAt"`
+	UpdatedAt     string `json:"updatedAt"`
+	NextAttemptAt string `json:"nextAttemptAt,omitempty"`
+}
+
+func (h *APIHandler) handleWebhookList(c *gin.Context) {
+	webhooks, err := h.services.WebhookService.List()
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	items := make([]webhookItem, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		items = append(items, toWebhookItem(webhook))
+	}
+	response.Success(c, gin.H{"items": items})
+}
+
+func (h *APIHandler) handleWebhookCreate(c *gin.Context, data map[string]interface{}) {
+	input := service.CreateWebhookInput{Events: webhookEvents(data)}
+	input.URL, _ = data["url"].(string)
+	input.Secret, _ = data["secret"].(string)
+
+	webhook, err := h.services.WebhookService.Create(input)
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	response.Success(c, toWebhookItem(webhook))
+}
+
+func (h *APIHandler) handleWebhookUpdate(c *gin.Context, data map[string]interface{}) {
+	id, _ := data["id"].(string)
+	if id == "" {
+		response.Error(c, response.CodeInvalidParam)
+		return
+	}
+
+	input := service.UpdateWebhookInput{Events: webhookEvents(data)}
+	if url, ok := data["url"].(string); ok {
+		input.URL = &url
+	}
+	if secret, ok := data["secret"].(string); ok {
+		input.Secret = &secret
+	}
+	if active, ok := data["active"].(bool); ok {
+		input.Active = &active
+	}
+
+	webhook, err := h.services.WebhookService.Update(id, input)
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	response.Success(c, toWebhookItem(webhook))
+}
+
+func (h *APIHandler) handleWebhookDelete(c *gin.Context, data map[string]interface{}) {
+	id, _ := data["id"].(string)
+	if id == "" {
+		response.Error(c, response.CodeInvalidParam)
+		return
+	}
+
+	if err := h.services.WebhookService.Delete(id); err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"id": id})
+}
+
+func (h *APIHandler) handleWebhookDeliveries(c *gin.Context, data map[string]interface{}) {
+	webhookID, _ := data["webhookId"].(string)
+	limit := 50
+	if v, ok := data["limit"].(float64); ok && v >= 1 {
+		limit = int(v)
+		if limit > repository.MaxWebhookDeliveries {
+			limit = repository.MaxWebhookDeliveries
+		}
+	}
+
+	deliveries, err := h.services.WebhookService.Deliveries(webhookID, limit)
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	items := make([]deliveryItem, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		items = append(items, toDeliveryItem(delivery))
+	}
+	response.Success(c, gin.H{"items": items})
+}
+
+func (h *APIHandler) handleWebhookRedeliver(c *gin.Context, data map[string]interface{}) {
+	id, _ := data["id"].(string)
+	if id == "" {
+		response.Error(c, response.CodeInvalidParam)
+		return
+	}
+
+	delivery, err := h.services.WebhookService.Redeliver(id)
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	response.Success(c, toDeliveryItem(delivery))
+}
+
+// webhookEvents 读取 events 参数，未传入时返回 nil
+func webhookEvents(data map[string]interface{}) []string {
+	list, ok := data["events"].([]interface{})
+	if !ok {
+		return nil
+	}
+	events := make([]string, 0, len(list))
+	for _, v := range list {
+		if event, ok := v.(string); ok {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// toWebhookItem 将 Webhook 转换为响应格式
+func toWebhookItem(webhook *domain.Webhook) webhookItem {
+	events := make([]string, 0, len(webhook.Events))
+	for _, event := range webhook.Events {
+		events = append(events, string(event))
+	}
+	return webhookItem{
+		ID:        webhook.ID,
+		URL:       webhook.URL,
+		Secret:    webhook.Secret,
+		Events:    events,
+		Active:    webhook.Active,
+		CreatedAt: webhook.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: webhook.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// toDeliveryItem 将投递记录转换为响应格式
+func toDeliveryItem(delivery *domain.WebhookDelivery) deliveryItem {
+	item := deliveryItem{
+		ID:           delivery.ID,
+		WebhookID:    delivery.WebhookID,
+		Event:        string(delivery.Event),
+		Payload:      delivery.Payload,
+		Status:       string(delivery.Status),
+		Attempts:     delivery.Attempts,
+		ResponseCode: delivery.ResponseCode,
+		LastError:    delivery.LastError,
+		RedeliveryOf: delivery.RedeliveryOf,
+		CreatedAt:    delivery.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    delivery.UpdatedAt.Format(time.RFC3339),
+	}
+	if delivery.Status == domain.DeliveryPending && !delivery.NextAttemptAt.IsZero() {
+		item.NextAttemptAt = delivery.NextAttemptAt.Format(time.RFC3339)
+	}
+	return item
+}
+
+// ==================== Newsletter Handlers ====================
+
+// subscriberItem 管理端的订阅者（不含令牌）
+type subscriberItem struct {
+	Email       string `json:"email"`
+	Status      string `json:"status"`
+	CreatedAt   string `json:"createdAt"`
+	ConfirmedAt string `json:"confirmedAt,omitempty"`
+}
+
+// issueItem 管理端的新文章通知
+type issueItem struct {
+	PostID      string `json:"postId"`
+	Title       string `json:"title"`
+	Pending     int    `json:"pending"`
+	Sent        int    `json:"sent"`
+	Failed      int    `json:"failed"`
+	LastError   string `json:"lastError,omitempty"`
+	Canceled    bool   `json:"canceled,omitempty"`
+	CreatedAt   string `json:"createdAt"`
+	CompletedAt string `json:"completedAt,omitempty"`
+}
+
+func (h *APIHandler) handleNewsletterSubscribe(c *gin.Context, data map[string]interface{}) {
+	email, _ := data["email"].(string)
+	if email == "" {
+		response.Error(c, response.CodeInvalidParam)
+		return
+	}
+
+	// 反垃圾检查（含按 IP 与邮箱的频率限制）。订阅没有人工复查队列，可疑的提交同样拒绝
+	held, ok := h.checkSubmission(c, "newsletter", data, "", email, "")
+	if !ok {
+		return
+	}
+	if held {
+		response.Error(c, response.CodeSpamRejected)
+		return
+	}
+
+	if err := h.services.NewsletterService.Subscribe(email); err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	// 已订阅的邮箱同样返回 pending，不暴露订阅状态
+	response.Success(c, gin.H{"status": string(domain.SubscriberPending)})
+}
+
+func (h *APIHandler) handleNewsletterConfirm(c *gin.Context, data map[string]interface{}) {
+	token, _ := data["token"].(string)
+	if token == "" {
+		response.Error(c, response.CodeInvalidParam)
+		return
+	}
+
+	subscriber, err := h.services.NewsletterService.Confirm(token)
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"email": subscriber.Email, "status": string(subscriber.Status)})
+}
+
+func (h *APIHandler) handleNewsletterUnsubscribe(c *gin.Context, data map[string]interface{}) {
+	token, _ := data["token"].(string)
+	if token == "" {
+		response.Error(c, response.CodeInvalidParam)
+		return
+	}
+
+	subscriber, err := h.services.NewsletterService.Unsubscribe(token)
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"email": subscriber.Email})
+}
+
+func (h *APIHandler) handleNewsletterSubscribers(c *gin.Context) {
+	subscribers, err := h.services.NewsletterService.Subscribers()
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	items := make([]subscriberItem, 0, len(subscribers))
+	confirmed := 0
+	for _, subscriber := range subscribers {
+		item := subscriberItem{
+			Email:     subscriber.Email,
+			Status:    string(subscriber.Status),
+			CreatedAt: subscriber.CreatedAt.Format(time.RFC3339),
+		}
+		if subscriber.IsConfirmed() {
+			item.ConfirmedAt = subscriber.ConfirmedAt.Format(time.RFC3339)
+			confirmed++
+		}
+		items = append(items, item)
+	}
+
+	response.Success(c, gin.H{
+		"items":     items,
+		"total":     len(items),
+		"confirmed": confirmed,
+		"enabled":   h.services.NewsletterService.Enabled(),
+	})
+}
+
+func (h *APIHandler) handleNewsletterIssues(c *gin.Context) {
+	issues, err := h.services.NewsletterService.Issues()
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	items := make([]issueItem, 0, len(issues))
+	for _, issue := range issues {
+		item := issueItem{
+			PostID:    issue.PostID,
+			Title:     issue.Title,
+			Pending:   len(issue.Pending),
+			Sent:      issue.Sent,
+			Failed:    issue.Failed,
+			LastError: issue.LastError,
+			Canceled:  issue.Canceled,
+			CreatedAt: issue.CreatedAt.Format(time.RFC3339),
+		}
+		if !issue.CompletedAt.IsZero() {
+			item.CompletedAt = issue.CompletedAt.Format(time.RFC3339)
+		}
+		items = append(items, item)
+	}
+
+	response.Success(c, gin.H{"items": items})
+}
+
+func (h *APIHandler) handleNewsletterRemove(c *gin.Context, data map[string]interface{}) {
+	email, _ := data["email"].(string)
+	if email == "" {
+		response.Error(c, response.CodeInvalidParam)
+		return
+	}
+
+	if err := h.services.NewsletterService.Remove(email); err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"email": email})
+}
+
+// ==================== Link Handlers ====================
+
+func (h *APIHandler) handlePostLinks(c *gin.Context, data map[string]interface{}) {
+	id, _ := data["id"].(string)
+	if id == "" {
+		response.Error(c, response.CodeInvalidParam)
+		return
+	}
+
+	post, ok := h.services.IndexService.GetPost(id)
+	if !ok {
+		response.Error(c, response.CodePostNotFound)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"outbound": h.services.LinkService.Outbound(id),
+		"inbound":  h.services.LinkService.Inbound(post.Slug.String()),
+	})
+}
+
+func (h *APIHandler) handleLinksReport(c *gin.Context) {
+	// 旧地址已配置重定向的链接仍可访问，不算失效
+	redirected := func(path string) bool {
+		_, err := h.services.RedirectService.Resolve(path)
+		return err == nil
+	}
+
+	report, err := h.services.LinkService.Report(redirected)
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	response.Success(c, report)
+}
+
+// ==================== Search Handlers ====================
+
+// 搜索分页上限：最大页码与每页最多结果数（保证偏移量不溢出）
+const (
+	maxSearchPage     = 10000
+	maxSearchPageSize = 50
+)
+
+func (h *APIHandler) handleSearch(c *gin.Context, data map[string]interface{}, isAdmin bool) {
+	query, _ := data["q"].(string)
+	if query == "" {
+		response.Error(c, response.CodeInvalidParam)
+		return
+	}
+
+	opts := service.SearchOptions{
+		Query:    query,
+		Page:     1,
+		PageSize: 10,
+	}
+	if page, ok := data["page"].(float64); ok {
+		opts.Page = int(min(page, maxSearchPage))
+	}
+	if pageSize, ok := data["pageSize"].(float64); ok {
+		opts.PageSize = int(min(pageSize, maxSearchPageSize))
+	}
+	// 仅管理端可以搜索草稿
+	if isAdmin {
+		opts.IncludeDrafts = true
+		if includeDrafts, ok := data["includeDrafts"].(bool); ok {
+			opts.IncludeDrafts = includeDrafts
+		}
+	}
+
+	response.Success(c, h.services.SearchService.Search(opts))
+}
+
+// ==================== BFF Handler ====================
+
+func (h *APIHandler) handlePageGet(c *gin.Context, data map[string]interface{}) {
+	page, _ := data["page"].(string)
+	modules, _ := data["modules"].([]interface{})
+	params, _ := data["params"].(map[string]interface{})
+
+	if page == "" || len(modules) == 0 {
+		response.Error(c, response.CodeInvalidParam)
+		return
+	}
+
+	// 转换 modules 为字符串切片
+	moduleNames := make([]string, 0, len(modules))
+	for _, m := range modules {
+		if name, ok := m.(string); ok {
+			moduleNames = append(moduleNames, name)
+		}
+	}
+
+	// 调用 BFF handler 内部方法
+	results := h.services.BFFHandler.ExecuteModules(sitePrefix(c), page, moduleNames, params)
+	response.Success(c, gin.H{
+		"page":    page,
+		"modules": results,
+	})
+}
+
+// ==================== File Handler ====================
+
+func (h *APIHandler) handleFileUpload(c *gin.Context) {
+	// 复用原有的上传逻辑
+	handler := NewUploadHandler(h.services.Uploads)
+	handler.Upload(c)
+}
+
+func (h *APIHandler) handleMediaList(c *gin.Context, data map[string]interface{}) {
+	prefix, _ := data["prefix"].(string)
+
+	infos, err := h.services.Uploads.List(prefix)
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	items := make([]gin.H, 0, len(infos))
+	for _, info := range infos {
+		items = append(items, gin.H{
+			"key":         info.Key,
+			"url":         sitePrefix(c) + storage.URLPrefix + "/" + info.Key,
+			"size":        info.Size,
+			"modTime":     info.ModTime,
+			"contentType": info.ContentType,
+		})
+	}
+	response.Success(c, gin.H{
+		"items": items,
+		"total": len(items),
+	})
+}
+
+func (h *APIHandler) handleMediaDelete(c *gin.Context, data map[string]interface{}) {
+	key, _ := data["key"].(string)
+	if key == "" {
+		response.Error(c, response.CodeInvalidParam)
+		return
+	}
+
+	if _, err := h.services.Uploads.Stat(key); err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+	if err := h.services.Uploads.Delete(key); err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+	response.Success(c, gin.H{"success": true})
+}
+
+// ==================== Backup Handlers ====================
+
+func (h *APIHandler) handleSiteExport(c *gin.Context, data map[string]interface{}) {
+	format, _ := data["format"].(string)
+	if format == "" {
+		format = service.ArchiveZip
+	}
+	if !service.IsValidArchiveFormat(format) {
+		response.Error(c, response.CodeInvalidParam)
+		return
+	}
+
+	// 先写入临时文件再发送，避免慢速下载长时间占用仓库读锁
+	tmp, err := os.CreateTemp("", "ventus-export-*")
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if _, err := h.services.BackupService.Export(tmp, format); err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+	size, err := tmp.Seek(0, io.SeekEnd)
+	if err == nil {
+		_, err = tmp.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	filename := service.BackupFileName(format, time.Now())
+	c.DataFromReader(http.StatusOK, size, service.ArchiveContentType(format), tmp, map[string]string{
+		"Content-Disposition": fmt.Sprintf(`attachment; filename="%s"`, filename),
+	})
+}
+
+func (h *APIHandler) handleSiteImport(c *gin.Context, data map[string]interface{}) {
+	mode, _ := data["mode"].(string)
+	if mode == "" {
+		mode = service.RestoreMerge
+	}
+	if !service.IsValidRestoreMode(mode) {
+		response.Error(c, response.CodeInvalidRestoreMode)
+		return
+	}
+
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		response.Error(c, response.CodeFileNotFound)
+		return
+	}
+	defer file.Close()
+
+	report, err := h.services.BackupService.Import(file, mode)
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	// 站点数据已在恢复的临界区内重新加载；文章直接改写了存储文件，
+	// 同步重建搜索与文章索引，并重新读取依赖已发布文章的数据
+	if err := h.rebuildIndexes(); err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+	if err := h.services.WebhookService.Rebuild(); err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+	if err := h.services.NewsletterService.Rebuild(); err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	response.Success(c, report)
+}
+
+// ==================== Import Handlers ====================
+
+func (h *APIHandler) handleImportWordPress(c *gin.Context, data map[string]interface{}) {
+	dryRun, _ := data["dryRun"].(bool)
+	includePages, _ := data["includePages"].(bool)
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		response.Error(c, response.CodeFileNotFound)
+		return
+	}
+	defer file.Close()
+
+	// 上传文件先落盘，由后台任务读取并在结束后删除
+	tmp, err := os.CreateTemp("", "ventus-wxr-*.xml")
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+	if _, err := io.Copy(tmp, file); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		mapErrorAndRespond(c, err)
+		return
+	}
+	tmp.Close()
+
+	editor := c.GetString("username")
+	job, err := h.importJobs.Start(func(progress func(done, total int)) (*service.ImportReport, error) {
+		defer os.Remove(tmp.Name())
+
+		f, err := os.Open(tmp.Name())
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		return h.services.ImportService.ImportWordPress(f, header.Filename, service.ImportOptions{
+			DryRun:       dryRun,
+			Editor:       editor,
+			IncludePages: includePages,
+			Progress:     progress,
+		})
+	})
+	if err != nil {
+		os.Remove(tmp.Name())
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	response.Success(c, job)
+}
+
+func (h *APIHandler) handleImportStatus(c *gin.Context, data map[string]interface{}) {
+	jobID, _ := data["jobId"].(string)
+	if jobID == "" {
+		response.Error(c, response.CodeInvalidParam)
+		return
+	}
+
+	job, err := h.importJobs.Get(jobID)
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	response.Success(c, job)
+}
+
+// ==================== Redirect Handlers ====================
+
+func (h *APIHandler) handleRedirectResolve(c *gin.Context, data map[string]interface{}) {
+	path, _ := data["path"].(string)
+	if path == "" {
+		response.Error(c, response.CodeInvalidParam)
+		return
+	}
+
+	post, err := h.services.RedirectService.Resolve(path)
+	if err != nil {
+		mapErrorAndRespond(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"id":   post.ID,
+		"slug": post.Slug.String(),
+		"url":  sitePrefix(c) + service.PostPath(post.Slug.String()),
+	})
+}
+
+// HandleRedirect 将未匹配路由的 GET 请求按旧地址重定向到文章，没有重定向时返回 404
+func (h *APIHandler) HandleRedirect(c *gin.Context) {
+	if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+		if post, err := h.services.RedirectService.Resolve(c.Request.URL.RequestURI()); err == nil {
+			c.Redirect(http.StatusMovedPermanently, sitePrefix(c)+service.PostPath(post.Slug.String()))
+			return
+		}
+	}
+	response.Error(c, response.CodeNotFound)
+}
+
+// sitePrefix 返回站点路由去掉的路径前缀（见 SiteRouter），没有时为空
+func sitePrefix(c *gin.Context) string {
+	return strings.TrimRight(c.GetHeader(site.ForwardedPrefixHeader), "/")
+}
+
+// ==================== Helper Functions ====================
+
+// rebuildIndexes 存储文件被直接改写后重建搜索索引、链接图与文章索引
+func (h *APIHandler) rebuildIndexes() error {
+	if err := h.services.SearchService.Rebuild(); err != nil {
+		return err
+	}
+	if err := h.services.LinkService.Rebuild(); err != nil {
+		return err
+	}
+	return h.services.IndexService.Rebuild()
+}
+
+// bindAdminRequest 解析管理 API 请求。
+// 上传类场景使用 multipart/form-data：sceneCode 与 data（JSON 字符串）为表单字段，文件为 file 字段
+func bindAdminRequest(c *gin.Context) (APIRequest, error) {
+	var req APIRequest
+	if !strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		err := c.ShouldBindJSON(&req)
+		return req, err
+	}
+
+	req.SceneCode = c.PostForm("sceneCode")
+	if req.SceneCode == "" {
+		return req, errors.New("sceneCode is required")
+	}
+	if data := c.PostForm("data"); data != "" {
+		if err := json.Unmarshal([]byte(data), &req.Data); err != nil {
+			return req, err
+		}
+	}
+	if req.Data == nil {
+		req.Data = map[string]interface{}{}
+	}
+	return req, nil
+}
+
+func mapErrorAndRespond(c *gin.Context, err error) {
+	code, message := errorCode(err)
+	response.ErrorWithMessage(c, code, message)
+}
+
+// errorCode 将业务错误映射为错误码与错误信息
+func errorCode(err error) (int, string) {
+	// 备份、导入与批量错误会携带具体原因
+	switch {
+	case errors.Is(err, repository.ErrBackupUnsupported):
+		return response.CodeBackupUnsupported, response.GetMessage(response.CodeBackupUnsupported)
+	case errors.Is(err, service.ErrInvalidArchive), errors.Is(err, service.ErrArchiveFormat):
+		return response.CodeInvalidArchive, err.Error()
+	case errors.Is(err, service.ErrChecksumMismatch):
+		return response.CodeChecksumMismatch, err.Error()
+	case errors.Is(err, service.ErrRestoreMode):
+		return response.CodeInvalidRestoreMode, response.GetMessage(response.CodeInvalidRestoreMode)
+	case errors.Is(err, service.ErrInvalidWXR):
+		return response.CodeInvalidImportFile, err.Error()
+	case errors.Is(err, service.ErrInvalidBulkRequest), errors.Is(err, repository.ErrInvalidBatch):
+		return response.CodeInvalidParam, err.Error()
+	case errors.Is(err, storage.ErrInvalidKey):
+		return response.CodeInvalidParam, err.Error()
+	case errors.Is(err, storage.ErrNotFound):
+		return response.CodeFileNotFound, response.GetMessage(response.CodeFileNotFound)
+	case errors.Is(err, domain.ErrEmptyCommentAuthor), errors.Is(err, domain.ErrEmptyCommentBody),
+		errors.Is(err, domain.ErrCommentAuthorTooLong), errors.Is(err, domain.ErrCommentBodyTooLong),
+		errors.Is(err, domain.ErrInvalidCommentEmail):
+		return response.CodeInvalidComment, err.Error()
+	case errors.Is(err, valueobject.ErrInvalidCommentStatus), errors.Is(err, valueobject.ErrInvalidReaction):
+		return response.CodeInvalidParam, err.Error()
+	case errors.Is(err, domain.ErrInvalidWebhookURL), errors.Is(err, domain.ErrInvalidWebhookEvent),
+		errors.Is(err, domain.ErrNoWebhookEvents):
+		return response.CodeInvalidWebhook, err.Error()
+	case errors.Is(err, mail.ErrInvalidMessage):
+		return response.CodeInvalidSubscriberEmail, response.GetMessage(response.CodeInvalidSubscriberEmail)
+	}
+
+	code := response.CodeInternalError
+	switch err {
+	case service.ErrVersionConflict:
+		code = response.CodeVersionConflict
+	case repository.ErrPostNotFound:
+		code = response.CodePostNotFound
 	case repository.ErrSlugExists:
-		response.Error(c, response.CodeSlugExists)
+		code = response.CodeSlugExists
+	case repository.ErrInvalidCursor:
+		code = response.CodeInvalidParam
+	case repository.ErrRevisionNotFound:
+		code = response.CodeRevisionNotFound
+	case repository.ErrHistoryUnsupported:
+		code = response.CodeHistoryUnsupported
+	case repository.ErrRebuildUnsupported:
+		code = response.CodeRebuildUnsupported
+	case repository.ErrCheckUnsupported:
+		code = response.CodeCheckUnsupported
+	case repository.ErrRedirectNotFound:
+		code = response.CodeRedirectNotFound
+	case repository.ErrBatchUnsupported:
+		code = response.CodeBatchUnsupported
+	case service.ErrBulkAborted:
+		code = response.CodeBulkAborted
+	case service.ErrImportRunning:
+		code = response.CodeImportRunning
+	case service.ErrImportJobNotFound:
+		code = response.CodeImportJobNotFound
+	case repository.ErrCommentNotFound:
+		code = response.CodeCommentNotFound
+	case service.ErrCommentsClosed:
+		code = response.CodeCommentsClosed
+	case service.ErrInvalidCommentParent:
+		code = response.CodeInvalidCommentParent
+	case service.ErrReactionRateLimited:
+		code = response.CodeRateLimited
+	case repository.ErrWebhookNotFound:
+		code = response.CodeWebhookNotFound
+	case repository.ErrDeliveryNotFound:
+		code = response.CodeDeliveryNotFound
+	case service.ErrNewsletterDisabled:
+		code = response.CodeNewsletterDisabled
+	case service.ErrInvalidNewsletterToken:
+		code = response.CodeInvalidNewsletterToken
+	case domain.ErrInvalidSubscriberEmail:
+		code = response.CodeInvalidSubscriberEmail
+	case repository.ErrSubscriberNotFound:
+		code = response.CodeSubscriberNotFound
 	case domain.ErrEmptyTitle:
-		response.Error(c, response.CodeInvalidTitle)
+		code = response.CodeInvalidTitle
 	case domain.ErrEmptyContent:
-		response.Error(c, response.CodeInvalidContent)
+		code = response.CodeInvalidContent
 	case domain.ErrAlreadyPublished:
-		response.Error(c, response.CodeInvalidStatus)
+		code = response.CodeInvalidStatus
 	case domain.ErrNotPublished:
-		response.Error(c, response.CodeInvalidStatus)
+		code = response.CodeInvalidStatus
 	default:
-		response.ErrorWithMessage(c, response.CodeInternalError, err.Error())
+		return code, err.Error()
 	}
+	return code, response.GetMessage(code)
 }
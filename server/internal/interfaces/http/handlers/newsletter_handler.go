@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/next-ai-ventus/server/internal/service"
+)
+
+// NewsletterHandler 处理邮件中的确认与退订链接，返回简单的 HTML 页面
+type NewsletterHandler struct {
+	newsletter *service.NewsletterService
+}
+
+// NewNewsletterHandler 创建邮件链接处理器
+func NewNewsletterHandler(newsletter *service.NewsletterService) *NewsletterHandler {
+	return &NewsletterHandler{
+		newsletter: newsletter,
+	}
+}
+
+// ConfirmPage 显示确认订阅的按钮（GET ?token=）。GET 请求不修改状态，避免邮件扫描器预取链接时误确认
+func (h *NewsletterHandler) ConfirmPage(c *gin.Context) {
+	newsletterForm(c, "Confirm your subscription",
+		"Click the button below to receive an email when a new post is published.", "Confirm")
+}
+
+// Confirm 确认订阅（POST ?token=）
+func (h *NewsletterHandler) Confirm(c *gin.Context) {
+	subscriber, err := h.newsletter.Confirm(c.Query("token"))
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	newsletterPage(c, http.StatusOK, "Subscription confirmed",
+		fmt.Sprintf("%s will receive an email when a new post is published.", subscriber.Email))
+}
+
+// UnsubscribePage 显示退订按钮（GET ?token=）
+func (h *NewsletterHandler) UnsubscribePage(c *gin.Context) {
+	newsletterForm(c, "Unsubscribe", "Click the button below to stop receiving emails about new posts.", "Unsubscribe")
+}
+
+// Unsubscribe 退订（POST ?token=）。邮件客户端的一键退订（RFC 8058）也以 POST 请求同一地址
+func (h *NewsletterHandler) Unsubscribe(c *gin.Context) {
+	subscriber, err := h.newsletter.Unsubscribe(c.Query("token"))
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	newsletterPage(c, http.StatusOK, "Unsubscribed",
+		fmt.Sprintf("%s will no longer receive emails about new posts.", subscriber.Email))
+}
+
+// fail 令牌无效时返回 400，其他错误返回 500
+func (h *NewsletterHandler) fail(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrInvalidNewsletterToken) {
+		newsletterPage(c, http.StatusBadRequest, "Link expired", "This link is invalid or has already been used.")
+		return
+	}
+	newsletterPage(c, http.StatusInternalServerError, "Something went wrong", "Please try again later.")
+}
+
+// newsletterPage 返回只有标题与一句说明的页面
+func newsletterPage(c *gin.Context, status int, title, message string) {
+	renderNewsletterPage(c, status, title, message, "")
+}
+
+// newsletterForm 返回带提交按钮的页面，按钮以 POST 携带同一令牌请求当前地址（含站点路径前缀）
+func newsletterForm(c *gin.Context, title, message, button string) {
+	action := sitePrefix(c) + c.Request.URL.Path + "?token=" + url.QueryEscape(c.Query("token"))
+	renderNewsletterPage(c, http.StatusOK, title, message, fmt.Sprintf(
+		`<form method="post" action="%s"><button type="submit">%s</button></form>`,
+		html.EscapeString(action), html.EscapeString(button)))
+}
+
+// renderNewsletterPage 渲染页面，form 为已转义的表单片段
+func renderNewsletterPage(c *gin.Context, status int, title, message, form string) {
+	c.Header("Cache-Control", "no-store")
+	c.Data(status, "text/html; charset=utf-8", []byte(fmt.Sprintf(
+		`<!DOCTYPE html><html><head><meta charset="utf-8"><meta name="viewport" content="width=device-width"><title>%s</title></head>`+
+			`<body style="font-family:sans-serif;max-width:480px;margin:80px auto;text-align:center"><h1>%s</h1><p>%s</p>%s</body></html>`,
+		html.EscapeString(title), html.EscapeString(title), html.EscapeString(message), form)))
+}
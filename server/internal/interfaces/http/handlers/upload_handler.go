@@ -1,25 +1,35 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
-	"os"
+	"io"
+	"net/http"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/next-ai-ventus/server/internal/interfaces/http/response"
+	"github.com/next-ai-ventus/server/internal/storage"
 )
 
+// UploadsPath 单站点模式下上传文件的默认存储目录
+const UploadsPath = "./storage/uploads"
+
+// signedURLExpiry 重定向到存储签名地址时的有效期
+const signedURLExpiry = time.Hour
+
 // UploadHandler 上传处理器
 type UploadHandler struct {
-	basePath string
+	uploads storage.Blob
 }
 
-// NewUploadHandler 创建上传处理器
-func NewUploadHandler() *UploadHandler {
+// NewUploadHandler 创建上传处理器，文件保存到站点的上传存储 uploads
+func NewUploadHandler(uploads storage.Blob) *UploadHandler {
 	return &UploadHandler{
-		basePath: "./storage/uploads",
+		uploads: uploads,
 	}
 }
 
@@ -52,30 +62,63 @@ func (h *UploadHandler) Upload(c *gin.Context) {
 		now.Year(), now.Month(), now.Day(),
 		header.Filename)
 
-	// 创建目录：uploads/YYYY/MM/
-	dir := filepath.Join(h.basePath,
-		fmt.Sprintf("%d", now.Year()),
-		fmt.Sprintf("%02d", now.Month()))
-
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		response.Error(c, response.CodeUploadFailed)
-		return
-	}
+	// 键：YYYY/MM/文件名
+	key := fmt.Sprintf("%d/%02d/%s", now.Year(), now.Month(), filename)
 
 	// 保存文件
-	dst := filepath.Join(dir, filename)
-	if err := c.SaveUploadedFile(header, dst); err != nil {
+	if err := h.uploads.Put(key, file, header.Header.Get("Content-Type")); err != nil {
 		response.Error(c, response.CodeUploadFailed)
 		return
 	}
 
 	// 返回访问 URL
-	url := fmt.Sprintf("/uploads/%d/%02d/%s", now.Year(), now.Month(), filename)
+	url := sitePrefix(c) + storage.URLPrefix + "/" + key
 	response.Success(c, gin.H{
 		"url": url,
 	})
 }
 
+// Serve 读取上传文件（GET/HEAD /uploads/*key）；存储要求重定向时跳转到签名地址
+func (h *UploadHandler) Serve(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	if _, err := storage.CleanKey(key); err != nil {
+		c.Status(http.StatusNotFound)
+		return
+	}
+
+	if r, ok := h.uploads.(storage.Redirector); ok && r.RedirectReads() {
+		url, err := h.uploads.SignedURL(key, signedURLExpiry)
+		if err != nil {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.Redirect(http.StatusFound, url)
+		return
+	}
+
+	body, info, err := h.uploads.Get(key)
+	if errors.Is(err, storage.ErrNotFound) {
+		c.Status(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+	defer body.Close()
+
+	c.Header("Content-Type", info.ContentType)
+	// 本地文件支持 Range 与条件请求
+	if rs, ok := body.(io.ReadSeeker); ok {
+		http.ServeContent(c.Writer, c.Request, filepath.Base(key), info.ModTime, rs)
+		return
+	}
+	if !info.ModTime.IsZero() {
+		c.Header("Last-Modified", info.ModTime.UTC().Format(http.TimeFormat))
+	}
+	c.DataFromReader(http.StatusOK, info.Size, info.ContentType, body, nil)
+}
+
 // isAllowedFileType 检查文件类型是否允许
 func isAllowedFileType(ext string) bool {
 	allowed := map[string]bool{
@@ -1,22 +1,18 @@
 package middleware
 
 import (
-	"os"
+	"errors"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 
 	"github.com/next-ai-ventus/server/internal/interfaces/http/response"
+	"github.com/next-ai-ventus/server/internal/service"
 )
 
-// JWTAuth JWT 认证中间件
-func JWTAuth() gin.HandlerFunc {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		secret = "your-secret-key" // 默认值，生产环境应使用环境变量
-	}
-
+// JWTAuth JWT 认证中间件（使用当前站点的密钥与 audience 校验）
+func JWTAuth(authService *service.AuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 从 Cookie 获取 token
 		tokenString, err := c.Cookie("token")
@@ -40,15 +36,9 @@ func JWTAuth() gin.HandlerFunc {
 		}
 
 		// 解析 token
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return []byte(secret), nil
-		})
-
+		claims, err := authService.ValidateToken(tokenString)
 		if err != nil {
-			if err == jwt.ErrTokenExpired {
+			if errors.Is(err, jwt.ErrTokenExpired) {
 				response.Error(c, response.CodeTokenExpired)
 			} else {
 				response.Error(c, response.CodeTokenInvalid)
@@ -57,18 +47,7 @@ func JWTAuth() gin.HandlerFunc {
 			return
 		}
 
-		if !token.Valid {
-			response.Error(c, response.CodeTokenInvalid)
-			c.Abort()
-			return
-		}
-
-		// 提取 claims
-		if claims, ok := token.Claims.(jwt.MapClaims); ok {
-			if username, ok := claims["username"].(string); ok {
-				c.Set("username", username)
-			}
-		}
+		c.Set("username", claims.Username)
 
 		c.Next()
 	}
@@ -44,6 +44,13 @@ const (
 	CodeVersionConflict     = 206
 	CodeInvalidStatus       = 207
 	CodeInvalidTag          = 208
+	CodeRevisionNotFound    = 209
+	CodeHistoryUnsupported  = 210
+	CodeRebuildUnsupported  = 211
+	CodeCheckUnsupported    = 212
+	CodeRedirectNotFound    = 213
+	CodeBatchUnsupported    = 214
+	CodeBulkAborted         = 215
 
 	// BFF 模块错误 (300-399)
 	CodeModuleNotFound      = 300
@@ -54,6 +61,38 @@ const (
 	CodeInvalidFileType     = 401
 	CodeFileTooLarge        = 402
 	CodeFileNotFound        = 403
+
+	// 备份错误 (500-599)
+	CodeBackupUnsupported   = 500
+	CodeInvalidArchive      = 501
+	CodeChecksumMismatch    = 502
+	CodeInvalidRestoreMode  = 503
+
+	// 导入错误 (600-699)
+	CodeInvalidImportFile   = 600
+	CodeImportRunning       = 601
+	CodeImportJobNotFound   = 602
+
+	// 评论错误 (700-799)
+	CodeCommentNotFound      = 700
+	CodeCommentsClosed       = 701
+	CodeInvalidComment       = 702
+	CodeInvalidCommentParent = 703
+
+	// 反垃圾错误 (800-899)
+	CodeSpamRejected = 800
+	CodeRateLimited  = 801
+
+	// Webhook 错误 (900-999)
+	CodeWebhookNotFound  = 900
+	CodeDeliveryNotFound = 901
+	CodeInvalidWebhook   = 902
+
+	// 邮件订阅错误 (1000-1099)
+	CodeNewsletterDisabled     = 1000
+	CodeInvalidSubscriberEmail = 1001
+	CodeInvalidNewsletterToken = 1002
+	CodeSubscriberNotFound     = 1003
 )
 
 // CodeMessageMap 错误码映射表
@@ -82,6 +121,13 @@ var CodeMessageMap = map[int]string{
 	CodeVersionConflict:    "version conflict",
 	CodeInvalidStatus:      "invalid status",
 	CodeInvalidTag:         "invalid tag",
+	CodeRevisionNotFound:   "revision not found",
+	CodeHistoryUnsupported: "post history not enabled",
+	CodeRebuildUnsupported: "index rebuild not supported",
+	CodeCheckUnsupported:   "content check not supported",
+	CodeRedirectNotFound:   "redirect not found",
+	CodeBatchUnsupported:   "atomic batch not supported",
+	CodeBulkAborted:        "not applied: another post in the batch failed",
 
 	CodeModuleNotFound:     "module not found",
 	CodeModuleExecuteError: "module execute error",
@@ -90,6 +136,32 @@ var CodeMessageMap = map[int]string{
 	CodeInvalidFileType:    "invalid file type",
 	CodeFileTooLarge:       "file too large",
 	CodeFileNotFound:       "file not found",
+
+	CodeBackupUnsupported:  "backup not supported",
+	CodeInvalidArchive:     "invalid backup archive",
+	CodeChecksumMismatch:   "backup checksum mismatch",
+	CodeInvalidRestoreMode: "invalid restore mode",
+
+	CodeInvalidImportFile:  "invalid import file",
+	CodeImportRunning:      "another import is running",
+	CodeImportJobNotFound:  "import job not found",
+
+	CodeCommentNotFound:      "comment not found",
+	CodeCommentsClosed:       "comments are closed",
+	CodeInvalidComment:       "invalid comment",
+	CodeInvalidCommentParent: "invalid parent comment",
+
+	CodeSpamRejected: "submission rejected",
+	CodeRateLimited:  "too many requests",
+
+	CodeWebhookNotFound:  "webhook not found",
+	CodeDeliveryNotFound: "webhook delivery not found",
+	CodeInvalidWebhook:   "invalid webhook",
+
+	CodeNewsletterDisabled:     "newsletter is not available",
+	CodeInvalidSubscriberEmail: "invalid email address",
+	CodeInvalidNewsletterToken: "invalid or expired link",
+	CodeSubscriberNotFound:     "subscriber not found",
 }
 
 // GetMessage 获取错误码对应的错误信息
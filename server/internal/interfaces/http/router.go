@@ -3,20 +3,19 @@ package http
 import (
 	"github.com/gin-gonic/gin"
 
-	"github.com/next-ai-ventus/server/internal/interfaces/bff"
 	"github.com/next-ai-ventus/server/internal/interfaces/http/handlers"
 	"github.com/next-ai-ventus/server/internal/interfaces/http/middleware"
 	"github.com/next-ai-ventus/server/internal/interfaces/http/response"
-	"github.com/next-ai-ventus/server/internal/service"
+	"github.com/next-ai-ventus/server/internal/storage"
 )
 
-// SetupRouter 配置路由
-func SetupRouter(
-	postService *service.PostService,
-	authService *service.AuthService,
-	bffHandler *bff.Handler,
-) *gin.Engine {
+// SetupRouter 配置路由。trustedProxies 为可信反向代理的 IP 或网段，
+// 只有来自这些地址的请求才采信 X-Forwarded-For，否则访客可伪造 IP 绕过按 IP 的限流与去重
+func SetupRouter(services *handlers.Services, trustedProxies []string) (*gin.Engine, error) {
 	r := gin.Default()
+	if err := r.SetTrustedProxies(trustedProxies); err != nil {
+		return nil, err
+	}
 
 	// 健康检查
 	r.GET("/health", func(c *gin.Context) {
@@ -24,25 +23,32 @@ func SetupRouter(
 	})
 
 	// 创建统一 API 处理器
-	apiHandler := handlers.NewAPIHandler(postService, authService, bffHandler)
+	apiHandler := handlers.NewAPIHandler(services)
 
 	// 公开 API - 统一 POST
 	r.POST("/api/public", apiHandler.HandlePublic)
 
-	// 静态文件（上传的图片）
-	r.Static("/uploads", "./storage/uploads")
+	// 上传的图片（从上传存储读取）
+	uploadHandler := handlers.NewUploadHandler(services.Uploads)
+	r.GET(storage.URLPrefix+"/*key", uploadHandler.Serve)
+	r.HEAD(storage.URLPrefix+"/*key", uploadHandler.Serve)
+
+	// 邮件中的确认与一键退订链接
+	newsletterHandler := handlers.NewNewsletterHandler(services.NewsletterService)
+	r.GET("/api/newsletter/confirm", newsletterHandler.ConfirmPage)
+	r.POST("/api/newsletter/confirm", newsletterHandler.Confirm)
+	r.GET("/api/newsletter/unsubscribe", newsletterHandler.UnsubscribePage)
+	r.POST("/api/newsletter/unsubscribe", newsletterHandler.Unsubscribe)
 
 	// 需认证 API - 统一 POST
 	admin := r.Group("/api/admin")
-	admin.Use(middleware.JWTAuth())
+	admin.Use(middleware.JWTAuth(services.AuthService))
 	{
 		admin.POST("", apiHandler.HandleAdmin)
 	}
 
-	// 404 处理
-	r.NoRoute(func(c *gin.Context) {
-		response.Error(c, response.CodeNotFound)
-	})
+	// 404 处理（旧地址先尝试重定向到导入的文章）
+	r.NoRoute(apiHandler.HandleRedirect)
 
-	return r
+	return r, nil
 }
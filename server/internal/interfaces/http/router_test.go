@@ -0,0 +1,257 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/interfaces/http/handlers"
+	"github.com/next-ai-ventus/server/internal/interfaces/http/response"
+	"github.com/next-ai-ventus/server/internal/repository"
+	"github.com/next-ai-ventus/server/internal/service"
+	"github.com/next-ai-ventus/server/internal/site"
+	"github.com/next-ai-ventus/server/internal/storage"
+)
+
+// newReactionRouter 创建只含文章、索引与表情回应服务的路由，并发布一篇文章
+func newReactionRouter(t *testing.T, trustedProxies []string) (*gin.Engine, string) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	repo := repository.NewMemoryPostRepository()
+	posts := service.NewPostService(repo, service.NewSlugService(repo))
+	index := service.NewIndexService(repo, nil)
+	posts.AddObserver(index)
+	reactions, err := service.NewReactionService(repository.NewMemoryReactionRepository(), []byte("secret"))
+	if err != nil {
+		t.Fatalf("NewReactionService() error = %v", err)
+	}
+
+	post, err := posts.CreatePost(service.CreatePostInput{Title: "Hello", Content: "Hello"})
+	if err != nil {
+		t.Fatalf("CreatePost() error = %v", err)
+	}
+	status := "published"
+	if _, err := posts.UpdatePost(post.ID, service.UpdatePostInput{Status: &status}, post.Version); err != nil {
+		t.Fatalf("UpdatePost() error = %v", err)
+	}
+
+	router, err := SetupRouter(&handlers.Services{PostService: posts, IndexService: index, ReactionService: reactions}, trustedProxies)
+	if err != nil {
+		t.Fatalf("SetupRouter() error = %v", err)
+	}
+	return router, post.ID
+}
+
+// react 从 remoteAddr 发送一次表情回应，返回响应码
+func react(t *testing.T, router *gin.Engine, postID, remoteAddr, forwardedFor string) int {
+	t.Helper()
+	body := fmt.Sprintf(`{"sceneCode": "post.react", "data": {"id": %q, "reaction": "like"}}`, postID)
+	req := httptest.NewRequest(http.MethodPost, "/api/public", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = remoteAddr
+	req.Header.Set("X-Forwarded-For", forwardedFor)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var resp response.Response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response %q: %v", w.Body.String(), err)
+	}
+	return resp.Code
+}
+
+func TestSetupRouter_IgnoresSpoofedForwardedFor(t *testing.T) {
+	router, postID := newReactionRouter(t, nil)
+
+	// 每次都伪造不同的 X-Forwarded-For，仍按连接地址计数
+	limited := false
+	for i := 0; i < 100 && !limited; i++ {
+		limited = react(t, router, postID, "203.0.113.7:4000", fmt.Sprintf("198.51.100.%d", i)) == response.CodeRateLimited
+	}
+	if !limited {
+		t.Error("spoofed X-Forwarded-For reset the reaction rate limit")
+	}
+}
+
+func TestSetupRouter_TrustsConfiguredProxies(t *testing.T) {
+	router, postID := newReactionRouter(t, []string{"10.0.0.0/8"})
+
+	// 可信代理转发的请求按 X-Forwarded-For 中的客户端计数
+	for i := 0; i < 100; i++ {
+		if code := react(t, router, postID, "10.0.0.1:4000", fmt.Sprintf("198.51.100.%d", i)); code != response.CodeSuccess {
+			t.Fatalf("request %d through trusted proxy code = %d, want success", i, code)
+		}
+	}
+
+	if _, err := SetupRouter(&handlers.Services{}, []string{"not-an-ip"}); err == nil {
+		t.Error("SetupRouter() with an invalid trusted proxy error = nil")
+	}
+}
+
+// newNewsletterRouter 创建只含邮件订阅与反垃圾服务的路由，并保存一个待确认的订阅者
+func newNewsletterRouter(t *testing.T) (*gin.Engine, repository.NewsletterRepository) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	repo := repository.NewMemoryNewsletterRepository()
+	subscriber, _ := domain.NewSubscriber("reader@example.com", "confirm-1", "unsub-1")
+	if err := repo.SaveSubscriber(subscriber); err != nil {
+		t.Fatalf("SaveSubscriber() error = %v", err)
+	}
+	newsletter, err := service.NewNewsletterService(repo, repository.NewMemoryPostRepository(), nil, service.NewsletterOptions{})
+	if err != nil {
+		t.Fatalf("NewNewsletterService() error = %v", err)
+	}
+	spam, err := service.NewSpamService(repository.NewMemorySpamRepository(), []byte("secret"))
+	if err != nil {
+		t.Fatalf("NewSpamService() error = %v", err)
+	}
+
+	router, err := SetupRouter(&handlers.Services{NewsletterService: newsletter, SpamService: spam}, nil)
+	if err != nil {
+		t.Fatalf("SetupRouter() error = %v", err)
+	}
+	return router, repo
+}
+
+func TestSetupRouter_NewsletterLinksRequirePost(t *testing.T) {
+	router, repo := newNewsletterRouter(t)
+
+	// GET 只显示按钮，邮件扫描器预取链接不会确认或退订
+	for _, path := range []string{"/api/newsletter/confirm?token=confirm-1", "/api/newsletter/unsubscribe?token=unsub-1"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `<form method="post"`) {
+			t.Errorf("GET %s = %d %q, want a form", path, w.Code, w.Body.String())
+		}
+	}
+	if got, _ := repo.FindSubscriber("reader@example.com"); got == nil || got.IsConfirmed() {
+		t.Fatalf("subscriber after GET = %+v, want still pending", got)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/newsletter/confirm?token=confirm-1", nil))
+	if got, _ := repo.FindSubscriber("reader@example.com"); w.Code != http.StatusOK || got == nil || !got.IsConfirmed() {
+		t.Errorf("POST confirm = %d, subscriber %+v, want confirmed", w.Code, got)
+	}
+
+	// 一键退订（RFC 8058）
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/newsletter/unsubscribe?token=unsub-1", strings.NewReader("List-Unsubscribe=One-Click"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	router.ServeHTTP(w, req)
+	if _, err := repo.FindSubscriber("reader@example.com"); w.Code != http.StatusOK || err != repository.ErrSubscriberNotFound {
+		t.Errorf("POST unsubscribe = %d, FindSubscriber() error = %v, want removed", w.Code, err)
+	}
+}
+
+func TestSetupRouter_NewsletterFormKeepsSitePrefix(t *testing.T) {
+	newsletterRouter, repo := newNewsletterRouter(t)
+	def := &site.Definition{
+		ID:          "blog",
+		PathPrefix:  "/blog",
+		ContentPath: filepath.Join(t.TempDir(), "content"),
+		Users:       []site.User{{Username: "admin", Password: "secret"}},
+	}
+	cfg := &site.Config{Sites: []*site.Definition{def}}
+	if err := cfg.Validate("secret"); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	router, err := NewSiteRouter(cfg, func(*site.Definition) (http.Handler, error) {
+		return newsletterRouter, nil
+	})
+	if err != nil {
+		t.Fatalf("NewSiteRouter() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blog/api/newsletter/confirm?token=confirm-1", nil))
+	if want := `action="/blog/api/newsletter/confirm?token=confirm-1"`; w.Code != http.StatusOK || !strings.Contains(w.Body.String(), want) {
+		t.Fatalf("GET confirm = %d %q, want form with %s", w.Code, w.Body.String(), want)
+	}
+
+	// 按表单地址提交，经前缀路由后确认订阅
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/blog/api/newsletter/confirm?token=confirm-1", nil))
+	if got, _ := repo.FindSubscriber("reader@example.com"); w.Code != http.StatusOK || got == nil || !got.IsConfirmed() {
+		t.Errorf("POST confirm = %d, subscriber %+v, want confirmed", w.Code, got)
+	}
+}
+
+func TestSetupRouter_NewsletterSubscribeChecksSpam(t *testing.T) {
+	router, _ := newNewsletterRouter(t)
+
+	// 没有表单令牌的订阅没有复查队列可放，直接拒绝
+	body := `{"sceneCode": "newsletter.subscribe", "data": {"email": "bot@example.com"}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/public", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var resp response.Response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response %q: %v", w.Body.String(), err)
+	}
+	if resp.Code != response.CodeSpamRejected {
+		t.Errorf("subscribe without form token code = %d, want %d", resp.Code, response.CodeSpamRejected)
+	}
+}
+
+func TestSetupRouter_BulkDeleteReportsInboundLinks(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := repository.NewMemoryPostRepository()
+	posts := service.NewPostService(repo, service.NewSlugService(repo))
+	index := service.NewIndexService(repo, nil)
+	posts.AddObserver(index)
+	links := service.NewLinkService(repo, storage.NewLocal(t.TempDir(), storage.URLPrefix), service.LinkOptions{})
+	posts.AddObserver(links)
+	auth := service.NewAuthService("secret")
+
+	target, _ := posts.CreatePost(service.CreatePostInput{Title: "Target", Content: "Target"})
+	kept, _ := posts.CreatePost(service.CreatePostInput{Title: "Kept", Content: "[t](/post/" + target.Slug.String() + "/)"})
+	removed, _ := posts.CreatePost(service.CreatePostInput{Title: "Removed", Content: "[t](/post/" + target.Slug.String() + "/)"})
+
+	router, err := SetupRouter(&handlers.Services{PostService: posts, IndexService: index, LinkService: links, AuthService: auth}, nil)
+	if err != nil {
+		t.Fatalf("SetupRouter() error = %v", err)
+	}
+	token, _ := auth.GenerateToken("admin")
+	body := fmt.Sprintf(`{"sceneCode": "post.bulk", "data": {"action": "delete", "items": [{"id": %q, "version": %d}, {"id": %q, "version": %d}]}}`,
+		target.ID, target.Version, removed.ID, removed.Version)
+	req := httptest.NewRequest(http.MethodPost, "/api/admin", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var resp struct {
+		Code int `json:"code"`
+		Data struct {
+			Items []struct {
+				ID           string                 `json:"id"`
+				Success      bool                   `json:"success"`
+				InboundLinks []*service.InboundLink `json:"inboundLinks"`
+			} `json:"items"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || resp.Code != response.CodeSuccess || len(resp.Data.Items) != 2 {
+		t.Fatalf("post.bulk response = %s (%v)", w.Body.String(), err)
+	}
+
+	// 同批删除的文章中的链接不再提示，只保留仍会失效的链接
+	got := resp.Data.Items[0]
+	if !got.Success || len(got.InboundLinks) != 1 || got.InboundLinks[0].Source.ID != kept.ID {
+		t.Errorf("deleted target = %+v, want one inbound link from %s", got, kept.ID)
+	}
+	if other := resp.Data.Items[1]; !other.Success || other.InboundLinks == nil || len(other.InboundLinks) != 0 {
+		t.Errorf("deleted linking post = %+v, want empty inboundLinks", other)
+	}
+}
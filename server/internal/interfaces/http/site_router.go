@@ -0,0 +1,78 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/next-ai-ventus/server/internal/interfaces/http/response"
+	"github.com/next-ai-ventus/server/internal/site"
+)
+
+// SiteRouter 按 Host 与路径前缀把请求分发到各站点的处理器
+type SiteRouter struct {
+	config   *site.Config
+	handlers map[string]http.Handler // 站点 ID -> 处理器
+}
+
+// NewSiteRouter 创建站点路由，build 为每个站点创建独立的处理器
+func NewSiteRouter(config *site.Config, build func(def *site.Definition) (http.Handler, error)) (*SiteRouter, error) {
+	r := &SiteRouter{
+		config:   config,
+		handlers: make(map[string]http.Handler, len(config.Sites)),
+	}
+	for _, def := range config.Sites {
+		handler, err := build(def)
+		if err != nil {
+			return nil, fmt.Errorf("site %s: %w", def.ID, err)
+		}
+		r.handlers[def.ID] = handler
+	}
+	return r, nil
+}
+
+// ServeHTTP 实现 http.Handler；按前缀匹配的站点会去掉前缀，并通过 X-Forwarded-Prefix 告知处理器
+func (r *SiteRouter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	def, prefix := r.config.Match(req.Host, req.URL.Path)
+	if def == nil {
+		writeNotFound(w)
+		return
+	}
+
+	if prefix != "" {
+		req = stripPrefix(req, prefix)
+	} else {
+		// 客户端自带的前缀不可信（会被用于重定向地址与 Cookie 路径）
+		req.Header.Del(site.ForwardedPrefixHeader)
+	}
+	r.handlers[def.ID].ServeHTTP(w, req)
+}
+
+// stripPrefix 复制请求并去掉路径前缀
+func stripPrefix(req *http.Request, prefix string) *http.Request {
+	r := req.Clone(req.Context())
+	r.URL.Path = strings.TrimPrefix(req.URL.Path, prefix)
+	if r.URL.Path == "" {
+		r.URL.Path = "/"
+	}
+	if req.URL.RawPath != "" {
+		r.URL.RawPath = strings.TrimPrefix(req.URL.RawPath, prefix)
+		if r.URL.RawPath == "" {
+			r.URL.RawPath = "/"
+		}
+	}
+	r.RequestURI = r.URL.RequestURI()
+	r.Header.Set(site.ForwardedPrefixHeader, prefix)
+	return r
+}
+
+// writeNotFound 没有站点匹配时返回统一格式的 404
+func writeNotFound(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusNotFound)
+	_ = json.NewEncoder(w).Encode(response.Response{
+		Code:    response.CodeNotFound,
+		Message: response.GetMessage(response.CodeNotFound),
+	})
+}
@@ -0,0 +1,114 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/next-ai-ventus/server/internal/site"
+)
+
+// seenRequest 站点处理器收到的请求
+type seenRequest struct {
+	site, path, rawPath, requestURI, prefix string
+}
+
+func newTestSiteRouter(t *testing.T, sites ...*site.Definition) (*SiteRouter, *seenRequest) {
+	t.Helper()
+
+	for _, def := range sites {
+		def.ContentPath = filepath.Join(t.TempDir(), "content")
+		def.Users = []site.User{{Username: "admin", Password: "secret"}}
+	}
+	cfg := &site.Config{Sites: sites}
+	if err := cfg.Validate("secret"); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	seen := &seenRequest{}
+	router, err := NewSiteRouter(cfg, func(def *site.Definition) (http.Handler, error) {
+		id := def.ID
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*seen = seenRequest{
+				site:       id,
+				path:       r.URL.Path,
+				rawPath:    r.URL.RawPath,
+				requestURI: r.RequestURI,
+				prefix:     r.Header.Get(site.ForwardedPrefixHeader),
+			}
+		}), nil
+	})
+	if err != nil {
+		t.Fatalf("NewSiteRouter() error = %v", err)
+	}
+	return router, seen
+}
+
+func TestSiteRouter_StripsPrefix(t *testing.T) {
+	router, seen := newTestSiteRouter(t,
+		&site.Definition{ID: "main"},
+		&site.Definition{ID: "blog", PathPrefix: "/blog"},
+	)
+
+	tests := []struct {
+		name   string
+		target string
+		header string // 客户端自带的 X-Forwarded-Prefix
+		want   seenRequest
+	}{
+		{
+			name:   "prefixed path",
+			target: "/blog/api/public?x=1",
+			want:   seenRequest{site: "blog", path: "/api/public", requestURI: "/api/public?x=1", prefix: "/blog"},
+		},
+		{
+			name:   "prefix only",
+			target: "/blog",
+			want:   seenRequest{site: "blog", path: "/", requestURI: "/", prefix: "/blog"},
+		},
+		{
+			name:   "escaped path",
+			target: "/blog/uploads/a%2Fb.png",
+			want:   seenRequest{site: "blog", path: "/uploads/a/b.png", rawPath: "/uploads/a%2Fb.png", requestURI: "/uploads/a%2Fb.png", prefix: "/blog"},
+		},
+		{
+			name:   "similar path is not the prefix",
+			target: "/blogroll",
+			want:   seenRequest{site: "main", path: "/blogroll", requestURI: "/blogroll"},
+		},
+		{
+			name:   "spoofed prefix header is removed",
+			target: "/api/public",
+			header: "/evil",
+			want:   seenRequest{site: "main", path: "/api/public", requestURI: "/api/public"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.header != "" {
+				req.Header.Set(site.ForwardedPrefixHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if *seen != tt.want {
+				t.Errorf("handler saw %+v, want %+v", *seen, tt.want)
+			}
+		})
+	}
+}
+
+func TestSiteRouter_NoMatch(t *testing.T) {
+	router, seen := newTestSiteRouter(t, &site.Definition{ID: "blog", Hosts: []string{"blog.example.com"}})
+
+	req := httptest.NewRequest(http.MethodGet, "http://other.example.com/", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound || seen.site != "" {
+		t.Errorf("status = %d, handled by %q, want 404 and no site", rec.Code, seen.site)
+	}
+}
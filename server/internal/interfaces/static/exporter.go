@@ -0,0 +1,582 @@
+package static
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/interfaces/bff"
+	"github.com/next-ai-ventus/server/internal/interfaces/bff/modules"
+	"github.com/next-ai-ventus/server/internal/repository"
+	"github.com/next-ai-ventus/server/internal/service"
+	"github.com/next-ai-ventus/server/internal/storage"
+)
+
+// manifestFileName 上次导出的清单（输出路径 -> 输入指纹），用于增量导出
+const manifestFileName = ".ventus-export.json"
+
+// feedSize 订阅中的文章数
+const feedSize = 20
+
+// listPageSize 读取已发布文章时每批的数量
+const listPageSize = 200
+
+// Options 静态导出选项
+type Options struct {
+	OutputDir string
+	Uploads   storage.Blob // 为 nil 时不复制上传文件
+	BaseURL   string       // 站点地址（如 https://blog.example.com），为空时不生成订阅与站点地图
+	Full      bool         // 忽略上次导出的清单，重新生成全部文件
+}
+
+// Report 导出结果
+type Report struct {
+	Written   int      `json:"written"`   // 本次写入的文件数
+	Unchanged int      `json:"unchanged"` // 输入未变化而跳过的文件数
+	Removed   int      `json:"removed"`   // 删除的过期文件数（如已取消发布的文章）
+	Posts     int      `json:"posts"`
+	Notes     []string `json:"notes,omitempty"`
+}
+
+// Exporter 将已发布的文章导出为静态站点。
+// 页面数据来自 BFF 模块（与线上 API 返回一致），再套用 HTML 模板渲染。
+type Exporter struct {
+	postService  *service.PostService
+	indexService *service.IndexService
+	bffHandler   *bff.Handler
+}
+
+// NewExporter 创建静态导出器；归档页使用 indexService 的归档数据（站点时区的发布月份）
+func NewExporter(postService *service.PostService, indexService *service.IndexService, bffHandler *bff.Handler) *Exporter {
+	return &Exporter{
+		postService:  postService,
+		indexService: indexService,
+		bffHandler:   bffHandler,
+	}
+}
+
+// Export 导出静态站点；只重新生成输入发生变化的文件，并删除不再存在的页面
+func (e *Exporter) Export(opts Options) (*Report, error) {
+	if opts.OutputDir == "" {
+		return nil, errors.New("output directory is required")
+	}
+	if err := os.MkdirAll(opts.OutputDir, 0755); err != nil {
+		return nil, fmt.Errorf("create output directory failed: %w", err)
+	}
+
+	b := &builder{
+		out:    opts.OutputDir,
+		prev:   map[string]string{},
+		next:   map[string]string{},
+		report: &Report{},
+	}
+	if !opts.Full {
+		b.prev = readManifest(filepath.Join(opts.OutputDir, manifestFileName))
+	}
+
+	layout, err := e.layout()
+	if err != nil {
+		return nil, err
+	}
+	b.layoutKey = layout.key
+
+	posts, err := e.publishedPosts()
+	if err != nil {
+		return nil, err
+	}
+	b.report.Posts = len(posts)
+
+	steps := []func() error{
+		func() error { return e.exportLists(b, layout, "", "/") },
+		func() error { return e.exportTags(b, layout, posts) },
+		func() error { return e.exportArchives(b, layout) },
+		func() error { return e.exportPosts(b, layout, posts) },
+	}
+	if opts.BaseURL != "" {
+		base := strings.TrimRight(opts.BaseURL, "/")
+		steps = append(steps,
+			func() error { return e.exportFeeds(b, layout, base, posts) },
+			func() error { return exportSitemap(b, base, posts) },
+		)
+	} else {
+		b.report.Notes = append(b.report.Notes, "base URL not set, feeds and sitemap skipped")
+	}
+	if opts.Uploads != nil {
+		steps = append(steps, func() error { return exportUploads(b, opts.Uploads) })
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := b.removeStale(); err != nil {
+		return nil, err
+	}
+	if err := writeManifest(filepath.Join(opts.OutputDir, manifestFileName), b.next); err != nil {
+		return nil, err
+	}
+	return b.report, nil
+}
+
+// publishedPosts 返回全部已发布文章（按时间倒序，与列表页一致），按游标分批读取
+func (e *Exporter) publishedPosts() ([]*domain.PostSummary, error) {
+	opts := repository.ListOptions{
+		Page:     1,
+		PageSize: listPageSize,
+		Status:   "published",
+		OrderBy:  "date_desc",
+	}
+	var posts []*domain.PostSummary
+	for {
+		result, err := e.postService.ListPosts(opts)
+		if err != nil {
+			return nil, fmt.Errorf("list posts failed: %w", err)
+		}
+		posts = append(posts, result.Items...)
+		if !result.HasMore || result.NextCursor == "" {
+			return posts, nil
+		}
+		opts.Cursor = result.NextCursor
+	}
+}
+
+// layout 读取所有页面共用的 Logo、Nav、Footer 模块
+func (e *Exporter) layout() (*siteLayout, error) {
+	results := e.bffHandler.ExecuteModules("", "home", []string{"Logo", "Nav", "Footer"}, nil)
+
+	layout := &siteLayout{}
+	if err := decodeModule(results, "Logo", &layout.Logo); err != nil {
+		return nil, err
+	}
+	if err := decodeModule(results, "Nav", &layout.Nav); err != nil {
+		return nil, err
+	}
+	if err := decodeModule(results, "Footer", &layout.Footer); err != nil {
+		return nil, err
+	}
+	for i := range layout.Nav.Links {
+		layout.Nav.Links[i].Href = staticHref(layout.Nav.Links[i].Href)
+	}
+
+	layout.key = fingerprint(templateVersion, layout)
+	return layout, nil
+}
+
+// exportLists 导出首页（或标签页）的全部分页，tag 为空时为首页
+func (e *Exporter) exportLists(b *builder, layout *siteLayout, tag, base string) error {
+	for page := 1; ; page++ {
+		params := map[string]interface{}{"page": float64(page)}
+		if tag != "" {
+			params["tag"] = tag
+		}
+		results := e.bffHandler.ExecuteModules("", "home", []string{"PostList"}, params)
+
+		var list postListData
+		if err := decodeModule(results, "PostList", &list); err != nil {
+			return err
+		}
+		if len(list.Items) == 0 && page > 1 {
+			return nil
+		}
+		for i := range list.Items {
+			list.Items[i].Href = postHref(list.Items[i].Slug)
+		}
+
+		view := &listView{
+			Layout: layout,
+			Title:  layout.Logo.SiteName,
+			Items:  list.Items,
+			Page:   page,
+		}
+		if tag != "" {
+			view.Title = "#" + tag
+			view.Tag = tag
+		}
+		if page > 1 {
+			view.PrevHref = pageHref(base, page-1)
+		}
+		if page < list.Pagination.TotalPages {
+			view.NextHref = pageHref(base, page+1)
+		}
+
+		target := pageFile(base, page)
+		if err := b.emit(target, fingerprint(b.layoutKey, view), func() ([]byte, error) {
+			return render(listTemplate, view)
+		}); err != nil {
+			return err
+		}
+
+		if page >= list.Pagination.TotalPages {
+			return nil
+		}
+	}
+}
+
+// exportTags 导出标签索引与每个标签的分页列表
+func (e *Exporter) exportTags(b *builder, layout *siteLayout, posts []*domain.PostSummary) error {
+	tags, err := e.postService.GetAllTags()
+	if err != nil {
+		return fmt.Errorf("list tags failed: %w", err)
+	}
+	sort.Strings(tags)
+
+	// 只导出含有已发布文章的标签
+	counts := make(map[string]int)
+	for _, post := range posts {
+		for _, tag := range post.GetTagNames() {
+			counts[tag]++
+		}
+	}
+
+	view := &tagsView{Layout: layout}
+	for _, tag := range tags {
+		if counts[tag] == 0 {
+			continue
+		}
+		view.Tags = append(view.Tags, tagLink{Name: tag, Count: counts[tag], Href: tagHref(tag)})
+		if err := e.exportLists(b, layout, tag, tagHref(tag)); err != nil {
+			return err
+		}
+	}
+
+	return b.emit("tag/index.html", fingerprint(b.layoutKey, view), func() ([]byte, error) {
+		return render(tagsTemplate, view)
+	})
+}
+
+// exportArchives 导出归档索引与按月归档页（与 Archive 模块一致，按站点时区的发布月份分组）
+func (e *Exporter) exportArchives(b *builder, layout *siteLayout) error {
+	index := &archiveView{Layout: layout, Title: "Archive"}
+	loc := e.indexService.Location()
+	for _, year := range e.indexService.GetArchive() {
+		for _, m := range year.Months {
+			month := &archiveMonth{
+				Label: fmt.Sprintf("%d-%02d", year.Year, m.Month),
+				Href:  fmt.Sprintf("/archive/%d/%02d/", year.Year, m.Month),
+			}
+			posts, _ := e.indexService.ListArchive(year.Year, m.Month, 0, 0)
+			for _, post := range posts {
+				month.Items = append(month.Items, archiveItem{
+					Title: post.Title,
+					Date:  post.PublishedAt.In(loc).Format("2006-01-02"),
+					Href:  postHref(post.Slug.String()),
+				})
+			}
+			index.Months = append(index.Months, month)
+		}
+	}
+
+	for _, month := range index.Months {
+		view := &archiveView{Layout: layout, Title: month.Label, Months: []*archiveMonth{month}}
+		target := strings.TrimPrefix(month.Href, "/") + "index.html"
+		if err := b.emit(target, fingerprint(b.layoutKey, view), func() ([]byte, error) {
+			return render(archiveTemplate, view)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return b.emit("archive/index.html", fingerprint(b.layoutKey, index), func() ([]byte, error) {
+		return render(archiveTemplate, index)
+	})
+}
+
+// exportPosts 导出文章页；文章版本未变化时不调用 Article 模块
+func (e *Exporter) exportPosts(b *builder, layout *siteLayout, posts []*domain.PostSummary) error {
+	for _, post := range posts {
+		slug := post.Slug.String()
+		key := fingerprint(b.layoutKey, post.ID, slug, post.Version, post.UpdatedAt)
+
+		err := b.emit("post/"+slug+"/index.html", key, func() ([]byte, error) {
+			results := e.bffHandler.ExecuteModules("", "post", []string{"Article"}, map[string]interface{}{"slug": slug})
+
+			var article modules.ArticleData
+			if err := decodeModule(results, "Article", &article); err != nil {
+				return nil, err
+			}
+			view := &postView{Layout: layout, Article: article, Content: template.HTML(article.HTML)}
+			for _, tag := range article.Tags {
+				view.Tags = append(view.Tags, tagLink{Name: tag, Href: tagHref(tag)})
+			}
+			return render(postTemplate, view)
+		})
+		if err != nil {
+			return fmt.Errorf("export post %s failed: %w", post.ID, err)
+		}
+	}
+	return nil
+}
+
+// siteLayout 页面公共部分（来自 Logo、Nav、Footer 模块）
+type siteLayout struct {
+	Logo struct {
+		SiteName string `json:"siteName"`
+		Logo     string `json:"logo"`
+	}
+	Nav struct {
+		Links []struct {
+			Name string `json:"name"`
+			Href string `json:"href"`
+		} `json:"links"`
+	}
+	Footer modules.FooterData
+
+	key string
+}
+
+// postListData PostList 模块数据（链接改写为静态地址）
+type postListData struct {
+	Items      []modules.PostItem     `json:"items"`
+	Pagination modules.PaginationInfo `json:"pagination"`
+}
+
+type listView struct {
+	Layout   *siteLayout
+	Title    string
+	Tag      string
+	Items    []modules.PostItem
+	Page     int
+	PrevHref string
+	NextHref string
+}
+
+type tagLink struct {
+	Name  string
+	Count int
+	Href  string
+}
+
+type tagsView struct {
+	Layout *siteLayout
+	Tags   []tagLink
+}
+
+type archiveItem struct {
+	Title string
+	Date  string
+	Href  string
+}
+
+type archiveMonth struct {
+	Label string
+	Href  string
+	Items []archiveItem
+}
+
+type archiveView struct {
+	Layout *siteLayout
+	Title  string
+	Months []*archiveMonth
+}
+
+type postView struct {
+	Layout  *siteLayout
+	Article modules.ArticleData
+	Content template.HTML // markdown.Parse 的输出，已转义
+	Tags    []tagLink
+}
+
+// decodeModule 将模块结果按 JSON（与 API 响应相同的结构）解码到 dst
+func decodeModule(results map[string]bff.ModuleResult, name string, dst interface{}) error {
+	result, ok := results[name]
+	if !ok {
+		return fmt.Errorf("module %s returned no result", name)
+	}
+	if result.Code != 200 {
+		return fmt.Errorf("module %s failed: %s", name, result.Error)
+	}
+	data, err := json.Marshal(result.Data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
+
+// staticHref 将线上前端地址改写为静态站点地址
+func staticHref(href string) string {
+	u, err := url.Parse(href)
+	if err != nil || u.IsAbs() {
+		return href
+	}
+	if tag := u.Query().Get("tag"); tag != "" && (u.Path == "/" || u.Path == "") {
+		return tagHref(tag)
+	}
+	if slug, ok := service.PostSlugFromPath(u); ok {
+		return postHref(slug)
+	}
+	return href
+}
+
+// postHref 返回文章在静态站点中的地址（文章页导出为 post/<slug>/index.html）
+func postHref(slug string) string {
+	return "/post/" + url.PathEscape(slug) + "/"
+}
+
+func tagHref(tag string) string {
+	return "/tag/" + url.PathEscape(tag) + "/"
+}
+
+// pageHref 返回列表第 page 页的地址，base 以 "/" 结尾
+func pageHref(base string, page int) string {
+	if page == 1 {
+		return base
+	}
+	return fmt.Sprintf("%spage/%d/", base, page)
+}
+
+// pageFile 返回列表第 page 页的输出文件（相对输出目录）
+func pageFile(base string, page int) string {
+	href := pageHref(base, page)
+	if unescaped, err := url.PathUnescape(href); err == nil {
+		href = unescaped
+	}
+	return strings.TrimPrefix(href, "/") + "index.html"
+}
+
+// fingerprint 计算页面输入的指纹
+func fingerprint(parts ...interface{}) string {
+	h := sha256.New()
+	enc := json.NewEncoder(h)
+	for _, part := range parts {
+		enc.Encode(part)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// builder 记录本次导出的文件，并跳过输入未变化的文件
+type builder struct {
+	out       string
+	layoutKey string
+	prev      map[string]string // 上次导出：路径 -> 指纹
+	next      map[string]string
+	report    *Report
+}
+
+// emit 在指纹变化或文件缺失时调用 generate 并写入 target（相对输出目录，使用 "/" 分隔）
+func (b *builder) emit(target, key string, generate func() ([]byte, error)) error {
+	b.next[target] = key
+
+	dst := filepath.Join(b.out, filepath.FromSlash(target))
+	if b.prev[target] == key && isRegularFile(dst) {
+		b.report.Unchanged++
+		return nil
+	}
+
+	data, err := generate()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(dst, data, 0644); err != nil {
+		return fmt.Errorf("write %s failed: %w", target, err)
+	}
+	b.report.Written++
+	return nil
+}
+
+// removeStale 删除上次导出过、本次不再生成的文件及其留下的空目录
+func (b *builder) removeStale() error {
+	var stale []string
+	for target := range b.prev {
+		if _, ok := b.next[target]; !ok && isSafeTarget(target) {
+			stale = append(stale, target)
+		}
+	}
+	sort.Strings(stale)
+
+	for _, target := range stale {
+		dst := filepath.Join(b.out, filepath.FromSlash(target))
+		if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		b.report.Removed++
+
+		// 清理空目录（os.Remove 不会删除非空目录）
+		for dir := path.Dir(target); dir != "."; dir = path.Dir(dir) {
+			if os.Remove(filepath.Join(b.out, filepath.FromSlash(dir))) != nil {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// isSafeTarget 清单中的路径必须位于输出目录内（防止被篡改的清单删除其他文件）
+func isSafeTarget(target string) bool {
+	clean := path.Clean(target)
+	return clean == target && !path.IsAbs(clean) && clean != ".." && !strings.HasPrefix(clean, "../")
+}
+
+// exportUploads 复制上传文件；大小与修改时间未变化的文件跳过
+func exportUploads(b *builder, uploads storage.Blob) error {
+	infos, err := uploads.List("")
+	if err != nil {
+		return err
+	}
+	for _, info := range infos {
+		key := info.Key
+		err := b.emit("uploads/"+key, fingerprint(info.Size, info.ModTime.UnixNano()), func() ([]byte, error) {
+			r, _, err := uploads.Get(key)
+			if err != nil {
+				return nil, err
+			}
+			defer r.Close()
+			return io.ReadAll(r)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readManifest 读取上次导出的清单，不存在或无法解析时返回空清单（全部重新生成）
+func readManifest(path string) map[string]string {
+	manifest := map[string]string{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return manifest
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return map[string]string{}
+	}
+	return manifest
+}
+
+// writeManifest 写入本次导出的清单
+func writeManifest(path string, manifest map[string]string) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// render 执行模板
+func render(tmpl *template.Template, data interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func isRegularFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
@@ -0,0 +1,238 @@
+package static
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/domain/valueobject"
+	"github.com/next-ai-ventus/server/internal/interfaces/bff"
+	"github.com/next-ai-ventus/server/internal/repository"
+	"github.com/next-ai-ventus/server/internal/service"
+	"github.com/next-ai-ventus/server/internal/site"
+	"github.com/next-ai-ventus/server/internal/storage"
+)
+
+// setupExporter 创建导出器，loc 为站点时区（为 nil 时使用 UTC）
+func setupExporter(t *testing.T, loc *time.Location) (*Exporter, *service.PostService) {
+	t.Helper()
+
+	repo := repository.NewMemoryPostRepository()
+	postService := service.NewPostService(repo, service.NewSlugService(repo))
+	indexService := service.NewIndexService(repo, loc)
+	postService.AddObserver(indexService)
+	bffHandler := bff.NewHandler(postService, indexService, service.NewSearchService(repo), nil, nil, nil, nil, site.Settings{})
+	return NewExporter(postService, indexService, bffHandler), postService
+}
+
+// publish 创建并发布文章，返回文章 ID
+func publish(t *testing.T, postService *service.PostService, title, content string, tags []string) string {
+	t.Helper()
+
+	post, err := postService.CreatePost(service.CreatePostInput{Title: title, Content: content, Tags: tags})
+	if err != nil {
+		t.Fatalf("CreatePost() error = %v", err)
+	}
+	status := "published"
+	if _, err := postService.UpdatePost(post.ID, service.UpdatePostInput{Status: &status}, post.Version); err != nil {
+		t.Fatalf("UpdatePost() error = %v", err)
+	}
+	return post.ID
+}
+
+func readOutput(t *testing.T, dir, name string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		t.Fatalf("read %s: %v", name, err)
+	}
+	return string(data)
+}
+
+func TestExporter_Export(t *testing.T) {
+	exporter, postService := setupExporter(t, nil)
+	for i := 1; i <= 11; i++ {
+		publish(t, postService, fmt.Sprintf("Post %d", i), fmt.Sprintf("# Heading\n\nBody <b>%d</b>", i), []string{"go"})
+	}
+	draft, err := postService.CreatePost(service.CreatePostInput{Title: "Secret Draft", Content: "Not yet"})
+	if err != nil {
+		t.Fatalf("CreatePost() error = %v", err)
+	}
+
+	uploads := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(uploads, "2024"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(uploads, "2024", "a.png"), []byte("png"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	out := t.TempDir()
+	opts := Options{OutputDir: out, Uploads: storage.NewLocal(uploads, storage.URLPrefix), BaseURL: "https://blog.example.com/"}
+	report, err := exporter.Export(opts)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if report.Posts != 11 || report.Written == 0 || report.Unchanged != 0 {
+		t.Fatalf("report = %+v", report)
+	}
+
+	// 分页：每页 10 篇，与 PostList 模块一致
+	home := readOutput(t, out, "index.html")
+	if !strings.Contains(home, `href="/page/2/"`) || strings.Count(home, "<article>") != 10 {
+		t.Errorf("home page should list 10 posts and link to page 2:\n%s", home)
+	}
+	if page2 := readOutput(t, out, "page/2/index.html"); strings.Count(page2, "<article>") != 1 || !strings.Contains(page2, `rel="prev" href="/"`) {
+		t.Errorf("page 2:\n%s", page2)
+	}
+	if tag := readOutput(t, out, "tag/go/page/2/index.html"); !strings.Contains(tag, "#go") {
+		t.Errorf("tag page 2:\n%s", tag)
+	}
+
+	// 文章页使用 Article 模块渲染的 HTML（正文中的 HTML 被转义）
+	post := readOutput(t, out, "post/post-1/index.html")
+	if !strings.Contains(post, "<h1>Post 1</h1>") || !strings.Contains(post, "&lt;b&gt;1&lt;/b&gt;") {
+		t.Errorf("post page:\n%s", post)
+	}
+	if _, err := os.Stat(filepath.Join(out, "post", draft.Slug.String())); !os.IsNotExist(err) {
+		t.Errorf("draft should not be exported, stat error = %v", err)
+	}
+
+	if archive := readOutput(t, out, "archive/index.html"); strings.Count(archive, "<li>") != 11 {
+		t.Errorf("archive:\n%s", archive)
+	}
+	if feed := readOutput(t, out, "feed.xml"); strings.Count(feed, "<item>") != 11 || !strings.Contains(feed, "<link>https://blog.example.com/post/post-1/</link>") {
+		t.Errorf("feed:\n%s", feed)
+	}
+	if sitemap := readOutput(t, out, "sitemap.xml"); !strings.Contains(sitemap, "<loc>https://blog.example.com/tag/go/</loc>") {
+		t.Errorf("sitemap:\n%s", sitemap)
+	}
+	if readOutput(t, out, "uploads/2024/a.png") != "png" {
+		t.Error("uploads not copied")
+	}
+
+	// 没有变化时不重写任何文件
+	report, err = exporter.Export(opts)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if report.Written != 0 || report.Removed != 0 {
+		t.Errorf("unchanged re-export report = %+v, want nothing written", report)
+	}
+}
+
+func TestExporter_PublishedPostsReadsAllBatches(t *testing.T) {
+	exporter, postService := setupExporter(t, nil)
+	for i := 0; i <= listPageSize; i++ {
+		publish(t, postService, fmt.Sprintf("Post %d", i), "Body", nil)
+	}
+
+	posts, err := exporter.publishedPosts()
+	if err != nil {
+		t.Fatalf("publishedPosts() error = %v", err)
+	}
+	if len(posts) != listPageSize+1 {
+		t.Fatalf("publishedPosts() = %d posts, want %d", len(posts), listPageSize+1)
+	}
+	seen := make(map[string]bool)
+	for _, post := range posts {
+		if seen[post.ID] {
+			t.Fatalf("post %s returned twice", post.ID)
+		}
+		seen[post.ID] = true
+	}
+}
+
+func TestExporter_ArchiveUsesSiteTimeZone(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	exporter, postService := setupExporter(t, loc)
+	slug, _ := valueobject.NewSlug("late-may")
+	// UTC 时间为 5 月 31 日，站点时区已是 6 月 1 日
+	date := time.Date(2024, 5, 31, 20, 0, 0, 0, time.UTC)
+	if _, err := postService.ImportPost(service.ImportPostInput{Title: "Late May", Slug: slug, Content: "Body", Date: date}); err != nil {
+		t.Fatalf("ImportPost() error = %v", err)
+	}
+
+	out := t.TempDir()
+	if _, err := exporter.Export(Options{OutputDir: out}); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	month := readOutput(t, out, "archive/2024/06/index.html")
+	if !strings.Contains(month, "Late May") || !strings.Contains(month, "2024-06-01") {
+		t.Errorf("archive/2024/06:\n%s", month)
+	}
+	if _, err := os.Stat(filepath.Join(out, "archive", "2024", "05")); !os.IsNotExist(err) {
+		t.Errorf("archive/2024/05 should not exist, got %v", err)
+	}
+}
+
+func TestExporter_Incremental(t *testing.T) {
+	exporter, postService := setupExporter(t, nil)
+	// 正文较长，修改末尾不影响列表页中的摘要
+	body := strings.Repeat("lorem ipsum ", 30)
+	first := publish(t, postService, "First", body+"one", []string{"a"})
+	publish(t, postService, "Second", "two", []string{"b"})
+
+	out := t.TempDir()
+	opts := Options{OutputDir: out}
+	if _, err := exporter.Export(opts); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	secondPage := filepath.Join(out, "post", "second", "index.html")
+	before, err := os.Stat(secondPage)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 只修改正文末尾：只重新生成该文章页
+	post, _ := postService.GetPost(first)
+	content := body + "one, edited"
+	if _, err := postService.UpdatePost(first, service.UpdatePostInput{Content: &content}, post.Version); err != nil {
+		t.Fatalf("UpdatePost() error = %v", err)
+	}
+	report, err := exporter.Export(opts)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if report.Written != 1 {
+		t.Errorf("report = %+v, want only the edited post written", report)
+	}
+	if !strings.Contains(readOutput(t, out, "post/first/index.html"), "one, edited") {
+		t.Error("edited post was not regenerated")
+	}
+	if after, _ := os.Stat(secondPage); !after.ModTime().Equal(before.ModTime()) {
+		t.Error("unchanged post page was rewritten")
+	}
+
+	// 取消发布：删除文章页与只属于它的标签页
+	post, _ = postService.GetPost(first)
+	status := "draft"
+	if _, err := postService.UpdatePost(first, service.UpdatePostInput{Status: &status}, post.Version); err != nil {
+		t.Fatalf("UpdatePost() error = %v", err)
+	}
+	report, err = exporter.Export(opts)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if report.Removed != 2 {
+		t.Errorf("report = %+v, want post and tag page removed", report)
+	}
+	for _, dir := range []string{"post/first", "tag/a"} {
+		if _, err := os.Stat(filepath.Join(out, dir)); !os.IsNotExist(err) {
+			t.Errorf("%s should be removed, stat error = %v", dir, err)
+		}
+	}
+
+	// -full 重新生成全部页面
+	report, err = exporter.Export(Options{OutputDir: out, Full: true})
+	if err != nil || report.Unchanged != 0 || report.Written == 0 {
+		t.Errorf("full export report = %+v, %v", report, err)
+	}
+}
@@ -0,0 +1,131 @@
+package static
+
+import (
+	"encoding/xml"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+)
+
+// feedVersion 订阅与站点地图格式的版本，修改输出结构时递增
+const feedVersion = 1
+
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        string   `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+	Description string   `xml:"description"`
+	Categories  []string `xml:"category"`
+}
+
+type sitemapDocument struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// exportFeeds 导出全站订阅 /feed.xml 与每个标签的订阅 /tag/<tag>/feed.xml
+func (e *Exporter) exportFeeds(b *builder, layout *siteLayout, base string, posts []*domain.PostSummary) error {
+	byTag := make(map[string][]*domain.PostSummary)
+	for _, post := range posts {
+		for _, tag := range post.GetTagNames() {
+			byTag[tag] = append(byTag[tag], post)
+		}
+	}
+
+	if err := emitFeed(b, "feed.xml", layout.Logo.SiteName, base+"/", base, posts); err != nil {
+		return err
+	}
+	for tag, tagged := range byTag {
+		title := layout.Logo.SiteName + " #" + tag
+		if err := emitFeed(b, "tag/"+tag+"/feed.xml", title, base+tagHref(tag), base, tagged); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// emitFeed 输出 RSS 2.0 订阅（最新 feedSize 篇）
+func emitFeed(b *builder, target, title, link, base string, posts []*domain.PostSummary) error {
+	if len(posts) > feedSize {
+		posts = posts[:feedSize]
+	}
+
+	channel := rssChannel{Title: title, Link: link, Description: title, Items: []rssItem{}}
+	for _, post := range posts {
+		date := post.CreatedAt
+		if post.PublishedAt != nil {
+			date = *post.PublishedAt
+		}
+		url := base + postHref(post.Slug.String())
+		channel.Items = append(channel.Items, rssItem{
+			Title:       post.Title,
+			Link:        url,
+			GUID:        url,
+			PubDate:     date.Format(time.RFC1123Z),
+			Description: post.Excerpt,
+			Categories:  post.GetTagNames(),
+		})
+		if channel.LastBuildDate == "" {
+			channel.LastBuildDate = post.UpdatedAt.Format(time.RFC1123Z)
+		}
+	}
+
+	doc := rssDocument{Version: "2.0", Channel: channel}
+	return b.emit(target, fingerprint(templateVersion, doc), func() ([]byte, error) {
+		return marshalXML(doc)
+	})
+}
+
+// exportSitemap 输出 /sitemap.xml（首页、标签页、归档页与文章页）
+func exportSitemap(b *builder, base string, posts []*domain.PostSummary) error {
+	doc := sitemapDocument{Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9"}
+	doc.URLs = append(doc.URLs, sitemapURL{Loc: base + "/"}, sitemapURL{Loc: base + "/archive/"}, sitemapURL{Loc: base + "/tag/"})
+
+	tags := make(map[string]bool)
+	for _, post := range posts {
+		doc.URLs = append(doc.URLs, sitemapURL{
+			Loc:     base + postHref(post.Slug.String()),
+			LastMod: post.UpdatedAt.Format("2006-01-02"),
+		})
+		for _, tag := range post.GetTagNames() {
+			if !tags[tag] {
+				tags[tag] = true
+				doc.URLs = append(doc.URLs, sitemapURL{Loc: base + tagHref(tag)})
+			}
+		}
+	}
+
+	return b.emit("sitemap.xml", fingerprint(templateVersion, doc), func() ([]byte, error) {
+		return marshalXML(doc)
+	})
+}
+
+// marshalXML 输出带 XML 声明的文档
+func marshalXML(doc interface{}) ([]byte, error) {
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
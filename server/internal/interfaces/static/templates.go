@@ -0,0 +1,85 @@
+package static
+
+import "html/template"
+
+// baseTemplate 页面骨架，{{template "content" .}} 由各页面模板定义
+const baseTemplate = `{{define "base"}}<!DOCTYPE html>
+<html lang="zh-CN">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{block "title" .}}{{.Layout.Logo.SiteName}}{{end}}</title>
+<link rel="alternate" type="application/rss+xml" title="{{.Layout.Logo.SiteName}}" href="/feed.xml">
+</head>
+<body>
+<header>
+<a class="logo" href="/">{{if .Layout.Logo.Logo}}<img src="{{.Layout.Logo.Logo}}" alt="">{{end}}{{.Layout.Logo.SiteName}}</a>
+<nav>{{range .Layout.Nav.Links}}<a href="{{.Href}}">{{.Name}}</a> {{end}}<a href="/tag/">Tags</a> <a href="/archive/">Archive</a></nav>
+</header>
+<main>
+{{template "content" .}}
+</main>
+<footer>
+<p>{{.Layout.Footer.Copyright}}</p>
+<p>{{.Layout.Footer.PoweredBy}}</p>
+</footer>
+</body>
+</html>
+{{end}}`
+
+const listContent = `{{define "title"}}{{.Title}}{{if gt .Page 1}} - {{.Page}}{{end}}{{end}}
+{{define "content"}}{{if .Tag}}<h1>{{.Title}}</h1>
+{{end}}{{range .Items}}<article>
+<h2><a href="{{.Href}}">{{.Title}}</a></h2>
+<time>{{.Date}}</time>
+<p>{{.Excerpt}}</p>
+{{if .Tags}}<p class="tags">{{range .Tags}}<a href="/tag/{{.}}/">#{{.}}</a> {{end}}</p>{{end}}
+</article>
+{{else}}<p>No posts yet.</p>
+{{end}}<nav class="pagination">{{if .PrevHref}}<a rel="prev" href="{{.PrevHref}}">Newer</a>{{end}} {{if .NextHref}}<a rel="next" href="{{.NextHref}}">Older</a>{{end}}</nav>
+{{end}}`
+
+const tagsContent = `{{define "title"}}Tags - {{.Layout.Logo.SiteName}}{{end}}
+{{define "content"}}<h1>Tags</h1>
+<ul class="tags">{{range .Tags}}
+<li><a href="{{.Href}}">#{{.Name}}</a> ({{.Count}})</li>{{end}}
+</ul>
+{{end}}`
+
+const archiveContent = `{{define "title"}}{{.Title}} - {{.Layout.Logo.SiteName}}{{end}}
+{{define "content"}}<h1>{{.Title}}</h1>
+{{range .Months}}<section>
+<h2><a href="{{.Href}}">{{.Label}}</a></h2>
+<ul>{{range .Items}}
+<li><time>{{.Date}}</time> <a href="{{.Href}}">{{.Title}}</a></li>{{end}}
+</ul>
+</section>
+{{end}}{{end}}`
+
+const postContent = `{{define "title"}}{{.Article.Title}} - {{.Layout.Logo.SiteName}}{{end}}
+{{define "content"}}<article>
+<h1>{{.Article.Title}}</h1>
+<p class="meta"><time>{{if .Article.PublishedAt}}{{.Article.PublishedAt}}{{else}}{{.Article.CreatedAt}}{{end}}</time> · {{.Article.WordCount}} words</p>
+<div class="content">
+{{.Content}}
+</div>
+{{if .Tags}}<p class="tags">{{range .Tags}}<a href="{{.Href}}">#{{.Name}}</a> {{end}}</p>{{end}}
+</article>
+{{end}}`
+
+var (
+	listTemplate    = newPageTemplate("list", listContent)
+	tagsTemplate    = newPageTemplate("tags", tagsContent)
+	archiveTemplate = newPageTemplate("archive", archiveContent)
+	postTemplate    = newPageTemplate("post", postContent)
+)
+
+// templateVersion 模板内容的指纹，模板修改后增量导出会重新生成全部页面
+var templateVersion = fingerprint(baseTemplate, listContent, tagsContent, archiveContent, postContent, feedVersion)
+
+// newPageTemplate 组合页面骨架与页面内容
+func newPageTemplate(name, content string) *template.Template {
+	t := template.Must(template.New(name).Parse(baseTemplate))
+	t = template.Must(t.Parse(content))
+	return t.Lookup("base")
+}
@@ -0,0 +1,36 @@
+package mail
+
+import "fmt"
+
+// 发送方式
+const (
+	TypeSMTP = "smtp"
+	TypeFile = "file"
+)
+
+// Config 邮件发送配置
+type Config struct {
+	Type string      `json:"type"` // 为空时不发送邮件；"smtp" 或 "file"（写入目录，用于测试）
+	From string      `json:"from"` // 发件人，如 "Ventus Blog <news@example.com>"
+	Dir  string      `json:"dir"`  // file 方式写入的目录
+	SMTP SMTPOptions `json:"smtp"`
+}
+
+// Enabled 检查是否配置了发送方式
+func (c Config) Enabled() bool {
+	return c.Type != ""
+}
+
+// New 按配置创建发送方式，未配置时返回 nil
+func New(cfg Config) (Mailer, error) {
+	switch cfg.Type {
+	case "":
+		return nil, nil
+	case TypeSMTP:
+		return NewSMTP(cfg.SMTP, cfg.From), nil
+	case TypeFile:
+		return NewFileDrop(cfg.Dir, cfg.From), nil
+	default:
+		return nil, fmt.Errorf("unknown mail type %q", cfg.Type)
+	}
+}
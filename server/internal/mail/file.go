@@ -0,0 +1,43 @@
+package mail
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileDrop 将邮件写入目录（每封一个 .eml 文件）而不真正发送，用于开发与测试
+type FileDrop struct {
+	dir  string
+	from string
+	now  func() time.Time
+}
+
+// NewFileDrop 创建写入 dir 目录的 FileDrop，发件人为 from
+func NewFileDrop(dir, from string) *FileDrop {
+	return &FileDrop{dir: dir, from: from, now: time.Now}
+}
+
+// Send 将邮件原文写入 <时间>-<随机后缀>.eml
+func (d *FileDrop) Send(msg *Message) error {
+	now := d.now()
+	data, err := Render(d.from, msg, now)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(d.dir, 0755); err != nil {
+		return fmt.Errorf("create mail directory failed: %w", err)
+	}
+
+	name := now.UTC().Format("20060102-150405") + "-" + randomHex(4) + ".eml"
+	tmpPath := filepath.Join(d.dir, "."+name+".tmp")
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("write mail failed: %w", err)
+	}
+	if err := os.Rename(tmpPath, filepath.Join(d.dir, name)); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("write mail failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package mail
+
+import (
+	netmail "net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileDrop_Send(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	drop := NewFileDrop(dir, "news@example.com")
+
+	for _, to := range []string{"a@example.com", "b@example.com"} {
+		if err := drop.Send(&Message{To: to, Subject: "Hi", Text: "Hello"}); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 2 {
+		t.Fatalf("mail directory has %d entries, want 2", len(entries))
+	}
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".eml") {
+			t.Errorf("unexpected file %s", entry.Name())
+		}
+		f, _ := os.Open(filepath.Join(dir, entry.Name()))
+		msg, err := netmail.ReadMessage(f)
+		f.Close()
+		if err != nil || msg.Header.Get("From") != "<news@example.com>" {
+			t.Errorf("%s: From = %q, err = %v", entry.Name(), msg.Header.Get("From"), err)
+		}
+	}
+}
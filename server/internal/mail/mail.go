@@ -0,0 +1,115 @@
+package mail
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	netmail "net/mail"
+	"sort"
+	"strings"
+	"time"
+)
+
+var ErrInvalidMessage = errors.New("invalid mail message")
+
+// Message 一封邮件，Text 与 HTML 至少有一个非空，两者都有时发送 multipart/alternative
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+	Headers map[string]string // 额外的邮件头（如 List-Unsubscribe）
+}
+
+// Mailer 邮件发送方式
+type Mailer interface {
+	// Send 发送一封邮件，返回错误时邮件未被接收
+	Send(msg *Message) error
+}
+
+// Render 生成邮件原文（RFC 5322），发件人为 from
+func Render(from string, msg *Message, now time.Time) ([]byte, error) {
+	sender, err := netmail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("%w: from %q", ErrInvalidMessage, from)
+	}
+	recipient, err := netmail.ParseAddress(msg.To)
+	if err != nil {
+		return nil, fmt.Errorf("%w: to %q", ErrInvalidMessage, msg.To)
+	}
+	if msg.Text == "" && msg.HTML == "" {
+		return nil, fmt.Errorf("%w: empty body", ErrInvalidMessage)
+	}
+
+	var buf bytes.Buffer
+	header := func(name, value string) error {
+		if strings.ContainsAny(name+value, "\r\n") {
+			return fmt.Errorf("%w: header %s", ErrInvalidMessage, name)
+		}
+		fmt.Fprintf(&buf, "%s: %s\r\n", name, value)
+		return nil
+	}
+
+	header("From", sender.String())
+	header("To", recipient.String())
+	if err := header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject)); err != nil {
+		return nil, err
+	}
+	header("Date", now.Format(time.RFC1123Z))
+	header("Message-ID", "<"+randomHex(12)+"@"+domainOf(sender.Address)+">")
+	header("MIME-Version", "1.0")
+
+	names := make([]string, 0, len(msg.Headers))
+	for name := range msg.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := header(name, msg.Headers[name]); err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case msg.HTML == "":
+		writePart(&buf, "text/plain", msg.Text)
+	case msg.Text == "":
+		writePart(&buf, "text/html", msg.HTML)
+	default:
+		boundary := "alt-" + randomHex(12)
+		fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", boundary)
+		fmt.Fprintf(&buf, "--%s\r\n", boundary)
+		writePart(&buf, "text/plain", msg.Text)
+		fmt.Fprintf(&buf, "\r\n--%s\r\n", boundary)
+		writePart(&buf, "text/html", msg.HTML)
+		fmt.Fprintf(&buf, "\r\n--%s--\r\n", boundary)
+	}
+	return buf.Bytes(), nil
+}
+
+// writePart 写入一段 quoted-printable 编码的正文（含其 Content-Type 头）
+func writePart(buf *bytes.Buffer, contentType, body string) {
+	fmt.Fprintf(buf, "Content-Type: %s; charset=utf-8\r\n", contentType)
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+	w := quotedprintable.NewWriter(buf)
+	w.Write([]byte(strings.ReplaceAll(body, "\n", "\r\n")))
+	w.Close()
+}
+
+// domainOf 返回邮箱地址的域名部分
+func domainOf(address string) string {
+	if at := strings.LastIndex(address, "@"); at >= 0 {
+		return address[at+1:]
+	}
+	return "localhost"
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,84 @@
+package mail
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	netmail "net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRender_Alternative(t *testing.T) {
+	msg := &Message{
+		To:      "reader@example.com",
+		Subject: "新文章：Hello",
+		Text:    "Hello\nworld",
+		HTML:    "<p>Hello world</p>",
+		Headers: map[string]string{"List-Unsubscribe": "<https://blog.example.com/u?token=abc>"},
+	}
+	data, err := Render("Blog <news@example.com>", msg, time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	parsed, err := netmail.ReadMessage(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("rendered message is not parseable: %v", err)
+	}
+	subject, _ := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if subject != msg.Subject {
+		t.Errorf("Subject = %q, want %q", subject, msg.Subject)
+	}
+	if parsed.Header.Get("List-Unsubscribe") != msg.Headers["List-Unsubscribe"] {
+		t.Errorf("List-Unsubscribe = %q", parsed.Header.Get("List-Unsubscribe"))
+	}
+	if !strings.HasSuffix(parsed.Header.Get("Message-ID"), "@example.com>") {
+		t.Errorf("Message-ID = %q", parsed.Header.Get("Message-ID"))
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q, want multipart/alternative", mediaType)
+	}
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	var bodies []string
+	for {
+		part, err := reader.NextRawPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("NextRawPart() error = %v", err)
+		}
+		body, _ := io.ReadAll(quotedprintable.NewReader(part))
+		bodies = append(bodies, part.Header.Get("Content-Type")+"|"+string(body))
+	}
+	want := []string{"text/plain; charset=utf-8|Hello\r\nworld", "text/html; charset=utf-8|<p>Hello world</p>"}
+	if len(bodies) != 2 || bodies[0] != want[0] || bodies[1] != want[1] {
+		t.Errorf("parts = %q, want %q", bodies, want)
+	}
+}
+
+func TestRender_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		msg  *Message
+	}{
+		{name: "bad recipient", from: "news@example.com", msg: &Message{To: "not an address", Text: "x"}},
+		{name: "bad sender", from: "", msg: &Message{To: "a@example.com", Text: "x"}},
+		{name: "empty body", from: "news@example.com", msg: &Message{To: "a@example.com"}},
+		{name: "header injection", from: "news@example.com", msg: &Message{To: "a@example.com", Text: "x", Headers: map[string]string{"X-Test": "a\r\nBcc: b@example.com"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Render(tt.from, tt.msg, time.Now()); !errors.Is(err, ErrInvalidMessage) {
+				t.Errorf("Render() error = %v, want ErrInvalidMessage", err)
+			}
+		})
+	}
+}
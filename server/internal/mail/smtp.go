@@ -0,0 +1,57 @@
+package mail
+
+import (
+	"fmt"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// defaultSMTPPort 未配置端口时使用的提交端口（STARTTLS）
+const defaultSMTPPort = 587
+
+// SMTPOptions SMTP 服务器配置
+type SMTPOptions struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"` // 默认 587，服务器支持时使用 STARTTLS
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// SMTP 通过 SMTP 服务器发送邮件
+type SMTP struct {
+	opts SMTPOptions
+	from string
+	now  func() time.Time
+	send func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewSMTP 创建 SMTP 发送方式，发件人为 from
+func NewSMTP(opts SMTPOptions, from string) *SMTP {
+	if opts.Port == 0 {
+		opts.Port = defaultSMTPPort
+	}
+	return &SMTP{opts: opts, from: from, now: time.Now, send: smtp.SendMail}
+}
+
+// Send 发送邮件，配置了用户名时使用 PLAIN 认证（要求 TLS 或本机服务器）
+func (s *SMTP) Send(msg *Message) error {
+	data, err := Render(s.from, msg, s.now())
+	if err != nil {
+		return err
+	}
+	sender, _ := netmail.ParseAddress(s.from)
+	recipient, _ := netmail.ParseAddress(msg.To)
+
+	var auth smtp.Auth
+	if s.opts.Username != "" {
+		auth = smtp.PlainAuth("", s.opts.Username, s.opts.Password, s.opts.Host)
+	}
+	addr := net.JoinHostPort(s.opts.Host, strconv.Itoa(s.opts.Port))
+	if err := s.send(addr, auth, sender.Address, []string{recipient.Address}, data); err != nil {
+		return fmt.Errorf("smtp send failed: %w", err)
+	}
+	return nil
+}
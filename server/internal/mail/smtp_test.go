@@ -0,0 +1,39 @@
+package mail
+
+import (
+	"errors"
+	"net/smtp"
+	"strings"
+	"testing"
+)
+
+func TestSMTP_Send(t *testing.T) {
+	var gotAddr, gotFrom string
+	var gotTo []string
+	var gotAuth smtp.Auth
+	var gotMsg []byte
+
+	s := NewSMTP(SMTPOptions{Host: "smtp.example.com", Username: "user", Password: "pw"}, "Blog <news@example.com>")
+	s.send = func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotAuth, gotFrom, gotTo, gotMsg = addr, auth, from, to, msg
+		return nil
+	}
+
+	if err := s.Send(&Message{To: "Reader <reader@example.com>", Subject: "Hi", Text: "Hello"}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if gotAddr != "smtp.example.com:587" || gotAuth == nil {
+		t.Errorf("addr = %q, auth = %v, want default port with auth", gotAddr, gotAuth)
+	}
+	if gotFrom != "news@example.com" || len(gotTo) != 1 || gotTo[0] != "reader@example.com" {
+		t.Errorf("envelope = %q -> %v, want bare addresses", gotFrom, gotTo)
+	}
+	if !strings.Contains(string(gotMsg), "Subject: Hi\r\n") {
+		t.Errorf("message missing subject:\n%s", gotMsg)
+	}
+
+	s.send = func(string, smtp.Auth, string, []string, []byte) error { return errors.New("421 try later") }
+	if err := s.Send(&Message{To: "reader@example.com", Text: "Hello"}); err == nil {
+		t.Error("Send() should return the server error")
+	}
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+)
+
+var (
+	ErrBatchUnsupported = errors.New("atomic batch is not supported by repository")
+	ErrInvalidBatch     = errors.New("invalid batch")
+	ErrVersionConflict  = errors.New("version conflict: post has been modified")
+)
+
+// Batch 一组需要同时生效的写操作
+type Batch struct {
+	Save     []*domain.Post // 保存（创建或更新）的文章
+	Delete   []string       // 删除的文章 ID
+	Versions map[string]int // 文章 ID -> 期望的当前版本（乐观锁），未列出的文章不检查
+	Editor   string         // 操作者用户名（版本化仓库用作提交作者）
+}
+
+// BatchWriter 支持原子批量写入的仓库：批次中的操作要么全部生效，要么全部不生效
+type BatchWriter interface {
+	// ApplyBatch 应用批次，返回错误时仓库内容保持不变
+	ApplyBatch(batch Batch) error
+}
+
+// CheckBatch 在应用前校验批次：同一文章只能出现一次，删除的文章必须存在，
+// 文章的当前版本与 Versions 一致，应用后 slug 不冲突。
+// version 返回文章的当前版本（不存在时 ok 为 false），slugOwner 返回当前持有 slug 的文章 ID（调用方需持有锁）
+func CheckBatch(batch Batch, version func(id string) (int, bool), slugOwner func(slug string) (string, bool)) error {
+	// 批次中出现的文章原有的 slug 都会被释放或覆盖
+	touched := make(map[string]bool, len(batch.Save)+len(batch.Delete))
+	for _, id := range batch.Delete {
+		if touched[id] {
+			return fmt.Errorf("%w: post %s appears more than once", ErrInvalidBatch, id)
+		}
+		if _, ok := version(id); !ok {
+			return ErrPostNotFound
+		}
+		touched[id] = true
+	}
+	for _, post := range batch.Save {
+		if touched[post.ID] {
+			return fmt.Errorf("%w: post %s appears more than once", ErrInvalidBatch, post.ID)
+		}
+		touched[post.ID] = true
+	}
+	for id, expected := range batch.Versions {
+		current, ok := version(id)
+		if !ok {
+			return ErrPostNotFound
+		}
+		if current != expected {
+			return fmt.Errorf("%w: post %s is at version %d", ErrVersionConflict, id, current)
+		}
+	}
+
+	claimed := make(map[string]string, len(batch.Save))
+	for _, post := range batch.Save {
+		slug := post.Slug.String()
+		if other, ok := claimed[slug]; ok && other != post.ID {
+			return ErrSlugExists
+		}
+		claimed[slug] = post.ID
+
+		if owner, ok := slugOwner(slug); ok && owner != post.ID && !touched[owner] {
+			return ErrSlugExists
+		}
+	}
+	return nil
+}
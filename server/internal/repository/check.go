@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/next-ai-ventus/server/internal/storage"
+)
+
+var ErrCheckUnsupported = errors.New("content check is not supported by repository")
+
+// 检查问题类型
+const (
+	IssueMissingFile     = "missing_file"     // meta.json 或 content.md 缺失
+	IssueInvalidJSON     = "invalid_json"     // meta.json 无法解析
+	IssueIDMismatch      = "id_mismatch"      // 目录名与 meta.id 不一致
+	IssueInvalidSlug     = "invalid_slug"     // slug 格式错误（文章无法加载）
+	IssueDuplicateSlug   = "duplicate_slug"   // 多篇文章使用同一 slug
+	IssueInvalidTag      = "invalid_tag"      // 标签格式错误（加载时被忽略）
+	IssueInvalidStatus   = "invalid_status"   // 状态无法识别（加载时按草稿处理）
+	IssueInvalidDate     = "invalid_date"     // 时间无法解析或缺失
+	IssueInvalidVersion  = "invalid_version"  // 版本号小于 1
+	IssueGarbledExcerpt  = "garbled_excerpt"  // 摘要乱码
+	IssueMissingUpload   = "missing_upload"   // 引用的上传文件不存在
+	IssueOrphanUpload    = "orphan_upload"    // 上传文件未被任何文章引用
+	IssueStaleSnapshot   = "stale_snapshot"   // 索引快照损坏或引用了不存在的文章
+	IssueUncommittedPost = "uncommitted_post" // 文章目录有未提交到版本历史的修改
+)
+
+// 问题级别
+const (
+	SeverityError   = "error"   // 文章无法加载或数据会丢失
+	SeverityWarning = "warning" // 文章可加载，但数据不完整或不一致
+)
+
+// CheckOptions 内容检查选项
+type CheckOptions struct {
+	Uploads storage.Blob // 上传文件存储，为 nil 时不检查上传文件引用
+	Fix     bool         // 是否执行安全的自动修复；为 false 时只报告（dry-run）
+}
+
+// CheckIssue 一条检查结果
+type CheckIssue struct {
+	PostID   string `json:"postId,omitempty"`
+	Path     string `json:"path"`
+	Kind     string `json:"kind"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	Fix      string `json:"fix,omitempty"` // 可自动修复时的修复方式
+	Fixed    bool   `json:"fixed"`
+}
+
+// CheckReport 内容检查报告
+type CheckReport struct {
+	DryRun bool          `json:"dryRun"`
+	Posts  int           `json:"posts"` // 检查的文章目录数
+	Issues []*CheckIssue `json:"issues"`
+	Fixed  int           `json:"fixed"`
+}
+
+// Add 添加一条检查结果
+func (r *CheckReport) Add(issue *CheckIssue) {
+	r.Issues = append(r.Issues, issue)
+	if issue.Fixed {
+		r.Fixed++
+	}
+}
+
+// Count 统计指定级别且未修复的问题数
+func (r *CheckReport) Count(severity string) int {
+	count := 0
+	for _, issue := range r.Issues {
+		if issue.Severity == severity && !issue.Fixed {
+			count++
+		}
+	}
+	return count
+}
+
+// ContentChecker 可检查（并修复）存储内容完整性的仓库
+type ContentChecker interface {
+	// CheckContent 检查存储内容；opts.Fix 为 true 时执行安全的自动修复并重新加载索引
+	CheckContent(opts CheckOptions) (*CheckReport, error)
+}
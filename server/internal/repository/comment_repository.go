@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/domain/valueobject"
+)
+
+var ErrCommentNotFound = errors.New("comment not found")
+
+// CommentListOptions 评论列表查询选项（管理端审核队列）
+type CommentListOptions struct {
+	PostID   string                    // 为空时不限文章
+	Status   valueobject.CommentStatus // 为空时不限状态
+	Page     int
+	PageSize int // <= 0 时返回全部
+}
+
+// CommentRepository 评论仓库接口
+type CommentRepository interface {
+	// FindByID 根据 ID 查找评论
+	FindByID(id string) (*domain.Comment, error)
+
+	// FindByPost 查找文章的全部评论（含各种状态，按创建时间正序）
+	FindByPost(postID string) ([]*domain.Comment, error)
+
+	// List 按条件分页查询评论（按创建时间倒序），同时返回总数
+	List(opts CommentListOptions) ([]*domain.Comment, int, error)
+
+	// CountByPost 统计每篇文章指定状态的评论数（postID -> 数量）
+	CountByPost(status valueobject.CommentStatus) (map[string]int, error)
+
+	// Save 保存评论（ID 相同时覆盖）
+	Save(comment *domain.Comment) error
+
+	// Delete 永久删除评论
+	Delete(id string) error
+
+	// DeleteByPost 永久删除文章的全部评论
+	DeleteByPost(postID string) error
+
+	// StorageLocker 备份与恢复时锁定存储，WriteLocked 结束前重新加载持久化的评论
+	StorageLocker
+}
+
+// MemoryCommentRepository 内存实现的 CommentRepository（用于测试）
+type MemoryCommentRepository struct {
+	comments map[string]*domain.Comment // id -> comment
+	mu       sync.RWMutex
+}
+
+// NewMemoryCommentRepository 创建内存评论仓库
+func NewMemoryCommentRepository() *MemoryCommentRepository {
+	return &MemoryCommentRepository{
+		comments: make(map[string]*domain.Comment),
+	}
+}
+
+// FindByID 根据 ID 查找评论
+func (r *MemoryCommentRepository) FindByID(id string) (*domain.Comment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	comment, ok := r.comments[id]
+	if !ok {
+		return nil, ErrCommentNotFound
+	}
+	copied := *comment
+	return &copied, nil
+}
+
+// FindByPost 查找文章的全部评论
+func (r *MemoryCommentRepository) FindByPost(postID string) ([]*domain.Comment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return CommentsOfPost(r.comments, postID), nil
+}
+
+// List 按条件分页查询评论
+func (r *MemoryCommentRepository) List(opts CommentListOptions) ([]*domain.Comment, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items, total := ListComments(r.comments, opts)
+	return items, total, nil
+}
+
+// CountByPost 统计每篇文章指定状态的评论数
+func (r *MemoryCommentRepository) CountByPost(status valueobject.CommentStatus) (map[string]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return CountComments(r.comments, status), nil
+}
+
+// Save 保存评论
+func (r *MemoryCommentRepository) Save(comment *domain.Comment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *comment
+	r.comments[comment.ID] = &copied
+	return nil
+}
+
+// Delete 永久删除评论
+func (r *MemoryCommentRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.comments[id]; !ok {
+		return ErrCommentNotFound
+	}
+	delete(r.comments, id)
+	return nil
+}
+
+// DeleteByPost 永久删除文章的全部评论
+func (r *MemoryCommentRepository) DeleteByPost(postID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, comment := range r.comments {
+		if comment.PostID == postID {
+			delete(r.comments, id)
+		}
+	}
+	return nil
+}
+
+// ReadLocked 持有锁执行 fn
+func (r *MemoryCommentRepository) ReadLocked(fn func() error) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return fn()
+}
+
+// WriteLocked 持有写锁执行 fn，内存实现无需重新加载
+func (r *MemoryCommentRepository) WriteLocked(fn func() error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return fn()
+}
+
+// CommentsOfPost 从评论表中筛选文章的评论（返回副本，按创建时间正序）
+func CommentsOfPost(comments map[string]*domain.Comment, postID string) []*domain.Comment {
+	result := []*domain.Comment{}
+	for _, comment := range comments {
+		if comment.PostID == postID {
+			copied := *comment
+			result = append(result, &copied)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return commentBefore(result[i], result[j]) })
+	return result
+}
+
+// ListComments 从评论表中按条件分页筛选评论（返回副本，按创建时间倒序）
+func ListComments(comments map[string]*domain.Comment, opts CommentListOptions) ([]*domain.Comment, int) {
+	var matched []*domain.Comment
+	for _, comment := range comments {
+		if opts.PostID != "" && comment.PostID != opts.PostID {
+			continue
+		}
+		if opts.Status != "" && comment.Status != opts.Status {
+			continue
+		}
+		matched = append(matched, comment)
+	}
+	sort.Slice(matched, func(i, j int) bool { return commentBefore(matched[j], matched[i]) })
+
+	total := len(matched)
+	if opts.PageSize > 0 {
+		page := opts.Page
+		if page < 1 {
+			page = 1
+		}
+		start := (page - 1) * opts.PageSize
+		if start > total {
+			start = total
+		}
+		end := start + opts.PageSize
+		if end > total {
+			end = total
+		}
+		matched = matched[start:end]
+	}
+
+	result := make([]*domain.Comment, 0, len(matched))
+	for _, comment := range matched {
+		copied := *comment
+		result = append(result, &copied)
+	}
+	return result, total
+}
+
+// CountComments 统计评论表中每篇文章指定状态的评论数
+func CountComments(comments map[string]*domain.Comment, status valueobject.CommentStatus) map[string]int {
+	counts := make(map[string]int)
+	for _, comment := range comments {
+		if comment.Status == status {
+			counts[comment.PostID]++
+		}
+	}
+	return counts
+}
+
+// commentBefore 创建时间正序，同一时间按 ID 排序
+func commentBefore(a, b *domain.Comment) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+	return a.ID < b.ID
+}
@@ -0,0 +1,14 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/next-ai-ventus/server/internal/repository"
+	"github.com/next-ai-ventus/server/internal/repository/repositorytest"
+)
+
+func TestMemoryPostRepository_Contract(t *testing.T) {
+	repositorytest.RunPostRepositoryContract(t, func(t *testing.T) repository.PostRepository {
+		return repository.NewMemoryPostRepository()
+	})
+}
@@ -0,0 +1,134 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+// batchDirPattern 批量写入的暂存目录（位于内容目录下，与文章目录在同一文件系统以便重命名）
+const batchDirPattern = ".batch-*"
+
+// ApplyBatch 原子地应用一组写操作。
+// 先把所有文章写入暂存目录，再逐个用重命名替换文章目录；
+// 任一步失败时把已替换的目录还原，仓库内容与索引保持不变
+func (r *FilePostRepository) ApplyBatch(batch repository.Batch) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := repository.CheckBatch(batch,
+		func(id string) (int, bool) {
+			if post, ok := r.posts[id]; ok {
+				return post.Version, true
+			}
+			return 0, false
+		},
+		func(slug string) (string, bool) { id, ok := r.slugMap[slug]; return id, ok },
+	)
+	if err != nil {
+		return err
+	}
+
+	stage, err := os.MkdirTemp(r.basePath, batchDirPattern)
+	if err != nil {
+		return fmt.Errorf("create batch directory failed: %w", err)
+	}
+	// 还原失败时保留暂存目录，其中的 old/ 是尚未移回的原文章
+	keepStage := false
+	defer func() {
+		if !keepStage {
+			os.RemoveAll(stage)
+		}
+	}()
+
+	// 写入新版本
+	for _, post := range batch.Save {
+		if err := writePost(filepath.Join(stage, "new", post.ID), post); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(stage, "old"), 0755); err != nil {
+		return fmt.Errorf("create batch directory failed: %w", err)
+	}
+
+	// 替换文章目录
+	tx := &batchSwap{postsDir: filepath.Join(r.basePath, "posts"), stage: stage}
+	abort := func(err error) error {
+		if rbErr := tx.rollback(); rbErr != nil {
+			keepStage = true
+			return errors.Join(err, fmt.Errorf("rollback failed, originals kept in %s: %w", stage, rbErr))
+		}
+		return err
+	}
+	for _, id := range batch.Delete {
+		if err := tx.remove(id); err != nil {
+			return abort(err)
+		}
+	}
+	for _, post := range batch.Save {
+		if err := tx.replace(post.ID); err != nil {
+			return abort(err)
+		}
+	}
+
+	// 文件已全部替换，更新内存索引与快照
+	for _, id := range batch.Delete {
+		r.unindexPostLocked(id)
+	}
+	for _, post := range batch.Save {
+		r.indexPostLocked(post)
+	}
+	_ = r.writeSnapshotLocked()
+	return nil
+}
+
+// batchSwap 记录已替换的文章目录，用于失败时还原
+type batchSwap struct {
+	postsDir string
+	stage    string
+	moved    []string // 已移入暂存目录的原文章目录
+	placed   []string // 已放入新版本的文章目录
+}
+
+// remove 将文章目录移入暂存目录
+func (s *batchSwap) remove(id string) error {
+	if err := os.Rename(filepath.Join(s.postsDir, id), filepath.Join(s.stage, "old", id)); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("move post directory failed: %w", err)
+	}
+	s.moved = append(s.moved, id)
+	return nil
+}
+
+// replace 用暂存的新版本替换文章目录
+func (s *batchSwap) replace(id string) error {
+	if err := s.remove(id); err != nil {
+		return err
+	}
+	if err := os.Rename(filepath.Join(s.stage, "new", id), filepath.Join(s.postsDir, id)); err != nil {
+		return fmt.Errorf("move post directory failed: %w", err)
+	}
+	s.placed = append(s.placed, id)
+	return nil
+}
+
+// rollback 删除已放入的新版本并移回原文章目录
+func (s *batchSwap) rollback() error {
+	var errs []error
+	for _, id := range s.placed {
+		if err := os.RemoveAll(filepath.Join(s.postsDir, id)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, id := range s.moved {
+		if err := os.Rename(filepath.Join(s.stage, "old", id), filepath.Join(s.postsDir, id)); err != nil {
+			errs = append(errs, fmt.Errorf("restore post %s failed: %w", id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
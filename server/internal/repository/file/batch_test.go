@@ -0,0 +1,49 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+func TestFilePostRepository_ApplyBatchPersists(t *testing.T) {
+	repo, tmpDir := setupTestRepo(t)
+
+	keep := createTestPost("2024-06-keep", "Keep", "keep")
+	remove := createTestPost("2024-06-remove", "Remove", "remove")
+	for _, post := range []*domain.Post{keep, remove} {
+		if err := repo.Save(post); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	keep.Category = "notes"
+	if err := repo.ApplyBatch(repository.Batch{Save: []*domain.Post{keep}, Delete: []string{remove.ID}}); err != nil {
+		t.Fatalf("ApplyBatch() error = %v", err)
+	}
+
+	// 暂存目录已清理
+	matches, _ := filepath.Glob(filepath.Join(tmpDir, batchDirPattern))
+	if len(matches) != 0 {
+		t.Errorf("batch directories left behind: %v", matches)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "posts", remove.ID)); !os.IsNotExist(err) {
+		t.Errorf("deleted post directory still exists: %v", err)
+	}
+
+	// 重新打开仓库，修改已写入磁盘
+	reopened, err := NewFilePostRepository(tmpDir)
+	if err != nil {
+		t.Fatalf("NewFilePostRepository() error = %v", err)
+	}
+	found, err := reopened.FindByID(keep.ID)
+	if err != nil || found.Category != "notes" {
+		t.Errorf("FindByID() = %+v, %v, want category notes", found, err)
+	}
+	if count, _ := reopened.Count(repository.CountOptions{}); count != 1 {
+		t.Errorf("Count() = %d, want 1", count)
+	}
+}
@@ -0,0 +1,461 @@
+package file
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/domain/valueobject"
+	"github.com/next-ai-ventus/server/internal/repository"
+	"github.com/next-ai-ventus/server/internal/storage"
+)
+
+// uploadRefRegex 匹配正文与封面中对 /uploads/ 的引用
+var uploadRefRegex = regexp.MustCompile(`/uploads/[^\s"'()<>\[\]?#]+`)
+
+// contentChecker 一次内容检查的上下文
+type contentChecker struct {
+	basePath string
+	opts     repository.CheckOptions
+	report   *repository.CheckReport
+}
+
+// checkedPost 已成功解析 meta.json 的文章
+type checkedPost struct {
+	id      string
+	meta    metaJSON
+	content string
+	modTime time.Time // meta.json 修改时间，用于修复时间字段
+	dirty   bool      // 已修复，需要写回 meta.json
+}
+
+// CheckContent 检查内容目录完整性。
+// opts.Fix 为 true 时执行安全修复，并重新加载索引与快照。
+func (r *FilePostRepository) CheckContent(opts repository.CheckOptions) (*repository.CheckReport, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report, err := checkContent(r.basePath, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if report.Fixed > 0 {
+		if err := r.loadIndexLocked(false); err != nil {
+			return nil, err
+		}
+		if err := r.writeSnapshotLocked(); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// checkContent 检查 basePath 下的所有文章目录、上传文件引用与索引快照
+func checkContent(basePath string, opts repository.CheckOptions) (*repository.CheckReport, error) {
+	c := &contentChecker{
+		basePath: basePath,
+		opts:     opts,
+		report:   &repository.CheckReport{DryRun: !opts.Fix, Issues: []*repository.CheckIssue{}},
+	}
+
+	entries, err := os.ReadDir(filepath.Join(basePath, "posts"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	var posts []*checkedPost
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		c.report.Posts++
+		if post := c.checkPost(entry.Name()); post != nil {
+			posts = append(posts, post)
+		}
+	}
+
+	c.checkSlugs(posts)
+
+	// 写回修复后的 meta.json
+	for _, post := range posts {
+		if !post.dirty {
+			continue
+		}
+		if err := writeMetaFile(filepath.Join(basePath, "posts", post.id), post.meta); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Uploads != nil {
+		if err := c.checkUploads(posts); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.checkSnapshot(posts); err != nil {
+		return nil, err
+	}
+
+	return c.report, nil
+}
+
+// checkPost 检查单个文章目录的文件与元数据，meta.json 无法解析时返回 nil
+func (c *contentChecker) checkPost(id string) *checkedPost {
+	postDir := filepath.Join(c.basePath, "posts", id)
+
+	metaPath := filepath.Join(postDir, "meta.json")
+	metaData, err := os.ReadFile(metaPath)
+	if err != nil {
+		c.fileIssue(id, "meta.json", err)
+		return nil
+	}
+	info, err := os.Stat(metaPath)
+	if err != nil {
+		c.fileIssue(id, "meta.json", err)
+		return nil
+	}
+
+	content, err := os.ReadFile(filepath.Join(postDir, "content.md"))
+	if err != nil {
+		c.fileIssue(id, "content.md", err)
+		return nil
+	}
+
+	var meta metaJSON
+	if err := json.Unmarshal(metaData, &meta); err != nil {
+		c.report.Add(&repository.CheckIssue{
+			PostID:   id,
+			Path:     postPath(id, "meta.json"),
+			Kind:     repository.IssueInvalidJSON,
+			Severity: repository.SeverityError,
+			Message:  err.Error(),
+		})
+		return nil
+	}
+
+	post := &checkedPost{id: id, meta: meta, content: string(content), modTime: info.ModTime()}
+	c.checkID(post)
+	c.checkTags(post)
+	c.checkStatus(post)
+	c.checkDates(post)
+	c.checkVersion(post)
+	c.checkExcerpt(post)
+	return post
+}
+
+// fileIssue 记录文件缺失或无法读取
+func (c *contentChecker) fileIssue(id, name string, err error) {
+	message := fmt.Sprintf("%s is missing", name)
+	if !os.IsNotExist(err) {
+		message = fmt.Sprintf("cannot read %s: %v", name, err)
+	}
+	c.report.Add(&repository.CheckIssue{
+		PostID:   id,
+		Path:     postPath(id, name),
+		Kind:     repository.IssueMissingFile,
+		Severity: repository.SeverityError,
+		Message:  message,
+	})
+}
+
+// add 记录 meta.json 中的问题；fix 非空表示可自动修复，开启修复时由 apply 修改元数据
+func (c *contentChecker) add(post *checkedPost, kind, severity, message, fix string, apply func(meta *metaJSON)) {
+	issue := &repository.CheckIssue{
+		PostID:   post.id,
+		Path:     postPath(post.id, "meta.json"),
+		Kind:     kind,
+		Severity: severity,
+		Message:  message,
+		Fix:      fix,
+	}
+	if fix != "" && c.opts.Fix {
+		apply(&post.meta)
+		post.dirty = true
+		issue.Fixed = true
+	}
+	c.report.Add(issue)
+}
+
+// checkID 目录名是文章的存储位置，meta.id 必须与之一致
+func (c *contentChecker) checkID(post *checkedPost) {
+	if post.meta.ID == post.id {
+		return
+	}
+	c.add(post, repository.IssueIDMismatch, repository.SeverityError,
+		fmt.Sprintf("meta id %q differs from directory name", post.meta.ID),
+		"set id to directory name",
+		func(meta *metaJSON) { meta.ID = post.id })
+}
+
+// checkTags 无效标签在加载时会被静默丢弃
+func (c *contentChecker) checkTags(post *checkedPost) {
+	for _, tag := range post.meta.Tags {
+		if _, err := valueobject.NewTag(tag); err == nil {
+			continue
+		}
+		invalid := tag
+		c.add(post, repository.IssueInvalidTag, repository.SeverityWarning,
+			fmt.Sprintf("tag %q is invalid and ignored on load", invalid),
+			"remove tag",
+			func(meta *metaJSON) { meta.Tags = removeString(meta.Tags, invalid) })
+	}
+}
+
+// checkStatus 无法识别的状态在加载时按草稿处理
+func (c *contentChecker) checkStatus(post *checkedPost) {
+	if _, err := valueobject.NewPostStatus(post.meta.Status); err == nil {
+		return
+	}
+	c.add(post, repository.IssueInvalidStatus, repository.SeverityWarning,
+		fmt.Sprintf("status %q is invalid and treated as draft", post.meta.Status),
+		"set status to draft",
+		func(meta *metaJSON) { meta.Status = valueobject.StatusDraft.String() })
+}
+
+// checkDates 无法解析的时间在加载时变为零值
+func (c *contentChecker) checkDates(post *checkedPost) {
+	fallback := post.modTime.Format(time.RFC3339)
+
+	createdAt := post.meta.CreatedAt
+	if !isValidTime(createdAt) {
+		createdAt = fallback
+		c.add(post, repository.IssueInvalidDate, repository.SeverityWarning,
+			fmt.Sprintf("createdAt %q cannot be parsed", post.meta.CreatedAt),
+			fmt.Sprintf("set createdAt to %s (meta.json modification time)", createdAt),
+			func(meta *metaJSON) { meta.CreatedAt = createdAt })
+	}
+
+	updatedAt := post.meta.UpdatedAt
+	if !isValidTime(updatedAt) {
+		updatedAt = createdAt
+		c.add(post, repository.IssueInvalidDate, repository.SeverityWarning,
+			fmt.Sprintf("updatedAt %q cannot be parsed", post.meta.UpdatedAt),
+			fmt.Sprintf("set updatedAt to %s", updatedAt),
+			func(meta *metaJSON) { meta.UpdatedAt = updatedAt })
+	}
+
+	switch {
+	case post.meta.PublishedAt != nil && !isValidTime(*post.meta.PublishedAt):
+		c.add(post, repository.IssueInvalidDate, repository.SeverityWarning,
+			fmt.Sprintf("publishedAt %q cannot be parsed", *post.meta.PublishedAt),
+			fmt.Sprintf("set publishedAt to %s", updatedAt),
+			func(meta *metaJSON) { meta.PublishedAt = &updatedAt })
+	case post.meta.PublishedAt == nil && post.meta.Status == valueobject.StatusPublished.String():
+		c.add(post, repository.IssueInvalidDate, repository.SeverityWarning,
+			"published post has no publishedAt",
+			fmt.Sprintf("set publishedAt to %s", updatedAt),
+			func(meta *metaJSON) { meta.PublishedAt = &updatedAt })
+	}
+}
+
+// checkVersion 版本号用于乐观锁，必须从 1 开始
+func (c *contentChecker) checkVersion(post *checkedPost) {
+	if post.meta.Version >= 1 {
+		return
+	}
+	c.add(post, repository.IssueInvalidVersion, repository.SeverityWarning,
+		fmt.Sprintf("version %d is less than 1", post.meta.Version),
+		"set version to 1",
+		func(meta *metaJSON) { meta.Version = 1 })
+}
+
+// checkExcerpt 检查摘要乱码（通常是按字节拼接多字节字符造成的）
+func (c *contentChecker) checkExcerpt(post *checkedPost) {
+	if !isGarbled(post.meta.Excerpt) {
+		return
+	}
+
+	regenerated := &domain.Post{Content: post.content}
+	regenerated.GenerateExcerpt(domain.ExcerptLength)
+	c.add(post, repository.IssueGarbledExcerpt, repository.SeverityWarning,
+		"excerpt contains garbled characters",
+		"regenerate excerpt from content",
+		func(meta *metaJSON) { meta.Excerpt = regenerated.Excerpt })
+}
+
+// checkSlugs 检查 slug 格式与重复。按目录名顺序，先出现的文章保留原 slug；
+// 先登记全部有效的 slug，建议的新 slug 不会与任何保留的 slug 冲突
+func (c *contentChecker) checkSlugs(posts []*checkedPost) {
+	owners := make(map[string]*checkedPost)
+	var taken []string
+	for _, post := range posts {
+		slug := post.meta.Slug
+		if _, err := valueobject.NewSlug(slug); err != nil {
+			continue
+		}
+		if _, ok := owners[slug]; !ok {
+			owners[slug] = post
+			taken = append(taken, slug)
+		}
+	}
+
+	for _, post := range posts {
+		slug := post.meta.Slug
+
+		if _, err := valueobject.NewSlug(slug); err != nil {
+			proposed := valueobject.GenerateFromTitle(post.meta.Title, taken).String()
+			c.add(post, repository.IssueInvalidSlug, repository.SeverityError,
+				fmt.Sprintf("slug %q is invalid, post cannot be loaded", slug),
+				fmt.Sprintf("rename slug to %q", proposed),
+				func(meta *metaJSON) { meta.Slug = proposed })
+			slug = proposed
+		} else if owner := owners[slug]; owner != post {
+			proposed := valueobject.GenerateFromTitle(slug, taken).String()
+			c.add(post, repository.IssueDuplicateSlug, repository.SeverityError,
+				fmt.Sprintf("slug %q is also used by %s", slug, owner.id),
+				fmt.Sprintf("rename slug to %q", proposed),
+				func(meta *metaJSON) { meta.Slug = proposed })
+			slug = proposed
+		} else {
+			continue
+		}
+
+		owners[slug] = post
+		taken = append(taken, slug)
+	}
+}
+
+// checkUploads 检查文章引用的上传文件是否存在，以及未被引用的上传文件
+func (c *contentChecker) checkUploads(posts []*checkedPost) error {
+	referenced := make(map[string]bool)
+
+	for _, post := range posts {
+		refs := uploadRefRegex.FindAllString(post.content, -1)
+		refs = append(refs, uploadRefRegex.FindAllString(post.meta.Cover, -1)...)
+
+		for _, ref := range refs {
+			name := path.Clean(strings.TrimPrefix(ref, "/uploads/"))
+			if unescaped, err := url.PathUnescape(name); err == nil {
+				name = unescaped
+			}
+			if strings.HasPrefix(name, "..") {
+				continue
+			}
+			referenced[name] = true
+
+			if _, err := c.opts.Uploads.Stat(name); err == nil {
+				continue
+			} else if !errors.Is(err, storage.ErrNotFound) && !errors.Is(err, storage.ErrInvalidKey) {
+				return err
+			}
+			c.report.Add(&repository.CheckIssue{
+				PostID:   post.id,
+				Path:     postPath(post.id, "content.md"),
+				Kind:     repository.IssueMissingUpload,
+				Severity: repository.SeverityWarning,
+				Message:  fmt.Sprintf("%s does not exist", ref),
+			})
+		}
+	}
+
+	// 未被引用的上传文件只报告，不自动删除
+	uploads, err := c.opts.Uploads.List("")
+	if err != nil {
+		return err
+	}
+	for _, upload := range uploads {
+		if !referenced[upload.Key] {
+			c.report.Add(&repository.CheckIssue{
+				Path:     "/uploads/" + upload.Key,
+				Kind:     repository.IssueOrphanUpload,
+				Severity: repository.SeverityWarning,
+				Message:  "upload is not referenced by any post",
+			})
+		}
+	}
+	return nil
+}
+
+// checkSnapshot 检查索引快照是否损坏，或引用了已不存在的文章
+func (c *contentChecker) checkSnapshot(posts []*checkedPost) error {
+	snapshotPath := filepath.Join(c.basePath, snapshotFileName)
+	if _, err := os.Stat(snapshotPath); os.IsNotExist(err) {
+		return nil
+	}
+
+	var message string
+	entries, ok := readSnapshot(snapshotPath)
+	if !ok {
+		message = "index snapshot is corrupt or has an outdated schema version"
+	} else {
+		loaded := make(map[string]bool, len(posts))
+		for _, post := range posts {
+			loaded[post.id] = true
+		}
+
+		var stale []string
+		for id := range entries {
+			if !loaded[id] {
+				stale = append(stale, id)
+			}
+		}
+		if len(stale) == 0 {
+			return nil
+		}
+		sort.Strings(stale)
+		message = fmt.Sprintf("index snapshot references missing posts: %s", strings.Join(stale, ", "))
+	}
+
+	issue := &repository.CheckIssue{
+		Path:     snapshotFileName,
+		Kind:     repository.IssueStaleSnapshot,
+		Severity: repository.SeverityWarning,
+		Message:  message,
+		Fix:      "delete snapshot and rebuild index",
+	}
+	if c.opts.Fix {
+		if err := os.Remove(snapshotPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove index snapshot failed: %w", err)
+		}
+		issue.Fixed = true
+	}
+	c.report.Add(issue)
+	return nil
+}
+
+// postPath 返回文章文件相对于内容目录的路径
+func postPath(id, name string) string {
+	return path.Join("posts", id, name)
+}
+
+// isValidTime 判断时间字符串能否按 RFC3339 解析
+func isValidTime(s string) bool {
+	_, err := time.Parse(time.RFC3339, s)
+	return err == nil
+}
+
+// isGarbled 判断文本是否为乱码：非法 UTF-8、替换字符或 C1 控制字符
+// （把 UTF-8 字节逐个当作字符拼接时会产生 U+0080~U+009F）
+func isGarbled(s string) bool {
+	if !utf8.ValidString(s) {
+		return true
+	}
+	for _, r := range s {
+		if r == utf8.RuneError || (r >= 0x80 && r <= 0x9f) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeString 从切片中移除指定字符串
+func removeString(items []string, target string) []string {
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if item != target {
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,212 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/next-ai-ventus/server/internal/repository"
+	"github.com/next-ai-ventus/server/internal/storage"
+)
+
+// writePostFiles 直接写入文章目录（模拟手工编辑或损坏的数据）
+func writePostFiles(t *testing.T, base, id, meta, content string) {
+	t.Helper()
+
+	dir := filepath.Join(base, "posts", id)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if meta != "" {
+		os.WriteFile(filepath.Join(dir, "meta.json"), []byte(meta), 0644)
+	}
+	if content != "" {
+		os.WriteFile(filepath.Join(dir, "content.md"), []byte(content), 0644)
+	}
+}
+
+// setupBrokenContent 构造包含各类问题的内容目录
+func setupBrokenContent(t *testing.T) (string, string) {
+	t.Helper()
+
+	base := t.TempDir()
+	uploads := t.TempDir()
+
+	writePostFiles(t, base, "a-good", `{"id":"a-good","title":"Good","slug":"good","excerpt":"ok","tags":["go"],"status":"published",
+		"createdAt":"2024-06-01T00:00:00Z","updatedAt":"2024-06-01T00:00:00Z","publishedAt":"2024-06-01T00:00:00Z","version":1,
+		"cover":"/uploads/2024/06/cover.png"}`, "![img](/uploads/2024/06/missing.png)")
+	writePostFiles(t, base, "b-no-content", `{"id":"b-no-content","title":"No content","slug":"no-content","status":"draft","version":1}`, "")
+	writePostFiles(t, base, "c-bad-json", `{"id":`, "body")
+	writePostFiles(t, base, "d-mismatch", `{"id":"other","title":"Dup","slug":"good","excerpt":"`+"Ã\u0083"+`",
+		"tags":["Bad Tag","web"],"status":"archived","createdAt":"yesterday","updatedAt":"2024-06-02T00:00:00Z","version":0}`, "# 测试")
+	writePostFiles(t, base, "e-bad-slug", `{"id":"e-bad-slug","title":"Hello World","slug":"Not A Slug","status":"published",
+		"createdAt":"2024-06-01T00:00:00Z","updatedAt":"2024-06-01T00:00:00Z","version":1}`, "body")
+
+	os.MkdirAll(filepath.Join(uploads, "2024", "06"), 0755)
+	os.WriteFile(filepath.Join(uploads, "2024", "06", "cover.png"), []byte("png"), 0644)
+	os.WriteFile(filepath.Join(uploads, "2024", "06", "orphan.png"), []byte("png"), 0644)
+
+	return base, uploads
+}
+
+// issueKinds 统计报告中每种问题的数量
+func issueKinds(report *repository.CheckReport) map[string]int {
+	kinds := make(map[string]int)
+	for _, issue := range report.Issues {
+		kinds[issue.Kind]++
+	}
+	return kinds
+}
+
+func TestCheckContent_DryRun(t *testing.T) {
+	base, uploads := setupBrokenContent(t)
+	before, _ := os.ReadFile(filepath.Join(base, "posts", "d-mismatch", "meta.json"))
+
+	report, err := checkContent(base, repository.CheckOptions{Uploads: storage.NewLocal(uploads, storage.URLPrefix)})
+	if err != nil {
+		t.Fatalf("checkContent() error = %v", err)
+	}
+
+	want := map[string]int{
+		repository.IssueMissingFile:    1,
+		repository.IssueInvalidJSON:    1,
+		repository.IssueIDMismatch:     1,
+		repository.IssueDuplicateSlug:  1,
+		repository.IssueInvalidSlug:    1,
+		repository.IssueInvalidTag:     1,
+		repository.IssueInvalidStatus:  1,
+		repository.IssueInvalidDate:    2, // createdAt 无法解析 + 已发布但缺少 publishedAt
+		repository.IssueInvalidVersion: 1,
+		repository.IssueGarbledExcerpt: 1,
+		repository.IssueMissingUpload:  1,
+		repository.IssueOrphanUpload:   1,
+	}
+	got := issueKinds(report)
+	for kind, n := range want {
+		if got[kind] != n {
+			t.Errorf("%s issues = %d, want %d", kind, got[kind], n)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("issue kinds = %v", got)
+	}
+
+	if !report.DryRun || report.Fixed != 0 || report.Posts != 5 {
+		t.Errorf("report = dryRun %v, fixed %d, posts %d", report.DryRun, report.Fixed, report.Posts)
+	}
+	after, _ := os.ReadFile(filepath.Join(base, "posts", "d-mismatch", "meta.json"))
+	if string(before) != string(after) {
+		t.Error("dry-run should not modify files")
+	}
+}
+
+func TestFilePostRepository_CheckContentFix(t *testing.T) {
+	base, uploads := setupBrokenContent(t)
+
+	repo, err := NewFilePostRepository(base)
+	if err != nil {
+		t.Fatalf("NewFilePostRepository() error = %v", err)
+	}
+	// 修复前：slug 无效的文章无法加载
+	if _, err := repo.FindByID("e-bad-slug"); err == nil {
+		t.Fatal("post with invalid slug should not load before fix")
+	}
+
+	report, err := repo.CheckContent(repository.CheckOptions{Uploads: storage.NewLocal(uploads, storage.URLPrefix), Fix: true})
+	if err != nil {
+		t.Fatalf("CheckContent() error = %v", err)
+	}
+	if report.DryRun || report.Fixed == 0 {
+		t.Fatalf("report = dryRun %v, fixed %d", report.DryRun, report.Fixed)
+	}
+
+	// 修复后索引已重新加载
+	post, err := repo.FindBySlug("hello-world")
+	if err != nil || post.ID != "e-bad-slug" || post.PublishedAt == nil {
+		t.Errorf("FindBySlug(hello-world) = %+v, %v", post, err)
+	}
+	fixed, err := repo.FindBySlug("good-2")
+	if err != nil {
+		t.Fatalf("FindBySlug(good-2) error = %v", err)
+	}
+	if fixed.ID != "d-mismatch" || fixed.Excerpt != "测试" || fixed.Version != 1 ||
+		fixed.Status.String() != "draft" || fixed.CreatedAt.IsZero() {
+		t.Errorf("fixed post = %+v", fixed)
+	}
+	if names := fixed.GetTagNames(); len(names) != 1 || names[0] != "web" {
+		t.Errorf("fixed tags = %v, want [web]", names)
+	}
+
+	// 再次检查只剩无法自动修复的问题
+	again, err := repo.CheckContent(repository.CheckOptions{Uploads: storage.NewLocal(uploads, storage.URLPrefix)})
+	if err != nil {
+		t.Fatalf("CheckContent() error = %v", err)
+	}
+	for _, issue := range again.Issues {
+		if issue.Fix != "" {
+			t.Errorf("fixable issue left after fix: %+v", issue)
+		}
+	}
+}
+
+func TestCheckContent_StaleSnapshot(t *testing.T) {
+	base := setupSnapshotRepo(t)
+	os.RemoveAll(filepath.Join(base, "posts", "p2"))
+
+	report, err := checkContent(base, repository.CheckOptions{})
+	if err != nil {
+		t.Fatalf("checkContent() error = %v", err)
+	}
+	if kinds := issueKinds(report); kinds[repository.IssueStaleSnapshot] != 1 {
+		t.Fatalf("issues = %v, want stale snapshot", kinds)
+	}
+
+	if _, err := checkContent(base, repository.CheckOptions{Fix: true}); err != nil {
+		t.Fatalf("checkContent(fix) error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(base, snapshotFileName)); !os.IsNotExist(err) {
+		t.Error("stale snapshot should be removed by fix")
+	}
+}
+
+func TestCheckContent_DuplicateSlugKeepsLaterValidSlug(t *testing.T) {
+	base := t.TempDir()
+	for _, p := range []struct{ id, slug string }{{"a", "x"}, {"b", "x"}, {"c", "x-2"}} {
+		writePostFiles(t, base, p.id, `{"id":"`+p.id+`","title":"X","slug":"`+p.slug+`","status":"draft",
+			"createdAt":"2024-06-01T00:00:00Z","updatedAt":"2024-06-01T00:00:00Z","version":1}`, "body")
+	}
+
+	report, err := checkContent(base, repository.CheckOptions{})
+	if err != nil {
+		t.Fatalf("checkContent() error = %v", err)
+	}
+
+	// c 的 slug x-2 有效且唯一，不应被改名；b 的建议 slug 需避开 x-2
+	var duplicates []string
+	for _, issue := range report.Issues {
+		if issue.Kind == repository.IssueDuplicateSlug {
+			duplicates = append(duplicates, issue.PostID+": "+issue.Fix)
+		}
+	}
+	if len(duplicates) != 1 || duplicates[0] != `b: rename slug to "x-3"` {
+		t.Errorf("duplicate slug issues = %q, want only b renamed to x-3", duplicates)
+	}
+}
+
+func TestIsGarbled(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"plain text", false},
+		{"中文摘要", false},
+		{"Ã\u0083Â\u0083", true},
+		{"bad \xff byte", true},
+		{"replacement �", true},
+	}
+	for _, tt := range tests {
+		if got := isGarbled(tt.text); got != tt.want {
+			t.Errorf("isGarbled(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
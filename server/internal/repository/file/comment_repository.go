@@ -0,0 +1,285 @@
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/domain/valueobject"
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+// commentsDirName 评论目录（位于内容目录下，每篇文章一个 <postID>.json）
+const commentsDirName = "comments"
+
+// commentJSON 是评论文件中单条评论的结构
+type commentJSON struct {
+	ID         string `json:"id"`
+	ParentID   string `json:"parentId,omitempty"`
+	AuthorName string `json:"authorName"`
+	EmailHash  string `json:"emailHash,omitempty"`
+	Body       string `json:"body"`
+	Status     string `json:"status"`
+	CreatedAt  string `json:"createdAt"`
+	UpdatedAt  string `json:"updatedAt"`
+}
+
+// FileCommentRepository 基于 JSON 文件的评论仓库，全部评论常驻内存；
+// 修改某篇文章的评论时只整体写回该文章的评论文件
+type FileCommentRepository struct {
+	dir      string
+	comments map[string]*domain.Comment
+	mu       sync.RWMutex
+}
+
+// NewFileCommentRepository 创建评论仓库并加载 basePath/comments 下的评论
+func NewFileCommentRepository(basePath string) (*FileCommentRepository, error) {
+	dir := filepath.Join(basePath, commentsDirName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create comments directory failed: %w", err)
+	}
+
+	r := &FileCommentRepository{
+		dir:      dir,
+		comments: make(map[string]*domain.Comment),
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// FindByID 根据 ID 查找评论
+func (r *FileCommentRepository) FindByID(id string) (*domain.Comment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	comment, ok := r.comments[id]
+	if !ok {
+		return nil, repository.ErrCommentNotFound
+	}
+	copied := *comment
+	return &copied, nil
+}
+
+// FindByPost 查找文章的全部评论
+func (r *FileCommentRepository) FindByPost(postID string) ([]*domain.Comment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return repository.CommentsOfPost(r.comments, postID), nil
+}
+
+// List 按条件分页查询评论
+func (r *FileCommentRepository) List(opts repository.CommentListOptions) ([]*domain.Comment, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items, total := repository.ListComments(r.comments, opts)
+	return items, total, nil
+}
+
+// CountByPost 统计每篇文章指定状态的评论数
+func (r *FileCommentRepository) CountByPost(status valueobject.CommentStatus) (map[string]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return repository.CountComments(r.comments, status), nil
+}
+
+// Save 保存评论并写回文章的评论文件，写入失败时恢复原状态
+func (r *FileCommentRepository) Save(comment *domain.Comment) error {
+	if !validPostFileID(comment.PostID) {
+		return fmt.Errorf("invalid post id %q", comment.PostID)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, existed := r.comments[comment.ID]
+	copied := *comment
+	r.comments[comment.ID] = &copied
+
+	err := r.writePost(comment.PostID)
+	if err == nil && existed && previous.PostID != comment.PostID {
+		err = r.writePost(previous.PostID)
+	}
+	if err != nil {
+		if existed {
+			r.comments[comment.ID] = previous
+		} else {
+			delete(r.comments, comment.ID)
+		}
+		return err
+	}
+	return nil
+}
+
+// Delete 永久删除评论并写回文章的评论文件
+func (r *FileCommentRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, ok := r.comments[id]
+	if !ok {
+		return repository.ErrCommentNotFound
+	}
+	delete(r.comments, id)
+
+	if err := r.writePost(previous.PostID); err != nil {
+		r.comments[id] = previous
+		return err
+	}
+	return nil
+}
+
+// DeleteByPost 删除文章的评论文件
+func (r *FileCommentRepository) DeleteByPost(postID string) error {
+	if !validPostFileID(postID) {
+		return fmt.Errorf("invalid post id %q", postID)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := os.Remove(r.postPath(postID)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("delete comments of %s failed: %w", postID, err)
+	}
+	for id, comment := range r.comments {
+		if comment.PostID == postID {
+			delete(r.comments, id)
+		}
+	}
+	return nil
+}
+
+// ReadLocked 持有锁执行 fn，期间不会有写入（用于备份）
+func (r *FileCommentRepository) ReadLocked(fn func() error) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return fn()
+}
+
+// WriteLocked 持有写锁执行 fn（fn 可直接替换数据文件），完成后（无论成功与否）在同一临界区内重新读取评论目录（用于恢复备份）
+func (r *FileCommentRepository) WriteLocked(fn func() error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fnErr := fn()
+	if err := r.reloadLocked(); err != nil {
+		return err
+	}
+	return fnErr
+}
+
+// reloadLocked 重新读取评论目录（调用方需持有写锁）
+func (r *FileCommentRepository) reloadLocked() error {
+	if err := os.MkdirAll(r.dir, 0755); err != nil {
+		return fmt.Errorf("create comments directory failed: %w", err)
+	}
+	r.comments = make(map[string]*domain.Comment)
+	return r.load()
+}
+
+// load 读取全部评论文件（调用方需持有写锁或处于构造阶段）
+func (r *FileCommentRepository) load() error {
+	entries, err := os.ReadDir(r.dir)
+	if err != nil {
+		return fmt.Errorf("read comments directory failed: %w", err)
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		postID := strings.TrimSuffix(name, ".json")
+
+		data, err := os.ReadFile(filepath.Join(r.dir, name))
+		if err != nil {
+			return fmt.Errorf("read comments of %s failed: %w", postID, err)
+		}
+		var records []commentJSON
+		if err := json.Unmarshal(data, &records); err != nil {
+			return fmt.Errorf("parse comments of %s failed: %w", postID, err)
+		}
+
+		for _, record := range records {
+			status, err := valueobject.NewCommentStatus(record.Status)
+			if err != nil {
+				return fmt.Errorf("parse comments of %s failed: %w", postID, err)
+			}
+			createdAt, _ := time.Parse(time.RFC3339, record.CreatedAt)
+			updatedAt, _ := time.Parse(time.RFC3339, record.UpdatedAt)
+			r.comments[record.ID] = &domain.Comment{
+				ID:         record.ID,
+				PostID:     postID,
+				ParentID:   record.ParentID,
+				AuthorName: record.AuthorName,
+				EmailHash:  record.EmailHash,
+				Body:       record.Body,
+				Status:     status,
+				CreatedAt:  createdAt,
+				UpdatedAt:  updatedAt,
+			}
+		}
+	}
+	return nil
+}
+
+// writePost 将文章的评论写回文件（先写临时文件再重命名），没有评论时删除文件
+func (r *FileCommentRepository) writePost(postID string) error {
+	comments := repository.CommentsOfPost(r.comments, postID)
+	path := r.postPath(postID)
+	if len(comments) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("delete comments of %s failed: %w", postID, err)
+		}
+		return nil
+	}
+
+	records := make([]commentJSON, 0, len(comments))
+	for _, comment := range comments {
+		records = append(records, commentJSON{
+			ID:         comment.ID,
+			ParentID:   comment.ParentID,
+			AuthorName: comment.AuthorName,
+			EmailHash:  comment.EmailHash,
+			Body:       comment.Body,
+			Status:     comment.Status.String(),
+			CreatedAt:  comment.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:  comment.UpdatedAt.Format(time.RFC3339),
+		})
+	}
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal comments failed: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("write comments of %s failed: %w", postID, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("replace comments of %s failed: %w", postID, err)
+	}
+	return nil
+}
+
+// postPath 返回文章的评论文件路径
+func (r *FileCommentRepository) postPath(postID string) string {
+	return filepath.Join(r.dir, postID+".json")
+}
+
+// validPostFileID 文章 ID 用作文件名，不能为空或包含路径分隔符
+func validPostFileID(postID string) bool {
+	return postID != "" && postID != "." && postID != ".." && !strings.ContainsAny(postID, `/\`)
+}
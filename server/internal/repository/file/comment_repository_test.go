@@ -0,0 +1,107 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/domain/valueobject"
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+func newTestComment(id, postID, parentID string, status valueobject.CommentStatus, createdAt time.Time) *domain.Comment {
+	return &domain.Comment{
+		ID:         id,
+		PostID:     postID,
+		ParentID:   parentID,
+		AuthorName: "Alice",
+		EmailHash:  domain.HashEmail("alice@example.com"),
+		Body:       "Hello " + id,
+		Status:     status,
+		CreatedAt:  createdAt,
+		UpdatedAt:  createdAt,
+	}
+}
+
+func TestFileCommentRepository_Persist(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo, err := NewFileCommentRepository(tmpDir)
+	if err != nil {
+		t.Fatalf("NewFileCommentRepository() error = %v", err)
+	}
+
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	comments := []*domain.Comment{
+		newTestComment("c1", "post-a", "", valueobject.CommentApproved, base),
+		newTestComment("c2", "post-a", "c1", valueobject.CommentPending, base.Add(time.Minute)),
+		newTestComment("c3", "post-b", "", valueobject.CommentSpam, base.Add(2*time.Minute)),
+	}
+	for _, comment := range comments {
+		if err := repo.Save(comment); err != nil {
+			t.Fatalf("Save(%s) error = %v", comment.ID, err)
+		}
+	}
+
+	// 重新打开后从评论文件读取
+	reopened, err := NewFileCommentRepository(tmpDir)
+	if err != nil {
+		t.Fatalf("NewFileCommentRepository() error = %v", err)
+	}
+	got, err := reopened.FindByPost("post-a")
+	if err != nil || len(got) != 2 || got[0].ID != "c1" || got[1].ParentID != "c1" {
+		t.Fatalf("FindByPost(post-a) = %v, %v, want c1 then reply c2", got, err)
+	}
+	if got[1].Status != valueobject.CommentPending || !got[1].CreatedAt.Equal(base.Add(time.Minute)) {
+		t.Errorf("reloaded comment = %+v", got[1])
+	}
+
+	counts, _ := reopened.CountByPost(valueobject.CommentApproved)
+	if len(counts) != 1 || counts["post-a"] != 1 {
+		t.Errorf("CountByPost(approved) = %v, want post-a: 1", counts)
+	}
+
+	// 管理端队列：按创建时间倒序、按状态过滤、分页
+	items, total, _ := reopened.List(repository.CommentListOptions{Page: 1, PageSize: 2})
+	if total != 3 || len(items) != 2 || items[0].ID != "c3" {
+		t.Errorf("List() = %v, total %d, want newest first", items, total)
+	}
+	items, total, _ = reopened.List(repository.CommentListOptions{Status: valueobject.CommentPending})
+	if total != 1 || items[0].ID != "c2" {
+		t.Errorf("List(pending) = %v, total %d, want c2", items, total)
+	}
+
+	// 删除最后一条评论后文件也被删除
+	if err := reopened.Delete("c3"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, commentsDirName, "post-b.json")); !os.IsNotExist(err) {
+		t.Errorf("comments file of post-b should be removed, stat error = %v", err)
+	}
+	if err := reopened.Delete("c3"); err != repository.ErrCommentNotFound {
+		t.Errorf("Delete(missing) error = %v, want ErrCommentNotFound", err)
+	}
+
+	if err := reopened.DeleteByPost("post-a"); err != nil {
+		t.Fatalf("DeleteByPost() error = %v", err)
+	}
+	if _, err := reopened.FindByID("c1"); err != repository.ErrCommentNotFound {
+		t.Errorf("FindByID() after DeleteByPost error = %v, want ErrCommentNotFound", err)
+	}
+}
+
+func TestFileCommentRepository_RejectsUnsafePostID(t *testing.T) {
+	repo, err := NewFileCommentRepository(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileCommentRepository() error = %v", err)
+	}
+
+	comment := newTestComment("c1", "../escape", "", valueobject.CommentPending, time.Now())
+	if err := repo.Save(comment); err == nil {
+		t.Error("Save() with path in post id should fail")
+	}
+	if _, err := repo.FindByID("c1"); err != repository.ErrCommentNotFound {
+		t.Errorf("rejected comment should not be stored, FindByID() error = %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package file
+
+import (
+	"container/list"
+	"sync"
+)
+
+// DefaultContentCacheSize 默认缓存的正文篇数
+const DefaultContentCacheSize = 128
+
+// contentCache 文章正文的 LRU 缓存（按篇数限制容量）
+type contentCache struct {
+	capacity int
+	ll       *list.List               // 最近使用的在前
+	items    map[string]*list.Element // id -> 链表节点
+	mu       sync.Mutex
+}
+
+// contentEntry 缓存条目
+type contentEntry struct {
+	id      string
+	content string
+}
+
+// newContentCache 创建正文缓存，capacity <= 0 时使用默认容量
+func newContentCache(capacity int) *contentCache {
+	if capacity <= 0 {
+		capacity = DefaultContentCacheSize
+	}
+	return &contentCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// get 读取正文，命中时标记为最近使用
+func (c *contentCache) get(id string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[id]
+	if !ok {
+		return "", false
+	}
+	c.ll.MoveToFront(elem)
+	return elem.Value.(*contentEntry).content, true
+}
+
+// put 写入正文，超出容量时淘汰最久未使用的条目
+func (c *contentCache) put(id, content string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[id]; ok {
+		elem.Value.(*contentEntry).content = content
+		c.ll.MoveToFront(elem)
+		return
+	}
+
+	c.items[id] = c.ll.PushFront(&contentEntry{id: id, content: content})
+	for c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*contentEntry).id)
+	}
+}
+
+// remove 移除正文
+func (c *contentCache) remove(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[id]; ok {
+		c.ll.Remove(elem)
+		delete(c.items, id)
+	}
+}
+
+// clear 清空缓存
+func (c *contentCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ll.Init()
+	c.items = make(map[string]*list.Element)
+}
+
+// len 返回缓存条目数
+func (c *contentCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.ll.Len()
+}
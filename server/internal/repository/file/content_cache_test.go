@@ -0,0 +1,38 @@
+package file
+
+import "testing"
+
+func TestContentCache(t *testing.T) {
+	cache := newContentCache(2)
+
+	cache.put("a", "A")
+	cache.put("b", "B")
+	cache.get("a") // a 成为最近使用
+	cache.put("c", "C")
+
+	if _, ok := cache.get("b"); ok {
+		t.Error("b should be evicted")
+	}
+	if got, ok := cache.get("a"); !ok || got != "A" {
+		t.Errorf("get(a) = %q, %v", got, ok)
+	}
+
+	cache.put("a", "A2")
+	if got, _ := cache.get("a"); got != "A2" {
+		t.Errorf("get(a) after overwrite = %q, want A2", got)
+	}
+	if cache.len() != 2 {
+		t.Errorf("len() = %d, want 2", cache.len())
+	}
+
+	cache.remove("a")
+	if _, ok := cache.get("a"); ok {
+		t.Error("a should be removed")
+	}
+}
+
+func TestContentCache_DefaultCapacity(t *testing.T) {
+	if cache := newContentCache(0); cache.capacity != DefaultContentCacheSize {
+		t.Errorf("capacity = %d, want %d", cache.capacity, DefaultContentCacheSize)
+	}
+}
@@ -0,0 +1,23 @@
+package file
+
+// ReadLocked 持有读锁执行 fn，期间不会有写入
+func (r *FilePostRepository) ReadLocked(fn func() error) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return fn()
+}
+
+// WriteLocked 持有写锁执行 fn，完成后（无论成功与否）按磁盘内容重建索引与快照
+func (r *FilePostRepository) WriteLocked(fn func() error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fnErr := fn()
+
+	if err := r.loadIndexLocked(false); err != nil {
+		return err
+	}
+	_ = r.writeSnapshotLocked()
+	return fnErr
+}
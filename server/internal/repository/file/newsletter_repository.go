@@ -0,0 +1,295 @@
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+// newsletterFileName 订阅者与通知文件（位于内容目录下）
+const newsletterFileName = "newsletter.json"
+
+// newsletterJSON 是 newsletter.json 的结构
+type newsletterJSON struct {
+	Subscribers []subscriberJSON `json:"subscribers"`
+	Issues      []issueJSON      `json:"issues"`
+}
+
+// subscriberJSON 是 newsletter.json 中单个订阅者的结构
+type subscriberJSON struct {
+	Email            string `json:"email"`
+	Status           string `json:"status"`
+	ConfirmToken     string `json:"confirmToken,omitempty"`
+	UnsubscribeToken string `json:"unsubscribeToken"`
+	CreatedAt        string `json:"createdAt"`
+	ConfirmedAt      string `json:"confirmedAt,omitempty"`
+}
+
+// issueJSON 是 newsletter.json 中单个通知的结构
+type issueJSON struct {
+	PostID      string   `json:"postId"`
+	Title       string   `json:"title"`
+	Pending     []string `json:"pending,omitempty"`
+	Sent        int      `json:"sent"`
+	Failed      int      `json:"failed"`
+	LastError   string   `json:"lastError,omitempty"`
+	Canceled    bool     `json:"canceled,omitempty"`
+	CreatedAt   string   `json:"createdAt"`
+	CompletedAt string   `json:"completedAt,omitempty"`
+}
+
+// FileNewsletterRepository 基于 JSON 文件的订阅仓库，全部记录常驻内存，每次修改整体写回
+type FileNewsletterRepository struct {
+	path        string
+	subscribers map[string]*domain.Subscriber
+	issues      map[string]*domain.NewsletterIssue
+	mu          sync.RWMutex
+}
+
+// NewFileNewsletterRepository 创建订阅仓库并加载 basePath/newsletter.json（不存在时为空）
+func NewFileNewsletterRepository(basePath string) (*FileNewsletterRepository, error) {
+	if err := os.MkdirAll(basePath, 0755); err != nil {
+		return nil, fmt.Errorf("create content directory failed: %w", err)
+	}
+
+	r := &FileNewsletterRepository{
+		path:        filepath.Join(basePath, newsletterFileName),
+		subscribers: make(map[string]*domain.Subscriber),
+		issues:      make(map[string]*domain.NewsletterIssue),
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ListSubscribers 列出全部订阅者
+func (r *FileNewsletterRepository) ListSubscribers() ([]*domain.Subscriber, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return repository.SortedSubscribers(r.subscribers), nil
+}
+
+// FindSubscriber 根据邮箱查找订阅者
+func (r *FileNewsletterRepository) FindSubscriber(email string) (*domain.Subscriber, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	subscriber, ok := r.subscribers[email]
+	if !ok {
+		return nil, repository.ErrSubscriberNotFound
+	}
+	copied := *subscriber
+	return &copied, nil
+}
+
+// FindSubscriberByToken 根据令牌查找订阅者
+func (r *FileNewsletterRepository) FindSubscriberByToken(token string) (*domain.Subscriber, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return repository.SubscriberByToken(r.subscribers, token)
+}
+
+// SaveSubscriber 保存订阅者并写回文件，写入失败时恢复原状态
+func (r *FileNewsletterRepository) SaveSubscriber(subscriber *domain.Subscriber) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, existed := r.subscribers[subscriber.Email]
+	copied := *subscriber
+	r.subscribers[subscriber.Email] = &copied
+	if err := r.write(); err != nil {
+		if existed {
+			r.subscribers[subscriber.Email] = previous
+		} else {
+			delete(r.subscribers, subscriber.Email)
+		}
+		return err
+	}
+	return nil
+}
+
+// DeleteSubscriber 删除订阅者并写回文件
+func (r *FileNewsletterRepository) DeleteSubscriber(email string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, ok := r.subscribers[email]
+	if !ok {
+		return repository.ErrSubscriberNotFound
+	}
+	delete(r.subscribers, email)
+	if err := r.write(); err != nil {
+		r.subscribers[email] = previous
+		return err
+	}
+	return nil
+}
+
+// ListIssues 列出全部通知
+func (r *FileNewsletterRepository) ListIssues() ([]*domain.NewsletterIssue, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return repository.SortedIssues(r.issues), nil
+}
+
+// FindIssue 根据文章 ID 查找通知
+func (r *FileNewsletterRepository) FindIssue(postID string) (*domain.NewsletterIssue, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	issue, ok := r.issues[postID]
+	if !ok {
+		return nil, repository.ErrIssueNotFound
+	}
+	return repository.CopyIssue(issue), nil
+}
+
+// SaveIssue 保存通知并写回文件，写入失败时恢复原状态
+func (r *FileNewsletterRepository) SaveIssue(issue *domain.NewsletterIssue) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, existed := r.issues[issue.PostID]
+	r.issues[issue.PostID] = repository.CopyIssue(issue)
+	if err := r.write(); err != nil {
+		if existed {
+			r.issues[issue.PostID] = previous
+		} else {
+			delete(r.issues, issue.PostID)
+		}
+		return err
+	}
+	return nil
+}
+
+// ReadLocked 持有锁执行 fn，期间不会有写入（用于备份）
+func (r *FileNewsletterRepository) ReadLocked(fn func() error) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return fn()
+}
+
+// WriteLocked 持有写锁执行 fn（fn 可直接替换数据文件），完成后（无论成功与否）在同一临界区内重新读取 newsletter.json（用于恢复备份）
+func (r *FileNewsletterRepository) WriteLocked(fn func() error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fnErr := fn()
+	if err := r.reloadLocked(); err != nil {
+		return err
+	}
+	return fnErr
+}
+
+// reloadLocked 重新读取 newsletter.json（调用方需持有写锁）
+func (r *FileNewsletterRepository) reloadLocked() error {
+	r.subscribers = make(map[string]*domain.Subscriber)
+	r.issues = make(map[string]*domain.NewsletterIssue)
+	return r.load()
+}
+
+// load 读取 newsletter.json（调用方需持有写锁或处于构造阶段）
+func (r *FileNewsletterRepository) load() error {
+	data, err := os.ReadFile(r.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read %s failed: %w", newsletterFileName, err)
+	}
+
+	var stored newsletterJSON
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return fmt.Errorf("parse %s failed: %w", newsletterFileName, err)
+	}
+
+	for _, record := range stored.Subscribers {
+		r.subscribers[record.Email] = &domain.Subscriber{
+			Email:            record.Email,
+			Status:           domain.SubscriberStatus(record.Status),
+			ConfirmToken:     record.ConfirmToken,
+			UnsubscribeToken: record.UnsubscribeToken,
+			CreatedAt:        parseStoredTime(record.CreatedAt),
+			ConfirmedAt:      parseStoredTime(record.ConfirmedAt),
+		}
+	}
+	for _, record := range stored.Issues {
+		r.issues[record.PostID] = &domain.NewsletterIssue{
+			PostID:      record.PostID,
+			Title:       record.Title,
+			Pending:     append([]string{}, record.Pending...),
+			Sent:        record.Sent,
+			Failed:      record.Failed,
+			LastError:   record.LastError,
+			Canceled:    record.Canceled,
+			CreatedAt:   parseStoredTime(record.CreatedAt),
+			CompletedAt: parseStoredTime(record.CompletedAt),
+		}
+	}
+	return nil
+}
+
+// write 将订阅者与通知写回文件（先写临时文件再重命名）
+func (r *FileNewsletterRepository) write() error {
+	stored := newsletterJSON{
+		Subscribers: make([]subscriberJSON, 0, len(r.subscribers)),
+		Issues:      make([]issueJSON, 0, len(r.issues)),
+	}
+	for _, subscriber := range repository.SortedSubscribers(r.subscribers) {
+		stored.Subscribers = append(stored.Subscribers, subscriberJSON{
+			Email:            subscriber.Email,
+			Status:           string(subscriber.Status),
+			ConfirmToken:     subscriber.ConfirmToken,
+			UnsubscribeToken: subscriber.UnsubscribeToken,
+			CreatedAt:        subscriber.CreatedAt.Format(time.RFC3339),
+			ConfirmedAt:      formatStoredTime(subscriber.ConfirmedAt),
+		})
+	}
+	for _, issue := range repository.SortedIssues(r.issues) {
+		stored.Issues = append(stored.Issues, issueJSON{
+			PostID:      issue.PostID,
+			Title:       issue.Title,
+			Pending:     issue.Pending,
+			Sent:        issue.Sent,
+			Failed:      issue.Failed,
+			LastError:   issue.LastError,
+			Canceled:    issue.Canceled,
+			CreatedAt:   issue.CreatedAt.Format(time.RFC3339),
+			CompletedAt: formatStoredTime(issue.CompletedAt),
+		})
+	}
+
+	data, err := json.MarshalIndent(stored, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal newsletter failed: %w", err)
+	}
+
+	tmpPath := r.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return fmt.Errorf("write %s failed: %w", newsletterFileName, err)
+	}
+	if err := os.Rename(tmpPath, r.path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("replace %s failed: %w", newsletterFileName, err)
+	}
+	return nil
+}
+
+// formatStoredTime 格式化为 RFC3339，零值时返回空字符串
+func formatStoredTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
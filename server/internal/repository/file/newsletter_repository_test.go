@@ -0,0 +1,94 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+func TestFileNewsletterRepository_Persist(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo, err := NewFileNewsletterRepository(tmpDir)
+	if err != nil {
+		t.Fatalf("NewFileNewsletterRepository() error = %v", err)
+	}
+
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	pending, _ := domain.NewSubscriber("pending@example.com", "confirm-1", "unsub-1")
+	confirmed, _ := domain.NewSubscriber("confirmed@example.com", "confirm-2", "unsub-2")
+	confirmed.Confirm(base)
+	for _, s := range []*domain.Subscriber{pending, confirmed} {
+		if err := repo.SaveSubscriber(s); err != nil {
+			t.Fatalf("SaveSubscriber() error = %v", err)
+		}
+	}
+	issue := &domain.NewsletterIssue{PostID: "p1", Title: "Hello", Pending: []string{"confirmed@example.com"}, CreatedAt: base}
+	if err := repo.SaveIssue(issue); err != nil {
+		t.Fatalf("SaveIssue() error = %v", err)
+	}
+
+	// 重新打开后从 newsletter.json 读取
+	reopened, err := NewFileNewsletterRepository(tmpDir)
+	if err != nil {
+		t.Fatalf("NewFileNewsletterRepository() error = %v", err)
+	}
+	list, _ := reopened.ListSubscribers()
+	if len(list) != 2 {
+		t.Fatalf("ListSubscribers() = %d, want 2", len(list))
+	}
+	got, err := reopened.FindSubscriberByToken("confirm-1")
+	if err != nil || got.Email != "pending@example.com" || got.IsConfirmed() {
+		t.Errorf("FindSubscriberByToken(confirm) = %+v, %v", got, err)
+	}
+	got, err = reopened.FindSubscriberByToken("unsub-2")
+	if err != nil || !got.IsConfirmed() || !got.ConfirmedAt.Equal(base) || got.ConfirmToken != "" {
+		t.Errorf("FindSubscriberByToken(unsubscribe) = %+v, %v", got, err)
+	}
+	if _, err := reopened.FindSubscriberByToken(""); err != repository.ErrSubscriberNotFound {
+		t.Errorf("FindSubscriberByToken(\"\") error = %v, want ErrSubscriberNotFound", err)
+	}
+
+	loaded, err := reopened.FindIssue("p1")
+	if err != nil || loaded.Title != "Hello" || len(loaded.Pending) != 1 || loaded.Done() {
+		t.Errorf("FindIssue() = %+v, %v", loaded, err)
+	}
+
+	if err := reopened.DeleteSubscriber("pending@example.com"); err != nil {
+		t.Fatalf("DeleteSubscriber() error = %v", err)
+	}
+	if err := reopened.DeleteSubscriber("pending@example.com"); err != repository.ErrSubscriberNotFound {
+		t.Errorf("DeleteSubscriber() twice error = %v, want ErrSubscriberNotFound", err)
+	}
+}
+
+func TestFileNewsletterRepository_WriteLocked(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo, err := NewFileNewsletterRepository(tmpDir)
+	if err != nil {
+		t.Fatalf("NewFileNewsletterRepository() error = %v", err)
+	}
+	subscriber, _ := domain.NewSubscriber("old@example.com", "confirm-1", "unsub-1")
+	if err := repo.SaveSubscriber(subscriber); err != nil {
+		t.Fatalf("SaveSubscriber() error = %v", err)
+	}
+
+	// 模拟恢复备份：在锁定状态下替换文件，返回前重新加载
+	data := `{"subscribers": [{"email": "restored@example.com", "status": "confirmed", "unsubscribeToken": "unsub-2", "createdAt": "2024-06-01T12:00:00Z"}], "issues": []}`
+	err = repo.WriteLocked(func() error {
+		return os.WriteFile(filepath.Join(tmpDir, newsletterFileName), []byte(data), 0644)
+	})
+	if err != nil {
+		t.Fatalf("WriteLocked() error = %v", err)
+	}
+
+	if _, err := repo.FindSubscriber("old@example.com"); err != repository.ErrSubscriberNotFound {
+		t.Errorf("FindSubscriber(old) after reload error = %v, want ErrSubscriberNotFound", err)
+	}
+	if got, err := repo.FindSubscriber("restored@example.com"); err != nil || got.Status != domain.SubscriberConfirmed {
+		t.Errorf("FindSubscriber(restored) = %+v, %v", got, err)
+	}
+}
@@ -13,22 +13,33 @@ import (
 	"github.com/next-ai-ventus/server/internal/repository"
 )
 
-// FilePostRepository 文件系统实现的 PostRepository
+// FilePostRepository 文件系统实现的 PostRepository。
+// 内存中只保存元数据索引，正文按需从 content.md 读取并放入 LRU 缓存；
+// 元数据同时持久化到 index.json 快照，启动时只重新读取有变化的文章目录。
 type FilePostRepository struct {
 	basePath string
-	posts    map[string]*domain.Post
+	posts    map[string]*domain.PostSummary
 	slugMap  map[string]string
 	tagMap   map[string]map[string]struct{}
+	stamps   map[string]postStamp // id -> 文件状态（写入快照用）
+	contents *contentCache
 	mu       sync.RWMutex
 }
 
-// NewFilePostRepository 创建文件存储仓库
+// NewFilePostRepository 创建文件存储仓库（使用默认正文缓存容量）
 func NewFilePostRepository(basePath string) (*FilePostRepository, error) {
+	return NewFilePostRepositoryWithCacheSize(basePath, DefaultContentCacheSize)
+}
+
+// NewFilePostRepositoryWithCacheSize 创建文件存储仓库，cacheSize 为最多缓存的正文篇数
+func NewFilePostRepositoryWithCacheSize(basePath string, cacheSize int) (*FilePostRepository, error) {
 	repo := &FilePostRepository{
 		basePath: basePath,
-		posts:    make(map[string]*domain.Post),
+		posts:    make(map[string]*domain.PostSummary),
 		slugMap:  make(map[string]string),
 		tagMap:   make(map[string]map[string]struct{}),
+		stamps:   make(map[string]postStamp),
+		contents: newContentCache(cacheSize),
 	}
 
 	// 确保目录存在
@@ -47,24 +58,58 @@ func NewFilePostRepository(basePath string) (*FilePostRepository, error) {
 
 // metaJSON 是 meta.json 的结构
 type metaJSON struct {
-	ID          string   `json:"id"`
-	Title       string   `json:"title"`
-	Slug        string   `json:"slug"`
-	Excerpt     string   `json:"excerpt"`
-	Tags        []string `json:"tags"`
-	Status      string   `json:"status"`
-	CreatedAt   string   `json:"createdAt"`
-	UpdatedAt   string   `json:"updatedAt"`
-	PublishedAt *string  `json:"publishedAt,omitempty"`
-	Version     int      `json:"version"`
-	Cover       string   `json:"cover,omitempty"`
-}
-
-// LoadIndex 从文件系统加载索引
+	ID             string   `json:"id"`
+	Title          string   `json:"title"`
+	Slug           string   `json:"slug"`
+	Excerpt        string   `json:"excerpt"`
+	Tags           []string `json:"tags"`
+	Status         string   `json:"status"`
+	CreatedAt      string   `json:"createdAt"`
+	UpdatedAt      string   `json:"updatedAt"`
+	PublishedAt    *string  `json:"publishedAt,omitempty"`
+	Version        int      `json:"version"`
+	Cover          string   `json:"cover,omitempty"`
+	Category       string   `json:"category,omitempty"`
+	CommentsClosed bool     `json:"commentsClosed,omitempty"`
+}
+
+// LoadIndex 从文件系统加载索引。
+// 优先使用 index.json 快照，文件状态未变化的文章直接取快照中的元数据；
+// 快照缺失、损坏或版本不一致时全量读取并重写快照。
 func (r *FilePostRepository) LoadIndex() error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	return r.loadIndexLocked(true)
+}
+
+// RebuildIndex 忽略快照，全量读取所有文章目录并重写快照
+func (r *FilePostRepository) RebuildIndex() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.loadIndexLocked(false); err != nil {
+		return err
+	}
+	return r.writeSnapshotLocked()
+}
+
+// loadIndexLocked 重建内存索引（调用方需持有写锁）
+func (r *FilePostRepository) loadIndexLocked(useSnapshot bool) error {
+	r.posts = make(map[string]*domain.PostSummary)
+	r.slugMap = make(map[string]string)
+	r.tagMap = make(map[string]map[string]struct{})
+	r.stamps = make(map[string]postStamp)
+	r.contents.clear()
+
+	var snapshot map[string]snapshotEntry
+	changed := true
+	if useSnapshot {
+		var ok bool
+		snapshot, ok = readSnapshot(r.snapshotPath())
+		changed = !ok
+	}
+
 	postsDir := filepath.Join(r.basePath, "posts")
 	entries, err := os.ReadDir(postsDir)
 	if err != nil {
@@ -80,52 +125,115 @@ func (r *FilePostRepository) LoadIndex() error {
 		}
 
 		postID := entry.Name()
-		post, err := r.loadPost(postID)
+		stamp, err := statPost(filepath.Join(postsDir, postID))
 		if err != nil {
-			// 跳过损坏的文章，记录错误
+			// 跳过损坏的文章
+			continue
+		}
+
+		var post *domain.PostSummary
+		if cached, ok := snapshot[postID]; ok && cached.Stamp == stamp {
+			post, err = cached.Meta.toSummary()
+		} else {
+			post, err = r.loadSummary(postID)
+			changed = true
+		}
+		if err != nil {
+			// 跳过损坏的文章
 			continue
 		}
 
 		r.posts[postID] = post
+		r.stamps[postID] = stamp
 		r.slugMap[post.Slug.String()] = postID
+		r.addToTagIndex(postID, post.Tags)
+	}
 
-		// 更新标签索引
-		for _, tag := range post.Tags {
-			tagName := tag.String()
-			if _, ok := r.tagMap[tagName]; !ok {
-				r.tagMap[tagName] = make(map[string]struct{})
-			}
-			r.tagMap[tagName][postID] = struct{}{}
+	// 快照中存在但目录已删除（或已损坏）的文章
+	for id := range snapshot {
+		if _, ok := r.posts[id]; !ok {
+			changed = true
 		}
 	}
 
+	if changed && useSnapshot {
+		// 快照只用于加速启动，写入失败时下次启动会重新读取
+		_ = r.writeSnapshotLocked()
+	}
 	return nil
 }
 
-// loadPost 加载单篇文章
-func (r *FilePostRepository) loadPost(id string) (*domain.Post, error) {
-	postDir := filepath.Join(r.basePath, "posts", id)
+// snapshotPath 返回索引快照路径
+func (r *FilePostRepository) snapshotPath() string {
+	return filepath.Join(r.basePath, snapshotFileName)
+}
 
-	// 读取 meta.json
-	metaPath := filepath.Join(postDir, "meta.json")
+// writeSnapshotLocked 将当前内存索引写入快照（调用方需持有锁）
+func (r *FilePostRepository) writeSnapshotLocked() error {
+	entries := make(map[string]snapshotEntry, len(r.posts))
+	for id, post := range r.posts {
+		entries[id] = snapshotEntry{Meta: newMetaJSON(post), Stamp: r.stamps[id]}
+	}
+	return writeSnapshot(r.snapshotPath(), entries)
+}
+
+// loadSummary 加载单篇文章的元数据（只读取 meta.json，正文按需加载）
+func (r *FilePostRepository) loadSummary(id string) (*domain.PostSummary, error) {
+	metaPath := filepath.Join(r.basePath, "posts", id, "meta.json")
 	metaData, err := os.ReadFile(metaPath)
 	if err != nil {
 		return nil, fmt.Errorf("read meta.json failed: %w", err)
 	}
 
-	var meta metaJSON
-	if err := json.Unmarshal(metaData, &meta); err != nil {
-		return nil, fmt.Errorf("parse meta.json failed: %w", err)
+	return DecodeMeta(metaData)
+}
+
+// loadContent 读取正文（优先使用缓存）
+func (r *FilePostRepository) loadContent(id string) (string, error) {
+	if content, ok := r.contents.get(id); ok {
+		return content, nil
 	}
 
-	// 读取 content.md
-	contentPath := filepath.Join(postDir, "content.md")
-	content, err := os.ReadFile(contentPath)
+	contentPath := filepath.Join(r.basePath, "posts", id, "content.md")
+	data, err := os.ReadFile(contentPath)
+	if err != nil {
+		return "", fmt.Errorf("read content.md failed: %w", err)
+	}
+
+	content := string(data)
+	r.contents.put(id, content)
+	return content, nil
+}
+
+// withContent 为摘要加载正文，还原完整文章
+func (r *FilePostRepository) withContent(summary *domain.PostSummary) (*domain.Post, error) {
+	content, err := r.loadContent(summary.ID)
+	if err != nil {
+		return nil, err
+	}
+	return summary.WithContent(content), nil
+}
+
+// DecodePost 从 meta.json 与 content.md 的原始内容重建文章
+func DecodePost(metaData, content []byte) (*domain.Post, error) {
+	summary, err := DecodeMeta(metaData)
 	if err != nil {
-		return nil, fmt.Errorf("read content.md failed: %w", err)
+		return nil, err
 	}
+	return summary.WithContent(string(content)), nil
+}
 
-	// 重建 Post
+// DecodeMeta 从 meta.json 的原始内容重建文章元数据
+func DecodeMeta(metaData []byte) (*domain.PostSummary, error) {
+	var meta metaJSON
+	if err := json.Unmarshal(metaData, &meta); err != nil {
+		return nil, fmt.Errorf("parse meta.json failed: %w", err)
+	}
+	return meta.toSummary()
+}
+
+// toSummary 将 meta.json 结构转换为文章元数据
+func (meta metaJSON) toSummary() (*domain.PostSummary, error) {
 	slug, err := valueobject.NewSlug(meta.Slug)
 	if err != nil {
 		return nil, fmt.Errorf("invalid slug: %w", err)
@@ -154,58 +262,51 @@ func (r *FilePostRepository) loadPost(id string) (*domain.Post, error) {
 		publishedAt = &pt
 	}
 
-	post := &domain.Post{
-		ID:          meta.ID,
-		Title:       meta.Title,
-		Slug:        slug,
-		Content:     string(content),
-		Excerpt:     meta.Excerpt,
-		Tags:        tags,
-		Status:      status,
-		CreatedAt:   createdAt,
-		UpdatedAt:   updatedAt,
-		PublishedAt: publishedAt,
-		Version:     meta.Version,
-		Cover:       meta.Cover,
+	summary := &domain.PostSummary{
+		ID:             meta.ID,
+		Title:          meta.Title,
+		Slug:           slug,
+		Excerpt:        meta.Excerpt,
+		Tags:           tags,
+		Status:         status,
+		CreatedAt:      createdAt,
+		UpdatedAt:      updatedAt,
+		PublishedAt:    publishedAt,
+		Version:        meta.Version,
+		Cover:          meta.Cover,
+		Category:       meta.Category,
+		CommentsClosed: meta.CommentsClosed,
 	}
 
-	return post, nil
+	return summary, nil
 }
 
-// savePost 保存单篇文章到文件
-func (r *FilePostRepository) savePost(post *domain.Post) error {
-	postDir := filepath.Join(r.basePath, "posts", post.ID)
-
-	// 创建目录
-	if err := os.MkdirAll(postDir, 0755); err != nil {
-		return fmt.Errorf("create post directory failed: %w", err)
-	}
-
-	// 准备 meta.json
-	tagNames := make([]string, len(post.Tags))
-	for i, tag := range post.Tags {
-		tagNames[i] = tag.String()
-	}
-
+// newMetaJSON 由文章元数据生成 meta.json 结构
+func newMetaJSON(post *domain.PostSummary) metaJSON {
 	meta := metaJSON{
-		ID:        post.ID,
-		Title:     post.Title,
-		Slug:      post.Slug.String(),
-		Excerpt:   post.Excerpt,
-		Tags:      tagNames,
-		Status:    post.Status.String(),
-		CreatedAt: post.CreatedAt.Format(time.RFC3339),
-		UpdatedAt: post.UpdatedAt.Format(time.RFC3339),
-		Version:   post.Version,
-		Cover:     post.Cover,
+		ID:             post.ID,
+		Title:          post.Title,
+		Slug:           post.Slug.String(),
+		Excerpt:        post.Excerpt,
+		Tags:           post.GetTagNames(),
+		Status:         post.Status.String(),
+		CreatedAt:      post.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      post.UpdatedAt.Format(time.RFC3339),
+		Version:        post.Version,
+		Cover:          post.Cover,
+		Category:       post.Category,
+		CommentsClosed: post.CommentsClosed,
 	}
 
 	if post.PublishedAt != nil {
 		publishedAtStr := post.PublishedAt.Format(time.RFC3339)
 		meta.PublishedAt = &publishedAtStr
 	}
+	return meta
+}
 
-	// 写入 meta.json
+// writeMetaFile 写入 meta.json
+func writeMetaFile(postDir string, meta metaJSON) error {
 	metaData, err := json.MarshalIndent(meta, "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshal meta.json failed: %w", err)
@@ -215,6 +316,25 @@ func (r *FilePostRepository) savePost(post *domain.Post) error {
 	if err := os.WriteFile(metaPath, metaData, 0644); err != nil {
 		return fmt.Errorf("write meta.json failed: %w", err)
 	}
+	return nil
+}
+
+// savePost 保存单篇文章到文件
+func (r *FilePostRepository) savePost(post *domain.Post) error {
+	return writePost(filepath.Join(r.basePath, "posts", post.ID), post)
+}
+
+// writePost 将文章写入指定目录
+func writePost(postDir string, post *domain.Post) error {
+	// 创建目录
+	if err := os.MkdirAll(postDir, 0755); err != nil {
+		return fmt.Errorf("create post directory failed: %w", err)
+	}
+
+	// 写入 meta.json
+	if err := writeMetaFile(postDir, newMetaJSON(post.Summary())); err != nil {
+		return err
+	}
 
 	// 写入 content.md
 	contentPath := filepath.Join(postDir, "content.md")
@@ -234,7 +354,7 @@ func (r *FilePostRepository) FindByID(id string) (*domain.Post, error) {
 	if !ok {
 		return nil, repository.ErrPostNotFound
 	}
-	return copyPost(post), nil
+	return r.withContent(post)
 }
 
 // FindBySlug 根据 Slug 查找文章
@@ -252,7 +372,7 @@ func (r *FilePostRepository) FindBySlug(slug string) (*domain.Post, error) {
 		return nil, repository.ErrPostNotFound
 	}
 
-	return copyPost(post), nil
+	return r.withContent(post)
 }
 
 // FindAll 查询文章列表
@@ -260,86 +380,36 @@ func (r *FilePostRepository) FindAll(opts repository.ListOptions) (*repository.P
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	// 设置默认值
-	if opts.Page <= 0 {
-		opts.Page = 1
-	}
-	if opts.PageSize <= 0 {
-		opts.PageSize = 10
-	}
-	if opts.OrderBy == "" {
-		opts.OrderBy = "date_desc"
-	}
-
 	// 筛选文章
-	var filtered []*domain.Post
+	var filtered []*domain.PostSummary
 	for _, post := range r.posts {
-		// 状态筛选
-		if opts.Status != "" && post.Status.String() != opts.Status {
-			continue
-		}
-		// 标签筛选
-		if opts.Tag != "" && !post.HasTag(opts.Tag) {
-			continue
+		if repository.MatchesListOptions(post, opts) {
+			filtered = append(filtered, post.Clone())
 		}
-		filtered = append(filtered, copyPost(post))
 	}
 
-	// 排序
-	if opts.OrderBy == "date_desc" {
-		sortPostsByDate(filtered, true)
-	} else if opts.OrderBy == "date_asc" {
-		sortPostsByDate(filtered, false)
-	}
-
-	// 分页
-	total := len(filtered)
-	totalPages := (total + opts.PageSize - 1) / opts.PageSize
-	if totalPages < 1 {
-		totalPages = 1
-	}
-
-	start := (opts.Page - 1) * opts.PageSize
-	end := start + opts.PageSize
-	if start > total {
-		start = total
-	}
-	if end > total {
-		end = total
-	}
-
-	var items []*domain.Post
-	if start < total {
-		items = filtered[start:end]
-	}
-
-	return &repository.PaginatedResult{
-		Items:      items,
-		Total:      total,
-		Page:       opts.Page,
-		PageSize:   opts.PageSize,
-		TotalPages: totalPages,
-	}, nil
+	// 排序与分页
+	return repository.Paginate(filtered, opts)
 }
 
 // FindByTag 根据标签查找文章
-func (r *FilePostRepository) FindByTag(tag string) ([]*domain.Post, error) {
+func (r *FilePostRepository) FindByTag(tag string) ([]*domain.PostSummary, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	ids, ok := r.tagMap[tag]
 	if !ok || len(ids) == 0 {
-		return []*domain.Post{}, nil
+		return []*domain.PostSummary{}, nil
 	}
 
-	var posts []*domain.Post
+	var posts []*domain.PostSummary
 	for id := range ids {
 		if post, ok := r.posts[id]; ok {
-			posts = append(posts, copyPost(post))
+			posts = append(posts, post.Clone())
 		}
 	}
 
-	sortPostsByDate(posts, true)
+	repository.SortPosts(posts, "date_desc")
 	return posts, nil
 }
 
@@ -374,23 +444,41 @@ func (r *FilePostRepository) Save(post *domain.Post) error {
 		return repository.ErrSlugExists
 	}
 
-	// 如果是更新，删除旧索引
-	if oldPost, ok := r.posts[post.ID]; ok {
-		delete(r.slugMap, oldPost.Slug.String())
-		r.removeFromTagIndex(post.ID, oldPost.Tags)
-	}
-
-	// 保存到文件
+	// 保存到文件（先写文件，失败时内存索引保持不变）
 	if err := r.savePost(post); err != nil {
 		return err
 	}
 
-	// 更新内存索引
-	r.posts[post.ID] = copyPost(post)
+	// 更新快照（失败时下次启动会按文件状态重新读取）
+	if r.indexPostLocked(post) {
+		_ = r.writeSnapshotLocked()
+	}
+
+	return nil
+}
+
+// indexPostLocked 用已写入文件的文章更新内存索引，返回文件状态是否已记录（调用方需持有写锁）
+func (r *FilePostRepository) indexPostLocked(post *domain.Post) bool {
+	// 如果是更新，删除旧索引（旧 slug 可能已被同一批次中的其他文章占用）
+	if oldPost, ok := r.posts[post.ID]; ok {
+		if r.slugMap[oldPost.Slug.String()] == post.ID {
+			delete(r.slugMap, oldPost.Slug.String())
+		}
+		r.removeFromTagIndex(post.ID, oldPost.Tags)
+	}
+
+	// 更新内存索引，正文放入缓存
+	r.posts[post.ID] = post.Summary()
+	r.contents.put(post.ID, post.Content)
 	r.slugMap[post.Slug.String()] = post.ID
 	r.addToTagIndex(post.ID, post.Tags)
 
-	return nil
+	stamp, err := statPost(filepath.Join(r.basePath, "posts", post.ID))
+	if err != nil {
+		return false
+	}
+	r.stamps[post.ID] = stamp
+	return true
 }
 
 // Delete 删除文章
@@ -398,7 +486,7 @@ func (r *FilePostRepository) Delete(id string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	post, ok := r.posts[id]
+	_, ok := r.posts[id]
 	if !ok {
 		return repository.ErrPostNotFound
 	}
@@ -409,12 +497,20 @@ func (r *FilePostRepository) Delete(id string) error {
 		return fmt.Errorf("remove post directory failed: %w", err)
 	}
 
-	// 删除索引
+	r.unindexPostLocked(id)
+	_ = r.writeSnapshotLocked()
+
+	return nil
+}
+
+// unindexPostLocked 删除文章的内存索引（调用方需持有写锁）
+func (r *FilePostRepository) unindexPostLocked(id string) {
+	post := r.posts[id]
 	delete(r.slugMap, post.Slug.String())
 	r.removeFromTagIndex(id, post.Tags)
 	delete(r.posts, id)
-
-	return nil
+	delete(r.stamps, id)
+	r.contents.remove(id)
 }
 
 // Exists 检查 Slug 是否已存在
@@ -468,44 +564,6 @@ func (r *FilePostRepository) removeFromTagIndex(id string, tags []valueobject.Ta
 	}
 }
 
-// copyPost 创建文章的深拷贝
-func copyPost(post *domain.Post) *domain.Post {
-	tags := make([]valueobject.Tag, len(post.Tags))
-	copy(tags, post.Tags)
-
-	return &domain.Post{
-		ID:          post.ID,
-		Title:       post.Title,
-		Slug:        post.Slug,
-		Content:     post.Content,
-		Excerpt:     post.Excerpt,
-		Tags:        tags,
-		Status:      post.Status,
-		CreatedAt:   post.CreatedAt,
-		UpdatedAt:   post.UpdatedAt,
-		PublishedAt: post.PublishedAt,
-		Version:     post.Version,
-		Cover:       post.Cover,
-	}
-}
-
-// sortPostsByDate 按日期排序文章
-func sortPostsByDate(posts []*domain.Post, desc bool) {
-	for i := 0; i < len(posts)-1; i++ {
-		for j := i + 1; j < len(posts); j++ {
-			shouldSwap := false
-			if desc {
-				shouldSwap = posts[i].CreatedAt.Before(posts[j].CreatedAt)
-			} else {
-				shouldSwap = posts[i].CreatedAt.After(posts[j].CreatedAt)
-			}
-			if shouldSwap {
-				posts[i], posts[j] = posts[j], posts[i]
-			}
-		}
-	}
-}
-
 // sortStrings 排序字符串切片
 func sortStrings(strs []string) {
 	for i := 0; i < len(strs)-1; i++ {
@@ -8,6 +8,7 @@ import (
 	"github.com/next-ai-ventus/server/internal/domain"
 	"github.com/next-ai-ventus/server/internal/domain/valueobject"
 	"github.com/next-ai-ventus/server/internal/repository"
+	"github.com/next-ai-ventus/server/internal/repository/repositorytest"
 )
 
 func setupTestRepo(t *testing.T) (*FilePostRepository, string) {
@@ -227,3 +228,62 @@ func TestFilePostRepository_Count(t *testing.T) {
 		}
 	})
 }
+
+func TestFilePostRepository_Contract(t *testing.T) {
+	repositorytest.RunPostRepositoryContract(t, func(t *testing.T) repository.PostRepository {
+		repo, _ := setupTestRepo(t)
+		return repo
+	})
+}
+
+func TestFilePostRepository_LazyContent(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo, err := NewFilePostRepositoryWithCacheSize(tmpDir, 2)
+	if err != nil {
+		t.Fatalf("NewFilePostRepositoryWithCacheSize() error = %v", err)
+	}
+
+	ids := []string{"p1", "p2", "p3", "p4"}
+	for _, id := range ids {
+		slug, _ := valueobject.NewSlug("slug-" + id)
+		post, _ := domain.NewPost(id, "Title "+id, slug, "Body of "+id, nil)
+		if err := repo.Save(post); err != nil {
+			t.Fatalf("Save(%s) error = %v", id, err)
+		}
+	}
+
+	// 缓存容量有限，被淘汰的正文从磁盘重新加载
+	if n := repo.contents.len(); n != 2 {
+		t.Errorf("cached contents = %d, want 2", n)
+	}
+	for _, id := range ids {
+		found, err := repo.FindByID(id)
+		if err != nil || found.Content != "Body of "+id {
+			t.Errorf("FindByID(%s) = %v, %v", id, found, err)
+		}
+	}
+
+	t.Run("reload reads metadata only", func(t *testing.T) {
+		reloaded, err := NewFilePostRepository(tmpDir)
+		if err != nil {
+			t.Fatalf("NewFilePostRepository() error = %v", err)
+		}
+		if n := reloaded.contents.len(); n != 0 {
+			t.Errorf("cached contents after load = %d, want 0", n)
+		}
+
+		found, err := reloaded.FindBySlug("slug-p3")
+		if err != nil || found.Content != "Body of p3" {
+			t.Errorf("FindBySlug() = %v, %v", found, err)
+		}
+	})
+
+	t.Run("missing content file", func(t *testing.T) {
+		os.Remove(filepath.Join(tmpDir, "posts", "p1", "content.md"))
+		repo.contents.remove("p1")
+
+		if _, err := repo.FindByID("p1"); err == nil {
+			t.Error("FindByID() should fail when content.md is missing")
+		}
+	})
+}
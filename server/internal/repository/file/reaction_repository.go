@@ -0,0 +1,127 @@
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+// reactionsDirName 表情回应目录（位于内容目录下，每篇文章一个 <postID>.json）
+const reactionsDirName = "reactions"
+
+// FileReactionRepository 基于 JSON 文件的表情回应仓库，每篇文章的回应单独保存
+type FileReactionRepository struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileReactionRepository 创建表情回应仓库（目录为 basePath/reactions）
+func NewFileReactionRepository(basePath string) (*FileReactionRepository, error) {
+	dir := filepath.Join(basePath, reactionsDirName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create reactions directory failed: %w", err)
+	}
+	return &FileReactionRepository{dir: dir}, nil
+}
+
+// LoadReactions 读取全部文章的表情回应
+func (r *FileReactionRepository) LoadReactions() (map[string]*repository.PostReactions, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entries, err := os.ReadDir(r.dir)
+	if err != nil {
+		return nil, fmt.Errorf("read reactions directory failed: %w", err)
+	}
+
+	result := make(map[string]*repository.PostReactions)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		postID := strings.TrimSuffix(name, ".json")
+
+		data, err := os.ReadFile(filepath.Join(r.dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("read reactions of %s failed: %w", postID, err)
+		}
+		reactions := repository.NewPostReactions()
+		if err := json.Unmarshal(data, reactions); err != nil {
+			return nil, fmt.Errorf("parse reactions of %s failed: %w", postID, err)
+		}
+		result[postID] = reactions.Clone()
+	}
+	return result, nil
+}
+
+// SaveReactions 覆盖写入文章的表情回应（先写临时文件再重命名）
+func (r *FileReactionRepository) SaveReactions(postID string, reactions *repository.PostReactions) error {
+	if !validPostFileID(postID) {
+		return fmt.Errorf("invalid post id %q", postID)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(reactions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal reactions failed: %w", err)
+	}
+
+	path := r.postPath(postID)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("write reactions of %s failed: %w", postID, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("replace reactions of %s failed: %w", postID, err)
+	}
+	return nil
+}
+
+// DeleteReactions 删除文章的表情回应文件
+func (r *FileReactionRepository) DeleteReactions(postID string) error {
+	if !validPostFileID(postID) {
+		return fmt.Errorf("invalid post id %q", postID)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := os.Remove(r.postPath(postID)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("delete reactions of %s failed: %w", postID, err)
+	}
+	return nil
+}
+
+// ReadLocked 持有锁执行 fn，期间不会有写入（用于备份）
+func (r *FileReactionRepository) ReadLocked(fn func() error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return fn()
+}
+
+// WriteLocked 持有锁执行 fn（fn 可直接替换表情回应目录，用于恢复备份）。回应不常驻内存，无需重新加载
+func (r *FileReactionRepository) WriteLocked(fn func() error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fnErr := fn()
+	if err := os.MkdirAll(r.dir, 0755); err != nil {
+		return fmt.Errorf("create reactions directory failed: %w", err)
+	}
+	return fnErr
+}
+
+// postPath 返回文章的表情回应文件路径
+func (r *FileReactionRepository) postPath(postID string) string {
+	return filepath.Join(r.dir, postID+".json")
+}
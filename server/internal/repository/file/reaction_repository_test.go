@@ -0,0 +1,51 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+func TestFileReactionRepository_Persist(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo, err := NewFileReactionRepository(tmpDir)
+	if err != nil {
+		t.Fatalf("NewFileReactionRepository() error = %v", err)
+	}
+
+	reactions := repository.NewPostReactions()
+	reactions.Counts["like"] = 2
+	reactions.Visitors["v1"] = []string{"like"}
+	reactions.Visitors["v2"] = []string{"like"}
+	if err := repo.SaveReactions("p1", reactions); err != nil {
+		t.Fatalf("SaveReactions() error = %v", err)
+	}
+	if err := repo.SaveReactions("p2", repository.NewPostReactions()); err != nil {
+		t.Fatalf("SaveReactions() error = %v", err)
+	}
+	if err := repo.SaveReactions("../escape", reactions); err == nil {
+		t.Error("SaveReactions() with path in post id should fail")
+	}
+
+	// 重新打开后从 reactions 目录读取
+	reopened, err := NewFileReactionRepository(tmpDir)
+	if err != nil {
+		t.Fatalf("NewFileReactionRepository() error = %v", err)
+	}
+	loaded, err := reopened.LoadReactions()
+	if err != nil {
+		t.Fatalf("LoadReactions() error = %v", err)
+	}
+	if len(loaded) != 2 || loaded["p1"].Counts["like"] != 2 || len(loaded["p1"].Visitors["v2"]) != 1 {
+		t.Errorf("LoadReactions() = %+v", loaded)
+	}
+
+	if err := reopened.DeleteReactions("p1"); err != nil {
+		t.Fatalf("DeleteReactions() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, reactionsDirName, "p1.json")); !os.IsNotExist(err) {
+		t.Errorf("reactions file of p1 should be removed, stat error = %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+// redirectsFileName 重定向表文件（位于内容目录下）
+const redirectsFileName = "redirects.json"
+
+// redirectJSON 是 redirects.json 中单条记录的结构
+type redirectJSON struct {
+	From      string `json:"from"`
+	PostID    string `json:"postId"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// FileRedirectRepository 基于 JSON 文件的重定向仓库，全部记录常驻内存
+type FileRedirectRepository struct {
+	path      string
+	redirects map[string]*domain.Redirect
+	mu        sync.RWMutex
+}
+
+// NewFileRedirectRepository 创建重定向仓库并加载 basePath/redirects.json（不存在时为空）
+func NewFileRedirectRepository(basePath string) (*FileRedirectRepository, error) {
+	if err := os.MkdirAll(basePath, 0755); err != nil {
+		return nil, fmt.Errorf("create content directory failed: %w", err)
+	}
+
+	r := &FileRedirectRepository{
+		path:      filepath.Join(basePath, redirectsFileName),
+		redirects: make(map[string]*domain.Redirect),
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Find 根据旧路径查找重定向
+func (r *FileRedirectRepository) Find(from string) (*domain.Redirect, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	redirect, ok := r.redirects[from]
+	if !ok {
+		return nil, repository.ErrRedirectNotFound
+	}
+	copied := *redirect
+	return &copied, nil
+}
+
+// FindByPost 查找指向某篇文章的所有重定向
+func (r *FileRedirectRepository) FindByPost(postID string) ([]*domain.Redirect, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return repository.RedirectsToPost(r.redirects, postID), nil
+}
+
+// Save 保存重定向并写回文件
+func (r *FileRedirectRepository) Save(redirect *domain.Redirect) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, existed := r.redirects[redirect.From]
+	copied := *redirect
+	r.redirects[redirect.From] = &copied
+
+	if err := r.write(); err != nil {
+		if existed {
+			r.redirects[redirect.From] = previous
+		} else {
+			delete(r.redirects, redirect.From)
+		}
+		return err
+	}
+	return nil
+}
+
+// Delete 删除重定向并写回文件
+func (r *FileRedirectRepository) Delete(from string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, ok := r.redirects[from]
+	if !ok {
+		return repository.ErrRedirectNotFound
+	}
+	delete(r.redirects, from)
+
+	if err := r.write(); err != nil {
+		r.redirects[from] = previous
+		return err
+	}
+	return nil
+}
+
+// ReadLocked 持有锁执行 fn，期间不会有写入（用于备份）
+func (r *FileRedirectRepository) ReadLocked(fn func() error) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return fn()
+}
+
+// WriteLocked 持有写锁执行 fn（fn 可直接替换数据文件），完成后（无论成功与否）在同一临界区内重新读取重定向文件（用于恢复备份）
+func (r *FileRedirectRepository) WriteLocked(fn func() error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fnErr := fn()
+	if err := r.reloadLocked(); err != nil {
+		return err
+	}
+	return fnErr
+}
+
+// reloadLocked 重新读取重定向文件（调用方需持有写锁）
+func (r *FileRedirectRepository) reloadLocked() error {
+	r.redirects = make(map[string]*domain.Redirect)
+	return r.load()
+}
+
+// load 读取重定向文件（调用方需持有写锁或处于构造阶段）
+func (r *FileRedirectRepository) load() error {
+	data, err := os.ReadFile(r.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read %s failed: %w", redirectsFileName, err)
+	}
+
+	var records []redirectJSON
+	if err := json.Unmarshal(data, &records); err != nil {
+		return fmt.Errorf("parse %s failed: %w", redirectsFileName, err)
+	}
+	for _, record := range records {
+		createdAt, _ := time.Parse(time.RFC3339, record.CreatedAt)
+		r.redirects[record.From] = &domain.Redirect{
+			From:      record.From,
+			PostID:    record.PostID,
+			CreatedAt: createdAt,
+		}
+	}
+	return nil
+}
+
+// write 将重定向表写回文件（先写临时文件再重命名）
+func (r *FileRedirectRepository) write() error {
+	records := make([]redirectJSON, 0, len(r.redirects))
+	for _, redirect := range r.redirects {
+		records = append(records, redirectJSON{
+			From:      redirect.From,
+			PostID:    redirect.PostID,
+			CreatedAt: redirect.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].From < records[j].From })
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal redirects failed: %w", err)
+	}
+
+	tmpPath := r.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("write %s failed: %w", redirectsFileName, err)
+	}
+	if err := os.Rename(tmpPath, r.path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("replace %s failed: %w", redirectsFileName, err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+func TestFileRedirectRepository_Persist(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo, err := NewFileRedirectRepository(tmpDir)
+	if err != nil {
+		t.Fatalf("NewFileRedirectRepository() error = %v", err)
+	}
+
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, from := range []string{"/2019/05/hello-world", "/?p=12"} {
+		if err := repo.Save(&domain.Redirect{From: from, PostID: "2019-05-hello-world", CreatedAt: createdAt}); err != nil {
+			t.Fatalf("Save(%s) error = %v", from, err)
+		}
+	}
+	if err := repo.Save(&domain.Redirect{From: "/about", PostID: "2018-12-about", CreatedAt: createdAt}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := repo.Delete("/about"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := repo.Delete("/about"); err != repository.ErrRedirectNotFound {
+		t.Errorf("Delete(missing) error = %v, want ErrRedirectNotFound", err)
+	}
+
+	// 重新打开后从 redirects.json 读取
+	reopened, err := NewFileRedirectRepository(tmpDir)
+	if err != nil {
+		t.Fatalf("NewFileRedirectRepository() error = %v", err)
+	}
+	redirect, err := reopened.Find("/?p=12")
+	if err != nil || redirect.PostID != "2019-05-hello-world" || !redirect.CreatedAt.Equal(createdAt) {
+		t.Errorf("Find() = %+v, %v", redirect, err)
+	}
+	if _, err := reopened.Find("/about"); err != repository.ErrRedirectNotFound {
+		t.Errorf("Find(deleted) error = %v, want ErrRedirectNotFound", err)
+	}
+
+	redirects, _ := reopened.FindByPost("2019-05-hello-world")
+	if len(redirects) != 2 || redirects[0].From != "/2019/05/hello-world" || redirects[1].From != "/?p=12" {
+		t.Errorf("FindByPost() = %+v", redirects)
+	}
+}
+
+func TestFileRedirectRepository_WriteLocked(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo, err := NewFileRedirectRepository(tmpDir)
+	if err != nil {
+		t.Fatalf("NewFileRedirectRepository() error = %v", err)
+	}
+	if err := repo.Save(&domain.Redirect{From: "/old", PostID: "p1"}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	// 模拟恢复备份：在锁定状态下替换文件，返回前重新加载
+	data := `[{"from": "/restored", "postId": "p2", "createdAt": "2024-01-02T03:04:05Z"}]`
+	err = repo.WriteLocked(func() error {
+		return os.WriteFile(filepath.Join(tmpDir, redirectsFileName), []byte(data), 0644)
+	})
+	if err != nil {
+		t.Fatalf("WriteLocked() error = %v", err)
+	}
+
+	if _, err := repo.Find("/old"); err != repository.ErrRedirectNotFound {
+		t.Errorf("Find(/old) after reload error = %v, want ErrRedirectNotFound", err)
+	}
+	if redirect, err := repo.Find("/restored"); err != nil || redirect.PostID != "p2" {
+		t.Errorf("Find(/restored) = %+v, %v", redirect, err)
+	}
+}
@@ -0,0 +1,91 @@
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// 索引快照文件名与结构版本（结构变化时递增，旧快照会被自动重建）
+const (
+	snapshotFileName = "index.json"
+	snapshotVersion  = 1
+)
+
+// snapshotJSON 是 index.json 的结构
+type snapshotJSON struct {
+	Version int                      `json:"version"`
+	Posts   map[string]snapshotEntry `json:"posts"`
+}
+
+// snapshotEntry 单篇文章的快照：元数据 + 文件状态
+type snapshotEntry struct {
+	Meta  metaJSON  `json:"meta"`
+	Stamp postStamp `json:"stamp"`
+}
+
+// postStamp 文章目录中两个文件的状态，用于判断文件是否变化
+type postStamp struct {
+	Meta    fileStamp `json:"meta"`
+	Content fileStamp `json:"content"`
+}
+
+// fileStamp 文件修改时间（纳秒）与大小
+type fileStamp struct {
+	ModTime int64 `json:"modTime"`
+	Size    int64 `json:"size"`
+}
+
+// statPost 读取文章目录中 meta.json 与 content.md 的文件状态
+func statPost(postDir string) (postStamp, error) {
+	var stamp postStamp
+
+	metaInfo, err := os.Stat(filepath.Join(postDir, "meta.json"))
+	if err != nil {
+		return stamp, fmt.Errorf("stat meta.json failed: %w", err)
+	}
+	contentInfo, err := os.Stat(filepath.Join(postDir, "content.md"))
+	if err != nil {
+		return stamp, fmt.Errorf("stat content.md failed: %w", err)
+	}
+
+	stamp.Meta = fileStamp{ModTime: metaInfo.ModTime().UnixNano(), Size: metaInfo.Size()}
+	stamp.Content = fileStamp{ModTime: contentInfo.ModTime().UnixNano(), Size: contentInfo.Size()}
+	return stamp, nil
+}
+
+// readSnapshot 读取索引快照。文件不存在、损坏或版本不一致时返回 false
+func readSnapshot(path string) (map[string]snapshotEntry, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var snapshot snapshotJSON
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, false
+	}
+	if snapshot.Version != snapshotVersion || snapshot.Posts == nil {
+		return nil, false
+	}
+	return snapshot.Posts, true
+}
+
+// writeSnapshot 写入索引快照（先写临时文件再重命名，避免留下半个文件）
+func writeSnapshot(path string, posts map[string]snapshotEntry) error {
+	data, err := json.Marshal(snapshotJSON{Version: snapshotVersion, Posts: posts})
+	if err != nil {
+		return fmt.Errorf("marshal index snapshot failed: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("write index snapshot failed: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("replace index snapshot failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package file
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/domain/valueobject"
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+// setupSnapshotRepo 创建包含两篇文章的仓库，返回内容目录
+func setupSnapshotRepo(t *testing.T) string {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	repo, err := NewFilePostRepository(tmpDir)
+	if err != nil {
+		t.Fatalf("NewFilePostRepository() error = %v", err)
+	}
+	for _, id := range []string{"p1", "p2"} {
+		slug, _ := valueobject.NewSlug("slug-" + id)
+		post, _ := domain.NewPost(id, "Title "+id, slug, "Body of "+id, nil)
+		if err := repo.Save(post); err != nil {
+			t.Fatalf("Save(%s) error = %v", id, err)
+		}
+	}
+	return tmpDir
+}
+
+// editSnapshot 修改快照中某篇文章的标题（文件状态不变），用于判断加载时是否读取了快照
+func editSnapshot(t *testing.T, dir, id, title string) {
+	t.Helper()
+
+	path := filepath.Join(dir, snapshotFileName)
+	posts, ok := readSnapshot(path)
+	if !ok {
+		t.Fatal("snapshot should be readable")
+	}
+	entry := posts[id]
+	entry.Meta.Title = title
+	posts[id] = entry
+	if err := writeSnapshot(path, posts); err != nil {
+		t.Fatalf("writeSnapshot() error = %v", err)
+	}
+}
+
+func mustLoad(t *testing.T, dir string) *FilePostRepository {
+	t.Helper()
+
+	repo, err := NewFilePostRepository(dir)
+	if err != nil {
+		t.Fatalf("NewFilePostRepository() error = %v", err)
+	}
+	return repo
+}
+
+func TestSnapshot_WrittenOnSave(t *testing.T) {
+	dir := setupSnapshotRepo(t)
+
+	posts, ok := readSnapshot(filepath.Join(dir, snapshotFileName))
+	if !ok {
+		t.Fatal("snapshot should exist after save")
+	}
+	if len(posts) != 2 || posts["p1"].Meta.Title != "Title p1" || posts["p1"].Stamp.Content.Size == 0 {
+		t.Errorf("snapshot = %+v", posts)
+	}
+}
+
+func TestSnapshot_UnchangedPostsComeFromSnapshot(t *testing.T) {
+	dir := setupSnapshotRepo(t)
+	editSnapshot(t, dir, "p1", "From snapshot")
+
+	repo := mustLoad(t, dir)
+	if post, _ := repo.FindByID("p1"); post.Title != "From snapshot" {
+		t.Errorf("Title = %q, want value from snapshot", post.Title)
+	}
+}
+
+func TestSnapshot_ChangedPostsAreReread(t *testing.T) {
+	dir := setupSnapshotRepo(t)
+	editSnapshot(t, dir, "p1", "Stale")
+
+	// 修改 meta.json 的修改时间，模拟文件在快照之后被编辑
+	metaPath := filepath.Join(dir, "posts", "p1", "meta.json")
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(metaPath, later, later); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+
+	repo := mustLoad(t, dir)
+	if post, _ := repo.FindByID("p1"); post.Title != "Title p1" {
+		t.Errorf("Title = %q, want value from meta.json", post.Title)
+	}
+
+	// 重新加载后快照已更新
+	posts, _ := readSnapshot(filepath.Join(dir, snapshotFileName))
+	if posts["p1"].Meta.Title != "Title p1" {
+		t.Errorf("snapshot title = %q, want refreshed", posts["p1"].Meta.Title)
+	}
+}
+
+func TestSnapshot_AddedAndRemovedDirectories(t *testing.T) {
+	dir := setupSnapshotRepo(t)
+
+	if err := os.RemoveAll(filepath.Join(dir, "posts", "p2")); err != nil {
+		t.Fatal(err)
+	}
+	newDir := filepath.Join(dir, "posts", "p3")
+	os.MkdirAll(newDir, 0755)
+	os.WriteFile(filepath.Join(newDir, "meta.json"), []byte(`{"id":"p3","title":"Title p3","slug":"slug-p3","status":"draft","version":1}`), 0644)
+	os.WriteFile(filepath.Join(newDir, "content.md"), []byte("Body of p3"), 0644)
+
+	repo := mustLoad(t, dir)
+	if _, err := repo.FindByID("p2"); err == nil {
+		t.Error("removed post should not be loaded from snapshot")
+	}
+	if post, err := repo.FindBySlug("slug-p3"); err != nil || post.Content != "Body of p3" {
+		t.Errorf("FindBySlug(p3) = %v, %v", post, err)
+	}
+
+	posts, _ := readSnapshot(filepath.Join(dir, snapshotFileName))
+	if _, ok := posts["p2"]; ok || len(posts) != 2 {
+		t.Errorf("snapshot ids = %v, want p1 and p3", posts)
+	}
+}
+
+func TestSnapshot_RebuiltWhenInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"corrupt", []byte("{not json")},
+		{"old version", mustMarshal(t, snapshotJSON{Version: snapshotVersion - 1, Posts: map[string]snapshotEntry{}})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := setupSnapshotRepo(t)
+			path := filepath.Join(dir, snapshotFileName)
+			if err := os.WriteFile(path, tt.data, 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			repo := mustLoad(t, dir)
+			if count, _ := repo.Count(repository.CountOptions{}); count != 2 {
+				t.Errorf("Count() = %d, want 2", count)
+			}
+			if posts, ok := readSnapshot(path); !ok || len(posts) != 2 {
+				t.Errorf("snapshot should be rewritten, got %v", posts)
+			}
+		})
+	}
+}
+
+func TestFilePostRepository_RebuildIndex(t *testing.T) {
+	dir := setupSnapshotRepo(t)
+	editSnapshot(t, dir, "p1", "Stale")
+
+	repo := mustLoad(t, dir)
+	if err := repo.RebuildIndex(); err != nil {
+		t.Fatalf("RebuildIndex() error = %v", err)
+	}
+
+	if post, _ := repo.FindByID("p1"); post.Title != "Title p1" {
+		t.Errorf("Title after rebuild = %q, want value from meta.json", post.Title)
+	}
+	posts, _ := readSnapshot(filepath.Join(dir, snapshotFileName))
+	if posts["p1"].Meta.Title != "Title p1" {
+		t.Errorf("snapshot title after rebuild = %q", posts["p1"].Meta.Title)
+	}
+}
+
+func mustMarshal(t *testing.T, v interface{}) []byte {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
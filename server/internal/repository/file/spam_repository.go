@@ -0,0 +1,208 @@
+package file
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+// 反垃圾数据文件（位于内容目录下）
+const (
+	spamFileName    = "spam.json"      // 禁止列表
+	spamLogFileName = "spam-log.jsonl" // 判定记录，每行一条，只追加
+)
+
+// spamJSON 是 spam.json 的结构（早期版本在此保存的判定记录含原始 IP，加载时忽略）
+type spamJSON struct {
+	Disallow []string `json:"disallow"`
+}
+
+// FileSpamRepository 基于文件的反垃圾仓库，禁止列表与最近的判定记录常驻内存。
+// 禁止列表修改时整体写回；判定记录追加写入，行数超过上限的两倍时压缩为最近的记录
+type FileSpamRepository struct {
+	path     string
+	logPath  string
+	disallow []string
+	log      []repository.SpamLogEntry
+	logLines int // 记录文件中的行数
+	mu       sync.RWMutex
+}
+
+// NewFileSpamRepository 创建反垃圾仓库并加载 basePath 下的数据文件（不存在时为空）
+func NewFileSpamRepository(basePath string) (*FileSpamRepository, error) {
+	if err := os.MkdirAll(basePath, 0755); err != nil {
+		return nil, fmt.Errorf("create content directory failed: %w", err)
+	}
+
+	r := &FileSpamRepository{
+		path:    filepath.Join(basePath, spamFileName),
+		logPath: filepath.Join(basePath, spamLogFileName),
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// DisallowList 读取禁止列表（返回副本）
+func (r *FileSpamRepository) DisallowList() ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]string{}, r.disallow...), nil
+}
+
+// SaveDisallowList 覆盖保存禁止列表并写回文件，写入失败时保持原列表
+func (r *FileSpamRepository) SaveDisallowList(entries []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	disallow := repository.NormalizeDisallowList(entries)
+	data, err := json.MarshalIndent(spamJSON{Disallow: disallow}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal spam data failed: %w", err)
+	}
+	if err := replaceFile(r.path, data); err != nil {
+		return fmt.Errorf("write %s failed: %w", spamFileName, err)
+	}
+	r.disallow = disallow
+	return nil
+}
+
+// AppendLog 在记录文件末尾追加一行，写入失败时不保留该记录
+func (r *FileSpamRepository) AppendLog(entry repository.SpamLogEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshal spam log failed: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f, err := os.OpenFile(r.logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("open %s failed: %w", spamLogFileName, err)
+	}
+	_, err = f.Write(append(line, '\n'))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("append %s failed: %w", spamLogFileName, err)
+	}
+
+	r.log = repository.AppendSpamLog(r.log, entry)
+	r.logLines++
+	if r.logLines > 2*repository.MaxSpamLogEntries {
+		return r.compactLogLocked()
+	}
+	return nil
+}
+
+// RecentLog 读取最近的判定记录
+func (r *FileSpamRepository) RecentLog(limit int) ([]repository.SpamLogEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return repository.RecentSpamLog(r.log, limit), nil
+}
+
+// ReadLocked 持有锁执行 fn，期间不会有写入（用于备份）
+func (r *FileSpamRepository) ReadLocked(fn func() error) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return fn()
+}
+
+// WriteLocked 持有写锁执行 fn（fn 可直接替换数据文件），完成后（无论成功与否）在同一临界区内重新读取数据文件（用于恢复备份）
+func (r *FileSpamRepository) WriteLocked(fn func() error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fnErr := fn()
+	if err := r.reloadLocked(); err != nil {
+		return err
+	}
+	return fnErr
+}
+
+// reloadLocked 重新读取数据文件（调用方需持有写锁）
+func (r *FileSpamRepository) reloadLocked() error {
+	r.log, r.logLines = nil, 0
+	return r.load()
+}
+
+// compactLogLocked 用内存中最近的记录重写记录文件（调用方需持有写锁）
+func (r *FileSpamRepository) compactLogLocked() error {
+	var buf bytes.Buffer
+	for _, entry := range r.log {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("marshal spam log failed: %w", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	if err := replaceFile(r.logPath, buf.Bytes()); err != nil {
+		return fmt.Errorf("compact %s failed: %w", spamLogFileName, err)
+	}
+	r.logLines = len(r.log)
+	return nil
+}
+
+// load 读取禁止列表与判定记录（调用方需持有写锁或处于构造阶段）。
+// 无法解析的记录行（如写入中断留下的半行）被跳过
+func (r *FileSpamRepository) load() error {
+	r.disallow = []string{}
+	data, err := os.ReadFile(r.path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("read %s failed: %w", spamFileName, err)
+	}
+	if err == nil {
+		var stored spamJSON
+		if err := json.Unmarshal(data, &stored); err != nil {
+			return fmt.Errorf("parse %s failed: %w", spamFileName, err)
+		}
+		r.disallow = repository.NormalizeDisallowList(stored.Disallow)
+	}
+
+	data, err = os.ReadFile(r.logPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read %s failed: %w", spamLogFileName, err)
+	}
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		var entry repository.SpamLogEntry
+		if len(line) > 0 && json.Unmarshal(line, &entry) == nil {
+			r.log = repository.AppendSpamLog(r.log, entry)
+		}
+	}
+	r.logLines = bytes.Count(data, []byte("\n"))
+
+	// 末尾的半行会与下一条记录连在一起，先重写文件
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		return r.compactLogLocked()
+	}
+	return nil
+}
+
+// replaceFile 先写临时文件再重命名，替换 path 的内容
+func replaceFile(path string, data []byte) error {
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
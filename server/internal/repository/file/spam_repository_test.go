@@ -0,0 +1,150 @@
+package file
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+func TestFileSpamRepository_Persist(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo, err := NewFileSpamRepository(tmpDir)
+	if err != nil {
+		t.Fatalf("NewFileSpamRepository() error = %v", err)
+	}
+
+	if err := repo.SaveDisallowList([]string{" Casino ", "spam@example.com", "casino", ""}); err != nil {
+		t.Fatalf("SaveDisallowList() error = %v", err)
+	}
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	for i, verdict := range []string{"accept", "reject"} {
+		entry := repository.SpamLogEntry{Time: base.Add(time.Duration(i) * time.Minute), Kind: "comment", IPHash: "a1b2", Verdict: verdict, Reasons: []string{"honeypot"}}
+		if err := repo.AppendLog(entry); err != nil {
+			t.Fatalf("AppendLog() error = %v", err)
+		}
+	}
+
+	// 重新打开后从 spam.json 与 spam-log.jsonl 读取
+	reopened, err := NewFileSpamRepository(tmpDir)
+	if err != nil {
+		t.Fatalf("NewFileSpamRepository() error = %v", err)
+	}
+	list, _ := reopened.DisallowList()
+	if len(list) != 2 || list[0] != "casino" || list[1] != "spam@example.com" {
+		t.Errorf("DisallowList() = %v, want normalized casino, spam@example.com", list)
+	}
+	entries, _ := reopened.RecentLog(1)
+	if len(entries) != 1 || entries[0].Verdict != "reject" || !entries[0].Time.Equal(base.Add(time.Minute)) {
+		t.Errorf("RecentLog(1) = %+v, want latest reject entry", entries)
+	}
+}
+
+func TestFileSpamRepository_LogIsBounded(t *testing.T) {
+	repo, err := NewFileSpamRepository(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileSpamRepository() error = %v", err)
+	}
+
+	for i := 0; i < repository.MaxSpamLogEntries+5; i++ {
+		if err := repo.AppendLog(repository.SpamLogEntry{Score: i}); err != nil {
+			t.Fatalf("AppendLog() error = %v", err)
+		}
+	}
+	entries, _ := repo.RecentLog(0)
+	if len(entries) != repository.MaxSpamLogEntries || entries[len(entries)-1].Score != 5 {
+		t.Errorf("RecentLog() kept %d entries, oldest score %d, want %d entries from score 5",
+			len(entries), entries[len(entries)-1].Score, repository.MaxSpamLogEntries)
+	}
+}
+
+func TestFileSpamRepository_LogIsAppended(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo, err := NewFileSpamRepository(tmpDir)
+	if err != nil {
+		t.Fatalf("NewFileSpamRepository() error = %v", err)
+	}
+	if err := repo.SaveDisallowList([]string{"casino"}); err != nil {
+		t.Fatalf("SaveDisallowList() error = %v", err)
+	}
+	disallow, _ := os.ReadFile(filepath.Join(tmpDir, spamFileName))
+
+	logPath := filepath.Join(tmpDir, spamLogFileName)
+	countLines := func() int {
+		data, _ := os.ReadFile(logPath)
+		return bytes.Count(data, []byte("\n"))
+	}
+
+	// 追加记录不改写 spam.json，记录文件超过上限的两倍时压缩
+	for i := 0; i < 2*repository.MaxSpamLogEntries; i++ {
+		if err := repo.AppendLog(repository.SpamLogEntry{Score: i}); err != nil {
+			t.Fatalf("AppendLog() error = %v", err)
+		}
+	}
+	if got := countLines(); got != 2*repository.MaxSpamLogEntries {
+		t.Errorf("log lines = %d, want %d", got, 2*repository.MaxSpamLogEntries)
+	}
+	if err := repo.AppendLog(repository.SpamLogEntry{Score: -1}); err != nil {
+		t.Fatalf("AppendLog() error = %v", err)
+	}
+	if got := countLines(); got != repository.MaxSpamLogEntries {
+		t.Errorf("log lines after compaction = %d, want %d", got, repository.MaxSpamLogEntries)
+	}
+	if data, _ := os.ReadFile(filepath.Join(tmpDir, spamFileName)); !bytes.Equal(data, disallow) {
+		t.Errorf("spam.json changed by AppendLog: %s", data)
+	}
+
+	// 写入中断留下的半行被跳过
+	f, err := os.OpenFile(logPath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"score": 7`)
+	f.Close()
+	reopened, err := NewFileSpamRepository(tmpDir)
+	if err != nil {
+		t.Fatalf("NewFileSpamRepository() error = %v", err)
+	}
+	if entries, _ := reopened.RecentLog(1); len(entries) != 1 || entries[0].Score != -1 {
+		t.Errorf("RecentLog(1) after reopen = %+v, want the last complete entry", entries)
+	}
+	if err := reopened.AppendLog(repository.SpamLogEntry{Score: 8}); err != nil {
+		t.Fatalf("AppendLog() error = %v", err)
+	}
+	again, _ := NewFileSpamRepository(tmpDir)
+	if entries, _ := again.RecentLog(2); len(entries) != 2 || entries[0].Score != 8 || entries[1].Score != -1 {
+		t.Errorf("RecentLog(2) after appending past a partial line = %+v", entries)
+	}
+}
+
+func TestFileSpamRepository_WriteLocked(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo, err := NewFileSpamRepository(tmpDir)
+	if err != nil {
+		t.Fatalf("NewFileSpamRepository() error = %v", err)
+	}
+	if err := repo.AppendLog(repository.SpamLogEntry{Score: 1}); err != nil {
+		t.Fatalf("AppendLog() error = %v", err)
+	}
+
+	// 模拟恢复备份：在锁定状态下替换文件，返回前重新加载
+	err = repo.WriteLocked(func() error {
+		if err := os.WriteFile(filepath.Join(tmpDir, spamFileName), []byte(`{"disallow": ["casino"]}`), 0644); err != nil {
+			return err
+		}
+		return os.Remove(filepath.Join(tmpDir, spamLogFileName))
+	})
+	if err != nil {
+		t.Fatalf("WriteLocked() error = %v", err)
+	}
+
+	if list, _ := repo.DisallowList(); len(list) != 1 || list[0] != "casino" {
+		t.Errorf("DisallowList() after reload = %v", list)
+	}
+	if entries, _ := repo.RecentLog(0); len(entries) != 0 {
+		t.Errorf("RecentLog() after reload = %+v, want empty", entries)
+	}
+}
@@ -0,0 +1,123 @@
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+// viewsFileName 浏览量文件（位于内容目录下）
+const viewsFileName = "views.json"
+
+// FileViewRepository 基于 JSON 文件的浏览量仓库，全部记录常驻内存。
+// 文件结构为 postID -> 日期 -> 浏览量，每次 AddViews 整体写回
+type FileViewRepository struct {
+	path  string
+	views map[string]repository.DailyViews
+	mu    sync.Mutex
+}
+
+// NewFileViewRepository 创建浏览量仓库并加载 basePath/views.json（不存在时为空）
+func NewFileViewRepository(basePath string) (*FileViewRepository, error) {
+	if err := os.MkdirAll(basePath, 0755); err != nil {
+		return nil, fmt.Errorf("create content directory failed: %w", err)
+	}
+
+	r := &FileViewRepository{
+		path:  filepath.Join(basePath, viewsFileName),
+		views: make(map[string]repository.DailyViews),
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// LoadViews 读取全部浏览量（返回副本）
+func (r *FileViewRepository) LoadViews() (map[string]repository.DailyViews, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return repository.CopyViews(r.views), nil
+}
+
+// AddViews 累加浏览量并写回文件，写入失败时内存中的数据保持不变
+func (r *FileViewRepository) AddViews(delta map[string]repository.DailyViews) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	merged := repository.CopyViews(r.views)
+	repository.MergeViews(merged, delta)
+	if err := r.write(merged); err != nil {
+		return err
+	}
+	r.views = merged
+	return nil
+}
+
+// ReadLocked 持有锁执行 fn，期间不会有写入（用于备份）
+func (r *FileViewRepository) ReadLocked(fn func() error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return fn()
+}
+
+// WriteLocked 持有写锁执行 fn（fn 可直接替换数据文件），完成后（无论成功与否）在同一临界区内重新读取浏览量文件（用于恢复备份）
+func (r *FileViewRepository) WriteLocked(fn func() error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fnErr := fn()
+	if err := r.reloadLocked(); err != nil {
+		return err
+	}
+	return fnErr
+}
+
+// reloadLocked 重新读取浏览量文件（调用方需持有写锁）
+func (r *FileViewRepository) reloadLocked() error {
+	r.views = make(map[string]repository.DailyViews)
+	return r.load()
+}
+
+// load 读取浏览量文件（调用方需持有锁或处于构造阶段）
+func (r *FileViewRepository) load() error {
+	data, err := os.ReadFile(r.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read %s failed: %w", viewsFileName, err)
+	}
+
+	if err := json.Unmarshal(data, &r.views); err != nil {
+		return fmt.Errorf("parse %s failed: %w", viewsFileName, err)
+	}
+	if r.views == nil {
+		r.views = make(map[string]repository.DailyViews)
+	}
+	return nil
+}
+
+// write 将浏览量写回文件（先写临时文件再重命名，map 的键按字典序输出）
+func (r *FileViewRepository) write(views map[string]repository.DailyViews) error {
+	data, err := json.MarshalIndent(views, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal views failed: %w", err)
+	}
+
+	tmpPath := r.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("write %s failed: %w", viewsFileName, err)
+	}
+	if err := os.Rename(tmpPath, r.path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("replace %s failed: %w", viewsFileName, err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+func TestFileViewRepository_Persist(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo, err := NewFileViewRepository(tmpDir)
+	if err != nil {
+		t.Fatalf("NewFileViewRepository() error = %v", err)
+	}
+
+	if err := repo.AddViews(map[string]repository.DailyViews{"p1": {"2024-06-01": 2}}); err != nil {
+		t.Fatalf("AddViews() error = %v", err)
+	}
+	if err := repo.AddViews(map[string]repository.DailyViews{
+		"p1": {"2024-06-01": 1, "2024-06-02": 4},
+		"p2": {"2024-06-02": 1},
+	}); err != nil {
+		t.Fatalf("AddViews() error = %v", err)
+	}
+
+	// 重新打开后从 views.json 读取
+	reopened, err := NewFileViewRepository(tmpDir)
+	if err != nil {
+		t.Fatalf("NewFileViewRepository() error = %v", err)
+	}
+	views, err := reopened.LoadViews()
+	if err != nil {
+		t.Fatalf("LoadViews() error = %v", err)
+	}
+	if views["p1"]["2024-06-01"] != 3 || views["p1"]["2024-06-02"] != 4 || views["p2"]["2024-06-02"] != 1 {
+		t.Errorf("LoadViews() = %v", views)
+	}
+
+	// 返回的是副本
+	views["p1"]["2024-06-01"] = 100
+	again, _ := reopened.LoadViews()
+	if again["p1"]["2024-06-01"] != 3 {
+		t.Errorf("LoadViews() shares state with the repository")
+	}
+}
+
+func TestFileViewRepository_WriteFailureKeepsState(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo, err := NewFileViewRepository(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.AddViews(map[string]repository.DailyViews{"p1": {"2024-06-01": 1}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 临时文件路径被目录占用，写入失败
+	if err := os.Mkdir(filepath.Join(tmpDir, viewsFileName+".tmp"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.AddViews(map[string]repository.DailyViews{"p1": {"2024-06-01": 5}}); err == nil {
+		t.Fatal("AddViews() error = nil, want write failure")
+	}
+	views, _ := repo.LoadViews()
+	if views["p1"]["2024-06-01"] != 1 {
+		t.Errorf("views after failed write = %v, want unchanged", views)
+	}
+}
+
+func TestFileViewRepository_WriteLocked(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo, err := NewFileViewRepository(tmpDir)
+	if err != nil {
+		t.Fatalf("NewFileViewRepository() error = %v", err)
+	}
+	if err := repo.AddViews(map[string]repository.DailyViews{"p1": {"2024-06-01": 1}}); err != nil {
+		t.Fatalf("AddViews() error = %v", err)
+	}
+
+	// 模拟恢复备份：在锁定状态下替换文件，返回前重新加载
+	err = repo.WriteLocked(func() error {
+		return os.WriteFile(filepath.Join(tmpDir, viewsFileName), []byte(`{"p2": {"2024-06-02": 7}}`), 0644)
+	})
+	if err != nil {
+		t.Fatalf("WriteLocked() error = %v", err)
+	}
+
+	views, _ := repo.LoadViews()
+	if len(views) != 1 || views["p2"]["2024-06-02"] != 7 {
+		t.Errorf("LoadViews() after reload = %v", views)
+	}
+}
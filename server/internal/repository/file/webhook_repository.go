@@ -0,0 +1,306 @@
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+// webhooksFileName Webhook 与投递记录文件（位于内容目录下）
+const webhooksFileName = "webhooks.json"
+
+// webhooksJSON 是 webhooks.json 的结构
+type webhooksJSON struct {
+	Webhooks   []webhookJSON  `json:"webhooks"`
+	Deliveries []deliveryJSON `json:"deliveries"`
+}
+
+// webhookJSON 是 webhooks.json 中单个 Webhook 的结构
+type webhookJSON struct {
+	ID        string   `json:"id"`
+	URL       string   `json:"url"`
+	Secret    string   `json:"secret"`
+	Events    []string `json:"events"`
+	Active    bool     `json:"active"`
+	CreatedAt string   `json:"createdAt"`
+	UpdatedAt string   `json:"updatedAt"`
+}
+
+// deliveryJSON 是 webhooks.json 中单条投递记录的结构
+type deliveryJSON struct {
+	ID            string `json:"id"`
+	WebhookID     string `json:"webhookId"`
+	Event         string `json:"event"`
+	Payload       string `json:"payload"`
+	Status        string `json:"status"`
+	Attempts      int    `json:"attempts"`
+	ResponseCode  int    `json:"responseCode,omitempty"`
+	LastError     string `json:"lastError,omitempty"`
+	RedeliveryOf  string `json:"redeliveryOf,omitempty"`
+	CreatedAt     string `json:"createdAt"`
+	UpdatedAt     string `json:"updatedAt"`
+	NextAttemptAt string `json:"nextAttemptAt,omitempty"`
+}
+
+// FileWebhookRepository 基于 JSON 文件的 Webhook 仓库，全部记录常驻内存，每次修改整体写回
+type FileWebhookRepository struct {
+	path       string
+	webhooks   map[string]*domain.Webhook
+	deliveries []*domain.WebhookDelivery
+	mu         sync.RWMutex
+}
+
+// NewFileWebhookRepository 创建 Webhook 仓库并加载 basePath/webhooks.json（不存在时为空）
+func NewFileWebhookRepository(basePath string) (*FileWebhookRepository, error) {
+	if err := os.MkdirAll(basePath, 0755); err != nil {
+		return nil, fmt.Errorf("create content directory failed: %w", err)
+	}
+
+	r := &FileWebhookRepository{
+		path:     filepath.Join(basePath, webhooksFileName),
+		webhooks: make(map[string]*domain.Webhook),
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ListWebhooks 列出全部 Webhook
+func (r *FileWebhookRepository) ListWebhooks() ([]*domain.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return repository.SortedWebhooks(r.webhooks), nil
+}
+
+// FindWebhook 根据 ID 查找 Webhook
+func (r *FileWebhookRepository) FindWebhook(id string) (*domain.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	webhook, ok := r.webhooks[id]
+	if !ok {
+		return nil, repository.ErrWebhookNotFound
+	}
+	return repository.CopyWebhook(webhook), nil
+}
+
+// SaveWebhook 保存 Webhook 并写回文件，写入失败时恢复原状态
+func (r *FileWebhookRepository) SaveWebhook(webhook *domain.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, existed := r.webhooks[webhook.ID]
+	r.webhooks[webhook.ID] = repository.CopyWebhook(webhook)
+	if err := r.write(r.deliveries); err != nil {
+		if existed {
+			r.webhooks[webhook.ID] = previous
+		} else {
+			delete(r.webhooks, webhook.ID)
+		}
+		return err
+	}
+	return nil
+}
+
+// DeleteWebhook 删除 Webhook 及其投递记录并写回文件
+func (r *FileWebhookRepository) DeleteWebhook(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, ok := r.webhooks[id]
+	if !ok {
+		return repository.ErrWebhookNotFound
+	}
+	delete(r.webhooks, id)
+	deliveries := repository.RemoveDeliveries(r.deliveries, id)
+	if err := r.write(deliveries); err != nil {
+		r.webhooks[id] = previous
+		return err
+	}
+	r.deliveries = deliveries
+	return nil
+}
+
+// FindDelivery 根据 ID 查找投递记录
+func (r *FileWebhookRepository) FindDelivery(id string) (*domain.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return repository.FindDeliveryIn(r.deliveries, id)
+}
+
+// ListDeliveries 列出投递记录
+func (r *FileWebhookRepository) ListDeliveries(webhookID string, limit int) ([]*domain.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return repository.ListDeliveriesIn(r.deliveries, webhookID, limit), nil
+}
+
+// DueDeliveries 列出到期待投递的记录
+func (r *FileWebhookRepository) DueDeliveries(now time.Time) ([]*domain.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return repository.DueDeliveriesIn(r.deliveries, now), nil
+}
+
+// SaveDelivery 保存投递记录并写回文件，写入失败时保持原记录
+func (r *FileWebhookRepository) SaveDelivery(delivery *domain.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deliveries := repository.PutDelivery(r.deliveries, delivery)
+	if err := r.write(deliveries); err != nil {
+		return err
+	}
+	r.deliveries = deliveries
+	return nil
+}
+
+// ReadLocked 持有锁执行 fn，期间不会有写入（用于备份）
+func (r *FileWebhookRepository) ReadLocked(fn func() error) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return fn()
+}
+
+// WriteLocked 持有写锁执行 fn（fn 可直接替换数据文件），完成后（无论成功与否）在同一临界区内重新读取 webhooks.json（用于恢复备份）
+func (r *FileWebhookRepository) WriteLocked(fn func() error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fnErr := fn()
+	if err := r.reloadLocked(); err != nil {
+		return err
+	}
+	return fnErr
+}
+
+// reloadLocked 重新读取 webhooks.json（调用方需持有写锁）
+func (r *FileWebhookRepository) reloadLocked() error {
+	r.webhooks = make(map[string]*domain.Webhook)
+	r.deliveries = nil
+	return r.load()
+}
+
+// load 读取 webhooks.json（调用方需持有写锁或处于构造阶段）
+func (r *FileWebhookRepository) load() error {
+	data, err := os.ReadFile(r.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read %s failed: %w", webhooksFileName, err)
+	}
+
+	var stored webhooksJSON
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return fmt.Errorf("parse %s failed: %w", webhooksFileName, err)
+	}
+
+	for _, record := range stored.Webhooks {
+		events := make([]domain.WebhookEvent, 0, len(record.Events))
+		for _, event := range record.Events {
+			events = append(events, domain.WebhookEvent(event))
+		}
+		r.webhooks[record.ID] = &domain.Webhook{
+			ID:        record.ID,
+			URL:       record.URL,
+			Secret:    record.Secret,
+			Events:    events,
+			Active:    record.Active,
+			CreatedAt: parseStoredTime(record.CreatedAt),
+			UpdatedAt: parseStoredTime(record.UpdatedAt),
+		}
+	}
+	for _, record := range stored.Deliveries {
+		r.deliveries = repository.PutDelivery(r.deliveries, &domain.WebhookDelivery{
+			ID:            record.ID,
+			WebhookID:     record.WebhookID,
+			Event:         domain.WebhookEvent(record.Event),
+			Payload:       record.Payload,
+			Status:        domain.DeliveryStatus(record.Status),
+			Attempts:      record.Attempts,
+			ResponseCode:  record.ResponseCode,
+			LastError:     record.LastError,
+			RedeliveryOf:  record.RedeliveryOf,
+			CreatedAt:     parseStoredTime(record.CreatedAt),
+			UpdatedAt:     parseStoredTime(record.UpdatedAt),
+			NextAttemptAt: parseStoredTime(record.NextAttemptAt),
+		})
+	}
+	return nil
+}
+
+// write 将 Webhook 与给定的投递记录写回文件（先写临时文件再重命名）
+func (r *FileWebhookRepository) write(deliveries []*domain.WebhookDelivery) error {
+	stored := webhooksJSON{
+		Webhooks:   make([]webhookJSON, 0, len(r.webhooks)),
+		Deliveries: make([]deliveryJSON, 0, len(deliveries)),
+	}
+	for _, webhook := range repository.SortedWebhooks(r.webhooks) {
+		events := make([]string, 0, len(webhook.Events))
+		for _, event := range webhook.Events {
+			events = append(events, string(event))
+		}
+		stored.Webhooks = append(stored.Webhooks, webhookJSON{
+			ID:        webhook.ID,
+			URL:       webhook.URL,
+			Secret:    webhook.Secret,
+			Events:    events,
+			Active:    webhook.Active,
+			CreatedAt: webhook.CreatedAt.Format(time.RFC3339),
+			UpdatedAt: webhook.UpdatedAt.Format(time.RFC3339),
+		})
+	}
+	for _, delivery := range deliveries {
+		record := deliveryJSON{
+			ID:           delivery.ID,
+			WebhookID:    delivery.WebhookID,
+			Event:        string(delivery.Event),
+			Payload:      delivery.Payload,
+			Status:       string(delivery.Status),
+			Attempts:     delivery.Attempts,
+			ResponseCode: delivery.ResponseCode,
+			LastError:    delivery.LastError,
+			RedeliveryOf: delivery.RedeliveryOf,
+			CreatedAt:    delivery.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:    delivery.UpdatedAt.Format(time.RFC3339),
+		}
+		if !delivery.NextAttemptAt.IsZero() {
+			record.NextAttemptAt = delivery.NextAttemptAt.Format(time.RFC3339)
+		}
+		stored.Deliveries = append(stored.Deliveries, record)
+	}
+
+	data, err := json.MarshalIndent(stored, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal webhooks failed: %w", err)
+	}
+
+	tmpPath := r.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("write %s failed: %w", webhooksFileName, err)
+	}
+	if err := os.Rename(tmpPath, r.path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("replace %s failed: %w", webhooksFileName, err)
+	}
+	return nil
+}
+
+// parseStoredTime 解析 RFC3339 时间，为空或无法解析时返回零值
+func parseStoredTime(value string) time.Time {
+	t, _ := time.Parse(time.RFC3339, value)
+	return t
+}
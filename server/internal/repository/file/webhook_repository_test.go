@@ -0,0 +1,95 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+func TestFileWebhookRepository_Persist(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo, err := NewFileWebhookRepository(tmpDir)
+	if err != nil {
+		t.Fatalf("NewFileWebhookRepository() error = %v", err)
+	}
+
+	webhook, _ := domain.NewWebhook("w1", "https://hooks.example.com", "secret", []domain.WebhookEvent{domain.EventPostPublished})
+	if err := repo.SaveWebhook(webhook); err != nil {
+		t.Fatalf("SaveWebhook() error = %v", err)
+	}
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	deliveries := []*domain.WebhookDelivery{
+		{ID: "d1", WebhookID: "w1", Event: domain.EventPostPublished, Payload: `{"a":1}`, Status: domain.DeliverySucceeded, Attempts: 1, ResponseCode: 200, CreatedAt: base},
+		{ID: "d2", WebhookID: "w1", Event: domain.EventPostPublished, Payload: `{"a":2}`, Status: domain.DeliveryPending, Attempts: 1, LastError: "timeout", CreatedAt: base.Add(time.Minute), NextAttemptAt: base.Add(2 * time.Minute)},
+	}
+	for _, delivery := range deliveries {
+		if err := repo.SaveDelivery(delivery); err != nil {
+			t.Fatalf("SaveDelivery() error = %v", err)
+		}
+	}
+
+	// 重新打开后从 webhooks.json 读取
+	reopened, err := NewFileWebhookRepository(tmpDir)
+	if err != nil {
+		t.Fatalf("NewFileWebhookRepository() error = %v", err)
+	}
+	got, err := reopened.FindWebhook("w1")
+	if err != nil || got.URL != webhook.URL || got.Secret != "secret" || !got.Subscribes(domain.EventPostPublished) {
+		t.Errorf("FindWebhook() = %+v, %v", got, err)
+	}
+
+	list, _ := reopened.ListDeliveries("w1", 0)
+	if len(list) != 2 || list[0].ID != "d2" || list[0].LastError != "timeout" {
+		t.Errorf("ListDeliveries() = %+v, want newest first", list)
+	}
+	if due, _ := reopened.DueDeliveries(base.Add(time.Minute)); len(due) != 0 {
+		t.Errorf("DueDeliveries() before next attempt = %+v, want none", due)
+	}
+	due, _ := reopened.DueDeliveries(base.Add(2 * time.Minute))
+	if len(due) != 1 || due[0].ID != "d2" {
+		t.Errorf("DueDeliveries() = %+v, want d2", due)
+	}
+
+	// 删除 Webhook 时一并删除投递记录
+	if err := reopened.DeleteWebhook("w1"); err != nil {
+		t.Fatalf("DeleteWebhook() error = %v", err)
+	}
+	if _, err := reopened.FindDelivery("d1"); err != repository.ErrDeliveryNotFound {
+		t.Errorf("FindDelivery() after DeleteWebhook error = %v, want ErrDeliveryNotFound", err)
+	}
+	if err := reopened.DeleteWebhook("w1"); err != repository.ErrWebhookNotFound {
+		t.Errorf("DeleteWebhook(missing) error = %v, want ErrWebhookNotFound", err)
+	}
+}
+
+func TestFileWebhookRepository_WriteLocked(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo, err := NewFileWebhookRepository(tmpDir)
+	if err != nil {
+		t.Fatalf("NewFileWebhookRepository() error = %v", err)
+	}
+	webhook, _ := domain.NewWebhook("w1", "https://hooks.example.com", "secret", []domain.WebhookEvent{domain.EventPostPublished})
+	if err := repo.SaveWebhook(webhook); err != nil {
+		t.Fatalf("SaveWebhook() error = %v", err)
+	}
+
+	// 模拟恢复备份：在锁定状态下替换文件，返回前重新加载
+	data := `{"webhooks": [{"id": "w2", "url": "https://restored.example.com", "secret": "s", "events": ["post.published"], "active": true}], "deliveries": []}`
+	err = repo.WriteLocked(func() error {
+		return os.WriteFile(filepath.Join(tmpDir, webhooksFileName), []byte(data), 0644)
+	})
+	if err != nil {
+		t.Fatalf("WriteLocked() error = %v", err)
+	}
+
+	if _, err := repo.FindWebhook("w1"); err != repository.ErrWebhookNotFound {
+		t.Errorf("FindWebhook(w1) after reload error = %v, want ErrWebhookNotFound", err)
+	}
+	if got, err := repo.FindWebhook("w2"); err != nil || got.URL != "https://restored.example.com" {
+		t.Errorf("FindWebhook(w2) = %+v, %v", got, err)
+	}
+}
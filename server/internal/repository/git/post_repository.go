@@ -0,0 +1,515 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/repository"
+	"github.com/next-ai-ventus/server/internal/repository/file"
+)
+
+// defaultAuthor 无法获取编辑者时使用的提交作者
+const defaultAuthor = "ventus"
+
+// GitPostRepository 为底层仓库的每次成功写入记录一次 git 提交
+type GitPostRepository struct {
+	repository.PostRepository
+	repo        *gogit.Repository
+	contentPath string // 内容目录的绝对路径
+	prefix      string // 内容目录相对于 git 工作区根目录的路径
+	mu          sync.Mutex
+}
+
+// NewGitPostRepository 包装底层仓库；contentPath 所在的 git 仓库不存在时自动初始化
+func NewGitPostRepository(inner repository.PostRepository, contentPath string) (*GitPostRepository, error) {
+	absPath, err := filepath.Abs(contentPath)
+	if err != nil {
+		return nil, fmt.Errorf("resolve content path failed: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(absPath); err == nil {
+		absPath = resolved
+	}
+
+	r := &GitPostRepository{
+		PostRepository: inner,
+		contentPath:    absPath,
+	}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// open 打开（或初始化）内容目录所在的 git 仓库，并计算内容目录在工作区中的路径
+func (r *GitPostRepository) open() error {
+	repo, err := gogit.PlainOpenWithOptions(r.contentPath, &gogit.PlainOpenOptions{DetectDotGit: true})
+	if errors.Is(err, gogit.ErrRepositoryNotExists) {
+		repo, err = gogit.PlainInit(r.contentPath, false)
+	}
+	if err != nil {
+		return fmt.Errorf("open git repository failed: %w", err)
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("open git worktree failed: %w", err)
+	}
+
+	root := wt.Filesystem.Root()
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+	rel, err := filepath.Rel(root, r.contentPath)
+	if err != nil {
+		return fmt.Errorf("resolve content path in worktree failed: %w", err)
+	}
+
+	prefix := filepath.ToSlash(rel)
+	if prefix == "." {
+		prefix = ""
+	}
+
+	r.repo = repo
+	r.prefix = prefix
+	return nil
+}
+
+// Save 保存文章并提交（作者为默认作者）
+func (r *GitPostRepository) Save(post *domain.Post) error {
+	return r.SaveAs(post, "")
+}
+
+// Delete 删除文章并提交（作者为默认作者）
+func (r *GitPostRepository) Delete(id string) error {
+	return r.DeleteAs(id, "")
+}
+
+// SaveAs 以指定编辑者身份保存文章并提交，提交失败时还原为保存前的状态
+func (r *GitPostRepository) SaveAs(post *domain.Post, editor string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	action := "Update"
+	previous, err := r.PostRepository.FindByID(post.ID)
+	switch {
+	case errors.Is(err, repository.ErrPostNotFound):
+		action = "Create"
+	case err != nil:
+		return err
+	}
+
+	if err := r.PostRepository.Save(post); err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("%s post %s: %s", action, post.ID, post.Title)
+	if err := r.commit(post.ID, message, editor); err != nil {
+		return r.rollback([]string{post.ID}, err, func() error {
+			if previous == nil {
+				return r.PostRepository.Delete(post.ID)
+			}
+			return r.PostRepository.Save(previous)
+		})
+	}
+	return nil
+}
+
+// DeleteAs 以指定编辑者身份删除文章并提交，提交失败时恢复文章
+func (r *GitPostRepository) DeleteAs(id, editor string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	post, err := r.PostRepository.FindByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := r.PostRepository.Delete(id); err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("Delete post %s: %s", id, post.Title)
+	if err := r.commit(id, message, editor); err != nil {
+		return r.rollback([]string{id}, err, func() error {
+			return r.PostRepository.Save(post)
+		})
+	}
+	return nil
+}
+
+// ApplyBatch 原子地应用底层仓库的批量写入，并把整个批次记录为一次提交；
+// 提交失败时把批次涉及的文章还原为写入前的状态，仓库内容保持不变
+func (r *GitPostRepository) ApplyBatch(batch repository.Batch) error {
+	writer, ok := r.PostRepository.(repository.BatchWriter)
+	if !ok {
+		return repository.ErrBatchUnsupported
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	undo, err := r.undoBatch(batch)
+	if err != nil {
+		return err
+	}
+	if err := writer.ApplyBatch(batch); err != nil {
+		return err
+	}
+
+	ids := make([]string, 0, len(batch.Save)+len(batch.Delete))
+	lines := make([]string, 0, cap(ids))
+	for _, post := range batch.Save {
+		ids = append(ids, post.ID)
+		lines = append(lines, fmt.Sprintf("Save post %s: %s", post.ID, post.Title))
+	}
+	for _, id := range batch.Delete {
+		ids = append(ids, id)
+		lines = append(lines, "Delete post "+id)
+	}
+
+	message := fmt.Sprintf("Batch update %d posts\n\n%s", len(ids), strings.Join(lines, "\n"))
+	if err := r.commitPosts(ids, message, batch.Editor); err != nil {
+		return r.rollback(ids, err, func() error {
+			return writer.ApplyBatch(undo)
+		})
+	}
+	return nil
+}
+
+// rollback 提交失败后调用 undo 还原已写入的文章，使磁盘内容与提交历史保持一致；
+// 返回提交错误（还原失败时一并返回）。调用方需持有锁
+func (r *GitPostRepository) rollback(ids []string, commitErr error, undo func() error) error {
+	if err := undo(); err != nil {
+		return errors.Join(commitErr, fmt.Errorf("rollback failed: %w", err))
+	}
+	// 暂存区与还原后的工作区保持一致，避免下次提交带上未生效的修改
+	if wt, err := r.repo.Worktree(); err == nil {
+		_ = r.stagePosts(wt, ids)
+	}
+	return commitErr
+}
+
+// undoBatch 返回撤销批次的批次：恢复被修改或删除的文章，删除新建的文章（调用方需持有锁）
+func (r *GitPostRepository) undoBatch(batch repository.Batch) (repository.Batch, error) {
+	undo := repository.Batch{Editor: batch.Editor}
+	for _, post := range batch.Save {
+		previous, err := r.PostRepository.FindByID(post.ID)
+		switch {
+		case err == nil:
+			undo.Save = append(undo.Save, previous)
+		case errors.Is(err, repository.ErrPostNotFound):
+			undo.Delete = append(undo.Delete, post.ID)
+		default:
+			return undo, err
+		}
+	}
+	for _, id := range batch.Delete {
+		previous, err := r.PostRepository.FindByID(id)
+		if errors.Is(err, repository.ErrPostNotFound) {
+			continue // 批次校验时会被拒绝
+		}
+		if err != nil {
+			return undo, err
+		}
+		undo.Save = append(undo.Save, previous)
+	}
+	return undo, nil
+}
+
+// History 获取文章的提交历史（最新的在前）
+func (r *GitPostRepository) History(id string) ([]repository.Revision, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	revisions := []repository.Revision{}
+
+	if _, err := r.repo.Head(); err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return revisions, nil // 尚无任何提交
+		}
+		return nil, err
+	}
+
+	dir := r.postDir(id) + "/"
+	iter, err := r.repo.Log(&gogit.LogOptions{
+		PathFilter: func(p string) bool {
+			return strings.HasPrefix(p, dir)
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read git log failed: %w", err)
+	}
+	defer iter.Close()
+
+	err = iter.ForEach(func(c *object.Commit) error {
+		revisions = append(revisions, repository.Revision{
+			Hash:    c.Hash.String(),
+			Message: strings.TrimSpace(c.Message),
+			Author:  c.Author.Name,
+			Time:    c.Author.When,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read git log failed: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// FindRevision 从 git 读取文章在指定提交时的版本
+func (r *GitPostRepository) FindRevision(id, hash string) (*domain.Post, error) {
+	if !plumbing.IsHash(hash) {
+		return nil, repository.ErrRevisionNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	commit, err := r.repo.CommitObject(plumbing.NewHash(hash))
+	if err != nil {
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			return nil, repository.ErrRevisionNotFound
+		}
+		return nil, err
+	}
+
+	dir := r.postDir(id)
+	metaData, err := readCommitFile(commit, dir+"/meta.json")
+	if err != nil {
+		return nil, err
+	}
+	content, err := readCommitFile(commit, dir+"/content.md")
+	if err != nil {
+		return nil, err
+	}
+
+	return file.DecodePost(metaData, content)
+}
+
+// RebuildIndex 转发给底层仓库重建索引
+func (r *GitPostRepository) RebuildIndex() error {
+	rebuilder, ok := r.PostRepository.(repository.IndexRebuilder)
+	if !ok {
+		return repository.ErrRebuildUnsupported
+	}
+	return rebuilder.RebuildIndex()
+}
+
+// CheckContent 检查底层仓库的内容，并报告有未提交修改的文章目录。
+// 开启修复时，底层修复产生的修改与未提交的目录会一并补充提交。
+func (r *GitPostRepository) CheckContent(opts repository.CheckOptions) (*repository.CheckReport, error) {
+	checker, ok := r.PostRepository.(repository.ContentChecker)
+	if !ok {
+		return nil, repository.ErrCheckUnsupported
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report, err := checker.CheckContent(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := r.uncommittedPosts()
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		issue := &repository.CheckIssue{
+			PostID:   id,
+			Path:     path.Join("posts", id),
+			Kind:     repository.IssueUncommittedPost,
+			Severity: repository.SeverityWarning,
+			Message:  "post directory has changes not recorded in history",
+			Fix:      "commit current files",
+		}
+		if opts.Fix {
+			if err := r.commit(id, fmt.Sprintf("Sync post %s", id), ""); err != nil {
+				return nil, err
+			}
+			issue.Fixed = true
+		}
+		report.Add(issue)
+	}
+	return report, nil
+}
+
+// uncommittedPosts 返回工作区中有未提交修改的文章 ID（调用方需持有锁）
+func (r *GitPostRepository) uncommittedPosts() ([]string, error) {
+	wt, err := r.repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("open git worktree failed: %w", err)
+	}
+	status, err := wt.Status()
+	if err != nil {
+		return nil, fmt.Errorf("git status failed: %w", err)
+	}
+
+	postsDir := path.Join(r.prefix, "posts") + "/"
+	seen := make(map[string]bool)
+	var ids []string
+	for name, st := range status {
+		if !strings.HasPrefix(name, postsDir) {
+			continue
+		}
+		if st.Worktree == gogit.Unmodified && st.Staging == gogit.Unmodified {
+			continue
+		}
+		id, _, _ := strings.Cut(strings.TrimPrefix(name, postsDir), "/")
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// ReadLocked 在禁止写入（包括提交）的状态下执行 fn
+func (r *GitPostRepository) ReadLocked(fn func() error) error {
+	locker, ok := r.PostRepository.(repository.StorageLocker)
+	if !ok {
+		return repository.ErrBackupUnsupported
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return locker.ReadLocked(fn)
+}
+
+// WriteLocked 在独占状态下执行 fn。完成后重新打开 git 仓库（.git 可能已被替换），
+// 并为有未提交修改的文章补充提交
+func (r *GitPostRepository) WriteLocked(fn func() error) error {
+	locker, ok := r.PostRepository.(repository.StorageLocker)
+	if !ok {
+		return repository.ErrBackupUnsupported
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fnErr := locker.WriteLocked(fn)
+
+	if err := r.open(); err != nil {
+		return err
+	}
+	ids, err := r.uncommittedPosts()
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := r.commit(id, fmt.Sprintf("Restore post %s", id), ""); err != nil {
+			return err
+		}
+	}
+	return fnErr
+}
+
+// commit 暂存文章目录的变更并提交（调用方需持有锁）
+func (r *GitPostRepository) commit(id, message, editor string) error {
+	return r.commitPosts([]string{id}, message, editor)
+}
+
+// commitPosts 暂存多个文章目录的变更并作为一次提交（调用方需持有锁）
+func (r *GitPostRepository) commitPosts(ids []string, message, editor string) error {
+	wt, err := r.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("open git worktree failed: %w", err)
+	}
+
+	if err := r.stagePosts(wt, ids); err != nil {
+		return err
+	}
+
+	author := editor
+	if author == "" {
+		author = defaultAuthor
+	}
+
+	_, err = wt.Commit(message+"\n\nEditor: "+author, &gogit.CommitOptions{
+		Author: &object.Signature{
+			Name: author,
+			When: time.Now(),
+		},
+	})
+	if err != nil && !errors.Is(err, gogit.ErrEmptyCommit) {
+		return fmt.Errorf("git commit failed: %w", err)
+	}
+	return nil
+}
+
+// stagePosts 使用 glob 暂存文章目录下的新增、修改与删除
+func (r *GitPostRepository) stagePosts(wt *gogit.Worktree, ids []string) error {
+	for _, id := range ids {
+		dir := r.postDir(id)
+		if err := wt.AddWithOptions(&gogit.AddOptions{Glob: dir + "/*"}); err != nil &&
+			!errors.Is(err, gogit.ErrGlobNoMatches) {
+			return fmt.Errorf("git add failed: %w", err)
+		}
+		if err := r.stageRemovals(wt, dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stageRemovals 将已从磁盘删除的文件从索引中移除
+func (r *GitPostRepository) stageRemovals(wt *gogit.Worktree, dir string) error {
+	idx, err := r.repo.Storer.Index()
+	if err != nil {
+		return fmt.Errorf("read git index failed: %w", err)
+	}
+
+	for _, entry := range idx.Entries {
+		if !strings.HasPrefix(entry.Name, dir+"/") {
+			continue
+		}
+		if _, err := wt.Filesystem.Lstat(entry.Name); err == nil {
+			continue
+		}
+		if _, err := wt.Remove(entry.Name); err != nil {
+			return fmt.Errorf("git rm %s failed: %w", entry.Name, err)
+		}
+	}
+	return nil
+}
+
+// postDir 返回文章目录在 git 工作区中的路径
+func (r *GitPostRepository) postDir(id string) string {
+	return path.Join(r.prefix, "posts", id)
+}
+
+// readCommitFile 读取提交中的文件内容
+func readCommitFile(commit *object.Commit, name string) ([]byte, error) {
+	f, err := commit.File(name)
+	if err != nil {
+		if errors.Is(err, object.ErrFileNotFound) {
+			return nil, repository.ErrRevisionNotFound
+		}
+		return nil, err
+	}
+
+	reader, err := f.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
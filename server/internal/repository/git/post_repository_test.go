@@ -0,0 +1,339 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/domain/valueobject"
+	"github.com/next-ai-ventus/server/internal/repository"
+	"github.com/next-ai-ventus/server/internal/repository/file"
+	"github.com/next-ai-ventus/server/internal/repository/repositorytest"
+)
+
+func setupTestRepo(t *testing.T) *GitPostRepository {
+	tmpDir := t.TempDir()
+
+	inner, err := file.NewFilePostRepository(tmpDir)
+	if err != nil {
+		t.Fatalf("create file repository failed: %v", err)
+	}
+
+	repo, err := NewGitPostRepository(inner, tmpDir)
+	if err != nil {
+		t.Fatalf("create git repository failed: %v", err)
+	}
+	return repo
+}
+
+func createTestPost(id, title, slugStr string) *domain.Post {
+	slug, _ := valueobject.NewSlug(slugStr)
+	post, _ := domain.NewPost(id, title, slug, "Test content", nil)
+	return post
+}
+
+func TestGitPostRepository_SaveCommits(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	post := createTestPost("2024-06-hello", "Hello World", "hello-world")
+	if err := repo.SaveAs(post, "alice"); err != nil {
+		t.Fatalf("SaveAs() error = %v", err)
+	}
+
+	if err := post.UpdateContent("Updated content"); err != nil {
+		t.Fatalf("UpdateContent() error = %v", err)
+	}
+	if err := repo.SaveAs(post, "bob"); err != nil {
+		t.Fatalf("SaveAs() error = %v", err)
+	}
+
+	history, err := repo.History(post.ID)
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("len(History()) = %d, want 2", len(history))
+	}
+
+	latest, first := history[0], history[1]
+	if latest.Author != "bob" || first.Author != "alice" {
+		t.Errorf("authors = %q, %q, want bob, alice", latest.Author, first.Author)
+	}
+	if !strings.HasPrefix(first.Message, "Create post 2024-06-hello: Hello World") {
+		t.Errorf("first message = %q", first.Message)
+	}
+	if !strings.Contains(latest.Message, "Editor: bob") {
+		t.Errorf("latest message = %q, want editor line", latest.Message)
+	}
+
+	t.Run("read historical version", func(t *testing.T) {
+		old, err := repo.FindRevision(post.ID, first.Hash)
+		if err != nil {
+			t.Fatalf("FindRevision() error = %v", err)
+		}
+		if old.Content != "Test content" {
+			t.Errorf("Content = %q, want original content", old.Content)
+		}
+	})
+
+	t.Run("unknown revision", func(t *testing.T) {
+		_, err := repo.FindRevision(post.ID, strings.Repeat("0", 40))
+		if err != repository.ErrRevisionNotFound {
+			t.Errorf("FindRevision() error = %v, want ErrRevisionNotFound", err)
+		}
+	})
+}
+
+func TestGitPostRepository_DeleteCommits(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	post := createTestPost("2024-06-bye", "Goodbye", "goodbye")
+	repo.Save(post)
+
+	if err := repo.DeleteAs(post.ID, "alice"); err != nil {
+		t.Fatalf("DeleteAs() error = %v", err)
+	}
+
+	history, err := repo.History(post.ID)
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("len(History()) = %d, want 2", len(history))
+	}
+	if !strings.HasPrefix(history[0].Message, "Delete post 2024-06-bye") {
+		t.Errorf("message = %q", history[0].Message)
+	}
+	if history[1].Author != defaultAuthor {
+		t.Errorf("Author = %q, want %q", history[1].Author, defaultAuthor)
+	}
+
+	// 删除后仍可从历史中读取
+	if _, err := repo.FindRevision(post.ID, history[1].Hash); err != nil {
+		t.Errorf("FindRevision() error = %v", err)
+	}
+	if _, err := repo.FindRevision(post.ID, history[0].Hash); err != repository.ErrRevisionNotFound {
+		t.Errorf("FindRevision() after delete error = %v, want ErrRevisionNotFound", err)
+	}
+}
+
+func TestGitPostRepository_EmptyHistory(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	history, err := repo.History("missing")
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(history) != 0 {
+		t.Errorf("len(History()) = %d, want 0", len(history))
+	}
+}
+
+func TestGitPostRepository_RebuildIndex(t *testing.T) {
+	repo := setupTestRepo(t)
+	if err := repo.Save(createTestPost("p1", "Hello", "hello")); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	if err := repo.RebuildIndex(); err != nil {
+		t.Fatalf("RebuildIndex() error = %v", err)
+	}
+	if post, err := repo.FindBySlug("hello"); err != nil || post.ID != "p1" {
+		t.Errorf("FindBySlug() after rebuild = %v, %v", post, err)
+	}
+
+	bare := &GitPostRepository{PostRepository: repository.NewMemoryPostRepository()}
+	if err := bare.RebuildIndex(); err != repository.ErrRebuildUnsupported {
+		t.Errorf("RebuildIndex() on memory repo error = %v, want ErrRebuildUnsupported", err)
+	}
+}
+
+func TestGitPostRepository_CheckContent(t *testing.T) {
+	tmpDir := t.TempDir()
+	inner, err := file.NewFilePostRepository(tmpDir)
+	if err != nil {
+		t.Fatalf("create file repository failed: %v", err)
+	}
+
+	// 先在未开启版本历史时写入，目录处于未提交状态
+	if err := inner.Save(createTestPost("p1", "Hello", "hello")); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	repo, err := NewGitPostRepository(inner, tmpDir)
+	if err != nil {
+		t.Fatalf("create git repository failed: %v", err)
+	}
+
+	report, err := repo.CheckContent(repository.CheckOptions{})
+	if err != nil {
+		t.Fatalf("CheckContent() error = %v", err)
+	}
+	if len(report.Issues) != 1 || report.Issues[0].Kind != repository.IssueUncommittedPost || report.Issues[0].PostID != "p1" {
+		t.Fatalf("Issues = %+v, want uncommitted p1", report.Issues)
+	}
+
+	if _, err := repo.CheckContent(repository.CheckOptions{Fix: true}); err != nil {
+		t.Fatalf("CheckContent(fix) error = %v", err)
+	}
+	if history, _ := repo.History("p1"); len(history) != 1 || !strings.HasPrefix(history[0].Message, "Sync post p1") {
+		t.Errorf("History() = %+v, want sync commit", history)
+	}
+
+	report, _ = repo.CheckContent(repository.CheckOptions{})
+	if len(report.Issues) != 0 {
+		t.Errorf("Issues after fix = %+v, want none", report.Issues)
+	}
+}
+
+func TestGitPostRepository_WriteLocked(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	// 在另一个目录生成文章文件，模拟从备份恢复
+	srcDir := t.TempDir()
+	src, err := file.NewFilePostRepository(srcDir)
+	if err != nil {
+		t.Fatalf("create file repository failed: %v", err)
+	}
+	if err := src.Save(createTestPost("p1", "Hello", "hello")); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	err = repo.WriteLocked(func() error {
+		for _, name := range []string{"meta.json", "content.md"} {
+			data, err := os.ReadFile(filepath.Join(srcDir, "posts", "p1", name))
+			if err != nil {
+				return err
+			}
+			target := filepath.Join(repo.contentPath, "posts", "p1", name)
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if err := os.WriteFile(target, data, 0644); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WriteLocked() error = %v", err)
+	}
+
+	if post, err := repo.FindBySlug("hello"); err != nil || post.ID != "p1" {
+		t.Errorf("FindBySlug() after restore = %v, %v", post, err)
+	}
+	if history, _ := repo.History("p1"); len(history) != 1 || !strings.HasPrefix(history[0].Message, "Restore post p1") {
+		t.Errorf("History() = %+v, want restore commit", history)
+	}
+
+	bare := &GitPostRepository{PostRepository: repository.NewMemoryPostRepository()}
+	if err := bare.WriteLocked(func() error { return nil }); err != repository.ErrBackupUnsupported {
+		t.Errorf("WriteLocked() on memory repo error = %v, want ErrBackupUnsupported", err)
+	}
+}
+
+func TestGitPostRepository_Contract(t *testing.T) {
+	repositorytest.RunPostRepositoryContract(t, func(t *testing.T) repository.PostRepository {
+		return setupTestRepo(t)
+	})
+}
+
+func TestGitPostRepository_ApplyBatchRollsBackOnCommitFailure(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	kept := createTestPost("p1", "Kept", "kept")
+	removed := createTestPost("p2", "Removed", "removed")
+	for _, post := range []*domain.Post{kept, removed} {
+		if err := repo.Save(post); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	restore := breakCommits(t, repo)
+	updated, _ := repo.FindByID("p1")
+	if err := updated.UpdateContent("Changed"); err != nil {
+		t.Fatalf("UpdateContent() error = %v", err)
+	}
+	batch := repository.Batch{
+		Save:   []*domain.Post{updated, createTestPost("p3", "Created", "created")},
+		Delete: []string{"p2"},
+	}
+	if err := repo.ApplyBatch(batch); err == nil {
+		t.Fatal("ApplyBatch() error = nil, want commit failure")
+	}
+	restore()
+
+	if post, err := repo.FindByID("p1"); err != nil || post.Content != "Test content" {
+		t.Errorf("FindByID(p1) after rollback = %v, %v, want original content", post, err)
+	}
+	if _, err := repo.FindByID("p2"); err != nil {
+		t.Errorf("FindByID(p2) after rollback error = %v, want restored", err)
+	}
+	if _, err := repo.FindByID("p3"); err != repository.ErrPostNotFound {
+		t.Errorf("FindByID(p3) after rollback error = %v, want ErrPostNotFound", err)
+	}
+	if _, err := os.Stat(filepath.Join(repo.contentPath, "posts", "p3")); !os.IsNotExist(err) {
+		t.Errorf("created post directory still on disk: %v", err)
+	}
+}
+
+func TestGitPostRepository_SaveAndDeleteRollBackOnCommitFailure(t *testing.T) {
+	repo := setupTestRepo(t)
+	existing := createTestPost("p1", "Existing", "existing")
+	if err := repo.Save(existing); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	restore := breakCommits(t, repo)
+	updated, _ := repo.FindByID("p1")
+	if err := updated.UpdateContent("Changed"); err != nil {
+		t.Fatalf("UpdateContent() error = %v", err)
+	}
+	if err := repo.SaveAs(updated, "alice"); err == nil {
+		t.Error("SaveAs(update) error = nil, want commit failure")
+	}
+	if err := repo.SaveAs(createTestPost("p2", "Created", "created"), "alice"); err == nil {
+		t.Error("SaveAs(create) error = nil, want commit failure")
+	}
+	if err := repo.DeleteAs("p1", "alice"); err == nil {
+		t.Error("DeleteAs() error = nil, want commit failure")
+	}
+	restore()
+
+	if post, err := repo.FindByID("p1"); err != nil || post.Content != "Test content" {
+		t.Errorf("FindByID(p1) after rollback = %v, %v, want original content", post, err)
+	}
+	if _, err := repo.FindByID("p2"); err != repository.ErrPostNotFound {
+		t.Errorf("FindByID(p2) after rollback error = %v, want ErrPostNotFound", err)
+	}
+
+	// 恢复后重新保存，提交只包含这次的修改
+	if err := repo.SaveAs(updated, "alice"); err != nil {
+		t.Fatalf("SaveAs() after restore error = %v", err)
+	}
+	history, err := repo.History("p1")
+	if err != nil || len(history) != 2 {
+		t.Errorf("History(p1) = %d revisions, %v, want 2", len(history), err)
+	}
+}
+
+// breakCommits 用同名文件替换对象目录，使暂存与提交失败；返回恢复函数
+func breakCommits(t *testing.T, repo *GitPostRepository) func() {
+	t.Helper()
+	objects := filepath.Join(repo.contentPath, ".git", "objects")
+	if err := os.Rename(objects, objects+".bak"); err != nil {
+		t.Fatalf("rename objects failed: %v", err)
+	}
+	if err := os.WriteFile(objects, nil, 0644); err != nil {
+		t.Fatalf("write objects failed: %v", err)
+	}
+	return func() {
+		if err := os.Remove(objects); err != nil {
+			t.Fatalf("remove objects failed: %v", err)
+		}
+		if err := os.Rename(objects+".bak", objects); err != nil {
+			t.Fatalf("restore objects failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrInvalidListParam = errors.New("invalid list parameter")
+
+// ParseListParams 解析管理端文章列表参数（post.list 场景与 AdminPostList 模块共用）
+func ParseListParams(params map[string]interface{}, opts *ListOptions) error {
+	if p, ok := params["page"].(float64); ok {
+		opts.Page = int(p)
+	}
+	if ps, ok := params["pageSize"].(float64); ok {
+		opts.PageSize = int(ps)
+	}
+	if s, ok := params["status"].(string); ok {
+		opts.Status = s
+	}
+	if t, ok := params["tag"].(string); ok {
+		opts.Tag = t
+	}
+	if c, ok := params["cursor"].(string); ok {
+		opts.Cursor = c
+	}
+
+	// 多标签筛选
+	opts.Tags = stringList(params["tags"])
+	opts.ExcludeTags = stringList(params["excludeTags"])
+	if mode, ok := params["tagMode"].(string); ok && mode != "" {
+		if mode != "and" && mode != "or" {
+			return fmt.Errorf("%w: tagMode %q", ErrInvalidListParam, mode)
+		}
+		opts.TagMode = mode
+	}
+
+	// 标题搜索
+	if q, ok := params["title"].(string); ok {
+		opts.TitleQuery = strings.TrimSpace(q)
+	}
+
+	// 排序
+	if orderBy, ok := params["orderBy"].(string); ok && orderBy != "" {
+		if !IsValidOrder(orderBy) {
+			return fmt.Errorf("%w: orderBy %q", ErrInvalidListParam, orderBy)
+		}
+		opts.OrderBy = orderBy
+	}
+
+	// 时间范围
+	ranges := []struct {
+		name string
+		dst  *TimeRange
+	}{
+		{"created", &opts.Created},
+		{"published", &opts.Published},
+		{"updated", &opts.Updated},
+	}
+	for _, r := range ranges {
+		from, err := parseDateParam(params, r.name+"From", false)
+		if err != nil {
+			return err
+		}
+		to, err := parseDateParam(params, r.name+"To", true)
+		if err != nil {
+			return err
+		}
+		r.dst.From, r.dst.To = from, to
+	}
+
+	return nil
+}
+
+// stringList 解析字符串数组参数（兼容逗号分隔的字符串）
+func stringList(v interface{}) []string {
+	var result []string
+	switch list := v.(type) {
+	case []interface{}:
+		for _, item := range list {
+			if s, ok := item.(string); ok && s != "" {
+				result = append(result, s)
+			}
+		}
+	case string:
+		for _, s := range strings.Split(list, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				result = append(result, s)
+			}
+		}
+	}
+	return result
+}
+
+// parseDateParam 解析日期参数，支持 "2006-01-02" 与 RFC3339。
+// 仅有日期的结束时间按当天结束处理，使范围包含整天。
+func parseDateParam(params map[string]interface{}, key string, endOfDay bool) (*time.Time, error) {
+	raw, ok := params[key].(string)
+	if !ok || raw == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.ParseInLocation("2006-01-02", raw, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s %q", ErrInvalidListParam, key, raw)
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return &t, nil
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseListParams(t *testing.T) {
+	opts := ListOptions{Page: 1, PageSize: 10}
+	err := ParseListParams(map[string]interface{}{
+		"page":          float64(2),
+		"tags":          []interface{}{"go", "", "web"},
+		"excludeTags":   "draft, old",
+		"tagMode":       "and",
+		"title":         "  hello ",
+		"orderBy":       "title_asc",
+		"createdFrom":   "2024-01-01",
+		"createdTo":     "2024-01-31",
+		"publishedFrom": "2024-01-01T08:00:00Z",
+	}, &opts)
+	if err != nil {
+		t.Fatalf("ParseListParams() error = %v", err)
+	}
+
+	if opts.Page != 2 || opts.PageSize != 10 || opts.TagMode != "and" || opts.TitleQuery != "hello" || opts.OrderBy != "title_asc" {
+		t.Errorf("opts = %+v", opts)
+	}
+	if len(opts.Tags) != 2 || len(opts.ExcludeTags) != 2 || opts.ExcludeTags[1] != "old" {
+		t.Errorf("Tags = %v, ExcludeTags = %v", opts.Tags, opts.ExcludeTags)
+	}
+	if opts.Created.From == nil || opts.Created.To == nil || opts.Created.To.Day() != 31 || opts.Created.To.Hour() != 23 {
+		t.Errorf("Created = %+v, want the whole of January", opts.Created)
+	}
+	if opts.Published.From == nil || opts.Published.To != nil {
+		t.Errorf("Published = %+v", opts.Published)
+	}
+
+	for _, params := range []map[string]interface{}{
+		{"tagMode": "xor"},
+		{"orderBy": "random"},
+		{"updatedFrom": "yesterday"},
+	} {
+		if err := ParseListParams(params, &ListOptions{}); !errors.Is(err, ErrInvalidListParam) {
+			t.Errorf("ParseListParams(%v) error = %v, want ErrInvalidListParam", params, err)
+		}
+	}
+}
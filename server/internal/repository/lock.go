@@ -0,0 +1,14 @@
+package repository
+
+import "errors"
+
+var ErrBackupUnsupported = errors.New("backup is not supported by repository")
+
+// StorageLocker 允许在锁定状态下直接读写底层存储文件的仓库（用于备份与恢复）
+type StorageLocker interface {
+	// ReadLocked 在禁止写入的状态下执行 fn，期间存储文件保持一致
+	ReadLocked(fn func() error) error
+
+	// WriteLocked 在独占状态下执行 fn（fn 可直接修改存储文件），完成后重新加载索引
+	WriteLocked(fn func() error) error
+}
@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+)
+
+var (
+	ErrSubscriberNotFound = errors.New("subscriber not found")
+	ErrIssueNotFound      = errors.New("newsletter issue not found")
+)
+
+// NewsletterRepository 订阅者与新文章通知仓库接口
+type NewsletterRepository interface {
+	// ListSubscribers 列出全部订阅者（按订阅时间正序）
+	ListSubscribers() ([]*domain.Subscriber, error)
+
+	// FindSubscriber 根据邮箱（小写）查找订阅者
+	FindSubscriber(email string) (*domain.Subscriber, error)
+
+	// FindSubscriberByToken 查找确认令牌或退订令牌为 token 的订阅者
+	FindSubscriberByToken(token string) (*domain.Subscriber, error)
+
+	// SaveSubscriber 保存订阅者（邮箱相同时覆盖）
+	SaveSubscriber(subscriber *domain.Subscriber) error
+
+	// DeleteSubscriber 删除订阅者
+	DeleteSubscriber(email string) error
+
+	// ListIssues 列出全部通知（按创建时间倒序）
+	ListIssues() ([]*domain.NewsletterIssue, error)
+
+	// FindIssue 根据文章 ID 查找通知
+	FindIssue(postID string) (*domain.NewsletterIssue, error)
+
+	// SaveIssue 保存通知（文章 ID 相同时覆盖）
+	SaveIssue(issue *domain.NewsletterIssue) error
+
+	// StorageLocker 备份与恢复时锁定存储，WriteLocked 结束前重新加载持久化的订阅者与通知
+	StorageLocker
+}
+
+// MemoryNewsletterRepository 内存实现的 NewsletterRepository（用于测试）
+type MemoryNewsletterRepository struct {
+	subscribers map[string]*domain.Subscriber
+	issues      map[string]*domain.NewsletterIssue
+	mu          sync.RWMutex
+}
+
+// NewMemoryNewsletterRepository 创建内存订阅仓库
+func NewMemoryNewsletterRepository() *MemoryNewsletterRepository {
+	return &MemoryNewsletterRepository{
+		subscribers: make(map[string]*domain.Subscriber),
+		issues:      make(map[string]*domain.NewsletterIssue),
+	}
+}
+
+// ListSubscribers 列出全部订阅者
+func (r *MemoryNewsletterRepository) ListSubscribers() ([]*domain.Subscriber, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return SortedSubscribers(r.subscribers), nil
+}
+
+// FindSubscriber 根据邮箱查找订阅者
+func (r *MemoryNewsletterRepository) FindSubscriber(email string) (*domain.Subscriber, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	subscriber, ok := r.subscribers[email]
+	if !ok {
+		return nil, ErrSubscriberNotFound
+	}
+	copied := *subscriber
+	return &copied, nil
+}
+
+// FindSubscriberByToken 根据令牌查找订阅者
+func (r *MemoryNewsletterRepository) FindSubscriberByToken(token string) (*domain.Subscriber, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return SubscriberByToken(r.subscribers, token)
+}
+
+// SaveSubscriber 保存订阅者
+func (r *MemoryNewsletterRepository) SaveSubscriber(subscriber *domain.Subscriber) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *subscriber
+	r.subscribers[subscriber.Email] = &copied
+	return nil
+}
+
+// DeleteSubscriber 删除订阅者
+func (r *MemoryNewsletterRepository) DeleteSubscriber(email string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.subscribers[email]; !ok {
+		return ErrSubscriberNotFound
+	}
+	delete(r.subscribers, email)
+	return nil
+}
+
+// ListIssues 列出全部通知
+func (r *MemoryNewsletterRepository) ListIssues() ([]*domain.NewsletterIssue, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return SortedIssues(r.issues), nil
+}
+
+// FindIssue 根据文章 ID 查找通知
+func (r *MemoryNewsletterRepository) FindIssue(postID string) (*domain.NewsletterIssue, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	issue, ok := r.issues[postID]
+	if !ok {
+		return nil, ErrIssueNotFound
+	}
+	return CopyIssue(issue), nil
+}
+
+// SaveIssue 保存通知
+func (r *MemoryNewsletterRepository) SaveIssue(issue *domain.NewsletterIssue) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.issues[issue.PostID] = CopyIssue(issue)
+	return nil
+}
+
+// ReadLocked 持有锁执行 fn
+func (r *MemoryNewsletterRepository) ReadLocked(fn func() error) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return fn()
+}
+
+// WriteLocked 持有写锁执行 fn，内存实现无需重新加载
+func (r *MemoryNewsletterRepository) WriteLocked(fn func() error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return fn()
+}
+
+// CopyIssue 复制通知（含待发送列表）
+func CopyIssue(issue *domain.NewsletterIssue) *domain.NewsletterIssue {
+	copied := *issue
+	copied.Pending = append([]string{}, issue.Pending...)
+	return &copied
+}
+
+// SortedSubscribers 按订阅时间正序返回订阅者的副本
+func SortedSubscribers(subscribers map[string]*domain.Subscriber) []*domain.Subscriber {
+	result := make([]*domain.Subscriber, 0, len(subscribers))
+	for _, subscriber := range subscribers {
+		copied := *subscriber
+		result = append(result, &copied)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.Before(result[j].CreatedAt)
+		}
+		return result[i].Email < result[j].Email
+	})
+	return result
+}
+
+// SortedIssues 按创建时间倒序返回通知的副本
+func SortedIssues(issues map[string]*domain.NewsletterIssue) []*domain.NewsletterIssue {
+	result := make([]*domain.NewsletterIssue, 0, len(issues))
+	for _, issue := range issues {
+		result = append(result, CopyIssue(issue))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.After(result[j].CreatedAt)
+		}
+		return result[i].PostID < result[j].PostID
+	})
+	return result
+}
+
+// SubscriberByToken 查找确认令牌或退订令牌为 token 的订阅者（返回副本）
+func SubscriberByToken(subscribers map[string]*domain.Subscriber, token string) (*domain.Subscriber, error) {
+	if token == "" {
+		return nil, ErrSubscriberNotFound
+	}
+	for _, subscriber := range subscribers {
+		if subscriber.ConfirmToken == token || subscriber.UnsubscribeToken == token {
+			copied := *subscriber
+			return &copied, nil
+		}
+	}
+	return nil, ErrSubscriberNotFound
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"errors"
 	"sync"
+	"time"
 
 	"github.com/next-ai-ventus/server/internal/domain"
 	"github.com/next-ai-ventus/server/internal/domain/valueobject"
@@ -15,11 +16,41 @@ var (
 
 // ListOptions 文章列表查询选项
 type ListOptions struct {
-	Page     int
-	PageSize int
-	Tag      string
-	Status   string // "", "draft", "published"
-	OrderBy  string // "date_desc", "date_asc"
+	Page        int
+	PageSize    int
+	Tag         string
+	Tags        []string // 多标签筛选，匹配方式由 TagMode 决定
+	TagMode     string   // "and"（默认，需包含全部标签）, "or"（包含任一标签）
+	ExcludeTags []string // 排除含有这些标签的文章
+	Status      string   // "", "draft", "published"
+	TitleQuery  string   // 标题子串（不区分大小写）
+	Created     TimeRange
+	Published   TimeRange // 设置后未发布的文章不会匹配
+	Updated     TimeRange
+	OrderBy     string // "date_desc", "date_asc", "updated_desc", "updated_asc", "published_desc", "published_asc", "title_asc", "title_desc"
+	Cursor      string // 键集分页游标（来自上一次结果的 NextCursor/PrevCursor），设置后忽略 Page
+}
+
+// TimeRange 时间范围（闭区间，From/To 为 nil 表示不限）
+type TimeRange struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// IsZero 判断是否未设置任何边界
+func (r TimeRange) IsZero() bool {
+	return r.From == nil && r.To == nil
+}
+
+// Contains 判断时间是否在范围内
+func (r TimeRange) Contains(t time.Time) bool {
+	if r.From != nil && t.Before(*r.From) {
+		return false
+	}
+	if r.To != nil && t.After(*r.To) {
+		return false
+	}
+	return true
 }
 
 // CountOptions 文章计数选项
@@ -29,11 +60,14 @@ type CountOptions struct {
 
 // PaginatedResult 分页结果
 type PaginatedResult struct {
-	Items      []*domain.Post
-	Total      int
+	Items      []*domain.PostSummary
+	Total      int // 游标分页时不统计，为 0
 	Page       int
 	PageSize   int
-	TotalPages int
+	TotalPages int // 游标分页时不统计，为 0
+	NextCursor string
+	PrevCursor string
+	HasMore    bool
 }
 
 // PostRepository 文章仓库接口
@@ -44,11 +78,11 @@ type PostRepository interface {
 	// FindBySlug 根据 Slug 查找文章
 	FindBySlug(slug string) (*domain.Post, error)
 
-	// FindAll 查询文章列表（支持分页、标签、状态筛选）
+	// FindAll 查询文章列表（支持分页、标签、状态筛选），只返回摘要，不含正文
 	FindAll(opts ListOptions) (*PaginatedResult, error)
 
 	// FindByTag 根据标签查找文章（不分页，用于索引）
-	FindByTag(tag string) ([]*domain.Post, error)
+	FindByTag(tag string) ([]*domain.PostSummary, error)
 
 	// FindAllTags 获取所有标签列表
 	FindAllTags() ([]string, error)
@@ -94,7 +128,7 @@ func (r *MemoryPostRepository) FindByID(id string) (*domain.Post, error) {
 	if !ok {
 		return nil, ErrPostNotFound
 	}
-	return post, nil
+	return copyPost(post), nil
 }
 
 // FindBySlug 根据 Slug 查找文章
@@ -106,7 +140,7 @@ func (r *MemoryPostRepository) FindBySlug(slug string) (*domain.Post, error) {
 	if !ok {
 		return nil, ErrPostNotFound
 	}
-	return r.posts[id], nil
+	return copyPost(r.posts[id]), nil
 }
 
 // FindAll 查询文章列表
@@ -114,107 +148,37 @@ func (r *MemoryPostRepository) FindAll(opts ListOptions) (*PaginatedResult, erro
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	// 设置默认值
-	if opts.Page <= 0 {
-		opts.Page = 1
-	}
-	if opts.PageSize <= 0 {
-		opts.PageSize = 10
-	}
-	if opts.OrderBy == "" {
-		opts.OrderBy = "date_desc"
-	}
-
 	// 筛选文章
-	var filtered []*domain.Post
+	var filtered []*domain.PostSummary
 	for _, post := range r.posts {
-		// 状态筛选
-		if opts.Status != "" && post.Status.String() != opts.Status {
-			continue
-		}
-		// 标签筛选
-		if opts.Tag != "" && !post.HasTag(opts.Tag) {
-			continue
+		if summary := post.Summary(); MatchesListOptions(summary, opts) {
+			filtered = append(filtered, summary)
 		}
-		filtered = append(filtered, post)
-	}
-
-	// 排序
-	if opts.OrderBy == "date_desc" {
-		// 按创建时间倒序
-		for i := 0; i < len(filtered)-1; i++ {
-			for j := i + 1; j < len(filtered); j++ {
-				if filtered[i].CreatedAt.Before(filtered[j].CreatedAt) {
-					filtered[i], filtered[j] = filtered[j], filtered[i]
-				}
-			}
-		}
-	} else if opts.OrderBy == "date_asc" {
-		// 按创建时间正序
-		for i := 0; i < len(filtered)-1; i++ {
-			for j := i + 1; j < len(filtered); j++ {
-				if filtered[i].CreatedAt.After(filtered[j].CreatedAt) {
-					filtered[i], filtered[j] = filtered[j], filtered[i]
-				}
-			}
-		}
-	}
-
-	// 分页
-	total := len(filtered)
-	totalPages := (total + opts.PageSize - 1) / opts.PageSize
-	if totalPages < 1 {
-		totalPages = 1
-	}
-
-	start := (opts.Page - 1) * opts.PageSize
-	end := start + opts.PageSize
-	if start > total {
-		start = total
-	}
-	if end > total {
-		end = total
 	}
 
-	var items []*domain.Post
-	if start < total {
-		items = filtered[start:end]
-	}
-
-	return &PaginatedResult{
-		Items:      items,
-		Total:      total,
-		Page:       opts.Page,
-		PageSize:   opts.PageSize,
-		TotalPages: totalPages,
-	}, nil
+	// 排序与分页
+	return Paginate(filtered, opts)
 }
 
 // FindByTag 根据标签查找文章
-func (r *MemoryPostRepository) FindByTag(tag string) ([]*domain.Post, error) {
+func (r *MemoryPostRepository) FindByTag(tag string) ([]*domain.PostSummary, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	ids, ok := r.tagIndex[tag]
 	if !ok || len(ids) == 0 {
-		return []*domain.Post{}, nil
+		return []*domain.PostSummary{}, nil
 	}
 
-	var posts []*domain.Post
+	var posts []*domain.PostSummary
 	for id := range ids {
 		if post, ok := r.posts[id]; ok {
-			posts = append(posts, post)
+			posts = append(posts, post.Summary())
 		}
 	}
 
 	// 按时间倒序排序
-	for i := 0; i < len(posts)-1; i++ {
-		for j := i + 1; j < len(posts); j++ {
-			if posts[i].CreatedAt.Before(posts[j].CreatedAt) {
-				posts[i], posts[j] = posts[j], posts[i]
-			}
-		}
-	}
+	SortPosts(posts, "date_desc")
 
 	return posts, nil
 }
@@ -258,6 +222,12 @@ func (r *MemoryPostRepository) Save(post *domain.Post) error {
 		return ErrSlugExists
 	}
 
+	r.saveLocked(post)
+	return nil
+}
+
+// saveLocked 保存文章并更新索引（调用方需持有写锁并已检查 slug 冲突）
+func (r *MemoryPostRepository) saveLocked(post *domain.Post) {
 	// 更新 slug 和标签索引
 	if oldPost, ok := r.posts[post.ID]; ok {
 		// 保存旧的 slug 和标签（从存储的旧对象读取）
@@ -265,8 +235,8 @@ func (r *MemoryPostRepository) Save(post *domain.Post) error {
 		oldTags := make([]valueobject.Tag, len(oldPost.Tags))
 		copy(oldTags, oldPost.Tags)
 
-		// 删除旧 slug（如果不同）
-		if oldSlugStr != post.Slug.String() {
+		// 删除旧 slug（如果不同，且未被同一批次中的其他文章占用）
+		if oldSlugStr != post.Slug.String() && r.slugIndex[oldSlugStr] == post.ID {
 			delete(r.slugIndex, oldSlugStr)
 		}
 		// 删除旧标签索引
@@ -281,7 +251,6 @@ func (r *MemoryPostRepository) Save(post *domain.Post) error {
 	r.addToTagIndex(post.ID, post.Tags)
 
 	r.version++
-	return nil
 }
 
 // Delete 删除文章
@@ -289,17 +258,51 @@ func (r *MemoryPostRepository) Delete(id string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	post, ok := r.posts[id]
-	if !ok {
+	if _, ok := r.posts[id]; !ok {
 		return ErrPostNotFound
 	}
 
+	r.deleteLocked(id)
+	return nil
+}
+
+// deleteLocked 删除文章及其索引（调用方需持有写锁）
+func (r *MemoryPostRepository) deleteLocked(id string) {
+	post := r.posts[id]
+
 	// 删除索引
 	delete(r.slugIndex, post.Slug.String())
 	r.removeFromTagIndex(id, post.Tags)
 	delete(r.posts, id)
 
 	r.version++
+}
+
+// ApplyBatch 原子地应用一组写操作（校验通过后才修改内存）
+func (r *MemoryPostRepository) ApplyBatch(batch Batch) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := CheckBatch(batch,
+		func(id string) (int, bool) {
+			if post, ok := r.posts[id]; ok {
+				return post.Version, true
+			}
+			return 0, false
+		},
+		func(slug string) (string, bool) { id, ok := r.slugIndex[slug]; return id, ok },
+	)
+	if err != nil {
+		return err
+	}
+
+	// 先删除，释放的 slug 可被同一批次中的文章使用
+	for _, id := range batch.Delete {
+		r.deleteLocked(id)
+	}
+	for _, post := range batch.Save {
+		r.saveLocked(post)
+	}
 	return nil
 }
 
@@ -361,17 +364,19 @@ func copyPost(post *domain.Post) *domain.Post {
 	copy(tags, post.Tags)
 
 	return &domain.Post{
-		ID:          post.ID,
-		Title:       post.Title,
-		Slug:        post.Slug,
-		Content:     post.Content,
-		Excerpt:     post.Excerpt,
-		Tags:        tags,
-		Status:      post.Status,
-		CreatedAt:   post.CreatedAt,
-		UpdatedAt:   post.UpdatedAt,
-		PublishedAt: post.PublishedAt,
-		Version:     post.Version,
-		Cover:       post.Cover,
+		ID:             post.ID,
+		Title:          post.Title,
+		Slug:           post.Slug,
+		Content:        post.Content,
+		Excerpt:        post.Excerpt,
+		Tags:           tags,
+		Status:         post.Status,
+		CreatedAt:      post.CreatedAt,
+		UpdatedAt:      post.UpdatedAt,
+		PublishedAt:    post.PublishedAt,
+		Version:        post.Version,
+		Cover:          post.Cover,
+		Category:       post.Category,
+		CommentsClosed: post.CommentsClosed,
 	}
 }
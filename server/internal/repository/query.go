@@ -0,0 +1,306 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// 游标方向
+const (
+	cursorNext = "next"
+	cursorPrev = "prev"
+)
+
+// sortKeyTimeLayout 定宽时间格式，保证字符串比较与时间先后一致
+const sortKeyTimeLayout = "20060102T150405.000000000"
+
+// pageCursor 游标内容：排序方式 + 排序键 + 文章 ID
+type pageCursor struct {
+	OrderBy string `json:"o"`
+	Key     string `json:"k"`
+	ID      string `json:"id"`
+	Dir     string `json:"d"`
+}
+
+// validOrders 支持的排序方式
+var validOrders = map[string]bool{
+	"date_desc":      true,
+	"date_asc":       true,
+	"updated_desc":   true,
+	"updated_asc":    true,
+	"published_desc": true,
+	"published_asc":  true,
+	"title_asc":      true,
+	"title_desc":     true,
+}
+
+// IsValidOrder 判断排序方式是否受支持
+func IsValidOrder(orderBy string) bool {
+	return validOrders[orderBy]
+}
+
+// MatchesListOptions 判断文章是否满足列表筛选条件（不含分页与排序）
+func MatchesListOptions(post *domain.PostSummary, opts ListOptions) bool {
+	// 状态筛选
+	if opts.Status != "" && post.Status.String() != opts.Status {
+		return false
+	}
+
+	// 标签筛选
+	if opts.Tag != "" && !post.HasTag(opts.Tag) {
+		return false
+	}
+	if len(opts.Tags) > 0 {
+		if opts.TagMode == "or" {
+			if !hasAnyTag(post, opts.Tags) {
+				return false
+			}
+		} else {
+			for _, tag := range opts.Tags {
+				if !post.HasTag(tag) {
+					return false
+				}
+			}
+		}
+	}
+	if hasAnyTag(post, opts.ExcludeTags) {
+		return false
+	}
+
+	// 标题子串
+	if opts.TitleQuery != "" &&
+		!strings.Contains(strings.ToLower(post.Title), strings.ToLower(opts.TitleQuery)) {
+		return false
+	}
+
+	// 时间范围
+	if !opts.Created.Contains(post.CreatedAt) || !opts.Updated.Contains(post.UpdatedAt) {
+		return false
+	}
+	if !opts.Published.IsZero() {
+		if post.PublishedAt == nil || !opts.Published.Contains(*post.PublishedAt) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hasAnyTag 判断文章是否包含任一标签
+func hasAnyTag(post *domain.PostSummary, tags []string) bool {
+	for _, tag := range tags {
+		if post.HasTag(tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedPost 带排序键的文章
+type sortedPost struct {
+	key  string
+	post *domain.PostSummary
+}
+
+// SortPosts 按排序方式对文章排序（同键时按 ID 排序，保证顺序稳定）
+func SortPosts(posts []*domain.PostSummary, orderBy string) {
+	sorted := sortByOrder(posts, orderBy)
+	for i, sp := range sorted {
+		posts[i] = sp.post
+	}
+}
+
+// Paginate 对已筛选的文章排序并分页。
+// 提供 Cursor 时使用键集分页，此时不统计 Total/TotalPages。
+func Paginate(posts []*domain.PostSummary, opts ListOptions) (*PaginatedResult, error) {
+	// 设置默认值
+	if opts.Page <= 0 {
+		opts.Page = 1
+	}
+	if opts.PageSize <= 0 {
+		opts.PageSize = 10
+	}
+	if opts.OrderBy == "" {
+		opts.OrderBy = "date_desc"
+	}
+
+	sorted := sortByOrder(posts, opts.OrderBy)
+
+	if opts.Cursor != "" {
+		return paginateByCursor(sorted, opts)
+	}
+
+	// 分页
+	total := len(sorted)
+	totalPages := (total + opts.PageSize - 1) / opts.PageSize
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	start := (opts.Page - 1) * opts.PageSize
+	end := start + opts.PageSize
+	if start > total {
+		start = total
+	}
+	if end > total {
+		end = total
+	}
+
+	result := &PaginatedResult{
+		Items:      collectPosts(sorted[start:end]),
+		Total:      total,
+		Page:       opts.Page,
+		PageSize:   opts.PageSize,
+		TotalPages: totalPages,
+		HasMore:    end < total,
+	}
+	fillCursors(result, sorted, start, end, opts.OrderBy)
+	return result, nil
+}
+
+// paginateByCursor 键集分页：从游标位置向后（或向前）取一页
+func paginateByCursor(sorted []sortedPost, opts ListOptions) (*PaginatedResult, error) {
+	cur, err := decodeCursor(opts.Cursor)
+	if err != nil || cur.OrderBy != opts.OrderBy {
+		return nil, ErrInvalidCursor
+	}
+
+	desc := isDescOrder(opts.OrderBy)
+	// 第一个排在游标之后的位置
+	pos := sort.Search(len(sorted), func(i int) bool {
+		return comesAfter(sorted[i].key, sorted[i].post.ID, cur.Key, cur.ID, desc)
+	})
+
+	var start, end int
+	switch cur.Dir {
+	case cursorNext:
+		start = pos
+		end = start + opts.PageSize
+		if end > len(sorted) {
+			end = len(sorted)
+		}
+	case cursorPrev:
+		// 排在游标之前的元素（不含游标本身）
+		end = pos
+		if end > 0 && sorted[end-1].post.ID == cur.ID && sorted[end-1].key == cur.Key {
+			end--
+		}
+		start = end - opts.PageSize
+		if start < 0 {
+			start = 0
+		}
+	default:
+		return nil, ErrInvalidCursor
+	}
+
+	result := &PaginatedResult{
+		Items:    collectPosts(sorted[start:end]),
+		Page:     opts.Page,
+		PageSize: opts.PageSize,
+		HasMore:  end < len(sorted),
+	}
+	fillCursors(result, sorted, start, end, opts.OrderBy)
+	return result, nil
+}
+
+// fillCursors 根据当前页在有序列表中的位置生成前后游标
+func fillCursors(result *PaginatedResult, sorted []sortedPost, start, end int, orderBy string) {
+	if start >= end {
+		return
+	}
+	if end < len(sorted) {
+		result.NextCursor = encodeCursor(pageCursor{OrderBy: orderBy, Key: sorted[end-1].key, ID: sorted[end-1].post.ID, Dir: cursorNext})
+	}
+	if start > 0 {
+		result.PrevCursor = encodeCursor(pageCursor{OrderBy: orderBy, Key: sorted[start].key, ID: sorted[start].post.ID, Dir: cursorPrev})
+	}
+}
+
+// sortByOrder 计算排序键并排序
+func sortByOrder(posts []*domain.PostSummary, orderBy string) []sortedPost {
+	sorted := make([]sortedPost, len(posts))
+	for i, post := range posts {
+		sorted[i] = sortedPost{key: sortKey(post, orderBy), post: post}
+	}
+
+	desc := isDescOrder(orderBy)
+	sort.Slice(sorted, func(i, j int) bool {
+		return comesAfter(sorted[j].key, sorted[j].post.ID, sorted[i].key, sorted[i].post.ID, desc)
+	})
+	return sorted
+}
+
+// sortKey 返回文章在指定排序方式下的排序键
+func sortKey(post *domain.PostSummary, orderBy string) string {
+	switch orderBy {
+	case "updated_desc", "updated_asc":
+		return post.UpdatedAt.UTC().Format(sortKeyTimeLayout)
+	case "published_desc", "published_asc":
+		// 未发布的文章键为空：倒序时排在最后，正序时排在最前
+		if post.PublishedAt == nil {
+			return ""
+		}
+		return post.PublishedAt.UTC().Format(sortKeyTimeLayout)
+	case "title_asc", "title_desc":
+		return strings.ToLower(post.Title)
+	default:
+		return post.CreatedAt.UTC().Format(sortKeyTimeLayout)
+	}
+}
+
+// isDescOrder 判断是否为倒序（未知排序方式按默认的 date_desc 处理）
+func isDescOrder(orderBy string) bool {
+	return !strings.HasSuffix(orderBy, "_asc")
+}
+
+// comesAfter 判断 (key, id) 在排序中是否位于 (refKey, refID) 之后
+func comesAfter(key, id, refKey, refID string, desc bool) bool {
+	if key != refKey {
+		if desc {
+			return key < refKey
+		}
+		return key > refKey
+	}
+	if desc {
+		return id < refID
+	}
+	return id > refID
+}
+
+// collectPosts 提取文章列表
+func collectPosts(sorted []sortedPost) []*domain.PostSummary {
+	if len(sorted) == 0 {
+		return nil
+	}
+	posts := make([]*domain.PostSummary, len(sorted))
+	for i, sp := range sorted {
+		posts[i] = sp.post
+	}
+	return posts
+}
+
+// encodeCursor 将游标编码为不透明字符串
+func encodeCursor(cur pageCursor) string {
+	data, _ := json.Marshal(cur)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor 解析游标字符串
+func decodeCursor(s string) (pageCursor, error) {
+	var cur pageCursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cur, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(data, &cur); err != nil {
+		return cur, ErrInvalidCursor
+	}
+	return cur, nil
+}
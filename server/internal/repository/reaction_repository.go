@@ -0,0 +1,103 @@
+package repository
+
+import "sync"
+
+// PostReactions 单篇文章的表情回应
+type PostReactions struct {
+	Counts   map[string]int      `json:"counts"`   // 表情 -> 数量
+	Visitors map[string][]string `json:"visitors"` // 访客哈希 -> 已回应的表情，用于去重
+}
+
+// ReactionRepository 表情回应仓库接口
+type ReactionRepository interface {
+	// LoadReactions 读取全部文章的表情回应（postID -> 回应）
+	LoadReactions() (map[string]*PostReactions, error)
+
+	// SaveReactions 覆盖保存一篇文章的表情回应
+	SaveReactions(postID string, reactions *PostReactions) error
+
+	// DeleteReactions 删除一篇文章的表情回应
+	DeleteReactions(postID string) error
+
+	// StorageLocker 备份与恢复时锁定存储
+	StorageLocker
+}
+
+// MemoryReactionRepository 内存实现的 ReactionRepository（用于测试）
+type MemoryReactionRepository struct {
+	reactions map[string]*PostReactions
+	mu        sync.Mutex
+}
+
+// NewMemoryReactionRepository 创建内存表情回应仓库
+func NewMemoryReactionRepository() *MemoryReactionRepository {
+	return &MemoryReactionRepository{
+		reactions: make(map[string]*PostReactions),
+	}
+}
+
+// LoadReactions 读取全部表情回应（返回副本）
+func (r *MemoryReactionRepository) LoadReactions() (map[string]*PostReactions, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make(map[string]*PostReactions, len(r.reactions))
+	for postID, reactions := range r.reactions {
+		result[postID] = reactions.Clone()
+	}
+	return result, nil
+}
+
+// SaveReactions 保存一篇文章的表情回应
+func (r *MemoryReactionRepository) SaveReactions(postID string, reactions *PostReactions) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reactions[postID] = reactions.Clone()
+	return nil
+}
+
+// DeleteReactions 删除一篇文章的表情回应
+func (r *MemoryReactionRepository) DeleteReactions(postID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.reactions, postID)
+	return nil
+}
+
+// ReadLocked 持有锁执行 fn
+func (r *MemoryReactionRepository) ReadLocked(fn func() error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return fn()
+}
+
+// WriteLocked 持有锁执行 fn
+func (r *MemoryReactionRepository) WriteLocked(fn func() error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return fn()
+}
+
+// NewPostReactions 创建空的表情回应
+func NewPostReactions() *PostReactions {
+	return &PostReactions{
+		Counts:   make(map[string]int),
+		Visitors: make(map[string][]string),
+	}
+}
+
+// Clone 深拷贝表情回应
+func (p *PostReactions) Clone() *PostReactions {
+	copied := NewPostReactions()
+	for reaction, count := range p.Counts {
+		copied.Counts[reaction] = count
+	}
+	for visitor, reactions := range p.Visitors {
+		copied.Visitors[visitor] = append([]string{}, reactions...)
+	}
+	return copied
+}
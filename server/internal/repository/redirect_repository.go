@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+)
+
+var ErrRedirectNotFound = errors.New("redirect not found")
+
+// RedirectRepository 重定向仓库接口
+type RedirectRepository interface {
+	// Find 根据规范化后的旧路径查找重定向
+	Find(from string) (*domain.Redirect, error)
+
+	// FindByPost 查找指向某篇文章的所有重定向（按旧路径排序）
+	FindByPost(postID string) ([]*domain.Redirect, error)
+
+	// Save 保存重定向（旧路径相同时覆盖）
+	Save(redirect *domain.Redirect) error
+
+	// Delete 删除重定向
+	Delete(from string) error
+
+	// StorageLocker 备份与恢复时锁定存储，WriteLocked 结束前重新加载持久化的重定向
+	StorageLocker
+}
+
+// MemoryRedirectRepository 内存实现的 RedirectRepository（用于测试）
+type MemoryRedirectRepository struct {
+	redirects map[string]*domain.Redirect // from -> redirect
+	mu        sync.RWMutex
+}
+
+// NewMemoryRedirectRepository 创建内存重定向仓库
+func NewMemoryRedirectRepository() *MemoryRedirectRepository {
+	return &MemoryRedirectRepository{
+		redirects: make(map[string]*domain.Redirect),
+	}
+}
+
+// Find 根据旧路径查找重定向
+func (r *MemoryRedirectRepository) Find(from string) (*domain.Redirect, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	redirect, ok := r.redirects[from]
+	if !ok {
+		return nil, ErrRedirectNotFound
+	}
+	copied := *redirect
+	return &copied, nil
+}
+
+// FindByPost 查找指向某篇文章的所有重定向
+func (r *MemoryRedirectRepository) FindByPost(postID string) ([]*domain.Redirect, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return RedirectsToPost(r.redirects, postID), nil
+}
+
+// Save 保存重定向
+func (r *MemoryRedirectRepository) Save(redirect *domain.Redirect) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *redirect
+	r.redirects[redirect.From] = &copied
+	return nil
+}
+
+// Delete 删除重定向
+func (r *MemoryRedirectRepository) Delete(from string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.redirects[from]; !ok {
+		return ErrRedirectNotFound
+	}
+	delete(r.redirects, from)
+	return nil
+}
+
+// ReadLocked 持有锁执行 fn
+func (r *MemoryRedirectRepository) ReadLocked(fn func() error) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return fn()
+}
+
+// WriteLocked 持有写锁执行 fn，内存实现无需重新加载
+func (r *MemoryRedirectRepository) WriteLocked(fn func() error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return fn()
+}
+
+// RedirectsToPost 从重定向表中筛选指向 postID 的条目（返回副本，按旧路径排序）
+func RedirectsToPost(redirects map[string]*domain.Redirect, postID string) []*domain.Redirect {
+	var result []*domain.Redirect
+	for _, redirect := range redirects {
+		if redirect.PostID == postID {
+			copied := *redirect
+			result = append(result, &copied)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].From < result[j].From })
+	return result
+}
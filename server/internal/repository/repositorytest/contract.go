@@ -0,0 +1,671 @@
+// Package repositorytest 提供各仓库实现共用的一致性测试，只应被 _test.go 文件导入
+package repositorytest
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/domain/valueobject"
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+// RunPostRepositoryContract 对任意 PostRepository 实现运行一致性测试。
+// newRepo 每次调用都必须返回一个全新的空仓库。
+func RunPostRepositoryContract(t *testing.T, newRepo func(t *testing.T) repository.PostRepository) {
+	t.Run("CopySemantics", func(t *testing.T) { contractCopySemantics(t, newRepo(t)) })
+	t.Run("Content", func(t *testing.T) { contractContent(t, newRepo(t)) })
+	t.Run("SlugIndex", func(t *testing.T) { contractSlugIndex(t, newRepo(t)) })
+	t.Run("TagIndex", func(t *testing.T) { contractTagIndex(t, newRepo(t)) })
+	t.Run("Delete", func(t *testing.T) { contractDelete(t, newRepo(t)) })
+	t.Run("Pagination", func(t *testing.T) { contractPagination(t, newRepo(t)) })
+	t.Run("Cursor", func(t *testing.T) { contractCursor(t, newRepo(t)) })
+	t.Run("Ordering", func(t *testing.T) { contractOrdering(t, newRepo(t)) })
+	t.Run("Filtering", func(t *testing.T) { contractFiltering(t, newRepo(t)) })
+	t.Run("RichQuery", func(t *testing.T) { contractRichQuery(t, newRepo(t)) })
+	t.Run("Concurrency", func(t *testing.T) { contractConcurrency(t, newRepo(t)) })
+	t.Run("Batch", func(t *testing.T) { contractBatch(t, newRepo(t)) })
+}
+
+// contractPost 创建测试用文章
+func contractPost(t *testing.T, id, slugStr string, createdAt time.Time, tags ...string) *domain.Post {
+	t.Helper()
+
+	slug, err := valueobject.NewSlug(slugStr)
+	if err != nil {
+		t.Fatalf("NewSlug(%q) error = %v", slugStr, err)
+	}
+
+	tagValues := make([]valueobject.Tag, 0, len(tags))
+	for _, name := range tags {
+		tag, err := valueobject.NewTag(name)
+		if err != nil {
+			t.Fatalf("NewTag(%q) error = %v", name, err)
+		}
+		tagValues = append(tagValues, tag)
+	}
+
+	post, err := domain.NewPost(id, "Title "+id, slug, "Content of "+id, tagValues)
+	if err != nil {
+		t.Fatalf("NewPost() error = %v", err)
+	}
+	post.CreatedAt = createdAt
+	post.UpdatedAt = createdAt
+	return post
+}
+
+// contractSave 保存文章，失败时终止测试
+func contractSave(t *testing.T, repo repository.PostRepository, posts ...*domain.Post) {
+	t.Helper()
+	for _, post := range posts {
+		if err := repo.Save(post); err != nil {
+			t.Fatalf("Save(%s) error = %v", post.ID, err)
+		}
+	}
+}
+
+// contractIDs 提取文章 ID 列表
+func contractIDs(posts []*domain.PostSummary) []string {
+	ids := make([]string, len(posts))
+	for i, post := range posts {
+		ids[i] = post.ID
+	}
+	return ids
+}
+
+var contractBaseTime = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func contractCopySemantics(t *testing.T, repo repository.PostRepository) {
+	post := contractPost(t, "p1", "copy-post", contractBaseTime, "go")
+	contractSave(t, repo, post)
+
+	// 保存后修改调用方持有的对象，不应影响存储
+	post.Title = "mutated after save"
+	post.Tags[0], _ = valueobject.NewTag("mutated")
+
+	found, err := repo.FindByID("p1")
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found.Title != "Title p1" || found.Tags[0].String() != "go" {
+		t.Errorf("stored post changed by caller mutation: title=%q tags=%v", found.Title, found.GetTagNames())
+	}
+
+	// 修改查询结果，不应影响存储
+	found.Title = "mutated after find"
+	found.Tags[0], _ = valueobject.NewTag("mutated")
+
+	if again, _ := repo.FindByID("p1"); again.Title != "Title p1" || again.Tags[0].String() != "go" {
+		t.Errorf("FindByID() returned shared state: title=%q", again.Title)
+	}
+
+	bySlug, err := repo.FindBySlug("copy-post")
+	if err != nil {
+		t.Fatalf("FindBySlug() error = %v", err)
+	}
+	bySlug.Title = "mutated via slug"
+
+	list, err := repo.FindAll(repository.ListOptions{})
+	if err != nil {
+		t.Fatalf("FindAll() error = %v", err)
+	}
+	list.Items[0].Title = "mutated via list"
+
+	byTag, err := repo.FindByTag("go")
+	if err != nil {
+		t.Fatalf("FindByTag() error = %v", err)
+	}
+	byTag[0].Title = "mutated via tag"
+
+	if again, _ := repo.FindByID("p1"); again.Title != "Title p1" {
+		t.Errorf("query results share state with storage: title=%q", again.Title)
+	}
+}
+
+func contractContent(t *testing.T, repo repository.PostRepository) {
+	post := contractPost(t, "p1", "with-content", contractBaseTime)
+	contractSave(t, repo, post)
+
+	// 列表只返回摘要，正文通过 FindByID/FindBySlug 加载
+	list, err := repo.FindAll(repository.ListOptions{})
+	if err != nil || len(list.Items) != 1 {
+		t.Fatalf("FindAll() = %v, %v", list, err)
+	}
+	if list.Items[0].Excerpt != post.Excerpt {
+		t.Errorf("summary Excerpt = %q, want %q", list.Items[0].Excerpt, post.Excerpt)
+	}
+	if found, err := repo.FindByID("p1"); err != nil || found.Content != "Content of p1" {
+		t.Errorf("FindByID() = %v, %v", found, err)
+	}
+
+	if err := post.UpdateContent("Updated body"); err != nil {
+		t.Fatalf("UpdateContent() error = %v", err)
+	}
+	contractSave(t, repo, post)
+
+	if found, err := repo.FindByID("p1"); err != nil || found.Content != "Updated body" {
+		t.Errorf("FindByID() after update = %v, %v", found, err)
+	}
+	if found, err := repo.FindBySlug("with-content"); err != nil || found.Content != "Updated body" {
+		t.Errorf("FindBySlug() after update = %v, %v", found, err)
+	}
+}
+
+func contractSlugIndex(t *testing.T, repo repository.PostRepository) {
+	post := contractPost(t, "p1", "old-slug", contractBaseTime)
+	other := contractPost(t, "p2", "other-slug", contractBaseTime)
+	contractSave(t, repo, post, other)
+
+	// 重命名 slug
+	post.Slug, _ = valueobject.NewSlug("new-slug")
+	contractSave(t, repo, post)
+
+	if _, err := repo.FindBySlug("old-slug"); !errors.Is(err, repository.ErrPostNotFound) {
+		t.Errorf("FindBySlug(old) error = %v, want repository.ErrPostNotFound", err)
+	}
+	if exists, _ := repo.Exists("old-slug"); exists {
+		t.Error("Exists(old) = true after rename")
+	}
+	if found, err := repo.FindBySlug("new-slug"); err != nil || found.ID != "p1" {
+		t.Errorf("FindBySlug(new) = %v, %v", found, err)
+	}
+
+	// 冲突的 slug 必须被拒绝，且不影响原有索引
+	other.Slug, _ = valueobject.NewSlug("new-slug")
+	if err := repo.Save(other); !errors.Is(err, repository.ErrSlugExists) {
+		t.Errorf("Save() with taken slug error = %v, want repository.ErrSlugExists", err)
+	}
+	if found, err := repo.FindBySlug("other-slug"); err != nil || found.ID != "p2" {
+		t.Errorf("FindBySlug(other) after rejected save = %v, %v", found, err)
+	}
+	if found, err := repo.FindBySlug("new-slug"); err != nil || found.ID != "p1" {
+		t.Errorf("FindBySlug(new) after rejected save = %v, %v", found, err)
+	}
+}
+
+func contractTagIndex(t *testing.T, repo repository.PostRepository) {
+	post := contractPost(t, "p1", "tagged", contractBaseTime, "go", "web")
+	contractSave(t, repo, post)
+
+	rust, _ := valueobject.NewTag("rust")
+	post.UpdateTags([]valueobject.Tag{rust})
+	contractSave(t, repo, post)
+
+	if posts, _ := repo.FindByTag("go"); len(posts) != 0 {
+		t.Errorf("FindByTag(go) = %v, want empty after retag", contractIDs(posts))
+	}
+	if posts, _ := repo.FindByTag("rust"); len(posts) != 1 {
+		t.Errorf("FindByTag(rust) len = %d, want 1", len(posts))
+	}
+
+	tags, err := repo.FindAllTags()
+	if err != nil {
+		t.Fatalf("FindAllTags() error = %v", err)
+	}
+	if fmt.Sprint(tags) != "[rust]" {
+		t.Errorf("FindAllTags() = %v, want [rust]", tags)
+	}
+
+	result, _ := repo.FindAll(repository.ListOptions{Tag: "web"})
+	if result.Total != 0 {
+		t.Errorf("FindAll(tag=web).Total = %d, want 0", result.Total)
+	}
+}
+
+func contractDelete(t *testing.T, repo repository.PostRepository) {
+	post := contractPost(t, "p1", "to-delete", contractBaseTime, "go")
+	contractSave(t, repo, post)
+
+	if err := repo.Delete("p1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := repo.Delete("p1"); !errors.Is(err, repository.ErrPostNotFound) {
+		t.Errorf("second Delete() error = %v, want repository.ErrPostNotFound", err)
+	}
+
+	if _, err := repo.FindByID("p1"); !errors.Is(err, repository.ErrPostNotFound) {
+		t.Errorf("FindByID() error = %v, want repository.ErrPostNotFound", err)
+	}
+	if exists, _ := repo.Exists("to-delete"); exists {
+		t.Error("Exists() = true after delete")
+	}
+	if posts, _ := repo.FindByTag("go"); len(posts) != 0 {
+		t.Errorf("FindByTag() len = %d, want 0 after delete", len(posts))
+	}
+	if tags, _ := repo.FindAllTags(); len(tags) != 0 {
+		t.Errorf("FindAllTags() = %v, want empty after delete", tags)
+	}
+	if count, _ := repo.Count(repository.CountOptions{}); count != 0 {
+		t.Errorf("Count() = %d, want 0 after delete", count)
+	}
+
+	// 删除后 slug 可被复用
+	reuse := contractPost(t, "p2", "to-delete", contractBaseTime)
+	if err := repo.Save(reuse); err != nil {
+		t.Errorf("Save() reusing deleted slug error = %v", err)
+	}
+}
+
+func contractPagination(t *testing.T, repo repository.PostRepository) {
+	t.Run("empty repository", func(t *testing.T) {
+		result, err := repo.FindAll(repository.ListOptions{})
+		if err != nil {
+			t.Fatalf("FindAll() error = %v", err)
+		}
+		if result.Total != 0 || len(result.Items) != 0 {
+			t.Errorf("Total = %d, len(Items) = %d, want 0", result.Total, len(result.Items))
+		}
+		if result.TotalPages != 1 {
+			t.Errorf("TotalPages = %d, want 1", result.TotalPages)
+		}
+	})
+
+	for i := 0; i < 25; i++ {
+		id := fmt.Sprintf("p%02d", i)
+		contractSave(t, repo, contractPost(t, id, fmt.Sprintf("post-%d", i), contractBaseTime.Add(time.Duration(i)*time.Hour)))
+	}
+
+	tests := []struct {
+		name      string
+		opts      repository.ListOptions
+		wantPage  int
+		wantSize  int
+		wantItems int
+		wantPages int
+	}{
+		{"defaults", repository.ListOptions{}, 1, 10, 10, 3},
+		{"negative page", repository.ListOptions{Page: -1, PageSize: 10}, 1, 10, 10, 3},
+		{"last partial page", repository.ListOptions{Page: 3, PageSize: 10}, 3, 10, 5, 3},
+		{"beyond last page", repository.ListOptions{Page: 4, PageSize: 10}, 4, 10, 0, 3},
+		{"exact multiple", repository.ListOptions{Page: 5, PageSize: 5}, 5, 5, 5, 5},
+		{"single page", repository.ListOptions{Page: 1, PageSize: 100}, 1, 100, 25, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := repo.FindAll(tt.opts)
+			if err != nil {
+				t.Fatalf("FindAll() error = %v", err)
+			}
+			if result.Total != 25 {
+				t.Errorf("Total = %d, want 25", result.Total)
+			}
+			if result.Page != tt.wantPage || result.PageSize != tt.wantSize {
+				t.Errorf("Page/PageSize = %d/%d, want %d/%d", result.Page, result.PageSize, tt.wantPage, tt.wantSize)
+			}
+			if len(result.Items) != tt.wantItems {
+				t.Errorf("len(Items) = %d, want %d", len(result.Items), tt.wantItems)
+			}
+			if result.TotalPages != tt.wantPages {
+				t.Errorf("TotalPages = %d, want %d", result.TotalPages, tt.wantPages)
+			}
+		})
+	}
+
+	t.Run("pages do not overlap", func(t *testing.T) {
+		seen := make(map[string]bool)
+		for page := 1; page <= 3; page++ {
+			result, _ := repo.FindAll(repository.ListOptions{Page: page, PageSize: 10})
+			for _, post := range result.Items {
+				if seen[post.ID] {
+					t.Errorf("post %s returned on multiple pages", post.ID)
+				}
+				seen[post.ID] = true
+			}
+		}
+		if len(seen) != 25 {
+			t.Errorf("pages covered %d posts, want 25", len(seen))
+		}
+	})
+}
+
+func contractCursor(t *testing.T, repo repository.PostRepository) {
+	for i := 0; i < 12; i++ {
+		id := fmt.Sprintf("p%02d", i)
+		contractSave(t, repo, contractPost(t, id, fmt.Sprintf("post-%d", i), contractBaseTime.Add(time.Duration(i)*time.Hour)))
+	}
+	// 与 p05 创建时间相同，按 ID 决定先后
+	contractSave(t, repo, contractPost(t, "p05b", "post-5b", contractBaseTime.Add(5*time.Hour)))
+
+	first, err := repo.FindAll(repository.ListOptions{PageSize: 5})
+	if err != nil {
+		t.Fatalf("FindAll() error = %v", err)
+	}
+	if first.NextCursor == "" || first.PrevCursor != "" || !first.HasMore {
+		t.Fatalf("first page cursors: next=%q prev=%q hasMore=%v", first.NextCursor, first.PrevCursor, first.HasMore)
+	}
+
+	// 滚动过程中发布新文章，不应影响后续页
+	contractSave(t, repo, contractPost(t, "p99", "post-new", contractBaseTime.Add(100*time.Hour)))
+
+	second, err := repo.FindAll(repository.ListOptions{PageSize: 5, Cursor: first.NextCursor})
+	if err != nil {
+		t.Fatalf("FindAll(next) error = %v", err)
+	}
+	third, err := repo.FindAll(repository.ListOptions{PageSize: 5, Cursor: second.NextCursor})
+	if err != nil {
+		t.Fatalf("FindAll(next) error = %v", err)
+	}
+
+	got := fmt.Sprint(contractIDs(first.Items), contractIDs(second.Items), contractIDs(third.Items))
+	want := "[p11 p10 p09 p08 p07] [p06 p05b p05 p04 p03] [p02 p01 p00]"
+	if got != want {
+		t.Errorf("pages = %s, want %s", got, want)
+	}
+	if third.HasMore || third.NextCursor != "" {
+		t.Errorf("last page hasMore=%v next=%q, want no more", third.HasMore, third.NextCursor)
+	}
+	if second.Total != 0 {
+		t.Errorf("cursor page Total = %d, want 0 (not counted)", second.Total)
+	}
+
+	// 向前翻页回到第二页
+	back, err := repo.FindAll(repository.ListOptions{PageSize: 5, Cursor: third.PrevCursor})
+	if err != nil {
+		t.Fatalf("FindAll(prev) error = %v", err)
+	}
+	if fmt.Sprint(contractIDs(back.Items)) != fmt.Sprint(contractIDs(second.Items)) {
+		t.Errorf("prev page = %v, want %v", contractIDs(back.Items), contractIDs(second.Items))
+	}
+
+	// 再向前翻页仍是原来的第一页，且新发布的文章排在它之前
+	top, err := repo.FindAll(repository.ListOptions{PageSize: 5, Cursor: back.PrevCursor})
+	if err != nil {
+		t.Fatalf("FindAll(prev) error = %v", err)
+	}
+	if got := fmt.Sprint(contractIDs(top.Items)); got != "[p11 p10 p09 p08 p07]" {
+		t.Errorf("top page = %s, want [p11 p10 p09 p08 p07]", got)
+	}
+	newest, err := repo.FindAll(repository.ListOptions{PageSize: 5, Cursor: top.PrevCursor})
+	if err != nil {
+		t.Fatalf("FindAll(prev) error = %v", err)
+	}
+	if got := fmt.Sprint(contractIDs(newest.Items)); got != "[p99]" || newest.PrevCursor != "" {
+		t.Errorf("newest page = %s prev=%q, want [p99] and no prev", got, newest.PrevCursor)
+	}
+
+	invalid := []repository.ListOptions{
+		{Cursor: "not-a-cursor"},
+		{Cursor: first.NextCursor, OrderBy: "date_asc"},
+	}
+	for _, opts := range invalid {
+		if _, err := repo.FindAll(opts); !errors.Is(err, repository.ErrInvalidCursor) {
+			t.Errorf("FindAll(%+v) error = %v, want repository.ErrInvalidCursor", opts, err)
+		}
+	}
+}
+
+func contractOrdering(t *testing.T, repo repository.PostRepository) {
+	oldest := contractPost(t, "a", "oldest", contractBaseTime, "go")
+	middle := contractPost(t, "b", "middle", contractBaseTime.Add(time.Hour), "go")
+	newest := contractPost(t, "c", "newest", contractBaseTime.Add(2*time.Hour), "go")
+	contractSave(t, repo, middle, newest, oldest)
+
+	desc, _ := repo.FindAll(repository.ListOptions{OrderBy: "date_desc"})
+	if got := fmt.Sprint(contractIDs(desc.Items)); got != "[c b a]" {
+		t.Errorf("FindAll(date_desc) = %s, want [c b a]", got)
+	}
+
+	asc, _ := repo.FindAll(repository.ListOptions{OrderBy: "date_asc"})
+	if got := fmt.Sprint(contractIDs(asc.Items)); got != "[a b c]" {
+		t.Errorf("FindAll(date_asc) = %s, want [a b c]", got)
+	}
+
+	defaultOrder, _ := repo.FindAll(repository.ListOptions{})
+	if got := fmt.Sprint(contractIDs(defaultOrder.Items)); got != "[c b a]" {
+		t.Errorf("FindAll(default) = %s, want [c b a]", got)
+	}
+
+	byTag, _ := repo.FindByTag("go")
+	if got := fmt.Sprint(contractIDs(byTag)); got != "[c b a]" {
+		t.Errorf("FindByTag() = %s, want [c b a]", got)
+	}
+
+	z := contractPost(t, "z", "z-post", contractBaseTime, "zeta", "alpha")
+	contractSave(t, repo, z)
+	tags, _ := repo.FindAllTags()
+	if got := fmt.Sprint(tags); got != "[alpha go zeta]" {
+		t.Errorf("FindAllTags() = %s, want sorted [alpha go zeta]", got)
+	}
+}
+
+func contractFiltering(t *testing.T, repo repository.PostRepository) {
+	draft := contractPost(t, "d", "draft-post", contractBaseTime, "go")
+	published := contractPost(t, "p", "published-post", contractBaseTime.Add(time.Hour), "go", "web")
+	if err := published.Publish(); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	contractSave(t, repo, draft, published)
+
+	tests := []struct {
+		name string
+		opts repository.ListOptions
+		want string
+	}{
+		{"status published", repository.ListOptions{Status: "published"}, "[p]"},
+		{"status draft", repository.ListOptions{Status: "draft"}, "[d]"},
+		{"tag", repository.ListOptions{Tag: "web"}, "[p]"},
+		{"tag and status", repository.ListOptions{Tag: "go", Status: "draft"}, "[d]"},
+		{"unknown tag", repository.ListOptions{Tag: "none"}, "[]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := repo.FindAll(tt.opts)
+			if err != nil {
+				t.Fatalf("FindAll() error = %v", err)
+			}
+			if got := fmt.Sprint(contractIDs(result.Items)); got != tt.want {
+				t.Errorf("FindAll() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	counts := map[string]int{"": 2, "published": 1, "draft": 1}
+	for status, want := range counts {
+		if got, _ := repo.Count(repository.CountOptions{Status: status}); got != want {
+			t.Errorf("Count(%q) = %d, want %d", status, got, want)
+		}
+	}
+}
+
+func contractRichQuery(t *testing.T, repo repository.PostRepository) {
+	at := func(hours int) time.Time { return contractBaseTime.Add(time.Duration(hours) * time.Hour) }
+
+	a := contractPost(t, "a", "a-post", at(0), "go", "web")
+	a.Title = "Zebra Patterns"
+	a.UpdatedAt = at(30)
+
+	b := contractPost(t, "b", "b-post", at(10), "go")
+	b.Title = "alpha release notes"
+	if err := b.Publish(); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	published := at(20)
+	b.PublishedAt = &published
+	b.UpdatedAt = at(10)
+
+	c := contractPost(t, "c", "c-post", at(20), "rust", "web")
+	c.Title = "Middle Ground"
+	if err := c.Publish(); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	published2 := at(25)
+	c.PublishedAt = &published2
+	c.UpdatedAt = at(20)
+
+	contractSave(t, repo, a, b, c)
+
+	from, to := at(5), at(22)
+	tests := []struct {
+		name string
+		opts repository.ListOptions
+		want string
+	}{
+		{"tags and", repository.ListOptions{Tags: []string{"go", "web"}}, "[a]"},
+		{"tags or", repository.ListOptions{Tags: []string{"rust", "web"}, TagMode: "or"}, "[c a]"},
+		{"exclude tags", repository.ListOptions{ExcludeTags: []string{"web"}}, "[b]"},
+		{"tags with exclusion", repository.ListOptions{Tags: []string{"go"}, ExcludeTags: []string{"web"}}, "[b]"},
+		{"title substring", repository.ListOptions{TitleQuery: "ALPHA"}, "[b]"},
+		{"created range", repository.ListOptions{Created: repository.TimeRange{From: &from, To: &to}}, "[c b]"},
+		{"created from", repository.ListOptions{Created: repository.TimeRange{From: &to}}, "[]"},
+		{"published range", repository.ListOptions{Published: repository.TimeRange{To: &to}}, "[b]"},
+		{"updated range", repository.ListOptions{Updated: repository.TimeRange{From: &to}}, "[a]"},
+		{"order title asc", repository.ListOptions{OrderBy: "title_asc"}, "[b c a]"},
+		{"order title desc", repository.ListOptions{OrderBy: "title_desc"}, "[a c b]"},
+		{"order updated desc", repository.ListOptions{OrderBy: "updated_desc"}, "[a c b]"},
+		{"order updated asc", repository.ListOptions{OrderBy: "updated_asc"}, "[b c a]"},
+		{"order published desc", repository.ListOptions{OrderBy: "published_desc"}, "[c b a]"},
+		{"order published asc", repository.ListOptions{OrderBy: "published_asc"}, "[a b c]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := repo.FindAll(tt.opts)
+			if err != nil {
+				t.Fatalf("FindAll() error = %v", err)
+			}
+			if got := fmt.Sprint(contractIDs(result.Items)); got != tt.want {
+				t.Errorf("FindAll() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("cursor with title order", func(t *testing.T) {
+		first, _ := repo.FindAll(repository.ListOptions{OrderBy: "title_asc", PageSize: 2})
+		next, err := repo.FindAll(repository.ListOptions{OrderBy: "title_asc", PageSize: 2, Cursor: first.NextCursor})
+		if err != nil {
+			t.Fatalf("FindAll() error = %v", err)
+		}
+		if got := fmt.Sprint(contractIDs(first.Items), contractIDs(next.Items)); got != "[b c] [a]" {
+			t.Errorf("pages = %s, want [b c] [a]", got)
+		}
+	})
+}
+
+func contractConcurrency(t *testing.T, repo repository.PostRepository) {
+	const workers = 8
+	const perWorker = 10
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				id := fmt.Sprintf("w%d-%d", w, i)
+				post := contractPost(t, id, fmt.Sprintf("w%d-post-%d", w, i), contractBaseTime.Add(time.Duration(i)*time.Minute), "go")
+				if err := repo.Save(post); err != nil {
+					t.Errorf("Save(%s) error = %v", id, err)
+					continue
+				}
+
+				// 读写交错
+				if found, err := repo.FindByID(id); err == nil {
+					found.Title = "concurrent mutation"
+				}
+				repo.FindAll(repository.ListOptions{Tag: "go"})
+				repo.FindByTag("go")
+				repo.FindAllTags()
+				repo.Exists(post.Slug.String())
+				repo.Count(repository.CountOptions{})
+
+				if i%2 == 1 {
+					if err := repo.Delete(id); err != nil {
+						t.Errorf("Delete(%s) error = %v", id, err)
+					}
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	want := workers * perWorker / 2
+	if count, _ := repo.Count(repository.CountOptions{}); count != want {
+		t.Errorf("Count() = %d, want %d", count, want)
+	}
+	if posts, _ := repo.FindByTag("go"); len(posts) != want {
+		t.Errorf("FindByTag() len = %d, want %d", len(posts), want)
+	}
+}
+
+func contractBatch(t *testing.T, repo repository.PostRepository) {
+	writer, ok := repo.(repository.BatchWriter)
+	if !ok {
+		t.Skip("repository does not implement repository.BatchWriter")
+	}
+
+	a := contractPost(t, "a", "post-a", contractBaseTime, "go")
+	b := contractPost(t, "b", "post-b", contractBaseTime, "go")
+	contractSave(t, repo, a, b)
+
+	t.Run("failed batch changes nothing", func(t *testing.T) {
+		renamed := contractPost(t, "a", "post-a", contractBaseTime, "rust")
+		conflict := contractPost(t, "c", "post-b", contractBaseTime)
+		if err := writer.ApplyBatch(repository.Batch{Save: []*domain.Post{renamed, conflict}}); !errors.Is(err, repository.ErrSlugExists) {
+			t.Fatalf("ApplyBatch() error = %v, want repository.ErrSlugExists", err)
+		}
+		if err := writer.ApplyBatch(repository.Batch{Delete: []string{"a", "missing"}}); !errors.Is(err, repository.ErrPostNotFound) {
+			t.Fatalf("ApplyBatch() error = %v, want repository.ErrPostNotFound", err)
+		}
+		stale := repository.Batch{
+			Save:     []*domain.Post{renamed},
+			Delete:   []string{"b"},
+			Versions: map[string]int{"a": a.Version, "b": b.Version + 1},
+		}
+		if err := writer.ApplyBatch(stale); !errors.Is(err, repository.ErrVersionConflict) {
+			t.Fatalf("ApplyBatch() error = %v, want repository.ErrVersionConflict", err)
+		}
+
+		if posts, _ := repo.FindByTag("go"); len(posts) != 2 {
+			t.Errorf("FindByTag(go) len = %d, want 2", len(posts))
+		}
+		if posts, _ := repo.FindByTag("rust"); len(posts) != 0 {
+			t.Errorf("FindByTag(rust) len = %d, want 0", len(posts))
+		}
+		if _, err := repo.FindByID("c"); !errors.Is(err, repository.ErrPostNotFound) {
+			t.Errorf("FindByID(c) error = %v, want repository.ErrPostNotFound", err)
+		}
+	})
+
+	t.Run("saves and deletes together", func(t *testing.T) {
+		// b 的 slug 在同一批次中释放并由新文章使用
+		updated := contractPost(t, "a", "post-a", contractBaseTime, "rust")
+		updated.Content = "Updated content"
+		created := contractPost(t, "c", "post-b", contractBaseTime)
+		if err := writer.ApplyBatch(repository.Batch{Save: []*domain.Post{updated, created}, Delete: []string{"b"}}); err != nil {
+			t.Fatalf("ApplyBatch() error = %v", err)
+		}
+
+		found, err := repo.FindByID("a")
+		if err != nil || found.Content != "Updated content" || !found.HasTag("rust") {
+			t.Errorf("FindByID(a) = %+v, %v", found, err)
+		}
+		if found, err := repo.FindBySlug("post-b"); err != nil || found.ID != "c" {
+			t.Errorf("FindBySlug(post-b) = %v, %v, want c", found, err)
+		}
+		if _, err := repo.FindByID("b"); !errors.Is(err, repository.ErrPostNotFound) {
+			t.Errorf("FindByID(b) error = %v, want repository.ErrPostNotFound", err)
+		}
+		if count, _ := repo.Count(repository.CountOptions{}); count != 2 {
+			t.Errorf("Count() = %d, want 2", count)
+		}
+	})
+
+	t.Run("swapping slugs", func(t *testing.T) {
+		first := contractPost(t, "a", "post-b", contractBaseTime)
+		second := contractPost(t, "c", "post-a", contractBaseTime)
+		if err := writer.ApplyBatch(repository.Batch{Save: []*domain.Post{second, first}}); err != nil {
+			t.Fatalf("ApplyBatch() error = %v", err)
+		}
+		if found, err := repo.FindBySlug("post-a"); err != nil || found.ID != "c" {
+			t.Errorf("FindBySlug(post-a) = %v, %v, want c", found, err)
+		}
+		if found, err := repo.FindBySlug("post-b"); err != nil || found.ID != "a" {
+			t.Errorf("FindBySlug(post-b) = %v, %v, want a", found, err)
+		}
+	})
+}
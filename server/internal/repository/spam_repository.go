@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxSpamLogEntries 判定记录最多保留的条数，超出时丢弃最早的记录
+const MaxSpamLogEntries = 500
+
+// SpamLogEntry 一次公开提交的反垃圾判定记录
+type SpamLogEntry struct {
+	Time      time.Time `json:"time"`
+	Kind      string    `json:"kind"`             // 提交类型，如 comment
+	IPHash    string    `json:"ipHash,omitempty"` // IP 的带密钥哈希（不保存原始 IP）
+	EmailHash string    `json:"emailHash,omitempty"`
+	Verdict   string    `json:"verdict"` // accept / moderate / reject
+	Score     int       `json:"score"`
+	Reasons   []string  `json:"reasons"`
+	Excerpt   string    `json:"excerpt,omitempty"` // 正文开头，便于复查
+}
+
+// SpamRepository 反垃圾数据仓库接口：管理员维护的禁止列表与判定记录
+type SpamRepository interface {
+	// DisallowList 读取禁止列表
+	DisallowList() ([]string, error)
+
+	// SaveDisallowList 覆盖保存禁止列表
+	SaveDisallowList(entries []string) error
+
+	// AppendLog 追加判定记录
+	AppendLog(entry SpamLogEntry) error
+
+	// RecentLog 读取最近的判定记录（按时间倒序），limit <= 0 时返回全部
+	RecentLog(limit int) ([]SpamLogEntry, error)
+
+	// StorageLocker 备份与恢复时锁定存储，WriteLocked 结束前重新加载持久化的禁止列表与判定记录
+	StorageLocker
+}
+
+// MemorySpamRepository 内存实现的 SpamRepository（用于测试）
+type MemorySpamRepository struct {
+	disallow []string
+	entries  []SpamLogEntry
+	mu       sync.RWMutex
+}
+
+// NewMemorySpamRepository 创建内存反垃圾仓库
+func NewMemorySpamRepository() *MemorySpamRepository {
+	return &MemorySpamRepository{}
+}
+
+// DisallowList 读取禁止列表（返回副本）
+func (r *MemorySpamRepository) DisallowList() ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]string{}, r.disallow...), nil
+}
+
+// SaveDisallowList 覆盖保存禁止列表
+func (r *MemorySpamRepository) SaveDisallowList(entries []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.disallow = NormalizeDisallowList(entries)
+	return nil
+}
+
+// AppendLog 追加判定记录
+func (r *MemorySpamRepository) AppendLog(entry SpamLogEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries = AppendSpamLog(r.entries, entry)
+	return nil
+}
+
+// RecentLog 读取最近的判定记录
+func (r *MemorySpamRepository) RecentLog(limit int) ([]SpamLogEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return RecentSpamLog(r.entries, limit), nil
+}
+
+// ReadLocked 持有锁执行 fn
+func (r *MemorySpamRepository) ReadLocked(fn func() error) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return fn()
+}
+
+// WriteLocked 持有写锁执行 fn，内存实现无需重新加载
+func (r *MemorySpamRepository) WriteLocked(fn func() error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return fn()
+}
+
+// NormalizeDisallowList 去掉空白与重复项，统一为小写并排序
+func NormalizeDisallowList(entries []string) []string {
+	seen := make(map[string]bool, len(entries))
+	result := []string{}
+	for _, entry := range entries {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" || seen[entry] {
+			continue
+		}
+		seen[entry] = true
+		result = append(result, entry)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// AppendSpamLog 追加判定记录，只保留最近 MaxSpamLogEntries 条
+func AppendSpamLog(entries []SpamLogEntry, entry SpamLogEntry) []SpamLogEntry {
+	entry.Reasons = append([]string{}, entry.Reasons...)
+	entries = append(entries, entry)
+	if len(entries) > MaxSpamLogEntries {
+		entries = append([]SpamLogEntry{}, entries[len(entries)-MaxSpamLogEntries:]...)
+	}
+	return entries
+}
+
+// RecentSpamLog 按时间倒序返回最近 limit 条判定记录（返回副本）
+func RecentSpamLog(entries []SpamLogEntry, limit int) []SpamLogEntry {
+	if limit <= 0 || limit > len(entries) {
+		limit = len(entries)
+	}
+	result := make([]SpamLogEntry, 0, limit)
+	for i := len(entries) - 1; i >= 0 && len(result) < limit; i-- {
+		entry := entries[i]
+		entry.Reasons = append([]string{}, entry.Reasons...)
+		result = append(result, entry)
+	}
+	return result
+}
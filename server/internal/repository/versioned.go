@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+)
+
+var (
+	ErrHistoryUnsupported = errors.New("post history is not supported by repository")
+	ErrRevisionNotFound   = errors.New("revision not found")
+	ErrRebuildUnsupported = errors.New("index rebuild is not supported by repository")
+)
+
+// Revision 文章的一个历史版本（对应一次提交）
+type Revision struct {
+	Hash    string    `json:"hash"`
+	Message string    `json:"message"`
+	Author  string    `json:"author"`
+	Time    time.Time `json:"time"`
+}
+
+// AuthoredWriter 可记录编辑者的写操作（由版本化仓库实现）
+type AuthoredWriter interface {
+	// SaveAs 以指定编辑者身份保存文章
+	SaveAs(post *domain.Post, editor string) error
+
+	// DeleteAs 以指定编辑者身份删除文章
+	DeleteAs(id, editor string) error
+}
+
+// VersionedRepository 支持历史版本查询的仓库
+type VersionedRepository interface {
+	// History 获取文章的提交历史（最新的在前）
+	History(id string) ([]Revision, error)
+
+	// FindRevision 读取文章在指定提交时的版本
+	FindRevision(id, hash string) (*domain.Post, error)
+}
+
+// IndexRebuilder 可从存储全量重建索引的仓库
+type IndexRebuilder interface {
+	// RebuildIndex 忽略缓存/快照，重新读取全部文章并重建索引
+	RebuildIndex() error
+}
@@ -0,0 +1,85 @@
+package repository
+
+import "sync"
+
+// DailyViews 单篇文章按天统计的浏览量，键为站点时区的日期 "2006-01-02"
+type DailyViews map[string]int
+
+// ViewRepository 浏览量仓库接口
+type ViewRepository interface {
+	// LoadViews 读取全部文章的浏览量（postID -> 每日浏览量）
+	LoadViews() (map[string]DailyViews, error)
+
+	// AddViews 累加浏览量（postID -> 日期 -> 增量）
+	AddViews(delta map[string]DailyViews) error
+
+	// StorageLocker 备份与恢复时锁定存储，WriteLocked 结束前重新加载持久化的浏览量
+	StorageLocker
+}
+
+// MemoryViewRepository 内存实现的 ViewRepository（用于测试）
+type MemoryViewRepository struct {
+	views map[string]DailyViews
+	mu    sync.Mutex
+}
+
+// NewMemoryViewRepository 创建内存浏览量仓库
+func NewMemoryViewRepository() *MemoryViewRepository {
+	return &MemoryViewRepository{
+		views: make(map[string]DailyViews),
+	}
+}
+
+// LoadViews 读取全部浏览量（返回副本）
+func (r *MemoryViewRepository) LoadViews() (map[string]DailyViews, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return CopyViews(r.views), nil
+}
+
+// AddViews 累加浏览量
+func (r *MemoryViewRepository) AddViews(delta map[string]DailyViews) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	MergeViews(r.views, delta)
+	return nil
+}
+
+// ReadLocked 持有锁执行 fn
+func (r *MemoryViewRepository) ReadLocked(fn func() error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return fn()
+}
+
+// WriteLocked 持有写锁执行 fn，内存实现无需重新加载
+func (r *MemoryViewRepository) WriteLocked(fn func() error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return fn()
+}
+
+// MergeViews 将 delta 累加到 dst
+func MergeViews(dst, delta map[string]DailyViews) {
+	for postID, days := range delta {
+		target := dst[postID]
+		if target == nil {
+			target = make(DailyViews, len(days))
+			dst[postID] = target
+		}
+		for day, count := range days {
+			target[day] += count
+		}
+	}
+}
+
+// CopyViews 深拷贝浏览量表
+func CopyViews(views map[string]DailyViews) map[string]DailyViews {
+	copied := make(map[string]DailyViews, len(views))
+	MergeViews(copied, views)
+	return copied
+}
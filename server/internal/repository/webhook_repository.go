@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+)
+
+var (
+	ErrWebhookNotFound  = errors.New("webhook not found")
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+)
+
+// MaxWebhookDeliveries 投递记录最多保留的条数，超出时丢弃最早的记录
+const MaxWebhookDeliveries = 500
+
+// WebhookRepository Webhook 与投递记录仓库接口
+type WebhookRepository interface {
+	// ListWebhooks 列出全部 Webhook（按创建时间正序）
+	ListWebhooks() ([]*domain.Webhook, error)
+
+	// FindWebhook 根据 ID 查找 Webhook
+	FindWebhook(id string) (*domain.Webhook, error)
+
+	// SaveWebhook 保存 Webhook（ID 相同时覆盖）
+	SaveWebhook(webhook *domain.Webhook) error
+
+	// DeleteWebhook 删除 Webhook 及其投递记录
+	DeleteWebhook(id string) error
+
+	// FindDelivery 根据 ID 查找投递记录
+	FindDelivery(id string) (*domain.WebhookDelivery, error)
+
+	// ListDeliveries 列出投递记录（按创建时间倒序），webhookID 为空时不限，limit <= 0 时返回全部
+	ListDeliveries(webhookID string, limit int) ([]*domain.WebhookDelivery, error)
+
+	// DueDeliveries 列出到期待投递的记录（状态为 pending 且下一次投递时间不晚于 now，按时间正序）
+	DueDeliveries(now time.Time) ([]*domain.WebhookDelivery, error)
+
+	// SaveDelivery 保存投递记录（ID 相同时覆盖）
+	SaveDelivery(delivery *domain.WebhookDelivery) error
+
+	// StorageLocker 备份与恢复时锁定存储，WriteLocked 结束前重新加载持久化的 Webhook 与投递记录
+	StorageLocker
+}
+
+// MemoryWebhookRepository 内存实现的 WebhookRepository（用于测试）
+type MemoryWebhookRepository struct {
+	webhooks   map[string]*domain.Webhook
+	deliveries []*domain.WebhookDelivery // 按创建时间正序
+	mu         sync.RWMutex
+}
+
+// NewMemoryWebhookRepository 创建内存 Webhook 仓库
+func NewMemoryWebhookRepository() *MemoryWebhookRepository {
+	return &MemoryWebhookRepository{
+		webhooks: make(map[string]*domain.Webhook),
+	}
+}
+
+// ListWebhooks 列出全部 Webhook
+func (r *MemoryWebhookRepository) ListWebhooks() ([]*domain.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return SortedWebhooks(r.webhooks), nil
+}
+
+// FindWebhook 根据 ID 查找 Webhook
+func (r *MemoryWebhookRepository) FindWebhook(id string) (*domain.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	webhook, ok := r.webhooks[id]
+	if !ok {
+		return nil, ErrWebhookNotFound
+	}
+	return CopyWebhook(webhook), nil
+}
+
+// SaveWebhook 保存 Webhook
+func (r *MemoryWebhookRepository) SaveWebhook(webhook *domain.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.webhooks[webhook.ID] = CopyWebhook(webhook)
+	return nil
+}
+
+// DeleteWebhook 删除 Webhook 及其投递记录
+func (r *MemoryWebhookRepository) DeleteWebhook(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.webhooks[id]; !ok {
+		return ErrWebhookNotFound
+	}
+	delete(r.webhooks, id)
+	r.deliveries = RemoveDeliveries(r.deliveries, id)
+	return nil
+}
+
+// FindDelivery 根据 ID 查找投递记录
+func (r *MemoryWebhookRepository) FindDelivery(id string) (*domain.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return FindDeliveryIn(r.deliveries, id)
+}
+
+// ListDeliveries 列出投递记录
+func (r *MemoryWebhookRepository) ListDeliveries(webhookID string, limit int) ([]*domain.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return ListDeliveriesIn(r.deliveries, webhookID, limit), nil
+}
+
+// DueDeliveries 列出到期待投递的记录
+func (r *MemoryWebhookRepository) DueDeliveries(now time.Time) ([]*domain.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return DueDeliveriesIn(r.deliveries, now), nil
+}
+
+// SaveDelivery 保存投递记录
+func (r *MemoryWebhookRepository) SaveDelivery(delivery *domain.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deliveries = PutDelivery(r.deliveries, delivery)
+	return nil
+}
+
+// ReadLocked 持有锁执行 fn
+func (r *MemoryWebhookRepository) ReadLocked(fn func() error) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return fn()
+}
+
+// WriteLocked 持有写锁执行 fn，内存实现无需重新加载
+func (r *MemoryWebhookRepository) WriteLocked(fn func() error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return fn()
+}
+
+// CopyWebhook 复制 Webhook（含事件列表）
+func CopyWebhook(webhook *domain.Webhook) *domain.Webhook {
+	copied := *webhook
+	copied.Events = append([]domain.WebhookEvent{}, webhook.Events...)
+	return &copied
+}
+
+// SortedWebhooks 按创建时间正序返回 Webhook 的副本
+func SortedWebhooks(webhooks map[string]*domain.Webhook) []*domain.Webhook {
+	result := make([]*domain.Webhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		result = append(result, CopyWebhook(webhook))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.Before(result[j].CreatedAt)
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// FindDeliveryIn 在投递记录中按 ID 查找（返回副本）
+func FindDeliveryIn(deliveries []*domain.WebhookDelivery, id string) (*domain.WebhookDelivery, error) {
+	for _, delivery := range deliveries {
+		if delivery.ID == id {
+			copied := *delivery
+			return &copied, nil
+		}
+	}
+	return nil, ErrDeliveryNotFound
+}
+
+// ListDeliveriesIn 按创建时间倒序列出投递记录（返回副本）
+func ListDeliveriesIn(deliveries []*domain.WebhookDelivery, webhookID string, limit int) []*domain.WebhookDelivery {
+	result := []*domain.WebhookDelivery{}
+	for i := len(deliveries) - 1; i >= 0; i-- {
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+		if webhookID != "" && deliveries[i].WebhookID != webhookID {
+			continue
+		}
+		copied := *deliveries[i]
+		result = append(result, &copied)
+	}
+	return result
+}
+
+// DueDeliveriesIn 列出到期待投递的记录（返回副本，按下一次投递时间正序）
+func DueDeliveriesIn(deliveries []*domain.WebhookDelivery, now time.Time) []*domain.WebhookDelivery {
+	result := []*domain.WebhookDelivery{}
+	for _, delivery := range deliveries {
+		if delivery.Status == domain.DeliveryPending && !delivery.NextAttemptAt.After(now) {
+			copied := *delivery
+			result = append(result, &copied)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].NextAttemptAt.Before(result[j].NextAttemptAt)
+	})
+	return result
+}
+
+// PutDelivery 覆盖或追加投递记录，只保留最近 MaxWebhookDeliveries 条
+func PutDelivery(deliveries []*domain.WebhookDelivery, delivery *domain.WebhookDelivery) []*domain.WebhookDelivery {
+	copied := *delivery
+	for i, existing := range deliveries {
+		if existing.ID == delivery.ID {
+			result := append([]*domain.WebhookDelivery{}, deliveries...)
+			result[i] = &copied
+			return result
+		}
+	}
+
+	result := append(append([]*domain.WebhookDelivery{}, deliveries...), &copied)
+	if len(result) > MaxWebhookDeliveries {
+		result = result[len(result)-MaxWebhookDeliveries:]
+	}
+	return result
+}
+
+// RemoveDeliveries 去掉某个 Webhook 的全部投递记录
+func RemoveDeliveries(deliveries []*domain.WebhookDelivery, webhookID string) []*domain.WebhookDelivery {
+	result := make([]*domain.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		if delivery.WebhookID != webhookID {
+			result = append(result, delivery)
+		}
+	}
+	return result
+}
@@ -1,19 +1,43 @@
 package service
 
 import (
+	"crypto/subtle"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/next-ai-ventus/server/internal/site"
 )
 
 // AuthService 认证服务
 type AuthService struct {
 	jwtSecret string
+	audience  string               // 非空时令牌必须带有相同的 aud
+	users     map[string]site.User // username -> 账号
 }
 
-// NewAuthService 创建认证服务
+// NewAuthService 创建认证服务（单站点模式，MVP 账号 admin/admin）
 func NewAuthService(secret string) *AuthService {
-	return &AuthService{jwtSecret: secret}
+	return &AuthService{
+		jwtSecret: secret,
+		users: map[string]site.User{
+			"admin": {Username: "admin", Password: "admin"},
+		},
+	}
+}
+
+// NewSiteAuthService 创建站点的认证服务，使用站点的账号、密钥与 audience
+func NewSiteAuthService(def *site.Definition) *AuthService {
+	users := make(map[string]site.User, len(def.Users))
+	for _, user := range def.Users {
+		users[user.Username] = user
+	}
+	return &AuthService{
+		jwtSecret: def.JWTSecret,
+		audience:  def.JWTAudience,
+		users:     users,
+	}
 }
 
 // Claims JWT 声明
@@ -31,16 +55,24 @@ func (s *AuthService) GenerateToken(username string) (string, error) {
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
+	if s.audience != "" {
+		claims.Audience = jwt.ClaimStrings{s.audience}
+	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(s.jwtSecret))
 }
 
-// ValidateToken 验证 JWT Token
+// ValidateToken 验证 JWT Token（签名算法、有效期与 audience）
 func (s *AuthService) ValidateToken(tokenString string) (*Claims, error) {
+	opts := []jwt.ParserOption{jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()})}
+	if s.audience != "" {
+		opts = append(opts, jwt.WithAudience(s.audience))
+	}
+
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		return []byte(s.jwtSecret), nil
-	})
+	}, opts...)
 
 	if err != nil {
 		return nil, err
@@ -53,10 +85,16 @@ func (s *AuthService) ValidateToken(tokenString string) (*Claims, error) {
 	return nil, jwt.ErrSignatureInvalid
 }
 
-// ValidateCredentials 验证用户名密码（MVP 硬编码）
+// ValidateCredentials 验证用户名密码
 func (s *AuthService) ValidateCredentials(username, password string) bool {
-	// MVP 版本使用硬编码账号
-	return username == "admin" && password == "admin"
+	user, ok := s.users[username]
+	if !ok {
+		return false
+	}
+	if user.PasswordHash != "" {
+		return bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) == nil
+	}
+	return subtle.ConstantTimeCompare([]byte(user.Password), []byte(password)) == 1
 }
 
 // GetSecret 获取 JWT Secret
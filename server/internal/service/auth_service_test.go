@@ -0,0 +1,57 @@
+package service
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/next-ai-ventus/server/internal/site"
+)
+
+func TestAuthService_SiteAudience(t *testing.T) {
+	blog := NewSiteAuthService(&site.Definition{ID: "blog", JWTSecret: "shared", JWTAudience: "blog"})
+	notes := NewSiteAuthService(&site.Definition{ID: "notes", JWTSecret: "shared", JWTAudience: "notes"})
+
+	token, err := blog.GenerateToken("admin")
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+	if _, err := blog.ValidateToken(token); err != nil {
+		t.Errorf("ValidateToken() on same site error = %v", err)
+	}
+	// 密钥相同也不能跨站点使用令牌
+	if _, err := notes.ValidateToken(token); err == nil {
+		t.Error("ValidateToken() accepted a token issued for another site")
+	}
+}
+
+func TestAuthService_ValidateCredentials(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("hashed-pw"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := NewSiteAuthService(&site.Definition{
+		ID:        "blog",
+		JWTSecret: "secret",
+		Users: []site.User{
+			{Username: "alice", Password: "plain-pw"},
+			{Username: "bob", PasswordHash: string(hash)},
+		},
+	})
+
+	tests := []struct {
+		username, password string
+		want               bool
+	}{
+		{"alice", "plain-pw", true},
+		{"alice", "wrong", false},
+		{"bob", "hashed-pw", true},
+		{"bob", string(hash), false},
+		{"admin", "admin", false},
+	}
+	for _, tt := range tests {
+		if got := auth.ValidateCredentials(tt.username, tt.password); got != tt.want {
+			t.Errorf("ValidateCredentials(%q, %q) = %v, want %v", tt.username, tt.password, got, tt.want)
+		}
+	}
+}
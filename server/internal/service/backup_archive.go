@@ -0,0 +1,245 @@
+package service
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// 归档格式
+const (
+	ArchiveZip   = "zip"
+	ArchiveTarGz = "tar.gz"
+)
+
+// IsValidArchiveFormat 判断归档格式是否受支持
+func IsValidArchiveFormat(format string) bool {
+	return format == ArchiveZip || format == ArchiveTarGz
+}
+
+// ArchiveContentType 返回归档格式对应的 MIME 类型
+func ArchiveContentType(format string) string {
+	if format == ArchiveTarGz {
+		return "application/gzip"
+	}
+	return "application/zip"
+}
+
+// archiveWriter 归档写入器
+type archiveWriter interface {
+	// WriteFile 写入一个普通文件
+	WriteFile(name string, mode fs.FileMode, modTime time.Time, size int64, r io.Reader) error
+
+	// Close 写入归档尾部
+	Close() error
+}
+
+// newArchiveWriter 按格式创建归档写入器
+func newArchiveWriter(w io.Writer, format string) (archiveWriter, error) {
+	switch format {
+	case ArchiveZip:
+		return &zipArchiveWriter{zw: zip.NewWriter(w)}, nil
+	case ArchiveTarGz:
+		gz := gzip.NewWriter(w)
+		return &tarGzArchiveWriter{gz: gz, tw: tar.NewWriter(gz)}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrArchiveFormat, format)
+	}
+}
+
+// zipArchiveWriter zip 格式写入器
+type zipArchiveWriter struct {
+	zw *zip.Writer
+}
+
+func (a *zipArchiveWriter) WriteFile(name string, mode fs.FileMode, modTime time.Time, size int64, r io.Reader) error {
+	header := &zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modTime}
+	header.SetMode(mode)
+
+	w, err := a.zw.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, r)
+	return err
+}
+
+func (a *zipArchiveWriter) Close() error {
+	return a.zw.Close()
+}
+
+// tarGzArchiveWriter tar.gz 格式写入器
+type tarGzArchiveWriter struct {
+	gz *gzip.Writer
+	tw *tar.Writer
+}
+
+func (a *tarGzArchiveWriter) WriteFile(name string, mode fs.FileMode, modTime time.Time, size int64, r io.Reader) error {
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     int64(mode.Perm()),
+		Size:     size,
+		ModTime:  modTime,
+	}
+	if err := a.tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := io.Copy(a.tw, r)
+	return err
+}
+
+func (a *tarGzArchiveWriter) Close() error {
+	if err := a.tw.Close(); err != nil {
+		return err
+	}
+	return a.gz.Close()
+}
+
+// extractArchive 将归档解压到 dir，根据文件头自动识别 zip 或 tar.gz。
+// 只接受普通文件，拒绝绝对路径、".." 与链接；解压总大小超过 maxBytes 时报错。
+func extractArchive(archivePath, dir string, maxBytes int64) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(f, magic); err != nil {
+		return fmt.Errorf("%w: file too short", ErrInvalidArchive)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	remaining := maxBytes
+	switch {
+	case bytes.Equal(magic, []byte("PK\x03\x04")):
+		return extractZip(f, dir, &remaining)
+	case magic[0] == 0x1f && magic[1] == 0x8b:
+		return extractTarGz(f, dir, &remaining)
+	default:
+		return fmt.Errorf("%w: unknown archive format", ErrInvalidArchive)
+	}
+}
+
+// extractZip 解压 zip 归档
+func extractZip(f *os.File, dir string, remaining *int64) error {
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	zr, err := zip.NewReader(f, info.Size())
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+
+	for _, entry := range zr.File {
+		if entry.FileInfo().IsDir() {
+			continue
+		}
+		if !entry.Mode().IsRegular() {
+			return fmt.Errorf("%w: %s is not a regular file", ErrInvalidArchive, entry.Name)
+		}
+
+		rc, err := entry.Open()
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+		}
+		err = writeExtracted(dir, entry.Name, entry.Mode(), rc, remaining)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extractTarGz 解压 tar.gz 归档
+func extractTarGz(f *os.File, dir string, remaining *int64) error {
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			continue
+		case tar.TypeReg:
+			if err := writeExtracted(dir, header.Name, fs.FileMode(header.Mode), tr, remaining); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%w: %s is not a regular file", ErrInvalidArchive, header.Name)
+		}
+	}
+}
+
+// writeExtracted 将归档中的一个文件写入 dir 下的对应位置
+func writeExtracted(dir, name string, mode fs.FileMode, r io.Reader, remaining *int64) error {
+	if !isSafeArchivePath(name) {
+		return fmt.Errorf("%w: unsafe path %q", ErrInvalidArchive, name)
+	}
+
+	target := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+
+	// 保证属主可写，便于之后复制与清理
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, mode.Perm()|0600)
+	if err != nil {
+		if os.IsExist(err) {
+			return fmt.Errorf("%w: duplicate entry %q", ErrInvalidArchive, name)
+		}
+		return err
+	}
+	defer out.Close()
+
+	n, err := io.Copy(out, io.LimitReader(r, *remaining+1))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	if n > *remaining {
+		return fmt.Errorf("%w: archive is too large", ErrInvalidArchive)
+	}
+	*remaining -= n
+	return nil
+}
+
+// isSafeArchivePath 判断归档中的路径是否为规范的相对路径
+func isSafeArchivePath(name string) bool {
+	if name == "" || strings.HasPrefix(name, "/") || strings.Contains(name, "\\") {
+		return false
+	}
+	if path.Clean(name) != name {
+		return false
+	}
+	for _, part := range strings.Split(name, "/") {
+		if part == ".." {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,718 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/repository"
+	"github.com/next-ai-ventus/server/internal/storage"
+)
+
+var (
+	ErrArchiveFormat    = errors.New("unsupported archive format")
+	ErrInvalidArchive   = errors.New("invalid backup archive")
+	ErrChecksumMismatch = errors.New("backup checksum mismatch")
+	ErrRestoreMode      = errors.New("invalid restore mode")
+)
+
+// 备份归档标识与结构版本
+const (
+	BackupFormat  = "ventus-backup"
+	BackupVersion = 1
+
+	manifestFileName   = "manifest.json"
+	redirectsFileName  = "redirects.json"
+	viewsFileName      = "views.json"
+	spamFileName       = "spam.json"
+	spamLogFileName    = "spam-log.jsonl"
+	webhooksFileName   = "webhooks.json"
+	newsletterFileName = "newsletter.json"
+	commentsDirName    = "comments"
+	reactionsDirName   = "reactions"
+	maxBackupBytes     = 8 << 30 // 解压后的总大小上限
+)
+
+// postDataDirs 内容目录下按文章保存数据的目录（每篇文章一个 <postID>.json），随备份一起保存
+var postDataDirs = []string{commentsDirName, reactionsDirName}
+
+// siteDataFiles 内容目录下随备份一起保存的站点数据文件
+var siteDataFiles = []string{redirectsFileName, viewsFileName, spamFileName, spamLogFileName, webhooksFileName, newsletterFileName}
+
+// 恢复模式
+const (
+	RestoreMerge   = "merge"   // 只添加本地不存在的文章与上传文件
+	RestoreReplace = "replace" // 用备份替换全部内容
+)
+
+// IsValidRestoreMode 判断恢复模式是否受支持
+func IsValidRestoreMode(mode string) bool {
+	return mode == RestoreMerge || mode == RestoreReplace
+}
+
+// BackupFileName 生成备份文件名
+func BackupFileName(format string, t time.Time) string {
+	return fmt.Sprintf("ventus-backup-%s.%s", t.Format("20060102-150405"), format)
+}
+
+// BackupManifest 备份清单（归档中的 manifest.json）
+type BackupManifest struct {
+	Format    string       `json:"format"`
+	Version   int          `json:"version"`
+	CreatedAt time.Time    `json:"createdAt"`
+	Posts     int          `json:"posts"`
+	Uploads   int          `json:"uploads"`
+	Revisions bool         `json:"revisions"` // 是否包含版本历史（content/.git）
+	Files     []BackupFile `json:"files"`
+}
+
+// BackupFile 清单中的单个文件
+type BackupFile struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// RestoreReport 恢复结果
+type RestoreReport struct {
+	Mode           string   `json:"mode"`
+	PostsAdded     int      `json:"postsAdded"`
+	PostsSkipped   int      `json:"postsSkipped"`
+	UploadsAdded   int      `json:"uploadsAdded"`
+	UploadsSkipped int      `json:"uploadsSkipped"`
+	CommentsAdded  int      `json:"commentsAdded"`  // 恢复的评论文件数（每篇文章一个）
+	ReactionsAdded int      `json:"reactionsAdded"` // 恢复的表情回应文件数（每篇文章一个）
+	Revisions      bool     `json:"revisions"`      // 是否恢复了版本历史
+	Conflicts      []string `json:"conflicts"`      // 因 slug 冲突而跳过的文章
+}
+
+// BackupService 全站备份与恢复服务
+//
+// 归档结构：
+//
+//	manifest.json            清单与校验和
+//	content/posts/<id>/...   文章（含草稿）
+//	content/redirects.json   旧地址重定向（存在时）
+//	content/views.json       浏览量（存在时）
+//	content/spam.json        反垃圾禁止列表（存在时）
+//	content/spam-log.jsonl   反垃圾判定记录（存在时）
+//	content/webhooks.json    Webhook 与投递记录（存在时）
+//	content/newsletter.json  订阅者与通知（存在时）
+//	content/comments/...     评论（每篇文章一个文件）
+//	content/reactions/...    表情回应（每篇文章一个文件）
+//	content/.git/...         版本历史（存在时）
+//	uploads/...              上传文件
+type BackupService struct {
+	repo        repository.PostRepository
+	contentPath string
+	uploads     storage.Blob
+	stores      []repository.StorageLocker
+}
+
+// NewBackupService 创建备份服务，上传文件通过 uploads 存储读写
+func NewBackupService(repo repository.PostRepository, contentPath string, uploads storage.Blob) *BackupService {
+	return &BackupService{
+		repo:        repo,
+		contentPath: contentPath,
+		uploads:     uploads,
+	}
+}
+
+// AddStores 登记内容目录下的站点数据存储（评论、浏览量等）。导出与恢复时与文章一同锁定，
+// 恢复时各存储在释放锁之前重新加载数据，期间的写入不会覆盖恢复的文件
+func (s *BackupService) AddStores(stores ...repository.StorageLocker) {
+	s.stores = append(s.stores, stores...)
+}
+
+// Export 将全站内容写为 format 格式的归档
+func (s *BackupService) Export(w io.Writer, format string) (*BackupManifest, error) {
+	locker, ok := s.repo.(repository.StorageLocker)
+	if !ok {
+		return nil, repository.ErrBackupUnsupported
+	}
+
+	aw, err := newArchiveWriter(w, format)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &BackupManifest{
+		Format:    BackupFormat,
+		Version:   BackupVersion,
+		CreatedAt: time.Now().UTC(),
+		Files:     []BackupFile{},
+	}
+
+	// 内容目录在读锁下归档，保证文章、版本历史与站点数据一致
+	err = lockAll(s.lockers(locker), false, func() error {
+		if err := addTree(aw, manifest, filepath.Join(s.contentPath, "posts"), "content/posts"); err != nil {
+			return err
+		}
+		for _, name := range postDataDirs {
+			if err := addTree(aw, manifest, filepath.Join(s.contentPath, name), "content/"+name); err != nil {
+				return err
+			}
+		}
+
+		for _, name := range siteDataFiles {
+			path := filepath.Join(s.contentPath, name)
+			if isRegularFile(path) {
+				if err := addFile(aw, manifest, path, "content/"+name); err != nil {
+					return err
+				}
+			}
+		}
+
+		gitPath := filepath.Join(s.contentPath, ".git")
+		if info, err := os.Stat(gitPath); err == nil && info.IsDir() {
+			manifest.Revisions = true
+			return addTree(aw, manifest, gitPath, "content/.git")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 上传文件写入后不再修改，无需持有锁
+	if err := addUploads(aw, manifest, s.uploads); err != nil {
+		return nil, err
+	}
+
+	for _, file := range manifest.Files {
+		switch {
+		case strings.HasPrefix(file.Path, "content/posts/") && strings.HasSuffix(file.Path, "/meta.json"):
+			manifest.Posts++
+		case strings.HasPrefix(file.Path, "uploads/"):
+			manifest.Uploads++
+		}
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal manifest failed: %w", err)
+	}
+	if err := aw.WriteFile(manifestFileName, 0644, manifest.CreatedAt, int64(len(data)), strings.NewReader(string(data))); err != nil {
+		return nil, err
+	}
+	if err := aw.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// Import 校验并恢复归档。恢复在文章仓库与全部站点数据存储的写锁下进行，
+// 期间的读写请求会等待而不会看到中间状态；各存储在释放锁之前重新加载恢复后的数据
+func (s *BackupService) Import(r io.Reader, mode string) (*RestoreReport, error) {
+	if !IsValidRestoreMode(mode) {
+		return nil, ErrRestoreMode
+	}
+	locker, ok := s.repo.(repository.StorageLocker)
+	if !ok {
+		return nil, repository.ErrBackupUnsupported
+	}
+
+	staging, err := os.MkdirTemp("", "ventus-restore-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(staging)
+
+	archivePath := filepath.Join(staging, "archive")
+	if err := saveReader(archivePath, r); err != nil {
+		return nil, err
+	}
+
+	// 解压与校验都在锁外完成，失败时不会触碰现有内容
+	filesDir := filepath.Join(staging, "files")
+	if err := extractArchive(archivePath, filesDir, maxBackupBytes); err != nil {
+		return nil, err
+	}
+	manifest, err := verifyBackup(filesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &RestoreReport{Mode: mode, Conflicts: []string{}}
+	err = lockAll(s.lockers(locker), true, func() error {
+		if mode == RestoreReplace {
+			return s.restoreReplace(filesDir, manifest, report)
+		}
+		return s.restoreMerge(filesDir, report)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// lockers 返回需要锁定的存储：先文章仓库，再按登记顺序的站点数据存储
+func (s *BackupService) lockers(posts repository.StorageLocker) []repository.StorageLocker {
+	return append([]repository.StorageLocker{posts}, s.stores...)
+}
+
+// lockAll 按顺序依次锁定 lockers，全部持有锁时执行 fn；write 为 true 时使用写锁
+func lockAll(lockers []repository.StorageLocker, write bool, fn func() error) error {
+	if len(lockers) == 0 {
+		return fn()
+	}
+	lock := lockers[0].ReadLocked
+	if write {
+		lock = lockers[0].WriteLocked
+	}
+	return lock(func() error {
+		return lockAll(lockers[1:], write, fn)
+	})
+}
+
+// restoreReplace 用备份替换文章、设置、上传文件与（备份中存在时）版本历史
+func (s *BackupService) restoreReplace(dir string, manifest *BackupManifest, report *RestoreReport) error {
+	stagedPosts := filepath.Join(dir, "content", "posts")
+	if err := replaceDir(filepath.Join(s.contentPath, "posts"), stagedPosts); err != nil {
+		return fmt.Errorf("restore posts failed: %w", err)
+	}
+	report.PostsAdded = manifest.Posts
+
+	for _, name := range postDataDirs {
+		if err := replaceDir(filepath.Join(s.contentPath, name), filepath.Join(dir, "content", name)); err != nil {
+			return fmt.Errorf("restore %s failed: %w", name, err)
+		}
+		for _, file := range manifest.Files {
+			if strings.HasPrefix(file.Path, "content/"+name+"/") {
+				*report.postDataAdded(name)++
+			}
+		}
+	}
+
+	for _, name := range siteDataFiles {
+		path := filepath.Join(s.contentPath, name)
+		staged := filepath.Join(dir, "content", name)
+		if isRegularFile(staged) {
+			if err := copyFile(staged, path); err != nil {
+				return fmt.Errorf("restore %s failed: %w", name, err)
+			}
+		} else if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	stagedGit := filepath.Join(dir, "content", ".git")
+	if manifest.Revisions {
+		if err := replaceDir(filepath.Join(s.contentPath, ".git"), stagedGit); err != nil {
+			return fmt.Errorf("restore revisions failed: %w", err)
+		}
+		report.Revisions = true
+	}
+
+	if err := s.replaceUploads(filepath.Join(dir, "uploads")); err != nil {
+		return fmt.Errorf("restore uploads failed: %w", err)
+	}
+	report.UploadsAdded = manifest.Uploads
+	return nil
+}
+
+// replaceUploads 写入备份中的上传文件，并删除备份中没有的上传文件
+func (s *BackupService) replaceUploads(staged string) error {
+	restored := make(map[string]bool)
+	err := walkFiles(staged, func(path, rel string) error {
+		key := filepath.ToSlash(rel)
+		restored[key] = true
+		return putFile(s.uploads, key, path)
+	})
+	if err != nil {
+		return err
+	}
+
+	existing, err := s.uploads.List("")
+	if err != nil {
+		return err
+	}
+	for _, info := range existing {
+		if !restored[info.Key] {
+			if err := s.uploads.Delete(info.Key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// restoreMerge 只添加本地不存在的文章、上传文件与设置；不恢复版本历史
+func (s *BackupService) restoreMerge(dir string, report *RestoreReport) error {
+	postsPath := filepath.Join(s.contentPath, "posts")
+	slugOwners := readSlugs(postsPath)
+
+	stagedPosts := filepath.Join(dir, "content", "posts")
+	entries, err := os.ReadDir(stagedPosts)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		id := entry.Name()
+		target := filepath.Join(postsPath, id)
+		if _, err := os.Stat(target); err == nil {
+			report.PostsSkipped++
+			continue
+		}
+
+		slug := readSlug(filepath.Join(stagedPosts, id, "meta.json"))
+		if owner, ok := slugOwners[slug]; ok && slug != "" {
+			report.PostsSkipped++
+			report.Conflicts = append(report.Conflicts, fmt.Sprintf("%s: slug %q is used by %s", id, slug, owner))
+			continue
+		}
+
+		if err := copyTree(filepath.Join(stagedPosts, id), target); err != nil {
+			return fmt.Errorf("restore post %s failed: %w", id, err)
+		}
+		if slug != "" {
+			slugOwners[slug] = id
+		}
+		report.PostsAdded++
+	}
+
+	// 评论与表情回应只恢复到本地存在、且还没有对应文件的文章
+	for _, name := range postDataDirs {
+		targetDir := filepath.Join(s.contentPath, name)
+		err = walkFiles(filepath.Join(dir, "content", name), func(path, rel string) error {
+			postID := strings.TrimSuffix(filepath.Base(rel), ".json")
+			target := filepath.Join(targetDir, rel)
+			if _, err := os.Stat(filepath.Join(postsPath, postID)); err != nil || isRegularFile(target) {
+				return nil
+			}
+			if err := copyFile(path, target); err != nil {
+				return fmt.Errorf("restore %s %s failed: %w", name, rel, err)
+			}
+			*report.postDataAdded(name)++
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, name := range siteDataFiles {
+		path := filepath.Join(s.contentPath, name)
+		staged := filepath.Join(dir, "content", name)
+		if isRegularFile(staged) && !isRegularFile(path) {
+			if err := copyFile(staged, path); err != nil {
+				return fmt.Errorf("restore %s failed: %w", name, err)
+			}
+		}
+	}
+
+	stagedUploads := filepath.Join(dir, "uploads")
+	return walkFiles(stagedUploads, func(path, rel string) error {
+		key := filepath.ToSlash(rel)
+		if _, err := s.uploads.Stat(key); err == nil {
+			report.UploadsSkipped++
+			return nil
+		} else if !errors.Is(err, storage.ErrNotFound) {
+			return err
+		}
+		if err := putFile(s.uploads, key, path); err != nil {
+			return fmt.Errorf("restore upload %s failed: %w", key, err)
+		}
+		report.UploadsAdded++
+		return nil
+	})
+}
+
+// postDataAdded 返回按文章保存的数据目录对应的恢复计数
+func (r *RestoreReport) postDataAdded(name string) *int {
+	if name == reactionsDirName {
+		return &r.ReactionsAdded
+	}
+	return &r.CommentsAdded
+}
+
+// verifyBackup 读取清单并校验每个文件的大小与 SHA-256，归档中不允许有清单之外的文件
+func verifyBackup(dir string) (*BackupManifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, manifestFileName))
+	if err != nil {
+		return nil, fmt.Errorf("%w: missing %s", ErrInvalidArchive, manifestFileName)
+	}
+
+	var manifest BackupManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	if manifest.Format != BackupFormat {
+		return nil, fmt.Errorf("%w: unknown format %q", ErrInvalidArchive, manifest.Format)
+	}
+	if manifest.Version < 1 || manifest.Version > BackupVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidArchive, manifest.Version)
+	}
+
+	listed := make(map[string]bool, len(manifest.Files))
+	for _, file := range manifest.Files {
+		if !isSafeArchivePath(file.Path) ||
+			!(strings.HasPrefix(file.Path, "content/") || strings.HasPrefix(file.Path, "uploads/")) {
+			return nil, fmt.Errorf("%w: unexpected path %q", ErrInvalidArchive, file.Path)
+		}
+		listed[file.Path] = true
+
+		size, sum, err := hashFile(filepath.Join(dir, filepath.FromSlash(file.Path)))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s is missing", ErrChecksumMismatch, file.Path)
+		}
+		if size != file.Size || sum != file.SHA256 {
+			return nil, fmt.Errorf("%w: %s", ErrChecksumMismatch, file.Path)
+		}
+	}
+
+	err = walkFiles(dir, func(path, rel string) error {
+		name := filepath.ToSlash(rel)
+		if name != manifestFileName && !listed[name] {
+			return fmt.Errorf("%w: %s is not listed in manifest", ErrInvalidArchive, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// addTree 将 root 下的所有普通文件以 prefix 为前缀写入归档（root 不存在时忽略）
+func addTree(aw archiveWriter, manifest *BackupManifest, root, prefix string) error {
+	return walkFiles(root, func(path, rel string) error {
+		return addFile(aw, manifest, path, prefix+"/"+filepath.ToSlash(rel))
+	})
+}
+
+// addFile 将单个文件写入归档并记录到清单
+func addFile(aw archiveWriter, manifest *BackupManifest, path, name string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	return addEntry(aw, manifest, name, info.Mode(), info.ModTime(), info.Size(), f)
+}
+
+// addUploads 将存储中的全部上传文件写入归档的 uploads/ 目录
+func addUploads(aw archiveWriter, manifest *BackupManifest, uploads storage.Blob) error {
+	infos, err := uploads.List("")
+	if err != nil {
+		return err
+	}
+	for _, info := range infos {
+		r, stat, err := uploads.Get(info.Key)
+		if err != nil {
+			return fmt.Errorf("read upload %s failed: %w", info.Key, err)
+		}
+		err = addEntry(aw, manifest, "uploads/"+info.Key, 0644, stat.ModTime, stat.Size, r)
+		r.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addEntry 将内容写入归档并记录大小与 SHA-256
+func addEntry(aw archiveWriter, manifest *BackupManifest, name string, mode fs.FileMode, modTime time.Time, size int64, r io.Reader) error {
+	h := sha256.New()
+	if err := aw.WriteFile(name, mode, modTime, size, io.TeeReader(r, h)); err != nil {
+		return fmt.Errorf("archive %s failed: %w", name, err)
+	}
+
+	manifest.Files = append(manifest.Files, BackupFile{
+		Path:   name,
+		Size:   size,
+		SHA256: hex.EncodeToString(h.Sum(nil)),
+	})
+	return nil
+}
+
+// putFile 将本地文件写入存储
+func putFile(blob storage.Blob, key, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return blob.Put(key, f, storage.ContentType(key))
+}
+
+// walkFiles 按路径顺序遍历 root 下的普通文件（跳过链接），root 不存在时不报错
+func walkFiles(root string, fn func(path, rel string) error) error {
+	if _, err := os.Stat(root); os.IsNotExist(err) {
+		return nil
+	}
+
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		return fn(path, rel)
+	})
+}
+
+// hashFile 计算文件大小与 SHA-256
+func hashFile(path string) (int64, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return 0, "", err
+	}
+	return n, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// replaceDir 用 src 的内容替换 dst：先复制到同级临时目录，再通过重命名切换，src 不存在时 dst 被清空
+func replaceDir(dst, src string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+
+	tmp := dst + ".restore"
+	old := dst + ".old"
+	os.RemoveAll(tmp)
+	os.RemoveAll(old)
+
+	if err := os.MkdirAll(tmp, 0755); err != nil {
+		return err
+	}
+	if err := copyTree(src, tmp); err != nil {
+		os.RemoveAll(tmp)
+		return err
+	}
+
+	if _, err := os.Stat(dst); err == nil {
+		if err := os.Rename(dst, old); err != nil {
+			os.RemoveAll(tmp)
+			return err
+		}
+	}
+	if err := os.Rename(tmp, dst); err != nil {
+		os.Rename(old, dst)
+		os.RemoveAll(tmp)
+		return err
+	}
+	return os.RemoveAll(old)
+}
+
+// copyTree 复制 src 下的所有普通文件到 dst
+func copyTree(src, dst string) error {
+	return walkFiles(src, func(path, rel string) error {
+		return copyFile(path, filepath.Join(dst, rel))
+	})
+}
+
+// copyFile 复制文件（保留权限位）
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// saveReader 将 r 的内容写入文件
+func saveReader(path string, r io.Reader) error {
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, r); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// isRegularFile 判断路径是否为普通文件
+func isRegularFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// readSlugs 读取 postsPath 下所有文章的 slug -> id（直接读取 meta.json，不经过仓库）
+func readSlugs(postsPath string) map[string]string {
+	owners := make(map[string]string)
+
+	entries, err := os.ReadDir(postsPath)
+	if err != nil {
+		return owners
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	for _, id := range names {
+		if slug := readSlug(filepath.Join(postsPath, id, "meta.json")); slug != "" {
+			if _, exists := owners[slug]; !exists {
+				owners[slug] = id
+			}
+		}
+	}
+	return owners
+}
+
+// readSlug 读取 meta.json 中的 slug，失败时返回空字符串
+func readSlug(metaPath string) string {
+	data, err := os.ReadFile(metaPath)
+	if err != nil {
+		return ""
+	}
+	var meta struct {
+		Slug string `json:"slug"`
+	}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return ""
+	}
+	return meta.Slug
+}
@@ -0,0 +1,340 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/repository"
+	"github.com/next-ai-ventus/server/internal/repository/file"
+	"github.com/next-ai-ventus/server/internal/storage"
+)
+
+type backupFixture struct {
+	contentPath string
+	uploadsPath string
+	repo        *file.FilePostRepository
+	posts       *PostService
+	backup      *BackupService
+}
+
+func setupBackupFixture(t *testing.T) *backupFixture {
+	t.Helper()
+
+	root := t.TempDir()
+	f := &backupFixture{
+		contentPath: filepath.Join(root, "content"),
+		uploadsPath: filepath.Join(root, "uploads"),
+	}
+	repo, err := file.NewFilePostRepository(f.contentPath)
+	if err != nil {
+		t.Fatalf("NewFilePostRepository() error = %v", err)
+	}
+	f.repo = repo
+	f.posts = NewPostService(repo, NewSlugService(repo))
+	f.backup = NewBackupService(repo, f.contentPath, storage.NewLocal(f.uploadsPath, storage.URLPrefix))
+	return f
+}
+
+func (f *backupFixture) createPost(t *testing.T, title string) string {
+	t.Helper()
+
+	post, err := f.posts.CreatePost(CreatePostInput{Title: title, Content: "Content of " + title})
+	if err != nil {
+		t.Fatalf("CreatePost() error = %v", err)
+	}
+	return post.ID
+}
+
+func (f *backupFixture) writeUpload(t *testing.T, name, content string) {
+	t.Helper()
+
+	path := filepath.Join(f.uploadsPath, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (f *backupFixture) export(t *testing.T, format string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if _, err := f.backup.Export(&buf, format); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestBackupService_RoundTrip(t *testing.T) {
+	for _, format := range []string{ArchiveZip, ArchiveTarGz} {
+		t.Run(format, func(t *testing.T) {
+			src := setupBackupFixture(t)
+			draftID := src.createPost(t, "Draft Post")
+			publishedID := src.createPost(t, "Published Post")
+			status := "published"
+			if _, err := src.posts.UpdatePost(publishedID, UpdatePostInput{Status: &status}, 1); err != nil {
+				t.Fatalf("UpdatePost() error = %v", err)
+			}
+			src.writeUpload(t, "2026/01/a.png", "png-data")
+			for _, name := range []string{viewsFileName, spamFileName, spamLogFileName, webhooksFileName, newsletterFileName} {
+				if err := os.WriteFile(filepath.Join(src.contentPath, name), []byte(`{}`), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, name := range postDataDirs {
+				os.MkdirAll(filepath.Join(src.contentPath, name), 0755)
+				if err := os.WriteFile(filepath.Join(src.contentPath, name, publishedID+".json"), []byte(`[]`), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var buf bytes.Buffer
+			manifest, err := src.backup.Export(&buf, format)
+			if err != nil {
+				t.Fatalf("Export() error = %v", err)
+			}
+			if manifest.Posts != 2 || manifest.Uploads != 1 || manifest.Revisions {
+				t.Errorf("manifest = %d posts, %d uploads, revisions %t", manifest.Posts, manifest.Uploads, manifest.Revisions)
+			}
+
+			dst := setupBackupFixture(t)
+			report, err := dst.backup.Import(&buf, RestoreReplace)
+			if err != nil {
+				t.Fatalf("Import() error = %v", err)
+			}
+			if report.PostsAdded != 2 || report.UploadsAdded != 1 || report.CommentsAdded != 1 || report.ReactionsAdded != 1 {
+				t.Errorf("report = %+v", report)
+			}
+
+			draft, err := dst.repo.FindByID(draftID)
+			if err != nil {
+				t.Fatalf("draft not restored: %v", err)
+			}
+			if draft.IsPublished() || draft.Content != "Content of Draft Post" {
+				t.Errorf("draft = %+v", draft)
+			}
+			published, err := dst.repo.FindByID(publishedID)
+			if err != nil || !published.IsPublished() {
+				t.Errorf("published post not restored: %v", err)
+			}
+
+			data, err := os.ReadFile(filepath.Join(dst.uploadsPath, "2026", "01", "a.png"))
+			if err != nil || string(data) != "png-data" {
+				t.Errorf("upload = %q, %v", data, err)
+			}
+			for _, name := range []string{viewsFileName, spamFileName, spamLogFileName, webhooksFileName, newsletterFileName} {
+				if _, err := os.Stat(filepath.Join(dst.contentPath, name)); err != nil {
+					t.Errorf("%s not restored: %v", name, err)
+				}
+			}
+			for _, name := range postDataDirs {
+				if _, err := os.Stat(filepath.Join(dst.contentPath, name, publishedID+".json")); err != nil {
+					t.Errorf("%s not restored: %v", name, err)
+				}
+			}
+		})
+	}
+}
+
+func TestBackupService_ReplaceRemovesLocalContent(t *testing.T) {
+	src := setupBackupFixture(t)
+	backupID := src.createPost(t, "From Backup")
+	archive := src.export(t, ArchiveZip)
+
+	dst := setupBackupFixture(t)
+	localID := dst.createPost(t, "Local Only")
+	dst.writeUpload(t, "local.png", "local")
+
+	if _, err := dst.backup.Import(bytes.NewReader(archive), RestoreReplace); err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	if _, err := dst.repo.FindByID(localID); !errors.Is(err, repository.ErrPostNotFound) {
+		t.Errorf("local post should be removed, got %v", err)
+	}
+	if _, err := dst.repo.FindByID(backupID); err != nil {
+		t.Errorf("backup post not restored: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dst.uploadsPath, "local.png")); !os.IsNotExist(err) {
+		t.Errorf("local upload should be removed, got %v", err)
+	}
+}
+
+func TestBackupService_ReplaceReloadsStores(t *testing.T) {
+	src := setupBackupFixture(t)
+	postID := src.createPost(t, "With Redirect")
+	srcRedirects, err := file.NewFileRedirectRepository(src.contentPath)
+	if err != nil {
+		t.Fatalf("NewFileRedirectRepository() error = %v", err)
+	}
+	if err := srcRedirects.Save(&domain.Redirect{From: "/old", PostID: postID}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	archive := src.export(t, ArchiveZip)
+
+	dst := setupBackupFixture(t)
+	dstRedirects, err := file.NewFileRedirectRepository(dst.contentPath)
+	if err != nil {
+		t.Fatalf("NewFileRedirectRepository() error = %v", err)
+	}
+	if err := dstRedirects.Save(&domain.Redirect{From: "/local", PostID: "local"}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	dst.backup.AddStores(dstRedirects)
+
+	if _, err := dst.backup.Import(bytes.NewReader(archive), RestoreReplace); err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	// 登记的存储在恢复返回前已重新加载
+	if redirect, err := dstRedirects.Find("/old"); err != nil || redirect.PostID != postID {
+		t.Errorf("Find(/old) = %+v, %v, want restored redirect", redirect, err)
+	}
+	if _, err := dstRedirects.Find("/local"); err == nil {
+		t.Error("local redirect should be removed")
+	}
+}
+
+func TestBackupService_Merge(t *testing.T) {
+	src := setupBackupFixture(t)
+	newID := src.createPost(t, "New Post")
+	conflictID := src.createPost(t, "Shared Title")
+	src.writeUpload(t, "shared.png", "backup")
+	src.writeUpload(t, "new.png", "new")
+	archive := src.export(t, ArchiveTarGz)
+
+	dst := setupBackupFixture(t)
+	// 本地文章占用了同一 slug，但 ID 不同
+	localID := dst.createPost(t, "Local Title")
+	metaPath := filepath.Join(dst.contentPath, "posts", localID, "meta.json")
+	meta, _ := os.ReadFile(metaPath)
+	meta = bytes.Replace(meta, []byte(`"slug": "local-title"`), []byte(`"slug": "shared-title"`), 1)
+	if err := os.WriteFile(metaPath, meta, 0644); err != nil {
+		t.Fatal(err)
+	}
+	dst.writeUpload(t, "shared.png", "local")
+
+	report, err := dst.backup.Import(bytes.NewReader(archive), RestoreMerge)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	if report.PostsAdded != 1 || report.PostsSkipped != 1 || len(report.Conflicts) != 1 {
+		t.Errorf("report = %+v", report)
+	}
+	if report.UploadsAdded != 1 || report.UploadsSkipped != 1 {
+		t.Errorf("uploads = %d added, %d skipped", report.UploadsAdded, report.UploadsSkipped)
+	}
+
+	if _, err := dst.repo.FindByID(newID); err != nil {
+		t.Errorf("new post not merged: %v", err)
+	}
+	if _, err := dst.repo.FindByID(conflictID); !errors.Is(err, repository.ErrPostNotFound) {
+		t.Errorf("conflicting post should be skipped, got %v", err)
+	}
+	if _, err := dst.repo.FindByID(localID); err != nil {
+		t.Errorf("local post should be kept: %v", err)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(dst.uploadsPath, "shared.png"))
+	if string(data) != "local" {
+		t.Errorf("existing upload overwritten: %q", data)
+	}
+}
+
+func TestBackupService_RejectsTamperedArchive(t *testing.T) {
+	src := setupBackupFixture(t)
+	src.createPost(t, "Hello")
+	archive := src.export(t, ArchiveZip)
+
+	// 重新打包并修改一个文章文件的内容
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tampered bytes.Buffer
+	zw := zip.NewWriter(&tampered)
+	for _, entry := range zr.File {
+		w, _ := zw.Create(entry.Name)
+		if filepath.Base(entry.Name) == "content.md" {
+			w.Write([]byte("tampered"))
+			continue
+		}
+		rc, _ := entry.Open()
+		var data bytes.Buffer
+		data.ReadFrom(rc)
+		rc.Close()
+		w.Write(data.Bytes())
+	}
+	zw.Close()
+
+	dst := setupBackupFixture(t)
+	existingID := dst.createPost(t, "Existing")
+	if _, err := dst.backup.Import(&tampered, RestoreReplace); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("Import() error = %v, want ErrChecksumMismatch", err)
+	}
+	if _, err := dst.repo.FindByID(existingID); err != nil {
+		t.Errorf("existing content should be untouched: %v", err)
+	}
+}
+
+func TestBackupService_RejectsUnsafePaths(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, _ := zw.Create("../escape.txt")
+	w.Write([]byte("x"))
+	zw.Close()
+
+	dst := setupBackupFixture(t)
+	if _, err := dst.backup.Import(&buf, RestoreMerge); !errors.Is(err, ErrInvalidArchive) {
+		t.Fatalf("Import() error = %v, want ErrInvalidArchive", err)
+	}
+}
+
+func TestBackupService_RejectsUnlistedFiles(t *testing.T) {
+	src := setupBackupFixture(t)
+	src.createPost(t, "Hello")
+	archive := src.export(t, ArchiveZip)
+
+	zr, _ := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, entry := range zr.File {
+		w, _ := zw.Create(entry.Name)
+		rc, _ := entry.Open()
+		var data bytes.Buffer
+		data.ReadFrom(rc)
+		rc.Close()
+		w.Write(data.Bytes())
+	}
+	w, _ := zw.Create("uploads/extra.png")
+	w.Write([]byte("extra"))
+	zw.Close()
+
+	dst := setupBackupFixture(t)
+	if _, err := dst.backup.Import(&buf, RestoreMerge); !errors.Is(err, ErrInvalidArchive) {
+		t.Fatalf("Import() error = %v, want ErrInvalidArchive", err)
+	}
+}
+
+func TestBackupService_Unsupported(t *testing.T) {
+	backup := NewBackupService(repository.NewMemoryPostRepository(), t.TempDir(), storage.NewLocal(t.TempDir(), storage.URLPrefix))
+
+	var buf bytes.Buffer
+	if _, err := backup.Export(&buf, ArchiveZip); err != repository.ErrBackupUnsupported {
+		t.Errorf("Export() error = %v, want ErrBackupUnsupported", err)
+	}
+	if _, err := backup.Import(&buf, RestoreMerge); err != repository.ErrBackupUnsupported {
+		t.Errorf("Import() error = %v, want ErrBackupUnsupported", err)
+	}
+	if _, err := backup.Import(&buf, "overwrite"); err != ErrRestoreMode {
+		t.Errorf("Import() error = %v, want ErrRestoreMode", err)
+	}
+}
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/domain/valueobject"
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+var (
+	ErrCommentsClosed       = errors.New("comments are closed")
+	ErrInvalidCommentParent = errors.New("invalid parent comment")
+)
+
+// CreateCommentInput 发表评论输入
+type CreateCommentInput struct {
+	PostID     string
+	ParentID   string // 回复的评论 ID，为空表示直接评论文章
+	AuthorName string
+	Email      string // 只保存哈希
+	Body       string // Markdown
+	Held       bool   // 反垃圾判定为可疑，直接放入垃圾评论队列等待复查
+}
+
+// CommentThread 评论及其回复（按创建时间正序）
+type CommentThread struct {
+	Comment *domain.Comment
+	Replies []*CommentThread
+}
+
+// CommentObserver 评论变更观察者
+type CommentObserver interface {
+	// CommentCreated 新评论保存成功后调用
+	CommentCreated(comment *domain.Comment)
+}
+
+// CommentService 评论应用服务：新评论进入待审核队列，审核通过后公开显示
+type CommentService struct {
+	repo      repository.CommentRepository
+	posts     repository.PostRepository
+	observers []CommentObserver
+}
+
+// NewCommentService 创建评论服务
+func NewCommentService(repo repository.CommentRepository, posts repository.PostRepository) *CommentService {
+	return &CommentService{
+		repo:  repo,
+		posts: posts,
+	}
+}
+
+// AddObserver 注册评论变更观察者（应在启动阶段调用）
+func (s *CommentService) AddObserver(observer CommentObserver) {
+	s.observers = append(s.observers, observer)
+}
+
+// Create 发表评论（待审核，Held 时为垃圾评论）。文章需已发布且未关闭评论，回复的评论需属于同一文章且已公开
+func (s *CommentService) Create(input CreateCommentInput) (*domain.Comment, error) {
+	post, err := s.posts.FindByID(input.PostID)
+	if err != nil {
+		return nil, err
+	}
+	if !post.IsPublished() {
+		return nil, repository.ErrPostNotFound
+	}
+	if post.CommentsClosed {
+		return nil, ErrCommentsClosed
+	}
+
+	if input.ParentID != "" {
+		parent, err := s.repo.FindByID(input.ParentID)
+		if err != nil {
+			if errors.Is(err, repository.ErrCommentNotFound) {
+				return nil, ErrInvalidCommentParent
+			}
+			return nil, err
+		}
+		if parent.PostID != post.ID || !parent.IsApproved() {
+			return nil, ErrInvalidCommentParent
+		}
+	}
+
+	id, err := newRecordID()
+	if err != nil {
+		return nil, err
+	}
+	comment, err := domain.NewComment(id, post.ID, input.ParentID, input.AuthorName, input.Email, input.Body)
+	if err != nil {
+		return nil, err
+	}
+	if input.Held {
+		comment.SetStatus(valueobject.CommentSpam)
+	}
+	if err := s.repo.Save(comment); err != nil {
+		return nil, err
+	}
+	for _, observer := range s.observers {
+		observer.CommentCreated(comment)
+	}
+	return comment, nil
+}
+
+// Get 获取评论
+func (s *CommentService) Get(id string) (*domain.Comment, error) {
+	return s.repo.FindByID(id)
+}
+
+// Threads 返回文章公开显示的评论树与已通过的评论数。
+// 已删除的评论下仍有公开回复时保留为占位（不含作者与内容），
+// 待审核与垃圾评论连同其回复都不显示
+func (s *CommentService) Threads(postID string) ([]*CommentThread, int, error) {
+	comments, err := s.repo.FindByPost(postID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	nodes := make(map[string]*CommentThread, len(comments))
+	for _, comment := range comments {
+		nodes[comment.ID] = &CommentThread{Comment: comment}
+	}
+	var roots []*CommentThread
+	for _, comment := range comments {
+		node := nodes[comment.ID]
+		if parent, ok := nodes[comment.ParentID]; ok {
+			parent.Replies = append(parent.Replies, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+
+	count := 0
+	for _, comment := range comments {
+		if comment.IsApproved() {
+			count++
+		}
+	}
+	return pruneThreads(roots), count, nil
+}
+
+// List 按条件分页查询评论（管理端审核队列）
+func (s *CommentService) List(opts repository.CommentListOptions) ([]*domain.Comment, int, error) {
+	return s.repo.List(opts)
+}
+
+// Moderate 修改评论的审核状态，返回修改后的评论；任一评论不存在时不做任何修改
+func (s *CommentService) Moderate(ids []string, status valueobject.CommentStatus) ([]*domain.Comment, error) {
+	comments := make([]*domain.Comment, 0, len(ids))
+	for _, id := range ids {
+		comment, err := s.repo.FindByID(id)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+
+	for _, comment := range comments {
+		if comment.Status == status {
+			continue
+		}
+		comment.SetStatus(status)
+		if err := s.repo.Save(comment); err != nil {
+			return nil, err
+		}
+	}
+	return comments, nil
+}
+
+// Purge 永久删除评论（通常用于清理垃圾评论），返回删除的条数
+func (s *CommentService) Purge(ids []string) (int, error) {
+	purged := 0
+	for _, id := range ids {
+		if err := s.repo.Delete(id); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// ApprovedCounts 返回每篇文章已通过的评论数（postID -> 数量）
+func (s *CommentService) ApprovedCounts() (map[string]int, error) {
+	return s.repo.CountByPost(valueobject.CommentApproved)
+}
+
+// ReadLocked 在禁止写入评论的状态下执行 fn（用于备份）
+func (s *CommentService) ReadLocked(fn func() error) error {
+	return s.repo.ReadLocked(fn)
+}
+
+// WriteLocked 在禁止写入评论的状态下执行 fn（fn 可直接替换评论文件），返回前重新加载评论（用于恢复备份）
+func (s *CommentService) WriteLocked(fn func() error) error {
+	return s.repo.WriteLocked(fn)
+}
+
+// PostSaved 实现 PostObserver，文章保存不影响评论
+func (s *CommentService) PostSaved(post *domain.Post) {}
+
+// PostDeleted 实现 PostObserver，文章删除时一并删除其评论
+func (s *CommentService) PostDeleted(id string) {
+	_ = s.repo.DeleteByPost(id)
+}
+
+// pruneThreads 去掉不公开显示的评论，已删除但仍有公开回复的评论清空内容后保留
+func pruneThreads(threads []*CommentThread) []*CommentThread {
+	result := []*CommentThread{}
+	for _, thread := range threads {
+		thread.Replies = pruneThreads(thread.Replies)
+		comment := thread.Comment
+		switch {
+		case comment.IsApproved():
+			result = append(result, thread)
+		case comment.Status == valueobject.CommentDeleted && len(thread.Replies) > 0:
+			comment.AuthorName = ""
+			comment.EmailHash = ""
+			comment.Body = ""
+			result = append(result, thread)
+		}
+	}
+	return result
+}
+
+// newRecordID 生成评论、Webhook 等记录的 ID：创建时间 + 随机后缀
+func newRecordID() (string, error) {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	return time.Now().UTC().Format("20060102150405") + "-" + hex.EncodeToString(suffix), nil
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/domain/valueobject"
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+func setupCommentService(t *testing.T) (*CommentService, *PostService, *domain.Post) {
+	t.Helper()
+
+	postService, repo := setupTestServices()
+	commentService := NewCommentService(repository.NewMemoryCommentRepository(), repo)
+	postService.AddObserver(commentService)
+
+	post, err := postService.CreatePost(CreatePostInput{Title: "Hello", Content: "Content"})
+	if err != nil {
+		t.Fatalf("CreatePost() error = %v", err)
+	}
+	published := "published"
+	post, err = postService.UpdatePost(post.ID, UpdatePostInput{Status: &published}, post.Version)
+	if err != nil {
+		t.Fatalf("UpdatePost() error = %v", err)
+	}
+	return commentService, postService, post
+}
+
+func TestCommentService_Create(t *testing.T) {
+	comments, postService, post := setupCommentService(t)
+
+	comment, err := comments.Create(CreateCommentInput{PostID: post.ID, AuthorName: "Alice", Email: "alice@example.com", Body: "Nice post"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if comment.Status != valueobject.CommentPending {
+		t.Errorf("new comment status = %v, want pending", comment.Status)
+	}
+
+	// 待审核的评论不能被回复
+	_, err = comments.Create(CreateCommentInput{PostID: post.ID, ParentID: comment.ID, AuthorName: "Bob", Body: "Reply"})
+	if err != ErrInvalidCommentParent {
+		t.Errorf("reply to pending comment error = %v, want ErrInvalidCommentParent", err)
+	}
+	comments.Moderate([]string{comment.ID}, valueobject.CommentApproved)
+	if _, err := comments.Create(CreateCommentInput{PostID: post.ID, ParentID: comment.ID, AuthorName: "Bob", Body: "Reply"}); err != nil {
+		t.Errorf("reply to approved comment error = %v", err)
+	}
+
+	// 回复的评论必须属于同一文章
+	other, _ := postService.CreatePost(CreatePostInput{Title: "Other", Content: "Content"})
+	published := "published"
+	other, _ = postService.UpdatePost(other.ID, UpdatePostInput{Status: &published}, other.Version)
+	if _, err := comments.Create(CreateCommentInput{PostID: other.ID, ParentID: comment.ID, AuthorName: "Bob", Body: "Reply"}); err != ErrInvalidCommentParent {
+		t.Errorf("reply across posts error = %v, want ErrInvalidCommentParent", err)
+	}
+
+	// 关闭评论
+	closed := true
+	if _, err := postService.UpdatePost(post.ID, UpdatePostInput{CommentsClosed: &closed}, post.Version); err != nil {
+		t.Fatalf("UpdatePost() error = %v", err)
+	}
+	if _, err := comments.Create(CreateCommentInput{PostID: post.ID, AuthorName: "Carol", Body: "Late"}); err != ErrCommentsClosed {
+		t.Errorf("Create() on closed post error = %v, want ErrCommentsClosed", err)
+	}
+
+	// 草稿与不存在的文章
+	draft, _ := postService.CreatePost(CreatePostInput{Title: "Draft", Content: "Content"})
+	for _, id := range []string{draft.ID, "missing"} {
+		if _, err := comments.Create(CreateCommentInput{PostID: id, AuthorName: "Dave", Body: "Hi"}); !errors.Is(err, repository.ErrPostNotFound) {
+			t.Errorf("Create(%s) error = %v, want ErrPostNotFound", id, err)
+		}
+	}
+
+	if _, err := comments.Create(CreateCommentInput{PostID: other.ID, AuthorName: "", Body: "Hi"}); err != domain.ErrEmptyCommentAuthor {
+		t.Errorf("Create() without author error = %v, want ErrEmptyCommentAuthor", err)
+	}
+}
+
+func TestCommentService_CreateHeld(t *testing.T) {
+	comments, _, post := setupCommentService(t)
+
+	comment, err := comments.Create(CreateCommentInput{PostID: post.ID, AuthorName: "Eve", Body: "Suspicious", Held: true})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if comment.Status != valueobject.CommentSpam {
+		t.Errorf("held comment status = %v, want spam", comment.Status)
+	}
+}
+
+func TestCommentService_Threads(t *testing.T) {
+	repo := repository.NewMemoryCommentRepository()
+	service := NewCommentService(repo, repository.NewMemoryPostRepository())
+
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	add := func(id, parentID string, status valueobject.CommentStatus) {
+		repo.Save(&domain.Comment{
+			ID: id, PostID: "p1", ParentID: parentID, AuthorName: "Author " + id, Body: "Body " + id,
+			Status: status, CreatedAt: base,
+		})
+		base = base.Add(time.Minute)
+	}
+	add("a", "", valueobject.CommentApproved)
+	add("a1", "a", valueobject.CommentApproved)
+	add("a2", "a", valueobject.CommentPending)
+	add("d", "", valueobject.CommentDeleted)
+	add("d1", "d", valueobject.CommentApproved)
+	add("e", "", valueobject.CommentDeleted)
+	add("s", "", valueobject.CommentSpam)
+	add("s1", "s", valueobject.CommentApproved)
+
+	threads, count, err := service.Threads("p1")
+	if err != nil {
+		t.Fatalf("Threads() error = %v", err)
+	}
+	if count != 4 {
+		t.Errorf("approved count = %d, want 4", count)
+	}
+	if len(threads) != 2 || threads[0].Comment.ID != "a" || threads[1].Comment.ID != "d" {
+		t.Fatalf("threads = %v, want a and placeholder d", threads)
+	}
+	if len(threads[0].Replies) != 1 || threads[0].Replies[0].Comment.ID != "a1" {
+		t.Errorf("replies of a = %v, want only approved a1", threads[0].Replies)
+	}
+	placeholder := threads[1].Comment
+	if placeholder.Body != "" || placeholder.AuthorName != "" || len(threads[1].Replies) != 1 {
+		t.Errorf("deleted comment placeholder = %+v, want empty body and author with reply d1", placeholder)
+	}
+}
+
+func TestCommentService_ModerateAndPurge(t *testing.T) {
+	comments, postService, post := setupCommentService(t)
+
+	first, _ := comments.Create(CreateCommentInput{PostID: post.ID, AuthorName: "Alice", Body: "One"})
+	second, _ := comments.Create(CreateCommentInput{PostID: post.ID, AuthorName: "Bob", Body: "Two"})
+
+	if _, err := comments.Moderate([]string{first.ID, "missing"}, valueobject.CommentApproved); !errors.Is(err, repository.ErrCommentNotFound) {
+		t.Errorf("Moderate() with missing id error = %v, want ErrCommentNotFound", err)
+	}
+	if got, _ := comments.Get(first.ID); got.Status != valueobject.CommentPending {
+		t.Errorf("Moderate() with missing id should not change other comments, status = %v", got.Status)
+	}
+
+	if _, err := comments.Moderate([]string{first.ID}, valueobject.CommentApproved); err != nil {
+		t.Fatalf("Moderate() error = %v", err)
+	}
+	if _, err := comments.Moderate([]string{second.ID}, valueobject.CommentSpam); err != nil {
+		t.Fatalf("Moderate() error = %v", err)
+	}
+	counts, _ := comments.ApprovedCounts()
+	if counts[post.ID] != 1 {
+		t.Errorf("ApprovedCounts() = %v, want 1 for post", counts)
+	}
+
+	spam, total, _ := comments.List(repository.CommentListOptions{Status: valueobject.CommentSpam})
+	if total != 1 || spam[0].ID != second.ID {
+		t.Errorf("List(spam) = %v, want second comment", spam)
+	}
+	if n, err := comments.Purge([]string{second.ID}); err != nil || n != 1 {
+		t.Errorf("Purge() = %d, %v, want 1", n, err)
+	}
+
+	// 删除文章时一并删除评论
+	if err := postService.DeletePost(post.ID); err != nil {
+		t.Fatalf("DeletePost() error = %v", err)
+	}
+	if _, err := comments.Get(first.ID); !errors.Is(err, repository.ErrCommentNotFound) {
+		t.Errorf("comment of deleted post error = %v, want ErrCommentNotFound", err)
+	}
+}
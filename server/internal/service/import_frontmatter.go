@@ -0,0 +1,206 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/pelletier/go-toml/v2"
+)
+
+var errNoFrontMatter = errors.New("no front matter")
+
+// frontMatter 解析后的 front matter 字段
+type frontMatter map[string]interface{}
+
+// splitFrontMatter 拆分 front matter 与正文。支持 YAML（---）、TOML（+++）与 JSON（{}）
+func splitFrontMatter(data []byte) (frontMatter, string, error) {
+	text := strings.TrimPrefix(string(data), "\ufeff")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	firstLine, rest, _ := strings.Cut(text, "\n")
+	switch strings.TrimSpace(firstLine) {
+	case "---":
+		raw, body, ok := cutFence(rest, "---")
+		if !ok {
+			return nil, "", fmt.Errorf("unterminated YAML front matter")
+		}
+		fm := frontMatter{}
+		if err := yaml.Unmarshal([]byte(raw), &fm); err != nil {
+			return nil, "", fmt.Errorf("parse YAML front matter: %w", err)
+		}
+		return fm, body, nil
+	case "+++":
+		raw, body, ok := cutFence(rest, "+++")
+		if !ok {
+			return nil, "", fmt.Errorf("unterminated TOML front matter")
+		}
+		fm := frontMatter{}
+		if err := toml.Unmarshal([]byte(raw), &fm); err != nil {
+			return nil, "", fmt.Errorf("parse TOML front matter: %w", err)
+		}
+		return fm, body, nil
+	}
+
+	if strings.HasPrefix(text, "{") {
+		dec := json.NewDecoder(strings.NewReader(text))
+		fm := frontMatter{}
+		if err := dec.Decode(&fm); err != nil {
+			return nil, "", fmt.Errorf("parse JSON front matter: %w", err)
+		}
+		var rest bytes.Buffer
+		rest.ReadFrom(dec.Buffered())
+		return fm, rest.String(), nil
+	}
+
+	return nil, text, errNoFrontMatter
+}
+
+// splitOpenFrontMatter 拆分省略了开头 "---" 的 YAML front matter（Hexo 写法）
+func splitOpenFrontMatter(text string) (frontMatter, string, bool) {
+	raw, body, ok := cutFence(text, "---")
+	if !ok || strings.TrimSpace(raw) == "" {
+		return nil, "", false
+	}
+	fm := frontMatter{}
+	if err := yaml.Unmarshal([]byte(raw), &fm); err != nil || len(fm) == 0 {
+		return nil, "", false
+	}
+	return fm, body, true
+}
+
+// cutFence 在 text 中找到单独成行的 fence，返回其前后的内容
+func cutFence(text, fence string) (string, string, bool) {
+	offset := 0
+	for offset <= len(text) {
+		line, _, found := strings.Cut(text[offset:], "\n")
+		if strings.TrimRight(line, " \t") == fence {
+			end := offset + len(line)
+			if found {
+				end++
+			}
+			return text[:offset], text[end:], true
+		}
+		if !found {
+			break
+		}
+		offset += len(line) + 1
+	}
+	return "", "", false
+}
+
+// String 读取第一个非空的字符串字段
+func (fm frontMatter) String(keys ...string) string {
+	for _, key := range keys {
+		switch v := fm[key].(type) {
+		case string:
+			if s := strings.TrimSpace(v); s != "" {
+				return s
+			}
+		case nil:
+		default:
+			if s := strings.TrimSpace(fmt.Sprint(v)); s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// Bool 读取布尔字段，支持 "true"/"false" 字符串
+func (fm frontMatter) Bool(key string) (value, ok bool) {
+	switch v := fm[key].(type) {
+	case bool:
+		return v, true
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "true", "yes":
+			return true, true
+		case "false", "no":
+			return false, true
+		}
+	}
+	return false, false
+}
+
+// List 读取列表字段，嵌套列表会被展开。
+// 字段为单个字符串时按逗号分隔，没有逗号时按空白分隔（Jekyll 写法）
+func (fm frontMatter) List(key string) []string {
+	if v, ok := fm[key].(string); ok {
+		if !strings.Contains(v, ",") {
+			return strings.Fields(v)
+		}
+		var values []string
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				values = append(values, part)
+			}
+		}
+		return values
+	}
+
+	var values []string
+	var collect func(v interface{})
+	collect = func(v interface{}) {
+		switch v := v.(type) {
+		case []interface{}:
+			for _, item := range v {
+				collect(item)
+			}
+		case nil:
+		default:
+			if s := strings.TrimSpace(fmt.Sprint(v)); s != "" {
+				values = append(values, s)
+			}
+		}
+	}
+	collect(fm[key])
+	return values
+}
+
+// Time 读取第一个可解析的时间字段
+func (fm frontMatter) Time(keys ...string) (time.Time, bool) {
+	for _, key := range keys {
+		switch v := fm[key].(type) {
+		case time.Time:
+			return v, true
+		case nil:
+		default:
+			if t, ok := parseImportTime(fmt.Sprint(v)); ok {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// importTimeLayouts 常见静态站点生成器的时间格式
+var importTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05 -07:00",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02 15:04:05",
+	"2006/01/02",
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+// parseImportTime 按常见格式解析时间，没有时区的时间按本地时区处理
+func parseImportTime(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range importTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSplitFrontMatter(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantTitle string
+		wantBody  string
+	}{
+		{
+			name:      "yaml",
+			input:     "---\ntitle: Hello\ntags: [go, web]\n---\nBody\n",
+			wantTitle: "Hello",
+			wantBody:  "Body\n",
+		},
+		{
+			name:      "toml",
+			input:     "+++\ntitle = \"Hello\"\ndate = 2023-05-01T10:00:00Z\n+++\n\nBody",
+			wantTitle: "Hello",
+			wantBody:  "\nBody",
+		},
+		{
+			name:      "json",
+			input:     "{\"title\": \"Hello\"}\nBody",
+			wantTitle: "Hello",
+			wantBody:  "\nBody",
+		},
+		{
+			name:      "crlf and bom",
+			input:     "\ufeff---\r\ntitle: Hello\r\n---\r\nBody",
+			wantTitle: "Hello",
+			wantBody:  "Body",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fm, body, err := splitFrontMatter([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("splitFrontMatter() error = %v", err)
+			}
+			if got := fm.String("title"); got != tt.wantTitle {
+				t.Errorf("title = %q, want %q", got, tt.wantTitle)
+			}
+			if body != tt.wantBody {
+				t.Errorf("body = %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+
+	if _, _, err := splitFrontMatter([]byte("# Just markdown")); err != errNoFrontMatter {
+		t.Errorf("splitFrontMatter() without front matter error = %v, want errNoFrontMatter", err)
+	}
+	if _, _, err := splitFrontMatter([]byte("---\ntitle: Hello\n")); err == nil {
+		t.Error("splitFrontMatter() with unterminated front matter should fail")
+	}
+}
+
+func TestFrontMatter_Fields(t *testing.T) {
+	fm, _, err := splitFrontMatter([]byte(`---
+title: Hello
+tags: go web
+categories:
+  - [Backend, Go]
+  - Notes
+draft: "true"
+date: 2023-05-01 10:30:00 +0800
+---
+`))
+	if err != nil {
+		t.Fatalf("splitFrontMatter() error = %v", err)
+	}
+
+	if got, want := fm.List("tags"), []string{"go", "web"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("tags = %v, want %v", got, want)
+	}
+	if got, want := fm.List("categories"), []string{"Backend", "Go", "Notes"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("categories = %v, want %v", got, want)
+	}
+	if draft, ok := fm.Bool("draft"); !ok || !draft {
+		t.Errorf("draft = %v, %v, want true", draft, ok)
+	}
+
+	date, ok := fm.Time("date")
+	want := time.Date(2023, 5, 1, 2, 30, 0, 0, time.UTC)
+	if !ok || !date.Equal(want) {
+		t.Errorf("date = %v, want %v", date, want)
+	}
+}
+
+func TestNormalizeImportTags(t *testing.T) {
+	tags, dropped := normalizeImportTags([]string{"Go", "Web Dev", "go", "!!!"})
+	if want := []string{"go", "web-dev"}; !reflect.DeepEqual(tags, want) {
+		t.Errorf("tags = %v, want %v", tags, want)
+	}
+	if want := []string{"!!!"}; !reflect.DeepEqual(dropped, want) {
+		t.Errorf("dropped = %v, want %v", dropped, want)
+	}
+}
+
+func TestRewriteImageRefs(t *testing.T) {
+	content := "![a](img/a.png \"A\")\n<img class=\"x\" src=\"b.jpg\">\n![remote](https://example.com/c.png)"
+	got := rewriteImageRefs(content, func(ref string) string { return "[" + ref + "]" })
+	want := "![a]([img/a.png] \"A\")\n<img class=\"x\" src=\"[b.jpg]\">\n![remote]([https://example.com/c.png])"
+	if got != want {
+		t.Errorf("rewriteImageRefs() = %q, want %q", got, want)
+	}
+}
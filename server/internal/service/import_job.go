@@ -0,0 +1,125 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	ErrImportRunning     = errors.New("another import is running")
+	ErrImportJobNotFound = errors.New("import job not found")
+)
+
+// 导入任务状态
+const (
+	JobRunning   = "running"
+	JobCompleted = "completed"
+	JobFailed    = "failed"
+)
+
+// maxFinishedJobs 保留的已结束任务数
+const maxFinishedJobs = 20
+
+// ImportJob 后台导入任务的状态快照
+type ImportJob struct {
+	ID         string        `json:"jobId"`
+	Status     string        `json:"status"`
+	Done       int           `json:"done"`
+	Total      int           `json:"total"`
+	Report     *ImportReport `json:"report,omitempty"`
+	Error      string        `json:"error,omitempty"`
+	StartedAt  time.Time     `json:"startedAt"`
+	FinishedAt *time.Time    `json:"finishedAt,omitempty"`
+}
+
+// ImportJobs 在后台执行导入并记录进度；同一时间只运行一个导入任务
+type ImportJobs struct {
+	jobs     map[string]*ImportJob
+	finished []string // 已结束任务的 ID，按结束时间排序
+	running  string
+	mu       sync.Mutex
+}
+
+// NewImportJobs 创建导入任务管理器
+func NewImportJobs() *ImportJobs {
+	return &ImportJobs{jobs: make(map[string]*ImportJob)}
+}
+
+// Start 在后台执行 run，run 通过 progress 报告进度；已有任务运行时返回 ErrImportRunning
+func (j *ImportJobs) Start(run func(progress func(done, total int)) (*ImportReport, error)) (*ImportJob, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.running != "" {
+		return nil, ErrImportRunning
+	}
+
+	id, err := newJobID()
+	if err != nil {
+		return nil, err
+	}
+	job := &ImportJob{ID: id, Status: JobRunning, StartedAt: time.Now()}
+	j.jobs[id] = job
+	j.running = id
+
+	progress := func(done, total int) {
+		j.mu.Lock()
+		defer j.mu.Unlock()
+		job.Done, job.Total = done, total
+	}
+	go func() {
+		report, err := run(progress)
+		j.finish(job, report, err)
+	}()
+
+	copied := *job
+	return &copied, nil
+}
+
+// Get 返回任务状态
+func (j *ImportJobs) Get(id string) (*ImportJob, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	job, ok := j.jobs[id]
+	if !ok {
+		return nil, ErrImportJobNotFound
+	}
+	copied := *job
+	return &copied, nil
+}
+
+// finish 记录任务结果，并清理过旧的已结束任务
+func (j *ImportJobs) finish(job *ImportJob, report *ImportReport, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := time.Now()
+	job.FinishedAt = &now
+	job.Report = report
+	if err != nil {
+		job.Status = JobFailed
+		job.Error = err.Error()
+	} else {
+		job.Status = JobCompleted
+	}
+	j.running = ""
+
+	j.finished = append(j.finished, job.ID)
+	for len(j.finished) > maxFinishedJobs {
+		delete(j.jobs, j.finished[0])
+		j.finished = j.finished[1:]
+	}
+}
+
+// newJobID 生成随机任务 ID
+func newJobID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
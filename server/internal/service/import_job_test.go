@@ -0,0 +1,67 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// waitJob 等待任务结束
+func waitJob(t *testing.T, jobs *ImportJobs, id string) *ImportJob {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := jobs.Get(id)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if job.Status != JobRunning {
+			return job
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("job %s did not finish", id)
+	return nil
+}
+
+func TestImportJobs(t *testing.T) {
+	jobs := NewImportJobs()
+
+	release := make(chan struct{})
+	job, err := jobs.Start(func(progress func(done, total int)) (*ImportReport, error) {
+		progress(1, 2)
+		<-release
+		progress(2, 2)
+		return &ImportReport{Created: 2}, nil
+	})
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if job.Status != JobRunning || job.ID == "" {
+		t.Errorf("job = %+v", job)
+	}
+
+	// 同一时间只允许一个导入任务
+	if _, err := jobs.Start(func(func(int, int)) (*ImportReport, error) { return nil, nil }); !errors.Is(err, ErrImportRunning) {
+		t.Errorf("second Start() error = %v, want ErrImportRunning", err)
+	}
+
+	close(release)
+	done := waitJob(t, jobs, job.ID)
+	if done.Status != JobCompleted || done.Done != 2 || done.Total != 2 || done.Report.Created != 2 || done.FinishedAt == nil {
+		t.Errorf("finished job = %+v", done)
+	}
+
+	failed, err := jobs.Start(func(func(int, int)) (*ImportReport, error) { return nil, ErrInvalidWXR })
+	if err != nil {
+		t.Fatalf("Start() after finish error = %v", err)
+	}
+	if done := waitJob(t, jobs, failed.ID); done.Status != JobFailed || done.Error != ErrInvalidWXR.Error() {
+		t.Errorf("failed job = %+v", done)
+	}
+
+	if _, err := jobs.Get("missing"); !errors.Is(err, ErrImportJobNotFound) {
+		t.Errorf("Get(missing) error = %v, want ErrImportJobNotFound", err)
+	}
+}
@@ -0,0 +1,508 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mozillazg/go-slugify"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/domain/valueobject"
+	"github.com/next-ai-ventus/server/internal/repository"
+	"github.com/next-ai-ventus/server/internal/storage"
+)
+
+// 单篇文章的导入结果
+const (
+	ImportCreated = "created" // 已创建（dry-run 时表示将会创建）
+	ImportSkipped = "skipped" // 已导入过，跳过
+	ImportFailed  = "failed"
+)
+
+// importedUploadsDir 导入的图片在上传目录中的子目录
+const importedUploadsDir = "imported"
+
+// importImageExts 允许导入的图片类型
+var importImageExts = map[string]bool{
+	".jpg":  true,
+	".jpeg": true,
+	".png":  true,
+	".webp": true,
+	".gif":  true,
+	".svg":  true,
+}
+
+// ImportOptions 导入选项
+type ImportOptions struct {
+	Format string // 源站点格式，为空时自动识别
+	DryRun bool   // 只生成报告，不保存文章与图片
+	Editor string // 操作者用户名（版本化仓库用作提交作者）
+
+	IncludePages bool                  // WordPress：同时导入页面（带 page 标签）
+	Progress     func(done, total int) // 每处理完一篇文章调用一次
+}
+
+// ImportReport 导入报告
+type ImportReport struct {
+	Source  string        `json:"source"`
+	Format  string        `json:"format"`
+	DryRun  bool          `json:"dryRun"`
+	Created int           `json:"created"`
+	Skipped int           `json:"skipped"`
+	Failed  int           `json:"failed"`
+	Images  int           `json:"images"` // 复制到上传目录的图片数
+	Items   []*ImportItem `json:"items"`
+}
+
+// ImportItem 单篇文章的导入结果
+type ImportItem struct {
+	Source    string   `json:"source"`
+	Title     string   `json:"title,omitempty"`
+	ID        string   `json:"id,omitempty"`
+	Slug      string   `json:"slug,omitempty"`
+	Status    string   `json:"status,omitempty"`
+	Author    string   `json:"author,omitempty"`
+	Result    string   `json:"result"`
+	Images    int      `json:"images"`
+	Redirects []string `json:"redirects,omitempty"` // 指向该文章的旧地址
+	Warnings  []string `json:"warnings,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// add 记录一篇文章的导入结果
+func (r *ImportReport) add(item *ImportItem) {
+	r.Items = append(r.Items, item)
+	switch item.Result {
+	case ImportCreated:
+		r.Created++
+		r.Images += item.Images
+	case ImportSkipped:
+		r.Skipped++
+	case ImportFailed:
+		r.Failed++
+	}
+}
+
+// WriteReport 将导入报告写为 JSON 文件
+func WriteReport(path string, report *ImportReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal import report failed: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// importedPost 从源站点解析出的文章（与源格式无关）
+type importedPost struct {
+	source   string // 源文件或条目，用于报告
+	title    string
+	slug     string // 源站点的 slug，会被规范化并去重
+	content  string
+	excerpt  string
+	cover    string
+	tags     []string // 标签与分类的原始名称
+	draft    bool
+	date     time.Time
+	updated  time.Time
+	author   string // 原作者，保存时用作提交作者
+	warnings []string
+
+	permalinks []string // 原站点的文章地址，导入后重定向到新文章
+
+	baseDir   string   // 相对图片路径的解析目录
+	assetDirs []string // 额外的图片查找目录
+}
+
+// imageResolver 将文章中的图片引用解析为本地文件
+type imageResolver func(post *importedPost, ref string) (string, bool)
+
+// ImportService 从其他博客系统导入文章
+type ImportService struct {
+	postService     *PostService
+	slugService     *SlugService
+	redirectService *RedirectService
+	uploads         storage.Blob
+}
+
+// NewImportService 创建导入服务，redirectService 为 nil 时不保存旧地址重定向
+func NewImportService(postService *PostService, slugService *SlugService, redirectService *RedirectService, uploads storage.Blob) *ImportService {
+	return &ImportService{
+		postService:     postService,
+		slugService:     slugService,
+		redirectService: redirectService,
+		uploads:         uploads,
+	}
+}
+
+// ImportMarkdownSite 导入 Hugo、Hexo 或 Jekyll 站点目录中的文章
+func (s *ImportService) ImportMarkdownSite(root string, opts ImportOptions) (*ImportReport, error) {
+	layout, err := detectSite(root, opts.Format)
+	if err != nil {
+		return nil, err
+	}
+	files, err := layout.postFiles()
+	if err != nil {
+		return nil, fmt.Errorf("list posts failed: %w", err)
+	}
+
+	report := &ImportReport{Source: root, Format: layout.format, DryRun: opts.DryRun, Items: []*ImportItem{}}
+	posts := make([]*importedPost, 0, len(files))
+	for _, file := range files {
+		post, err := layout.parsePost(file)
+		if err != nil {
+			rel, _ := filepath.Rel(root, file.path)
+			report.add(&ImportItem{Source: filepath.ToSlash(rel), Result: ImportFailed, Error: err.Error()})
+			continue
+		}
+		posts = append(posts, post)
+	}
+
+	s.importPosts(posts, layout.resolveImage, opts, report)
+	return report, nil
+}
+
+// ImportWordPress 导入 WordPress 导出文件（WXR），source 为报告中显示的来源。
+// 图片保留原地址，固定链接保存为重定向。
+func (s *ImportService) ImportWordPress(r io.Reader, source string, opts ImportOptions) (*ImportReport, error) {
+	site, err := parseWXR(r, opts.IncludePages)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ImportReport{Source: source, Format: SiteWordPress, DryRun: opts.DryRun, Items: []*ImportItem{}}
+	for _, item := range site.skipped {
+		report.add(item)
+	}
+
+	s.importPosts(site.posts, func(*importedPost, string) (string, bool) { return "", false }, opts, report)
+	return report, nil
+}
+
+// importPosts 按发布时间顺序保存文章，较早的文章优先获得原 slug
+func (s *ImportService) importPosts(posts []*importedPost, resolve imageResolver, opts ImportOptions, report *ImportReport) {
+	sort.SliceStable(posts, func(i, j int) bool { return posts[i].date.Before(posts[j].date) })
+
+	existing, err := s.existingPosts()
+	if err != nil {
+		for _, post := range posts {
+			report.add(&ImportItem{Source: post.source, Title: post.title, Result: ImportFailed, Error: err.Error()})
+		}
+		return
+	}
+
+	var reserved []string
+	for i, post := range posts {
+		// 标题与发布时间都相同的文章视为已导入过
+		if id, ok := existing[importKey(post.title, post.date)]; ok && !post.date.IsZero() {
+			report.add(&ImportItem{Source: post.source, Title: post.title, ID: id, Result: ImportSkipped, Warnings: []string{"already imported"}})
+		} else {
+			item := s.importPost(post, resolve, opts, reserved)
+			if item.Result == ImportCreated {
+				reserved = append(reserved, item.Slug)
+			}
+			report.add(item)
+		}
+
+		if opts.Progress != nil {
+			opts.Progress(i+1, len(posts))
+		}
+	}
+}
+
+// existingPosts 返回已有文章的 标题+创建时间 -> ID
+func (s *ImportService) existingPosts() (map[string]string, error) {
+	existing := make(map[string]string)
+	for page := 1; ; page++ {
+		result, err := s.postService.ListPosts(repository.ListOptions{Page: page, PageSize: indexPageSize})
+		if err != nil {
+			return nil, fmt.Errorf("list existing posts failed: %w", err)
+		}
+		for _, post := range result.Items {
+			existing[importKey(post.Title, post.CreatedAt)] = post.ID
+		}
+		if page >= result.TotalPages {
+			break
+		}
+	}
+	return existing, nil
+}
+
+// importKey 判断重复导入使用的键（时间精确到秒）
+func importKey(title string, date time.Time) string {
+	return fmt.Sprintf("%s\x00%d", title, date.Unix())
+}
+
+// importPost 导入单篇文章
+func (s *ImportService) importPost(post *importedPost, resolve imageResolver, opts ImportOptions, reserved []string) *ImportItem {
+	item := &ImportItem{
+		Source:   post.source,
+		Title:    post.title,
+		Status:   valueobject.StatusPublished.String(),
+		Author:   post.author,
+		Warnings: post.warnings,
+	}
+	if post.draft {
+		item.Status = valueobject.StatusDraft.String()
+	}
+	fail := func(err error) *ImportItem {
+		item.Result = ImportFailed
+		item.Error = err.Error()
+		return item
+	}
+
+	if strings.TrimSpace(post.content) == "" {
+		return fail(fmt.Errorf("empty content"))
+	}
+
+	slug, err := s.slugService.ResolveSlug(post.slug, post.title, reserved)
+	if err != nil {
+		return fail(fmt.Errorf("resolve slug failed: %w", err))
+	}
+	item.Slug = slug.String()
+	if base := slugify.Slugify(post.slug); base != "" && base != item.Slug {
+		item.Warnings = append(item.Warnings, fmt.Sprintf("slug %q is taken, using %q", base, item.Slug))
+	}
+
+	date := post.date
+	if date.IsZero() {
+		date = time.Now()
+	}
+	item.ID = fmt.Sprintf("%d-%02d-%s", date.Year(), date.Month(), item.Slug)
+
+	tags, dropped := normalizeImportTags(post.tags)
+	for _, name := range dropped {
+		item.Warnings = append(item.Warnings, fmt.Sprintf("tag %q cannot be converted", name))
+	}
+
+	// 复制图片并改写引用
+	images := newImageImporter(s.uploads, item.ID, opts.DryRun)
+	content := rewriteImageRefs(post.content, func(ref string) string {
+		return images.rewrite(post, ref, resolve)
+	})
+	cover := post.cover
+	if cover != "" {
+		cover = images.rewrite(post, cover, resolve)
+	}
+	item.Warnings = append(item.Warnings, images.warnings...)
+	if images.err != nil {
+		images.rollback()
+		return fail(images.err)
+	}
+	item.Images = len(images.copied)
+	item.Redirects = redirectPaths(post.permalinks)
+
+	if opts.DryRun {
+		item.Result = ImportCreated
+		return item
+	}
+
+	editor := post.author
+	if editor == "" {
+		editor = opts.Editor
+	}
+	_, err = s.postService.ImportPost(ImportPostInput{
+		Title:     post.title,
+		Slug:      slug,
+		Content:   content,
+		Excerpt:   post.excerpt,
+		Tags:      tags,
+		Cover:     cover,
+		Draft:     post.draft,
+		Date:      date,
+		UpdatedAt: post.updated,
+		Editor:    editor,
+	})
+	if err != nil {
+		images.rollback()
+		return fail(err)
+	}
+
+	if s.redirectService != nil {
+		for _, from := range item.Redirects {
+			if _, err := s.redirectService.Add(from, item.ID); err != nil {
+				item.Warnings = append(item.Warnings, fmt.Sprintf("save redirect %q failed: %v", from, err))
+			}
+		}
+	}
+
+	item.Result = ImportCreated
+	return item
+}
+
+// redirectPaths 规范化旧地址并去重，无法作为重定向的地址（如站点首页）被忽略
+func redirectPaths(links []string) []string {
+	var paths []string
+	seen := make(map[string]bool)
+	for _, link := range links {
+		path, err := domain.NormalizeRedirectPath(link)
+		if err != nil || seen[path] {
+			continue
+		}
+		seen[path] = true
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// normalizeImportTags 将标签与分类名称转换为合法标签（去重），返回无法转换的名称
+func normalizeImportTags(names []string) ([]string, []string) {
+	var tags, dropped []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		tag := slugify.Slugify(name)
+		if len(tag) > 30 {
+			tag = strings.TrimRight(tag[:30], "-")
+		}
+		if _, err := valueobject.NewTag(tag); err != nil {
+			dropped = append(dropped, name)
+			continue
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags, dropped
+}
+
+var (
+	// markdownImagePattern 匹配 ![alt](url "title")，第 1 组为 url
+	markdownImagePattern = regexp.MustCompile(`!\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+["'][^"']*["'])?\s*\)`)
+	// htmlImagePattern 匹配 <img src="url">，第 1 组为 url
+	htmlImagePattern = regexp.MustCompile(`<img\s[^>]*?src=["']([^"']+)["']`)
+)
+
+// rewriteImageRefs 用 fn 的返回值替换正文中的所有图片地址
+func rewriteImageRefs(content string, fn func(ref string) string) string {
+	for _, pattern := range []*regexp.Regexp{markdownImagePattern, htmlImagePattern} {
+		matches := pattern.FindAllStringSubmatchIndex(content, -1)
+		if len(matches) == 0 {
+			continue
+		}
+
+		var b strings.Builder
+		last := 0
+		for _, m := range matches {
+			start, end := m[2], m[3]
+			b.WriteString(content[last:start])
+			b.WriteString(fn(content[start:end]))
+			last = end
+		}
+		b.WriteString(content[last:])
+		content = b.String()
+	}
+	return content
+}
+
+// imageImporter 将一篇文章引用的本地图片写入上传存储的 imported/<id>/ 下
+type imageImporter struct {
+	uploads  storage.Blob
+	prefix   string
+	urlBase  string
+	dryRun   bool
+	copied   map[string]string // 源文件 -> 新地址
+	names    map[string]bool   // 已使用的文件名
+	keys     []string          // 已写入的键
+	warnings []string
+	err      error
+}
+
+// newImageImporter 创建图片导入器
+func newImageImporter(uploads storage.Blob, postID string, dryRun bool) *imageImporter {
+	prefix := path.Join(importedUploadsDir, postID)
+	return &imageImporter{
+		uploads: uploads,
+		prefix:  prefix,
+		urlBase: storage.URLPrefix + "/" + prefix,
+		dryRun:  dryRun,
+		copied:  make(map[string]string),
+		names:   make(map[string]bool),
+	}
+}
+
+// rewrite 复制 ref 指向的本地图片并返回新地址；远程图片与找不到的图片保持原样
+func (i *imageImporter) rewrite(post *importedPost, ref string, resolve imageResolver) string {
+	if i.err != nil || isRemoteRef(ref) || strings.HasPrefix(ref, "/uploads/") {
+		return ref
+	}
+
+	refPath := ref
+	if cut, _, found := strings.Cut(refPath, "#"); found {
+		refPath = cut
+	}
+	if cut, _, found := strings.Cut(refPath, "?"); found {
+		refPath = cut
+	}
+	if unescaped, err := url.PathUnescape(refPath); err == nil {
+		refPath = unescaped
+	}
+
+	src, ok := resolve(post, refPath)
+	if !ok {
+		i.warnings = append(i.warnings, fmt.Sprintf("image %q not found", ref))
+		return ref
+	}
+	if newURL, ok := i.copied[src]; ok {
+		return newURL
+	}
+
+	ext := strings.ToLower(filepath.Ext(src))
+	if !importImageExts[ext] {
+		i.warnings = append(i.warnings, fmt.Sprintf("image %q has unsupported type", ref))
+		return ref
+	}
+
+	name := i.uniqueName(filepath.Base(src))
+	if !i.dryRun {
+		key := i.prefix + "/" + name
+		if err := putFile(i.uploads, key, src); err != nil {
+			i.err = fmt.Errorf("copy image %q failed: %w", ref, err)
+			return ref
+		}
+		i.keys = append(i.keys, key)
+	}
+
+	newURL := i.urlBase + "/" + url.PathEscape(name)
+	i.copied[src] = newURL
+	return newURL
+}
+
+// uniqueName 为同名但来源不同的图片追加序号
+func (i *imageImporter) uniqueName(name string) string {
+	ext := filepath.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	candidate := name
+	for n := 2; i.names[candidate]; n++ {
+		candidate = fmt.Sprintf("%s-%d%s", stem, n, ext)
+	}
+	i.names[candidate] = true
+	return candidate
+}
+
+// rollback 删除已写入的图片（文章保存失败时调用）
+func (i *imageImporter) rollback() {
+	for _, key := range i.keys {
+		i.uploads.Delete(key)
+	}
+	i.keys = nil
+}
+
+// isRemoteRef 判断是否为远程或内联图片
+func isRemoteRef(ref string) bool {
+	lower := strings.ToLower(ref)
+	for _, prefix := range []string{"http://", "https://", "//", "data:"} {
+		if strings.HasPrefix(lower, prefix) {
+			return true
+		}
+	}
+	return false
+}
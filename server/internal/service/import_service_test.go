@@ -0,0 +1,322 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/repository"
+	"github.com/next-ai-ventus/server/internal/storage"
+)
+
+// writeSiteFiles 按相对路径写入测试站点文件
+func writeSiteFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func setupImportService(t *testing.T) (*ImportService, *PostService, *repository.MemoryPostRepository, string) {
+	t.Helper()
+
+	repo := repository.NewMemoryPostRepository()
+	slugService := NewSlugService(repo)
+	postService := NewPostService(repo, slugService)
+	redirectService := NewRedirectService(repository.NewMemoryRedirectRepository(), repo)
+	uploadsPath := t.TempDir()
+	return NewImportService(postService, slugService, redirectService, storage.NewLocal(uploadsPath, storage.URLPrefix)), postService, repo, uploadsPath
+}
+
+func findItem(t *testing.T, report *ImportReport, source string) *ImportItem {
+	t.Helper()
+
+	for _, item := range report.Items {
+		if item.Source == source {
+			return item
+		}
+	}
+	t.Fatalf("report has no item for %s: %+v", source, report.Items)
+	return nil
+}
+
+func TestImportService_Hugo(t *testing.T) {
+	site := t.TempDir()
+	writeSiteFiles(t, site, map[string]string{
+		"hugo.toml": "baseURL = \"https://example.com\"",
+		"content/posts/first/index.md": `+++
+title = "First Post"
+date = 2023-05-01T10:00:00Z
+lastmod = 2023-06-01T10:00:00Z
+tags = ["Go", "Web Dev"]
+categories = ["Notes"]
+summary = "Short summary"
++++
+Intro
+
+![diagram](diagram.png)
+![logo](/images/logo.png)
+![missing](nope.png)
+`,
+		"content/posts/first/diagram.png": "png",
+		"static/images/logo.png":          "logo",
+		"content/posts/wip.md": `---
+title: Work In Progress
+draft: true
+date: 2023-07-01
+---
+Draft body
+`,
+		"content/posts/_index.md": "---\ntitle: Posts\n---\n",
+	})
+
+	importer, postService, _, uploadsPath := setupImportService(t)
+	report, err := importer.ImportMarkdownSite(site, ImportOptions{})
+	if err != nil {
+		t.Fatalf("ImportMarkdownSite() error = %v", err)
+	}
+	if report.Format != SiteHugo || report.Created != 2 || report.Failed != 0 || report.Images != 2 {
+		t.Fatalf("report = %+v", report)
+	}
+
+	item := findItem(t, report, "content/posts/first/index.md")
+	if item.Slug != "first" || item.ID != "2023-05-first" {
+		t.Errorf("item = %+v", item)
+	}
+	if len(item.Warnings) != 1 || !strings.Contains(item.Warnings[0], "nope.png") {
+		t.Errorf("warnings = %v, want missing image warning", item.Warnings)
+	}
+
+	post, err := postService.GetPostBySlug("first")
+	if err != nil {
+		t.Fatalf("GetPostBySlug() error = %v", err)
+	}
+	if !post.IsPublished() || post.PublishedAt == nil || post.PublishedAt.Year() != 2023 {
+		t.Errorf("post should be published in 2023, got %v %v", post.Status, post.PublishedAt)
+	}
+	if post.Excerpt != "Short summary" {
+		t.Errorf("Excerpt = %q", post.Excerpt)
+	}
+	if got := post.GetTagNames(); strings.Join(got, ",") != "go,web-dev,notes" {
+		t.Errorf("tags = %v", got)
+	}
+	if !post.UpdatedAt.Equal(time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("UpdatedAt = %v", post.UpdatedAt)
+	}
+	if !strings.Contains(post.Content, "](/uploads/imported/2023-05-first/diagram.png)") ||
+		!strings.Contains(post.Content, "](/uploads/imported/2023-05-first/logo.png)") ||
+		!strings.Contains(post.Content, "](nope.png)") {
+		t.Errorf("content images not rewritten:\n%s", post.Content)
+	}
+	if data, err := os.ReadFile(filepath.Join(uploadsPath, "imported", "2023-05-first", "diagram.png")); err != nil || string(data) != "png" {
+		t.Errorf("copied image = %q, %v", data, err)
+	}
+
+	draft, err := postService.GetPostBySlug("wip")
+	if err != nil || draft.IsPublished() {
+		t.Errorf("draft = %v, %v", draft, err)
+	}
+}
+
+func TestImportService_Hexo(t *testing.T) {
+	site := t.TempDir()
+	writeSiteFiles(t, site, map[string]string{
+		"_config.yml": "title: Hexo",
+		"source/_posts/hello-hexo.md": `title: Hello Hexo
+date: 2022-01-02 08:00:00
+tags:
+- hexo
+categories:
+- [Tech, Blog]
+---
+Hello <!--more--> world ![a](pic.jpg)
+`,
+		"source/_posts/hello-hexo/pic.jpg": "jpg",
+		"source/_drafts/idea.md": `---
+title: Idea
+---
+Someday
+`,
+	})
+
+	importer, postService, _, _ := setupImportService(t)
+	report, err := importer.ImportMarkdownSite(site, ImportOptions{})
+	if err != nil {
+		t.Fatalf("ImportMarkdownSite() error = %v", err)
+	}
+	if report.Format != SiteHexo || report.Created != 2 || report.Images != 1 {
+		t.Fatalf("report = %+v", report)
+	}
+
+	post, err := postService.GetPostBySlug("hello-hexo")
+	if err != nil {
+		t.Fatalf("GetPostBySlug() error = %v", err)
+	}
+	if strings.Contains(post.Content, "<!--more-->") || !strings.Contains(post.Content, "/uploads/imported/2022-01-hello-hexo/pic.jpg") {
+		t.Errorf("content = %q", post.Content)
+	}
+	if got := post.GetTagNames(); strings.Join(got, ",") != "hexo,tech,blog" {
+		t.Errorf("tags = %v", got)
+	}
+
+	idea, err := postService.GetPostBySlug("idea")
+	if err != nil || idea.IsPublished() {
+		t.Errorf("draft from _drafts = %v, %v", idea, err)
+	}
+}
+
+func TestImportService_JekyllDryRunAndCollisions(t *testing.T) {
+	site := t.TempDir()
+	writeSiteFiles(t, site, map[string]string{
+		"_config.yml": "title: Jekyll",
+		"_posts/2021-03-04-hello-world.md": `---
+title: Hello World
+tags: ruby jekyll
+---
+See ![x](/assets/x.gif)
+`,
+		"_posts/2021-04-01-unpublished.md": `---
+title: Hidden
+published: false
+---
+Hidden body
+`,
+		"assets/x.gif": "gif",
+	})
+
+	importer, postService, repo, uploadsPath := setupImportService(t)
+
+	// 已有文章占用了 hello-world
+	if _, err := postService.CreatePost(CreatePostInput{Title: "Hello World!", Content: "Existing"}); err != nil {
+		t.Fatalf("CreatePost() error = %v", err)
+	}
+
+	report, err := importer.ImportMarkdownSite(site, ImportOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("ImportMarkdownSite() error = %v", err)
+	}
+	if !report.DryRun || report.Format != SiteJekyll || report.Created != 2 {
+		t.Fatalf("report = %+v", report)
+	}
+	item := findItem(t, report, "_posts/2021-03-04-hello-world.md")
+	if item.Slug != "hello-world-2" || item.ID != "2021-03-hello-world-2" || item.Images != 1 {
+		t.Errorf("item = %+v", item)
+	}
+	if hidden := findItem(t, report, "_posts/2021-04-01-unpublished.md"); hidden.Status != "draft" {
+		t.Errorf("hidden status = %q, want draft", hidden.Status)
+	}
+
+	// dry-run 不保存文章与图片
+	if count, _ := repo.Count(repository.CountOptions{}); count != 1 {
+		t.Errorf("Count() after dry-run = %d, want 1", count)
+	}
+	if entries, _ := os.ReadDir(uploadsPath); len(entries) != 0 {
+		t.Errorf("dry-run copied images: %v", entries)
+	}
+
+	report, err = importer.ImportMarkdownSite(site, ImportOptions{})
+	if err != nil || report.Created != 2 {
+		t.Fatalf("ImportMarkdownSite() = %+v, %v", report, err)
+	}
+	post, err := postService.GetPostBySlug("hello-world-2")
+	if err != nil {
+		t.Fatalf("GetPostBySlug() error = %v", err)
+	}
+	if post.PublishedAt == nil || post.PublishedAt.Format("2006-01-02") != "2021-03-04" {
+		t.Errorf("PublishedAt = %v, want date from file name", post.PublishedAt)
+	}
+
+	// 再次导入时跳过已导入的文章
+	report, err = importer.ImportMarkdownSite(site, ImportOptions{})
+	if err != nil || report.Created != 0 || report.Skipped != 2 {
+		t.Errorf("re-import report = %+v, %v", report, err)
+	}
+}
+
+func TestImportService_WordPress(t *testing.T) {
+	importer, postService, repo, _ := setupImportService(t)
+	open := func() *os.File {
+		f, err := os.Open(filepath.Join("testdata", "wxr", "blog.xml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { f.Close() })
+		return f
+	}
+
+	// dry-run 只生成报告
+	report, err := importer.ImportWordPress(open(), "blog.xml", ImportOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("ImportWordPress() error = %v", err)
+	}
+	if report.Format != SiteWordPress || report.Created != 4 || report.Skipped != 1 {
+		t.Fatalf("dry-run report = %+v", report)
+	}
+	if count, _ := repo.Count(repository.CountOptions{}); count != 0 {
+		t.Errorf("Count() after dry-run = %d, want 0", count)
+	}
+	if _, err := importer.redirectService.Resolve("/2019/05/hello-world/"); err != repository.ErrRedirectNotFound {
+		t.Errorf("dry-run saved redirect, Resolve() error = %v", err)
+	}
+
+	var progress []int
+	report, err = importer.ImportWordPress(open(), "blog.xml", ImportOptions{
+		IncludePages: true,
+		Progress:     func(done, total int) { progress = append(progress, done*10+total) },
+	})
+	if err != nil {
+		t.Fatalf("ImportWordPress() error = %v", err)
+	}
+	if report.Created != 5 || report.Failed != 0 {
+		t.Fatalf("report = %+v", report)
+	}
+	if len(progress) != 5 || progress[0] != 15 || progress[4] != 55 {
+		t.Errorf("progress = %v, want 1..5 of 5", progress)
+	}
+
+	item := findItem(t, report, "post #12")
+	if item.ID != "2019-05-hello-world" || item.Author != "Alice Liddell" || strings.Join(item.Redirects, " ") != "/2019/05/hello-world /?p=12" {
+		t.Errorf("item = %+v", item)
+	}
+
+	post, err := postService.GetPostBySlug("hello-world")
+	if err != nil {
+		t.Fatalf("GetPostBySlug() error = %v", err)
+	}
+	if !post.IsPublished() || strings.Join(post.GetTagNames(), ",") != "web-dev,golang" {
+		t.Errorf("post = %v %v", post.Status, post.GetTagNames())
+	}
+
+	// 旧固定链接与短链接都指向新文章，草稿不公开解析
+	for _, from := range []string{"https://old.example.com/2019/05/hello-world/", "/?p=12"} {
+		resolved, err := importer.redirectService.Resolve(from)
+		if err != nil || resolved.ID != "2019-05-hello-world" {
+			t.Errorf("Resolve(%q) = %v, %v", from, resolved, err)
+		}
+	}
+	if _, err := importer.redirectService.Resolve("/?p=40"); err != repository.ErrRedirectNotFound {
+		t.Errorf("Resolve(draft) error = %v, want ErrRedirectNotFound", err)
+	}
+
+	// 再次导入时跳过
+	report, err = importer.ImportWordPress(open(), "blog.xml", ImportOptions{IncludePages: true})
+	if err != nil || report.Created != 0 || report.Skipped != 6 {
+		t.Errorf("re-import report = %+v, %v", report, err)
+	}
+}
+
+func TestImportService_UnknownSite(t *testing.T) {
+	importer, _, _, _ := setupImportService(t)
+	if _, err := importer.ImportMarkdownSite(t.TempDir(), ImportOptions{}); err == nil {
+		t.Error("ImportMarkdownSite() on empty directory should fail")
+	}
+}
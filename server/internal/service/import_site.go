@@ -0,0 +1,259 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+var ErrUnknownSiteFormat = errors.New("unknown site format")
+
+// 可导入的静态站点格式
+const (
+	SiteHugo   = "hugo"
+	SiteHexo   = "hexo"
+	SiteJekyll = "jekyll"
+)
+
+// IsValidSiteFormat 判断站点格式是否受支持（空字符串表示自动识别）
+func IsValidSiteFormat(format string) bool {
+	switch format {
+	case "", SiteHugo, SiteHexo, SiteJekyll:
+		return true
+	}
+	return false
+}
+
+// jekyllFileName 匹配 Jekyll 文章文件名 YYYY-MM-DD-slug.md
+var jekyllFileName = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})-(.+)$`)
+
+// siteLayout 静态站点的目录结构
+type siteLayout struct {
+	root       string
+	format     string
+	postDirs   []string // 文章目录
+	draftDirs  []string // 草稿目录（其中的文章一律按草稿导入）
+	staticDirs []string // 以 "/" 开头的图片路径的查找根目录
+}
+
+// detectSite 识别站点格式并返回目录结构；format 为空时自动识别
+func detectSite(root, format string) (*siteLayout, error) {
+	if format == "" {
+		switch {
+		case isDir(filepath.Join(root, "source", "_posts")):
+			format = SiteHexo
+		case isDir(filepath.Join(root, "_posts")):
+			format = SiteJekyll
+		case isDir(filepath.Join(root, "content")):
+			format = SiteHugo
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnknownSiteFormat, root)
+		}
+	}
+
+	layout := &siteLayout{root: root, format: format}
+	switch format {
+	case SiteHugo:
+		content := filepath.Join(root, "content")
+		// 优先导入文章栏目，没有时导入整个 content 目录
+		for _, section := range []string{"posts", "post", "blog"} {
+			if dir := filepath.Join(content, section); isDir(dir) {
+				layout.postDirs = append(layout.postDirs, dir)
+			}
+		}
+		if len(layout.postDirs) == 0 {
+			layout.postDirs = []string{content}
+		}
+		layout.staticDirs = []string{filepath.Join(root, "static"), filepath.Join(root, "assets")}
+	case SiteHexo:
+		layout.postDirs = []string{filepath.Join(root, "source", "_posts")}
+		layout.draftDirs = []string{filepath.Join(root, "source", "_drafts")}
+		layout.staticDirs = []string{filepath.Join(root, "source")}
+	case SiteJekyll:
+		layout.postDirs = []string{filepath.Join(root, "_posts")}
+		layout.draftDirs = []string{filepath.Join(root, "_drafts")}
+		layout.staticDirs = []string{root}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownSiteFormat, format)
+	}
+
+	for _, dir := range layout.postDirs {
+		if !isDir(dir) {
+			return nil, fmt.Errorf("%w: %s not found", ErrUnknownSiteFormat, dir)
+		}
+	}
+	return layout, nil
+}
+
+// sitePostFile 站点中的一个文章文件
+type sitePostFile struct {
+	path  string
+	draft bool // 位于草稿目录
+}
+
+// postFiles 列出站点的文章文件（按路径排序）
+func (l *siteLayout) postFiles() ([]sitePostFile, error) {
+	var files []sitePostFile
+	collect := func(dir string, draft bool) error {
+		if !isDir(dir) {
+			return nil
+		}
+		return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if path != dir && strings.HasPrefix(d.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !isMarkdownFile(d.Name()) || d.Name() == "_index.md" {
+				return nil
+			}
+			files = append(files, sitePostFile{path: path, draft: draft})
+			return nil
+		})
+	}
+
+	for _, dir := range l.postDirs {
+		if err := collect(dir, false); err != nil {
+			return nil, err
+		}
+	}
+	for _, dir := range l.draftDirs {
+		if err := collect(dir, true); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].path < files[j].path })
+	return files, nil
+}
+
+// parsePost 解析文章文件，将 front matter 映射为导入文章
+func (l *siteLayout) parsePost(file sitePostFile) (*importedPost, error) {
+	data, err := os.ReadFile(file.path)
+	if err != nil {
+		return nil, err
+	}
+
+	rel, _ := filepath.Rel(l.root, file.path)
+	post := &importedPost{
+		source:  filepath.ToSlash(rel),
+		baseDir: filepath.Dir(file.path),
+	}
+
+	fm, body, err := splitFrontMatter(data)
+	if errors.Is(err, errNoFrontMatter) {
+		if open, rest, ok := splitOpenFrontMatter(body); ok && l.format == SiteHexo {
+			fm, body = open, rest
+		} else {
+			post.warnings = append(post.warnings, "no front matter")
+			fm = frontMatter{}
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	// 文件名：Hugo 页面包使用目录名，Jekyll 去掉日期前缀
+	name := strings.TrimSuffix(filepath.Base(file.path), filepath.Ext(file.path))
+	if name == "index" {
+		name = filepath.Base(filepath.Dir(file.path))
+	}
+	var fileDate time.Time
+	if m := jekyllFileName.FindStringSubmatch(name); m != nil {
+		if t, err := time.ParseInLocation("2006-01-02", m[1], time.Local); err == nil {
+			fileDate = t
+			name = m[2]
+		}
+	}
+
+	post.title = fm.String("title")
+	if post.title == "" {
+		post.title = name
+		post.warnings = append(post.warnings, "missing title, using file name")
+	}
+	post.slug = fm.String("slug", "url_slug")
+	if post.slug == "" {
+		post.slug = name
+	}
+	post.content = strings.TrimSpace(strings.Replace(body, "<!--more-->", "", 1))
+	post.excerpt = fm.String("summary", "excerpt", "description")
+	post.cover = fm.String("cover", "image", "featured_image", "thumbnail")
+
+	post.tags = append(fm.List("tags"), fm.List("categories")...)
+	post.tags = append(post.tags, fm.List("category")...)
+
+	if date, ok := fm.Time("date", "publishDate", "published_at"); ok {
+		post.date = date
+	} else if !fileDate.IsZero() {
+		post.date = fileDate
+	} else if info, err := os.Stat(file.path); err == nil {
+		post.date = info.ModTime()
+		post.warnings = append(post.warnings, "missing date, using file modification time")
+	}
+	post.updated, _ = fm.Time("lastmod", "updated", "last_modified_at", "modified")
+
+	post.draft = file.draft
+	if draft, ok := fm.Bool("draft"); ok && draft {
+		post.draft = true
+	}
+	if published, ok := fm.Bool("published"); ok && !published {
+		post.draft = true
+	}
+
+	// Hexo 资源目录：source/_posts/<文件名>/
+	if l.format == SiteHexo {
+		post.assetDirs = []string{filepath.Join(filepath.Dir(file.path), strings.TrimSuffix(filepath.Base(file.path), filepath.Ext(file.path)))}
+	}
+	return post, nil
+}
+
+// resolveImage 将文章中的图片路径解析为本地文件，找不到时返回 false
+func (l *siteLayout) resolveImage(post *importedPost, ref string) (string, bool) {
+	var candidates []string
+	if strings.HasPrefix(ref, "/") {
+		for _, dir := range l.staticDirs {
+			candidates = append(candidates, filepath.Join(dir, filepath.FromSlash(ref)))
+		}
+	} else {
+		candidates = append(candidates, filepath.Join(post.baseDir, filepath.FromSlash(ref)))
+		for _, dir := range post.assetDirs {
+			candidates = append(candidates, filepath.Join(dir, filepath.FromSlash(ref)))
+		}
+	}
+
+	root := filepath.Clean(l.root) + string(filepath.Separator)
+	for _, candidate := range candidates {
+		// 不允许通过 ".." 读取站点目录之外的文件
+		if !strings.HasPrefix(filepath.Clean(candidate), root) {
+			continue
+		}
+		if isRegularFile(candidate) {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// isMarkdownFile 判断是否为 markdown 文件
+func isMarkdownFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".md", ".markdown":
+		return true
+	}
+	return false
+}
+
+// isDir 判断路径是否为目录
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
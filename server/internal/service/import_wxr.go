@@ -0,0 +1,306 @@
+package service
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/next-ai-ventus/server/pkg/markdown"
+)
+
+var ErrInvalidWXR = errors.New("invalid WordPress export file")
+
+// SiteWordPress WordPress 导出文件（WXR）格式
+const SiteWordPress = "wordpress"
+
+// contentNamespace content:encoded 的命名空间（excerpt 命名空间随 WXR 版本变化，按后缀识别）
+const contentNamespace = "http://purl.org/rss/1.0/modules/content/"
+
+// wxrDateLayout wp:post_date 等字段的时间格式
+const wxrDateLayout = "2006-01-02 15:04:05"
+
+var (
+	// wxrCaptionPattern 匹配 [caption ...]<img ...> 说明文字[/caption]
+	wxrCaptionPattern = regexp.MustCompile(`(?s)\[caption[^\]]*\](.*?)\[/caption\]`)
+	// wxrCaptionImagePattern 拆分图片（可能带链接）与说明文字
+	wxrCaptionImagePattern = regexp.MustCompile(`(?s)^\s*((?:<a\s[^>]*>\s*)?<img\s[^>]*>(?:\s*</a>)?)(.*)$`)
+	// wxrEmbedPattern 匹配 [embed]url[/embed]
+	wxrEmbedPattern = regexp.MustCompile(`\[embed[^\]]*\](.*?)\[/embed\]`)
+	// wxrUnsupportedShortcode 匹配无法转换的常见短代码
+	wxrUnsupportedShortcode = regexp.MustCompile(`\[(gallery|audio|video|playlist)[\s\]]`)
+)
+
+// wxrDocument WXR 文件结构（只包含导入需要的字段）
+type wxrDocument struct {
+	Channel wxrChannel `xml:"channel"`
+}
+
+type wxrChannel struct {
+	Title   string      `xml:"title"`
+	Version string      `xml:"wxr_version"`
+	BaseURL string      `xml:"base_site_url"`
+	Authors []wxrAuthor `xml:"author"`
+	Items   []wxrItem   `xml:"item"`
+}
+
+type wxrAuthor struct {
+	Login       string `xml:"author_login"`
+	DisplayName string `xml:"author_display_name"`
+}
+
+type wxrItem struct {
+	Title         string        `xml:"title"`
+	Link          string        `xml:"link"`
+	PubDate       string        `xml:"pubDate"`
+	Creator       string        `xml:"creator"`
+	GUID          string        `xml:"guid"`
+	Encoded       []wxrEncoded  `xml:"encoded"`
+	PostID        string        `xml:"post_id"`
+	PostDate      string        `xml:"post_date"`
+	PostDateGMT   string        `xml:"post_date_gmt"`
+	ModifiedGMT   string        `xml:"post_modified_gmt"`
+	PostName      string        `xml:"post_name"`
+	Status        string        `xml:"status"`
+	PostType      string        `xml:"post_type"`
+	Password      string        `xml:"post_password"`
+	AttachmentURL string        `xml:"attachment_url"`
+	Categories    []wxrCategory `xml:"category"`
+	Meta          []wxrMeta     `xml:"postmeta"`
+}
+
+type wxrEncoded struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+type wxrCategory struct {
+	Domain string `xml:"domain,attr"`
+	Name   string `xml:",chardata"`
+}
+
+type wxrMeta struct {
+	Key   string `xml:"meta_key"`
+	Value string `xml:"meta_value"`
+}
+
+// content 返回 content:encoded 与 excerpt:encoded
+func (i *wxrItem) content() (content, excerpt string) {
+	for _, e := range i.Encoded {
+		switch {
+		case e.XMLName.Space == contentNamespace:
+			content = e.Value
+		case strings.HasSuffix(strings.TrimRight(e.XMLName.Space, "/"), "/excerpt"):
+			excerpt = e.Value
+		}
+	}
+	return content, excerpt
+}
+
+// meta 读取自定义字段
+func (i *wxrItem) meta(key string) string {
+	for _, m := range i.Meta {
+		if m.Key == key {
+			return strings.TrimSpace(m.Value)
+		}
+	}
+	return ""
+}
+
+// wxrSite 解析后的 WordPress 导出内容
+type wxrSite struct {
+	title   string
+	posts   []*importedPost
+	skipped []*ImportItem // 回收站中的文章等不导入的条目
+}
+
+// parseWXR 解析 WordPress 导出文件，将文章（及页面）映射为导入文章
+func parseWXR(r io.Reader, includePages bool) (*wxrSite, error) {
+	var doc wxrDocument
+	decoder := xml.NewDecoder(r)
+	decoder.Strict = false
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWXR, err)
+	}
+	if doc.Channel.Version == "" {
+		return nil, fmt.Errorf("%w: missing wp:wxr_version", ErrInvalidWXR)
+	}
+
+	authors := make(map[string]string, len(doc.Channel.Authors))
+	for _, author := range doc.Channel.Authors {
+		authors[author.Login] = author.DisplayName
+	}
+	attachments := make(map[string]string)
+	for _, item := range doc.Channel.Items {
+		if item.PostType == "attachment" && item.AttachmentURL != "" {
+			attachments[item.PostID] = strings.TrimSpace(item.AttachmentURL)
+		}
+	}
+
+	site := &wxrSite{title: strings.TrimSpace(doc.Channel.Title)}
+	for i := range doc.Channel.Items {
+		item := &doc.Channel.Items[i]
+		switch item.PostType {
+		case "post":
+		case "page":
+			if !includePages {
+				continue
+			}
+		default:
+			// 附件、菜单、修订版本等
+			continue
+		}
+
+		source := fmt.Sprintf("%s #%s", item.PostType, item.PostID)
+		switch item.Status {
+		case "trash", "auto-draft", "inherit":
+			site.skipped = append(site.skipped, &ImportItem{
+				Source:   source,
+				Title:    strings.TrimSpace(item.Title),
+				Result:   ImportSkipped,
+				Warnings: []string{fmt.Sprintf("status %q is not imported", item.Status)},
+			})
+			continue
+		}
+
+		post, err := item.toPost(source, authors, attachments)
+		if err != nil {
+			site.skipped = append(site.skipped, &ImportItem{
+				Source: source,
+				Title:  strings.TrimSpace(item.Title),
+				Result: ImportFailed,
+				Error:  err.Error(),
+			})
+			continue
+		}
+		site.posts = append(site.posts, post)
+	}
+	return site, nil
+}
+
+// toPost 将 WXR 条目映射为导入文章
+func (i *wxrItem) toPost(source string, authors, attachments map[string]string) (*importedPost, error) {
+	post := &importedPost{
+		source: source,
+		title:  strings.TrimSpace(i.Title),
+		author: strings.TrimSpace(i.Creator),
+	}
+	if post.title == "" {
+		post.title = "Untitled " + i.PostID
+		post.warnings = append(post.warnings, "missing title")
+	}
+	// 作者使用显示名称，没有时使用登录名
+	if name := strings.TrimSpace(authors[post.author]); name != "" {
+		post.author = name
+	}
+
+	// post_name 中的非 ASCII 字符是百分号编码的
+	post.slug = i.PostName
+	if unescaped, err := url.PathUnescape(i.PostName); err == nil {
+		post.slug = unescaped
+	}
+
+	// 状态：只有 publish 保持发布，其余按草稿导入
+	switch {
+	case i.Status == "publish" && i.Password != "":
+		post.draft = true
+		post.warnings = append(post.warnings, "password protected, imported as draft")
+	case i.Status == "publish":
+	default:
+		post.draft = true
+		if i.Status != "draft" {
+			post.warnings = append(post.warnings, fmt.Sprintf("status %q imported as draft", i.Status))
+		}
+	}
+
+	// 时间：优先使用 GMT 时间
+	if t, ok := parseWXRTime(i.PostDateGMT, time.UTC); ok {
+		post.date = t
+	} else if t, ok := parseWXRTime(i.PostDate, time.Local); ok {
+		post.date = t
+	} else if t, err := time.Parse(time.RFC1123Z, strings.TrimSpace(i.PubDate)); err == nil {
+		post.date = t
+	} else {
+		post.warnings = append(post.warnings, "missing date")
+	}
+	post.updated, _ = parseWXRTime(i.ModifiedGMT, time.UTC)
+
+	for _, category := range i.Categories {
+		if category.Domain != "category" && category.Domain != "post_tag" {
+			continue
+		}
+		name := strings.TrimSpace(category.Name)
+		if name == "" || (category.Domain == "category" && strings.EqualFold(name, "Uncategorized")) {
+			continue
+		}
+		post.tags = append(post.tags, name)
+	}
+	if i.PostType == "page" {
+		post.tags = append(post.tags, "page")
+	}
+
+	if thumbnail := i.meta("_thumbnail_id"); thumbnail != "" {
+		post.cover = attachments[thumbnail]
+	}
+
+	content, excerpt := i.content()
+	body, warnings := convertWordPressContent(content)
+	post.warnings = append(post.warnings, warnings...)
+	post.content = body
+	if excerpt = strings.TrimSpace(excerpt); excerpt != "" {
+		if converted, err := markdown.FromHTML(excerpt); err == nil {
+			post.excerpt = converted
+		}
+	}
+
+	// 旧地址：固定链接与 ?p= 短链接
+	for _, link := range []string{i.Link, i.GUID} {
+		if link = strings.TrimSpace(link); link != "" {
+			post.permalinks = append(post.permalinks, link)
+		}
+	}
+	return post, nil
+}
+
+// convertWordPressContent 转换短代码后将文章 HTML 转为 Markdown
+func convertWordPressContent(content string) (string, []string) {
+	var warnings []string
+
+	content = strings.ReplaceAll(content, "<!--more-->", "")
+	content = wxrCaptionPattern.ReplaceAllStringFunc(content, func(match string) string {
+		inner := wxrCaptionPattern.FindStringSubmatch(match)[1]
+		m := wxrCaptionImagePattern.FindStringSubmatch(inner)
+		if m == nil {
+			return inner
+		}
+		return "<figure>" + m[1] + "<figcaption>" + strings.TrimSpace(m[2]) + "</figcaption></figure>"
+	})
+	content = wxrEmbedPattern.ReplaceAllString(content, `<p><a href="$1">$1</a></p>`)
+	for _, m := range wxrUnsupportedShortcode.FindAllStringSubmatch(content, -1) {
+		warnings = append(warnings, fmt.Sprintf("shortcode [%s] is not converted", m[1]))
+	}
+
+	body, err := markdown.FromHTML(content)
+	if err != nil {
+		warnings = append(warnings, err.Error())
+		return content, warnings
+	}
+	return body, warnings
+}
+
+// parseWXRTime 解析 WXR 时间字段，未设置（0000-00-00）时返回 false
+func parseWXRTime(value string, loc *time.Location) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.HasPrefix(value, "0000") {
+		return time.Time{}, false
+	}
+	t, err := time.ParseInLocation(wxrDateLayout, value, loc)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
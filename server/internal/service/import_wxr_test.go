@@ -0,0 +1,112 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// parseWXRFixture 解析 testdata/wxr 下的导出文件
+func parseWXRFixture(t *testing.T, name string, includePages bool) (*wxrSite, error) {
+	t.Helper()
+
+	f, err := os.Open(filepath.Join("testdata", "wxr", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	return parseWXR(f, includePages)
+}
+
+func findImportedPost(t *testing.T, site *wxrSite, source string) *importedPost {
+	t.Helper()
+
+	for _, post := range site.posts {
+		if post.source == source {
+			return post
+		}
+	}
+	t.Fatalf("no post parsed from %s", source)
+	return nil
+}
+
+func TestParseWXR(t *testing.T) {
+	site, err := parseWXRFixture(t, "blog.xml", false)
+	if err != nil {
+		t.Fatalf("parseWXR() error = %v", err)
+	}
+	if site.title != "Old Blog" || len(site.posts) != 4 {
+		t.Fatalf("site = %q with %d posts, want Old Blog with 4", site.title, len(site.posts))
+	}
+	if len(site.skipped) != 1 || site.skipped[0].Source != "post #60" || site.skipped[0].Result != ImportSkipped {
+		t.Errorf("skipped = %+v, want trashed post #60", site.skipped)
+	}
+
+	hello := findImportedPost(t, site, "post #12")
+	if hello.title != "Hello World" || hello.slug != "hello-world" || hello.author != "Alice Liddell" || hello.draft {
+		t.Errorf("hello = %+v", hello)
+	}
+	if !hello.date.Equal(time.Date(2019, 5, 1, 10, 0, 0, 0, time.UTC)) || !hello.updated.Equal(time.Date(2019, 6, 2, 10, 30, 0, 0, time.UTC)) {
+		t.Errorf("dates = %v, %v", hello.date, hello.updated)
+	}
+	if strings.Join(hello.tags, ",") != "Web Dev,Golang" {
+		t.Errorf("tags = %v, want categories and tags without Uncategorized", hello.tags)
+	}
+	if hello.cover != "https://old.example.com/wp-content/uploads/2019/05/cat.jpg" {
+		t.Errorf("cover = %q, want thumbnail attachment url", hello.cover)
+	}
+	if hello.excerpt != "A *short* hello." {
+		t.Errorf("excerpt = %q", hello.excerpt)
+	}
+	wantContent := "Welcome to **WordPress**.\n\n![Cat](https://old.example.com/wp-content/uploads/2019/05/cat.jpg)\n\n*A sleepy cat*\n\n- One\n- Two"
+	if hello.content != wantContent {
+		t.Errorf("content =\n%q\nwant\n%q", hello.content, wantContent)
+	}
+	if strings.Join(hello.permalinks, " ") != "https://old.example.com/2019/05/hello-world/ https://old.example.com/?p=12" {
+		t.Errorf("permalinks = %v", hello.permalinks)
+	}
+
+	// 百分号编码的 slug、没有显示名称的作者、无法转换的短代码
+	chinese := findImportedPost(t, site, "post #30")
+	if chinese.slug != "你好" || chinese.author != "bob" || chinese.content != "第一段\n\n第二段 [gallery ids=\"1,2\"]" {
+		t.Errorf("chinese = %+v", chinese)
+	}
+	if len(chinese.warnings) != 1 || !strings.Contains(chinese.warnings[0], "gallery") {
+		t.Errorf("warnings = %v, want gallery warning", chinese.warnings)
+	}
+
+	// 草稿没有 GMT 时间，使用本地时间
+	draft := findImportedPost(t, site, "post #40")
+	if !draft.draft || !draft.date.Equal(time.Date(2020, 2, 1, 12, 0, 0, 0, time.Local)) {
+		t.Errorf("draft = %+v", draft)
+	}
+
+	secret := findImportedPost(t, site, "post #50")
+	if !secret.draft || len(secret.warnings) != 1 || !strings.Contains(secret.warnings[0], "password") {
+		t.Errorf("password protected post = %+v, want draft with warning", secret)
+	}
+}
+
+func TestParseWXR_IncludePages(t *testing.T) {
+	site, err := parseWXRFixture(t, "blog.xml", true)
+	if err != nil {
+		t.Fatalf("parseWXR() error = %v", err)
+	}
+
+	about := findImportedPost(t, site, "page #2")
+	if about.content != "About me" || strings.Join(about.tags, ",") != "page" {
+		t.Errorf("page = %+v", about)
+	}
+}
+
+func TestParseWXR_Invalid(t *testing.T) {
+	if _, err := parseWXRFixture(t, "not-wxr.xml", false); !errors.Is(err, ErrInvalidWXR) {
+		t.Errorf("parseWXR(plain rss) error = %v, want ErrInvalidWXR", err)
+	}
+	if _, err := parseWXR(strings.NewReader("not xml at all"), false); !errors.Is(err, ErrInvalidWXR) {
+		t.Errorf("parseWXR(garbage) error = %v, want ErrInvalidWXR", err)
+	}
+}
@@ -2,134 +2,208 @@ package service
 
 import (
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/next-ai-ventus/server/internal/domain"
 	"github.com/next-ai-ventus/server/internal/repository"
 )
 
-// Index 是文章索引结构
-type Index struct {
-	PostIDs   []string            // 按时间倒序的文章ID列表
-	SlugToID  map[string]string   // slug -> id
-	TagToIDs  map[string][]string // tag -> ids（按时间倒序）
-	DateToIDs map[string][]string // "2024-06" -> ids
+// indexPageSize 重建索引时每次从仓库读取的文章数
+const indexPageSize = 100
+
+// TagCount 标签与文章数
+type TagCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
 }
 
-// IndexService 索引管理服务
-type IndexService struct {
-	repo repository.PostRepository
+// ArchiveMonth 归档中的月份
+type ArchiveMonth struct {
+	Month int `json:"month"`
+	Count int `json:"count"`
 }
 
-// NewIndexService 创建索引服务
-func NewIndexService(repo repository.PostRepository) *IndexService {
-	return &IndexService{repo: repo}
+// ArchiveYear 归档中的年份（月份倒序）
+type ArchiveYear struct {
+	Year   int            `json:"year"`
+	Count  int            `json:"count"`
+	Months []ArchiveMonth `json:"months"`
 }
 
-// BuildIndex 从仓库构建完整索引
-func (s *IndexService) BuildIndex() (*Index, error) {
-	// 获取所有文章
-	result, err := s.repo.FindAll(repository.ListOptions{
-		Page:     1,
-		PageSize: 10000, // 获取所有
-	})
-	if err != nil {
-		return nil, err
+// IndexService 文章索引服务：常驻内存、并发安全，文章保存或删除时增量更新。
+// 文章按创建时间倒序（同一时间按 ID 倒序）保存在有序列表中，标签列表保持相同顺序；
+// 已发布的文章另按发布时间倒序保存，用于归档。位置与范围查询均通过二分查找完成
+type IndexService struct {
+	repo      repository.PostRepository
+	loc       *time.Location
+	mu        sync.RWMutex
+	posts     []*domain.PostSummary            // 全部文章（按创建时间倒序）
+	published []*domain.PostSummary            // 已发布的文章（按发布时间倒序）
+	byID      map[string]*domain.PostSummary   // id -> 文章
+	slugToID  map[string]string                // slug -> id
+	tags      map[string][]*domain.PostSummary // tag -> 文章（按创建时间倒序）
+	tagCounts map[string]int                   // tag -> 已发布文章数
+	months    map[string]int                   // 站点时区的发布月份 "2024-06" -> 已发布文章数
+}
+
+// NewIndexService 创建索引服务（需调用 Rebuild 加载已有文章），
+// loc 为站点时区，用于按发布时间归档，为 nil 时使用 UTC
+func NewIndexService(repo repository.PostRepository, loc *time.Location) *IndexService {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return &IndexService{
+		repo:      repo,
+		loc:       loc,
+		byID:      make(map[string]*domain.PostSummary),
+		slugToID:  make(map[string]string),
+		tags:      make(map[string][]*domain.PostSummary),
+		tagCounts: make(map[string]int),
+		months:    make(map[string]int),
 	}
+}
+
+// Location 返回归档使用的站点时区
+func (s *IndexService) Location() *time.Location {
+	return s.loc
+}
 
-	index := &Index{
-		PostIDs:   make([]string, 0),
-		SlugToID:  make(map[string]string),
-		TagToIDs:  make(map[string][]string),
-		DateToIDs: make(map[string][]string),
+// Rebuild 从仓库分页读取全部文章并重建索引
+func (s *IndexService) Rebuild() error {
+	var posts []*domain.PostSummary
+	for page := 1; ; page++ {
+		result, err := s.repo.FindAll(repository.ListOptions{Page: page, PageSize: indexPageSize})
+		if err != nil {
+			return err
+		}
+		for _, item := range result.Items {
+			posts = append(posts, item.Clone())
+		}
+		if page >= result.TotalPages {
+			break
+		}
 	}
+	sort.Slice(posts, func(i, j int) bool {
+		return createdBefore(posts[i], posts[j])
+	})
 
-	// 按时间倒序排序（FindAll 默认已排序）
-	for _, post := range result.Items {
-		s.addToIndex(index, post)
+	var published []*domain.PostSummary
+	byID := make(map[string]*domain.PostSummary, len(posts))
+	slugToID := make(map[string]string, len(posts))
+	tags := make(map[string][]*domain.PostSummary)
+	tagCounts := make(map[string]int)
+	months := make(map[string]int)
+	for _, post := range posts {
+		byID[post.ID] = post
+		slugToID[post.Slug.String()] = post.ID
+		for _, tag := range post.GetTagNames() {
+			tags[tag] = append(tags[tag], post)
+		}
+		if isArchived(post) {
+			published = append(published, post)
+			months[s.monthKey(post)]++
+			for _, tag := range post.GetTagNames() {
+				tagCounts[tag]++
+			}
+		}
 	}
+	sort.Slice(published, func(i, j int) bool {
+		return publishedBefore(published[i], published[j])
+	})
 
-	return index, nil
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.posts = posts
+	s.published = published
+	s.byID = byID
+	s.slugToID = slugToID
+	s.tags = tags
+	s.tagCounts = tagCounts
+	s.months = months
+	return nil
 }
 
-// addToIndex 添加单篇文章到索引
-func (s *IndexService) addToIndex(index *Index, post *domain.Post) {
-	// 添加到文章ID列表
-	index.PostIDs = append(index.PostIDs, post.ID)
+// PostSaved 实现 PostObserver，增量更新索引
+func (s *IndexService) PostSaved(post *domain.Post) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	// 添加 slug 映射
-	index.SlugToID[post.Slug.String()] = post.ID
+	s.removeLocked(post.ID)
+	s.addLocked(post.Summary())
+}
 
-	// 添加标签索引
-	for _, tag := range post.Tags {
-		tagName := tag.String()
-		index.TagToIDs[tagName] = append(index.TagToIDs[tagName], post.ID)
-	}
+// PostDeleted 实现 PostObserver，从索引中移除文章
+func (s *IndexService) PostDeleted(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	// 添加日期索引
-	dateKey := post.CreatedAt.Format("2006-01")
-	index.DateToIDs[dateKey] = append(index.DateToIDs[dateKey], post.ID)
+	s.removeLocked(id)
 }
 
-// SearchByTag 根据标签搜索文章ID
-func (s *IndexService) SearchByTag(index *Index, tag string) []string {
-	if ids, ok := index.TagToIDs[tag]; ok {
-		// 返回副本避免外部修改
-		result := make([]string, len(ids))
-		copy(result, ids)
-		return result
-	}
-	return []string{}
+// Len 返回索引中的文章数
+func (s *IndexService) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.posts)
 }
 
-// SearchByDateRange 根据日期范围搜索文章ID
-func (s *IndexService) SearchByDateRange(index *Index, start, end time.Time) []string {
-	result := make(map[string]bool)
+// SearchByTag 根据标签搜索文章ID（按时间倒序）
+func (s *IndexService) SearchByTag(tag string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-	// 遍历日期索引
-	for dateKey, ids := range index.DateToIDs {
-		date, _ := time.Parse("2006-01", dateKey)
-		if (date.Equal(start) || date.After(start)) && (date.Before(end) || date.Equal(end)) {
-			for _, id := range ids {
-				result[id] = true
-			}
-		}
-	}
+	return postIDs(s.tags[tag])
+}
 
-	// 转换为切片
-	var ids []string
-	for id := range result {
-		ids = append(ids, id)
-	}
+// SearchByDateRange 搜索创建时间在 [start, end] 内的文章ID（按时间倒序）
+func (s *IndexService) SearchByDateRange(start, end time.Time) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-	// 按原始顺序排序
-	sort.Slice(ids, func(i, j int) bool {
-		idxI := -1
-		idxJ := -1
-		for k, id := range index.PostIDs {
-			if id == ids[i] {
-				idxI = k
-			}
-			if id == ids[j] {
-				idxJ = k
-			}
-		}
-		return idxI < idxJ
+	// 倒序列表中：第一篇不晚于 end 的文章，到第一篇早于 start 的文章之前
+	from := sort.Search(len(s.posts), func(i int) bool {
+		return !s.posts[i].CreatedAt.After(end)
 	})
+	to := sort.Search(len(s.posts), func(i int) bool {
+		return s.posts[i].CreatedAt.Before(start)
+	})
+	if from >= to {
+		return []string{}
+	}
+	return postIDs(s.posts[from:to])
+}
 
-	return ids
+// GetPost 根据 ID 获取文章摘要（副本）
+func (s *IndexService) GetPost(id string) (*domain.PostSummary, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	post, ok := s.byID[id]
+	if !ok {
+		return nil, false
+	}
+	return post.Clone(), true
 }
 
 // GetSlugID 根据 slug 获取文章ID
-func (s *IndexService) GetSlugID(index *Index, slug string) (string, bool) {
-	id, ok := index.SlugToID[slug]
+func (s *IndexService) GetSlugID(slug string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	id, ok := s.slugToID[slug]
 	return id, ok
 }
 
 // GetAllTags 获取所有标签列表
-func (s *IndexService) GetAllTags(index *Index) []string {
-	tags := make([]string, 0, len(index.TagToIDs))
-	for tag := range index.TagToIDs {
+func (s *IndexService) GetAllTags() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tags := make([]string, 0, len(s.tags))
+	for tag := range s.tags {
 		tags = append(tags, tag)
 	}
 	sort.Strings(tags)
@@ -137,20 +211,220 @@ func (s *IndexService) GetAllTags(index *Index) []string {
 }
 
 // GetTagCount 获取标签文章数量
-func (s *IndexService) GetTagCount(index *Index, tag string) int {
-	if ids, ok := index.TagToIDs[tag]; ok {
-		return len(ids)
+func (s *IndexService) GetTagCount(tag string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.tags[tag])
+}
+
+// GetPublishedTagCounts 获取已发布文章的标签统计（按文章数倒序，同数按名称排序）
+func (s *IndexService) GetPublishedTagCounts() []TagCount {
+	s.mu.RLock()
+	counts := make([]TagCount, 0, len(s.tagCounts))
+	for tag, count := range s.tagCounts {
+		counts = append(counts, TagCount{Name: tag, Count: count})
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Name < counts[j].Name
+	})
+	return counts
+}
+
+// GetArchiveMonths 获取归档月份列表（站点时区的发布月份，只统计已发布的文章）
+func (s *IndexService) GetArchiveMonths() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// 倒序排序（最新的在前）
+	return s.sortedMonthsLocked()
+}
+
+// GetArchive 获取 年 → 月 → 文章数 的归档树（年份与月份均倒序）
+func (s *IndexService) GetArchive() []ArchiveYear {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var years []ArchiveYear
+	for _, key := range s.sortedMonthsLocked() {
+		t, err := time.Parse("2006-01", key)
+		if err != nil {
+			continue
+		}
+		if len(years) == 0 || years[len(years)-1].Year != t.Year() {
+			years = append(years, ArchiveYear{Year: t.Year(), Months: []ArchiveMonth{}})
+		}
+		year := &years[len(years)-1]
+		year.Count += s.months[key]
+		year.Months = append(year.Months, ArchiveMonth{Month: int(t.Month()), Count: s.months[key]})
+	}
+	if years == nil {
+		years = []ArchiveYear{}
+	}
+	return years
+}
+
+// ListArchive 分页列出某年（month 为 0）或某月发布的文章（按发布时间倒序），返回当页文章与总数。
+// offset 为负或超出总数时返回空页
+func (s *IndexService) ListArchive(year, month, offset, limit int) ([]*domain.PostSummary, int) {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, s.loc)
+	end := start.AddDate(1, 0, 0)
+	if month > 0 {
+		start = time.Date(year, time.Month(month), 1, 0, 0, 0, 0, s.loc)
+		end = start.AddDate(0, 1, 0)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// 倒序列表中：第一篇早于 end 的文章，到第一篇早于 start 的文章之前
+	from := sort.Search(len(s.published), func(i int) bool {
+		return s.published[i].PublishedAt.Before(end)
+	})
+	to := sort.Search(len(s.published), func(i int) bool {
+		return s.published[i].PublishedAt.Before(start)
+	})
+	total := to - from
+
+	// 先与剩余数量比较再相加，避免极大的 offset 或 limit 溢出
+	if offset < 0 || offset > total {
+		offset = total
+	}
+	from += offset
+	if limit > 0 && limit < to-from {
+		to = from + limit
+	}
+	items := make([]*domain.PostSummary, 0, to-from)
+	for _, post := range s.published[from:to] {
+		items = append(items, post.Clone())
 	}
-	return 0
+	return items, total
 }
 
-// GetArchiveMonths 获取归档月份列表
-func (s *IndexService) GetArchiveMonths(index *Index) []string {
-	months := make([]string, 0, len(index.DateToIDs))
-	for month := range index.DateToIDs {
+// sortedMonthsLocked 返回倒序的归档月份
+func (s *IndexService) sortedMonthsLocked() []string {
+	months := make([]string, 0, len(s.months))
+	for month := range s.months {
 		months = append(months, month)
 	}
-	// 倒序排序（最新的在前）
 	sort.Sort(sort.Reverse(sort.StringSlice(months)))
 	return months
 }
+
+// addLocked 将文章插入各有序列表
+func (s *IndexService) addLocked(post *domain.PostSummary) {
+	s.posts = insertPost(s.posts, post, createdBefore)
+	s.byID[post.ID] = post
+	s.slugToID[post.Slug.String()] = post.ID
+	for _, tag := range post.GetTagNames() {
+		s.tags[tag] = insertPost(s.tags[tag], post, createdBefore)
+	}
+	if isArchived(post) {
+		s.published = insertPost(s.published, post, publishedBefore)
+		s.months[s.monthKey(post)]++
+		for _, tag := range post.GetTagNames() {
+			s.tagCounts[tag]++
+		}
+	}
+}
+
+// removeLocked 从各有序列表中移除文章（使用索引中保存的旧版本定位）
+func (s *IndexService) removeLocked(id string) {
+	post, ok := s.byID[id]
+	if !ok {
+		return
+	}
+
+	s.posts = removePost(s.posts, post, createdBefore)
+	delete(s.byID, id)
+	// slug 可能已被其他文章使用
+	if s.slugToID[post.Slug.String()] == id {
+		delete(s.slugToID, post.Slug.String())
+	}
+	for _, tag := range post.GetTagNames() {
+		if list := removePost(s.tags[tag], post, createdBefore); len(list) > 0 {
+			s.tags[tag] = list
+		} else {
+			delete(s.tags, tag)
+		}
+	}
+	if isArchived(post) {
+		s.published = removePost(s.published, post, publishedBefore)
+		month := s.monthKey(post)
+		if s.months[month]--; s.months[month] <= 0 {
+			delete(s.months, month)
+		}
+		for _, tag := range post.GetTagNames() {
+			if s.tagCounts[tag]--; s.tagCounts[tag] <= 0 {
+				delete(s.tagCounts, tag)
+			}
+		}
+	}
+}
+
+// monthKey 返回文章在站点时区的发布月份
+func (s *IndexService) monthKey(post *domain.PostSummary) string {
+	return post.PublishedAt.In(s.loc).Format("2006-01")
+}
+
+// isArchived 已发布且有发布时间的文章才进入归档
+func isArchived(post *domain.PostSummary) bool {
+	return post.IsPublished() && post.PublishedAt != nil
+}
+
+// createdBefore 创建时间倒序，同一时间按 ID 倒序
+func createdBefore(a, b *domain.PostSummary) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.After(b.CreatedAt)
+	}
+	return a.ID > b.ID
+}
+
+// publishedBefore 发布时间倒序，同一时间按 ID 倒序
+func publishedBefore(a, b *domain.PostSummary) bool {
+	if !a.PublishedAt.Equal(*b.PublishedAt) {
+		return a.PublishedAt.After(*b.PublishedAt)
+	}
+	return a.ID > b.ID
+}
+
+// postPosition 二分查找文章在有序列表中的位置（不存在时为应插入的位置）
+func postPosition(list []*domain.PostSummary, post *domain.PostSummary, before func(a, b *domain.PostSummary) bool) int {
+	return sort.Search(len(list), func(i int) bool {
+		return !before(list[i], post)
+	})
+}
+
+// insertPost 将文章插入有序列表
+func insertPost(list []*domain.PostSummary, post *domain.PostSummary, before func(a, b *domain.PostSummary) bool) []*domain.PostSummary {
+	i := postPosition(list, post, before)
+	list = append(list, nil)
+	copy(list[i+1:], list[i:])
+	list[i] = post
+	return list
+}
+
+// removePost 从有序列表中移除文章
+func removePost(list []*domain.PostSummary, post *domain.PostSummary, before func(a, b *domain.PostSummary) bool) []*domain.PostSummary {
+	i := postPosition(list, post, before)
+	if i >= len(list) || list[i].ID != post.ID {
+		return list
+	}
+	copy(list[i:], list[i+1:])
+	list[len(list)-1] = nil
+	return list[:len(list)-1]
+}
+
+// postIDs 提取文章ID（返回副本，调用方可以修改）
+func postIDs(posts []*domain.PostSummary) []string {
+	ids := make([]string, len(posts))
+	for i, post := range posts {
+		ids[i] = post.ID
+	}
+	return ids
+}
@@ -1,6 +1,9 @@
 package service
 
 import (
+	"fmt"
+	"math"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,13 +19,21 @@ func createTestPostWithDate(id, title, slugStr string, date time.Time) *domain.P
 	return post
 }
 
-func TestIndexService_BuildIndex(t *testing.T) {
+// createPublishedPostWithDate 创建在 date 创建并发布的文章
+func createPublishedPostWithDate(id, title, slugStr string, date time.Time) *domain.Post {
+	post := createTestPostWithDate(id, title, slugStr, date)
+	post.Publish()
+	post.PublishedAt = &date
+	return post
+}
+
+func TestIndexService_Rebuild(t *testing.T) {
 	repo := repository.NewMemoryPostRepository()
 	
 	// 创建测试数据
-	post1 := createTestPostWithDate("1", "Go Post", "go-post", time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC))
-	post2 := createTestPostWithDate("2", "Rust Post", "rust-post", time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC))
-	post3 := createTestPostWithDate("3", "Web Post", "web-post", time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC))
+	post1 := createPublishedPostWithDate("1", "Go Post", "go-post", time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC))
+	post2 := createPublishedPostWithDate("2", "Rust Post", "rust-post", time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC))
+	post3 := createPublishedPostWithDate("3", "Web Post", "web-post", time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC))
 	
 	tagGo, _ := valueobject.NewTag("go")
 	tagWeb, _ := valueobject.NewTag("web")
@@ -35,23 +46,25 @@ func TestIndexService_BuildIndex(t *testing.T) {
 	repo.Save(post2)
 	repo.Save(post3)
 
-	service := NewIndexService(repo)
-	index, err := service.BuildIndex()
+	service := NewIndexService(repo, nil)
+	err := service.Rebuild()
 	
 	if err != nil {
-		t.Errorf("BuildIndex() error = %v", err)
+		t.Errorf("Rebuild() error = %v", err)
 	}
-	if len(index.PostIDs) != 3 {
-		t.Errorf("len(PostIDs) = %d, want 3", len(index.PostIDs))
+	if service.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", service.Len())
 	}
-	if len(index.SlugToID) != 3 {
-		t.Errorf("len(SlugToID) = %d, want 3", len(index.SlugToID))
+	for _, slug := range []string{"go-post", "rust-post", "web-post"} {
+		if _, ok := service.GetSlugID(slug); !ok {
+			t.Errorf("GetSlugID(%q) not found", slug)
+		}
 	}
-	if len(index.TagToIDs) != 2 {
-		t.Errorf("len(TagToIDs) = %d, want 2", len(index.TagToIDs))
+	if len(service.GetAllTags()) != 2 {
+		t.Errorf("len(GetAllTags()) = %d, want 2", len(service.GetAllTags()))
 	}
-	if len(index.DateToIDs) != 2 { // 2024-06 and 2024-05
-		t.Errorf("len(DateToIDs) = %d, want 2", len(index.DateToIDs))
+	if len(service.GetArchiveMonths()) != 2 { // 2024-06 and 2024-05
+		t.Errorf("len(GetArchiveMonths()) = %d, want 2", len(service.GetArchiveMonths()))
 	}
 }
 
@@ -73,8 +86,8 @@ func TestIndexService_SearchByTag(t *testing.T) {
 	repo.Save(post2)
 	repo.Save(post3)
 
-	service := NewIndexService(repo)
-	index, _ := service.BuildIndex()
+	service := NewIndexService(repo, nil)
+	service.Rebuild()
 
 	tests := []struct {
 		name     string
@@ -88,7 +101,7 @@ func TestIndexService_SearchByTag(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ids := service.SearchByTag(index, tt.tag)
+			ids := service.SearchByTag(tt.tag)
 			if len(ids) != tt.expected {
 				t.Errorf("SearchByTag() len = %d, want %d", len(ids), tt.expected)
 			}
@@ -107,8 +120,8 @@ func TestIndexService_SearchByDateRange(t *testing.T) {
 	repo.Save(post2)
 	repo.Save(post3)
 
-	service := NewIndexService(repo)
-	index, _ := service.BuildIndex()
+	service := NewIndexService(repo, nil)
+	service.Rebuild()
 
 	tests := []struct {
 		name     string
@@ -138,7 +151,7 @@ func TestIndexService_SearchByDateRange(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ids := service.SearchByDateRange(index, tt.start, tt.end)
+			ids := service.SearchByDateRange(tt.start, tt.end)
 			if len(ids) != tt.expected {
 				t.Errorf("SearchByDateRange() len = %d, want %d", len(ids), tt.expected)
 			}
@@ -151,11 +164,11 @@ func TestIndexService_GetSlugID(t *testing.T) {
 	post := createTestPostWithDate("1", "Test", "test-slug", time.Now())
 	repo.Save(post)
 
-	service := NewIndexService(repo)
-	index, _ := service.BuildIndex()
+	service := NewIndexService(repo, nil)
+	service.Rebuild()
 
 	t.Run("existing slug", func(t *testing.T) {
-		id, ok := service.GetSlugID(index, "test-slug")
+		id, ok := service.GetSlugID("test-slug")
 		if !ok {
 			t.Error("GetSlugID() should return ok=true")
 		}
@@ -165,7 +178,7 @@ func TestIndexService_GetSlugID(t *testing.T) {
 	})
 
 	t.Run("non-existing slug", func(t *testing.T) {
-		_, ok := service.GetSlugID(index, "non-existing")
+		_, ok := service.GetSlugID("non-existing")
 		if ok {
 			t.Error("GetSlugID() should return ok=false")
 		}
@@ -187,10 +200,10 @@ func TestIndexService_GetAllTags(t *testing.T) {
 	repo.Save(post1)
 	repo.Save(post2)
 
-	service := NewIndexService(repo)
-	index, _ := service.BuildIndex()
+	service := NewIndexService(repo, nil)
+	service.Rebuild()
 
-	tags := service.GetAllTags(index)
+	tags := service.GetAllTags()
 	if len(tags) != 2 {
 		t.Errorf("GetAllTags() len = %d, want 2", len(tags))
 	}
@@ -214,15 +227,15 @@ func TestIndexService_GetTagCount(t *testing.T) {
 	repo.Save(post1)
 	repo.Save(post2)
 
-	service := NewIndexService(repo)
-	index, _ := service.BuildIndex()
+	service := NewIndexService(repo, nil)
+	service.Rebuild()
 
-	count := service.GetTagCount(index, "go")
+	count := service.GetTagCount("go")
 	if count != 2 {
 		t.Errorf("GetTagCount() = %d, want 2", count)
 	}
 
-	count = service.GetTagCount(index, "non-existing")
+	count = service.GetTagCount("non-existing")
 	if count != 0 {
 		t.Errorf("GetTagCount() = %d, want 0", count)
 	}
@@ -231,18 +244,18 @@ func TestIndexService_GetTagCount(t *testing.T) {
 func TestIndexService_GetArchiveMonths(t *testing.T) {
 	repo := repository.NewMemoryPostRepository()
 	
-	post1 := createTestPostWithDate("1", "Test", "test-1", time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC))
-	post2 := createTestPostWithDate("2", "Test", "test-2", time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC))
-	post3 := createTestPostWithDate("3", "Test", "test-3", time.Date(2024, 4, 20, 0, 0, 0, 0, time.UTC))
+	post1 := createPublishedPostWithDate("1", "Test", "test-1", time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC))
+	post2 := createPublishedPostWithDate("2", "Test", "test-2", time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC))
+	post3 := createPublishedPostWithDate("3", "Test", "test-3", time.Date(2024, 4, 20, 0, 0, 0, 0, time.UTC))
 	
 	repo.Save(post1)
 	repo.Save(post2)
 	repo.Save(post3)
 
-	service := NewIndexService(repo)
-	index, _ := service.BuildIndex()
+	service := NewIndexService(repo, nil)
+	service.Rebuild()
 
-	months := service.GetArchiveMonths(index)
+	months := service.GetArchiveMonths()
 	if len(months) != 3 {
 		t.Errorf("GetArchiveMonths() len = %d, want 3", len(months))
 	}
@@ -252,7 +265,7 @@ func TestIndexService_GetArchiveMonths(t *testing.T) {
 	}
 }
 
-func BenchmarkBuildIndex(b *testing.B) {
+func BenchmarkRebuild(b *testing.B) {
 	repo := repository.NewMemoryPostRepository()
 	
 	// 创建 1000 篇文章
@@ -263,13 +276,200 @@ func BenchmarkBuildIndex(b *testing.B) {
 		repo.Save(post)
 	}
 
-	service := NewIndexService(repo)
+	service := NewIndexService(repo, nil)
 	
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, err := service.BuildIndex()
-		if err != nil {
+		if err := service.Rebuild(); err != nil {
 			b.Fatal(err)
 		}
 	}
 }
+
+func TestIndexService_RebuildAllPages(t *testing.T) {
+	repo := repository.NewMemoryPostRepository()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	total := indexPageSize*2 + 50
+	for i := 0; i < total; i++ {
+		id := fmt.Sprintf("post-%03d", i)
+		repo.Save(createTestPostWithDate(id, "Title", id, base.Add(time.Duration(i)*time.Hour)))
+	}
+
+	service := NewIndexService(repo, nil)
+	if err := service.Rebuild(); err != nil {
+		t.Fatal(err)
+	}
+	if service.Len() != total {
+		t.Errorf("Len() = %d, want %d", service.Len(), total)
+	}
+
+	// 闭区间，按时间倒序
+	ids := service.SearchByDateRange(base.Add(10*time.Hour), base.Add(12*time.Hour))
+	want := []string{"post-012", "post-011", "post-010"}
+	if strings.Join(ids, ",") != strings.Join(want, ",") {
+		t.Errorf("SearchByDateRange() = %v, want %v", ids, want)
+	}
+	if ids := service.SearchByDateRange(base.Add(-2*time.Hour), base.Add(-time.Hour)); len(ids) != 0 {
+		t.Errorf("SearchByDateRange() before all posts = %v, want empty", ids)
+	}
+}
+
+func TestIndexService_Incremental(t *testing.T) {
+	service := NewIndexService(repository.NewMemoryPostRepository(), nil)
+	tagGo, _ := valueobject.NewTag("go")
+	tagWeb, _ := valueobject.NewTag("web")
+
+	june := createPublishedPostWithDate("1", "June", "june", time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC))
+	june.UpdateTags([]valueobject.Tag{tagGo})
+	may := createPublishedPostWithDate("2", "May", "may", time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC))
+	may.UpdateTags([]valueobject.Tag{tagGo})
+	service.PostSaved(may)
+	service.PostSaved(june)
+
+	if ids := service.SearchByTag("go"); strings.Join(ids, ",") != "1,2" {
+		t.Errorf("SearchByTag(go) = %v, want [1 2]", ids)
+	}
+
+	// 修改标签与 slug：旧的标签与 slug 不再指向文章
+	slug, _ := valueobject.NewSlug("june-renamed")
+	june.Slug = slug
+	june.UpdateTags([]valueobject.Tag{tagWeb})
+	service.PostSaved(june)
+
+	if ids := service.SearchByTag("go"); strings.Join(ids, ",") != "2" {
+		t.Errorf("SearchByTag(go) after update = %v, want [2]", ids)
+	}
+	if service.GetTagCount("web") != 1 {
+		t.Errorf("GetTagCount(web) = %d, want 1", service.GetTagCount("web"))
+	}
+	if _, ok := service.GetSlugID("june"); ok {
+		t.Error("old slug still indexed")
+	}
+	if id, _ := service.GetSlugID("june-renamed"); id != "1" {
+		t.Errorf("GetSlugID(june-renamed) = %q, want 1", id)
+	}
+	if service.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", service.Len())
+	}
+
+	// 删除后空的标签与月份一并移除
+	service.PostDeleted("2")
+	service.PostDeleted("missing")
+	if tags := service.GetAllTags(); strings.Join(tags, ",") != "web" {
+		t.Errorf("GetAllTags() = %v, want [web]", tags)
+	}
+	if months := service.GetArchiveMonths(); strings.Join(months, ",") != "2024-06" {
+		t.Errorf("GetArchiveMonths() = %v, want [2024-06]", months)
+	}
+}
+
+func TestIndexService_Observer(t *testing.T) {
+	repo := repository.NewMemoryPostRepository()
+	postService := NewPostService(repo, NewSlugService(repo))
+	service := NewIndexService(repo, nil)
+	postService.AddObserver(service)
+
+	post, err := postService.CreatePost(CreatePostInput{Title: "Hello", Content: "Body", Tags: []string{"go"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if service.GetTagCount("go") != 1 {
+		t.Errorf("GetTagCount(go) = %d, want 1", service.GetTagCount("go"))
+	}
+
+	if err := postService.DeletePost(post.ID); err != nil {
+		t.Fatal(err)
+	}
+	if service.Len() != 0 {
+		t.Errorf("Len() after delete = %d, want 0", service.Len())
+	}
+}
+
+func TestIndexService_ArchiveTimeZone(t *testing.T) {
+	repo := repository.NewMemoryPostRepository()
+	// UTC 6 月 30 日 20:00 是 UTC+8 的 7 月 1 日
+	repo.Save(createPublishedPostWithDate("1", "Late", "late", time.Date(2024, 6, 30, 20, 0, 0, 0, time.UTC)))
+
+	utc := NewIndexService(repo, nil)
+	utc.Rebuild()
+	if months := utc.GetArchiveMonths(); strings.Join(months, ",") != "2024-06" {
+		t.Errorf("UTC months = %v, want [2024-06]", months)
+	}
+
+	shanghai := NewIndexService(repo, time.FixedZone("UTC+8", 8*3600))
+	shanghai.Rebuild()
+	if months := shanghai.GetArchiveMonths(); strings.Join(months, ",") != "2024-07" {
+		t.Errorf("UTC+8 months = %v, want [2024-07]", months)
+	}
+	if _, total := shanghai.ListArchive(2024, 7, 0, 10); total != 1 {
+		t.Errorf("ListArchive(2024-07) total = %d, want 1", total)
+	}
+	if _, total := shanghai.ListArchive(2024, 6, 0, 10); total != 0 {
+		t.Errorf("ListArchive(2024-06) total = %d, want 0", total)
+	}
+}
+
+func TestIndexService_Archive(t *testing.T) {
+	repo := repository.NewMemoryPostRepository()
+	dates := map[string]time.Time{
+		"a": time.Date(2023, 12, 5, 0, 0, 0, 0, time.UTC),
+		"b": time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		"c": time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC),
+		"d": time.Date(2024, 6, 20, 0, 0, 0, 0, time.UTC),
+	}
+	for id, date := range dates {
+		repo.Save(createPublishedPostWithDate(id, "Title", id, date))
+	}
+	// 草稿不进入归档
+	repo.Save(createTestPostWithDate("draft", "Draft", "draft", time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)))
+
+	service := NewIndexService(repo, nil)
+	service.Rebuild()
+
+	tree := service.GetArchive()
+	want := []ArchiveYear{
+		{Year: 2024, Count: 3, Months: []ArchiveMonth{{Month: 6, Count: 2}, {Month: 5, Count: 1}}},
+		{Year: 2023, Count: 1, Months: []ArchiveMonth{{Month: 12, Count: 1}}},
+	}
+	if fmt.Sprint(tree) != fmt.Sprint(want) {
+		t.Errorf("GetArchive() = %v, want %v", tree, want)
+	}
+
+	// 按年分页，发布时间倒序
+	page, total := service.ListArchive(2024, 0, 0, 2)
+	if total != 3 || len(page) != 2 || page[0].ID != "d" || page[1].ID != "c" {
+		t.Errorf("ListArchive(2024) page 1 = %v (total %d)", summaryIDs(page), total)
+	}
+	page, _ = service.ListArchive(2024, 0, 2, 2)
+	if len(page) != 1 || page[0].ID != "b" {
+		t.Errorf("ListArchive(2024) page 2 = %v", summaryIDs(page))
+	}
+	if page, _ := service.ListArchive(2024, 0, 10, 2); len(page) != 0 {
+		t.Errorf("ListArchive(2024) past the end = %v", summaryIDs(page))
+	}
+	if page, _ := service.ListArchive(2024, 0, 1, math.MaxInt); len(page) != 2 {
+		t.Errorf("ListArchive(2024) with huge limit = %v", summaryIDs(page))
+	}
+	// 第 3e17 页（每页 10 篇）的偏移量
+	for _, offset := range []int{-1, math.MaxInt, (3e17 - 1) * 10} {
+		if page, total := service.ListArchive(2024, 0, offset, math.MaxInt); len(page) != 0 || total != 3 {
+			t.Errorf("ListArchive(2024, offset %d) = %v (total %d), want empty page", offset, summaryIDs(page), total)
+		}
+	}
+
+	// 取消发布后从归档移除
+	post, _ := repo.FindByID("b")
+	post.Unpublish()
+	service.PostSaved(post)
+	if months := service.GetArchiveMonths(); strings.Join(months, ",") != "2024-06,2023-12" {
+		t.Errorf("GetArchiveMonths() after unpublish = %v", months)
+	}
+}
+
+func summaryIDs(posts []*domain.PostSummary) []string {
+	ids := make([]string, len(posts))
+	for i, post := range posts {
+		ids[i] = post.ID
+	}
+	return ids
+}
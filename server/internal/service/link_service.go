@@ -0,0 +1,339 @@
+package service
+
+import (
+	"errors"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/repository"
+	"github.com/next-ai-ventus/server/internal/storage"
+	"github.com/next-ai-ventus/server/pkg/markdown"
+)
+
+// 站内链接类型
+const (
+	LinkKindPost   = "post"   // 指向文章（见 PostSlugFromPath）
+	LinkKindUpload = "upload" // 指向上传文件：/uploads/<key>
+)
+
+// 失效原因
+const (
+	BrokenNotFound      = "not_found"      // 目标文章不存在
+	BrokenUnpublished   = "unpublished"    // 已发布的文章指向未发布的文章
+	BrokenMissingUpload = "missing_upload" // 上传文件不存在
+)
+
+// PostLink 文章正文中的一条站内链接
+type PostLink struct {
+	Kind   string `json:"kind"`
+	Target string `json:"target"` // 文章 Slug 或上传文件的键
+	URL    string `json:"url"`
+	Text   string `json:"text"`
+	Image  bool   `json:"image"`
+	Line   int    `json:"line"`
+}
+
+// LinkedPost 链接图中的文章
+type LinkedPost struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Slug      string `json:"slug"`
+	Published bool   `json:"published"`
+}
+
+// InboundLink 指向某篇文章的链接及其所在文章
+type InboundLink struct {
+	Source *LinkedPost `json:"source"`
+	Link   PostLink    `json:"link"`
+}
+
+// BrokenLink 失效的站内链接
+type BrokenLink struct {
+	Source *LinkedPost `json:"source"`
+	Link   PostLink    `json:"link"`
+	Reason string      `json:"reason"`
+}
+
+// LinkReport 站内链接检查报告
+type LinkReport struct {
+	Posts  int           `json:"posts"`
+	Links  int           `json:"links"`
+	Broken []*BrokenLink `json:"broken"`
+}
+
+// linkedDoc 链接图中的文章及其出链
+type linkedDoc struct {
+	post  LinkedPost
+	links []PostLink
+}
+
+// LinkOptions 链接服务配置
+type LinkOptions struct {
+	SiteURL    string // 站点地址，以此开头的绝对链接按站内链接处理
+	PathPrefix string // 站点按路径前缀路由时的前缀，站内链接可带此前缀
+}
+
+// LinkService 维护文章之间的站内链接图，随文章保存与删除增量更新
+type LinkService struct {
+	repo    repository.PostRepository
+	uploads storage.Blob
+	opts    LinkOptions
+
+	mu   sync.RWMutex
+	docs map[string]*linkedDoc // 文章 ID -> 文章
+}
+
+// NewLinkService 创建链接服务，uploads 为空时报告不检查上传文件
+func NewLinkService(repo repository.PostRepository, uploads storage.Blob, opts LinkOptions) *LinkService {
+	opts.SiteURL = strings.TrimRight(opts.SiteURL, "/")
+	opts.PathPrefix = strings.TrimRight(opts.PathPrefix, "/")
+	return &LinkService{
+		repo:    repo,
+		uploads: uploads,
+		opts:    opts,
+		docs:    make(map[string]*linkedDoc),
+	}
+}
+
+// Rebuild 从仓库重新解析全部文章的链接
+func (s *LinkService) Rebuild() error {
+	// 列表只包含摘要，正文需逐篇加载
+	docs := make(map[string]*linkedDoc)
+	for page := 1; ; page++ {
+		result, err := s.repo.FindAll(repository.ListOptions{Page: page, PageSize: indexPageSize})
+		if err != nil {
+			return err
+		}
+		for _, item := range result.Items {
+			post, err := s.repo.FindByID(item.ID)
+			if errors.Is(err, repository.ErrPostNotFound) {
+				continue // 期间被删除
+			}
+			if err != nil {
+				return err
+			}
+			docs[post.ID] = s.parse(post)
+		}
+		if page >= result.TotalPages {
+			break
+		}
+	}
+
+	s.mu.Lock()
+	s.docs = docs
+	s.mu.Unlock()
+	return nil
+}
+
+// PostSaved 实现 PostObserver，重新解析文章的链接
+func (s *LinkService) PostSaved(post *domain.Post) {
+	doc := s.parse(post)
+
+	s.mu.Lock()
+	s.docs[post.ID] = doc
+	s.mu.Unlock()
+}
+
+// PostDeleted 实现 PostObserver，移除文章及其出链
+func (s *LinkService) PostDeleted(id string) {
+	s.mu.Lock()
+	delete(s.docs, id)
+	s.mu.Unlock()
+}
+
+// Outbound 返回文章的站内出链（按出现顺序）
+func (s *LinkService) Outbound(id string) []PostLink {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	doc, ok := s.docs[id]
+	if !ok {
+		return []PostLink{}
+	}
+	return append([]PostLink{}, doc.links...)
+}
+
+// Inbound 返回指向 slug 的全部链接（含草稿中的链接，不含文章自身的链接）
+func (s *LinkService) Inbound(slug string) []*InboundLink {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*InboundLink, 0)
+	for _, doc := range s.sortedDocsLocked() {
+		if doc.post.Slug == slug {
+			continue
+		}
+		for _, link := range doc.links {
+			if link.Kind == LinkKindPost && link.Target == slug {
+				source := doc.post
+				result = append(result, &InboundLink{Source: &source, Link: link})
+			}
+		}
+	}
+	return result
+}
+
+// Backlinks 返回链接到某篇文章的已发布文章（每篇只出现一次）
+func (s *LinkService) Backlinks(id string) []*LinkedPost {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*LinkedPost, 0)
+	target, ok := s.docs[id]
+	if !ok {
+		return result
+	}
+	for _, doc := range s.sortedDocsLocked() {
+		if doc.post.ID == id || !doc.post.Published {
+			continue
+		}
+		for _, link := range doc.links {
+			if link.Kind == LinkKindPost && link.Target == target.post.Slug {
+				source := doc.post
+				result = append(result, &source)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// Report 检查全部站内链接：指向不存在文章的链接、已发布文章指向未发布文章的链接、
+// 引用不存在的上传文件。redirected 非空时，其地址能通过重定向找到文章的链接不算失效
+func (s *LinkService) Report(redirected func(path string) bool) (*LinkReport, error) {
+	s.mu.RLock()
+	docs := s.sortedDocsLocked()
+	bySlug := make(map[string]*linkedDoc, len(docs))
+	for _, doc := range docs {
+		bySlug[doc.post.Slug] = doc
+	}
+	s.mu.RUnlock()
+
+	report := &LinkReport{Posts: len(docs), Broken: make([]*BrokenLink, 0)}
+	uploads := make(map[string]bool) // 上传文件键 -> 是否存在
+	for _, doc := range docs {
+		for _, link := range doc.links {
+			report.Links++
+
+			reason := ""
+			switch link.Kind {
+			case LinkKindPost:
+				target, ok := bySlug[link.Target]
+				switch {
+				case !ok:
+					if redirected == nil || !redirected(link.URL) {
+						reason = BrokenNotFound
+					}
+				case doc.post.Published && !target.post.Published:
+					reason = BrokenUnpublished
+				}
+			case LinkKindUpload:
+				if s.uploads == nil {
+					continue
+				}
+				exists, checked := uploads[link.Target]
+				if !checked {
+					var err error
+					if exists, err = s.uploadExists(link.Target); err != nil {
+						return nil, err
+					}
+					uploads[link.Target] = exists
+				}
+				if !exists {
+					reason = BrokenMissingUpload
+				}
+			}
+
+			if reason != "" {
+				source := doc.post
+				report.Broken = append(report.Broken, &BrokenLink{Source: &source, Link: link, Reason: reason})
+			}
+		}
+	}
+	return report, nil
+}
+
+// uploadExists 检查上传文件是否存在
+func (s *LinkService) uploadExists(key string) (bool, error) {
+	_, err := s.uploads.Stat(key)
+	if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrInvalidKey) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// sortedDocsLocked 按标题与 ID 排序返回全部文章，调用方需持有锁
+func (s *LinkService) sortedDocsLocked() []*linkedDoc {
+	docs := make([]*linkedDoc, 0, len(s.docs))
+	for _, doc := range s.docs {
+		docs = append(docs, doc)
+	}
+	sort.Slice(docs, func(i, j int) bool {
+		if docs[i].post.Title != docs[j].post.Title {
+			return docs[i].post.Title < docs[j].post.Title
+		}
+		return docs[i].post.ID < docs[j].post.ID
+	})
+	return docs
+}
+
+// parse 解析文章正文中的站内链接（外部链接忽略）
+func (s *LinkService) parse(post *domain.Post) *linkedDoc {
+	doc := &linkedDoc{
+		post: LinkedPost{
+			ID:        post.ID,
+			Title:     post.Title,
+			Slug:      post.Slug.String(),
+			Published: post.IsPublished(),
+		},
+	}
+	for _, link := range markdown.ExtractLinks(post.Content) {
+		kind, target, ok := s.classify(link.URL)
+		if !ok {
+			continue
+		}
+		doc.links = append(doc.links, PostLink{
+			Kind:   kind,
+			Target: target,
+			URL:    link.URL,
+			Text:   link.Text,
+			Image:  link.Image,
+			Line:   link.Line,
+		})
+	}
+	return doc
+}
+
+// classify 判断链接是否为站内文章或上传文件链接，返回类型与目标
+func (s *LinkService) classify(raw string) (kind, target string, ok bool) {
+	if s.opts.SiteURL != "" && strings.HasPrefix(raw, s.opts.SiteURL+"/") {
+		raw = strings.TrimPrefix(raw, s.opts.SiteURL)
+	}
+	if s.opts.PathPrefix != "" && strings.HasPrefix(raw, s.opts.PathPrefix+"/") {
+		raw = strings.TrimPrefix(raw, s.opts.PathPrefix)
+	}
+	if !strings.HasPrefix(raw, "/") || strings.HasPrefix(raw, "//") {
+		return "", "", false
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", "", false
+	}
+
+	switch {
+	case strings.HasPrefix(u.Path, storage.URLPrefix+"/"):
+		key := path.Clean(strings.TrimPrefix(u.Path, storage.URLPrefix+"/"))
+		if key == "." || strings.HasPrefix(key, "..") {
+			return "", "", false
+		}
+		return LinkKindUpload, key, true
+	}
+	if slug, ok := PostSlugFromPath(u); ok {
+		return LinkKindPost, slug, true
+	}
+	return "", "", false
+}
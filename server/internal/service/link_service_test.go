@@ -0,0 +1,110 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/storage"
+)
+
+func TestLinkService_Graph(t *testing.T) {
+	postService, repo := setupTestServices()
+	uploads := storage.NewLocal(t.TempDir(), storage.URLPrefix)
+	uploads.Put("present.png", strings.NewReader("png"), "image/png")
+	links := NewLinkService(repo, uploads, LinkOptions{SiteURL: "https://blog.example.com/", PathPrefix: "/blog"})
+	postService.AddObserver(links)
+
+	publish := func(post *domain.Post) {
+		status := "published"
+		if _, err := postService.UpdatePost(post.ID, UpdatePostInput{Status: &status}, post.Version); err != nil {
+			t.Fatalf("UpdatePost() error = %v", err)
+		}
+	}
+
+	target, _ := postService.CreatePost(CreatePostInput{Title: "Target", Content: "Linked from elsewhere."})
+	publish(target)
+	draft, _ := postService.CreatePost(CreatePostInput{
+		Title:   "Draft",
+		Content: "[target](/post/" + target.Slug.String() + "/)",
+	})
+	source, _ := postService.CreatePost(CreatePostInput{
+		Title: "Source",
+		Content: "See [target](/pages/post/index.html?slug=" + target.Slug.String() + ") and " +
+			"[absolute](https://blog.example.com/post/" + target.Slug.String() + "#intro).\n" +
+			"[gone](/post/missing) [draft](/post/" + draft.Slug.String() + ") [external](https://example.com/post/x)\n" +
+			"![ok](/blog/uploads/present.png) ![lost](/uploads/lost.png?v=2)\n",
+	})
+	publish(source)
+
+	if got := links.Outbound(source.ID); len(got) != 6 {
+		t.Fatalf("Outbound() = %+v, want 6 internal links", got)
+	}
+	if got := links.Inbound(target.Slug.String()); len(got) != 3 {
+		t.Errorf("Inbound() = %d links, want 3 (two from source, one from draft)", len(got))
+	}
+	backlinks := links.Backlinks(target.ID)
+	if len(backlinks) != 1 || backlinks[0].ID != source.ID {
+		t.Errorf("Backlinks() = %+v, want only the published source", backlinks)
+	}
+
+	t.Run("report", func(t *testing.T) {
+		report, err := links.Report(nil)
+		if err != nil {
+			t.Fatalf("Report() error = %v", err)
+		}
+		reasons := make(map[string]string)
+		for _, broken := range report.Broken {
+			reasons[broken.Link.Target] = broken.Reason
+		}
+		want := map[string]string{
+			"missing":           BrokenNotFound,
+			draft.Slug.String(): BrokenUnpublished,
+			"lost.png":          BrokenMissingUpload,
+		}
+		if len(reasons) != len(want) {
+			t.Fatalf("Report().Broken = %v, want %v", reasons, want)
+		}
+		for target, reason := range want {
+			if reasons[target] != reason {
+				t.Errorf("reason for %q = %q, want %q", target, reasons[target], reason)
+			}
+		}
+	})
+
+	t.Run("redirected links are not broken", func(t *testing.T) {
+		report, _ := links.Report(func(path string) bool { return path == "/post/missing" })
+		for _, broken := range report.Broken {
+			if broken.Link.Target == "missing" {
+				t.Errorf("redirected link reported as broken: %+v", broken)
+			}
+		}
+	})
+
+	t.Run("deleted target breaks inbound links", func(t *testing.T) {
+		if err := postService.DeletePost(target.ID); err != nil {
+			t.Fatalf("DeletePost() error = %v", err)
+		}
+		report, _ := links.Report(nil)
+		count := 0
+		for _, broken := range report.Broken {
+			if broken.Link.Target == target.Slug.String() && broken.Reason == BrokenNotFound {
+				count++
+			}
+		}
+		if count != 3 {
+			t.Errorf("broken links to deleted post = %d, want 3", count)
+		}
+	})
+
+	t.Run("rebuild", func(t *testing.T) {
+		rebuilt := NewLinkService(repo, nil, LinkOptions{})
+		if err := rebuilt.Rebuild(); err != nil {
+			t.Fatalf("Rebuild() error = %v", err)
+		}
+		// 未配置站点地址与前缀时，绝对链接与带前缀的链接视为外部链接
+		if got := rebuilt.Outbound(source.ID); len(got) != 4 {
+			t.Errorf("Outbound() after Rebuild = %d, want 4", len(got))
+		}
+	})
+}
@@ -0,0 +1,450 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/mail"
+	"github.com/next-ai-ventus/server/internal/repository"
+	"github.com/next-ai-ventus/server/pkg/markdown"
+)
+
+var (
+	ErrNewsletterDisabled     = errors.New("newsletter is not configured")
+	ErrInvalidNewsletterToken = errors.New("invalid or expired newsletter link")
+)
+
+// 邮件订阅参数
+const (
+	NewsletterConfirmTTL      = 48 * time.Hour // 确认链接的有效期，过期未确认的订阅会被清除
+	DefaultNewsletterBatch    = 20             // 每批最多发送的邮件数
+	newsletterMaxPostAge      = 24 * time.Hour // 只为发布时间在此之内的文章发送（导入旧文章不发送）
+	newsletterUnsubscribePath = "/api/newsletter/unsubscribe"
+	newsletterConfirmPath     = "/api/newsletter/confirm"
+)
+
+// NewsletterOptions 邮件订阅配置
+type NewsletterOptions struct {
+	SiteName  string
+	SiteURL   string // 站点对外地址，用于邮件中的链接
+	BatchSize int    // 每批最多发送的邮件数，默认 DefaultNewsletterBatch
+}
+
+// NewsletterService 新文章邮件通知：订阅需点击确认邮件中的链接（双重确认），
+// 发布新文章时为全部已确认的订阅者创建一份通知，由后台任务分批发送（每个间隔一批）。
+// 每封邮件都带有一键退订链接（同时写入 List-Unsubscribe 头）
+type NewsletterService struct {
+	repo   repository.NewsletterRepository
+	posts  repository.PostRepository
+	mailer mail.Mailer
+	opts   NewsletterOptions
+	now    func() time.Time
+
+	published publishedPosts
+
+	sendMu sync.Mutex // 同一时间只发送一批
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewNewsletterService 创建邮件订阅服务并读取已发布的文章。mailer 为 nil 时不接受订阅也不发送
+func NewNewsletterService(repo repository.NewsletterRepository, posts repository.PostRepository, mailer mail.Mailer, opts NewsletterOptions) (*NewsletterService, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultNewsletterBatch
+	}
+	opts.SiteURL = strings.TrimRight(opts.SiteURL, "/")
+
+	s := &NewsletterService{
+		repo:   repo,
+		posts:  posts,
+		mailer: mailer,
+		opts:   opts,
+		now:    time.Now,
+	}
+	if err := s.Rebuild(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Enabled 检查是否配置了邮件发送
+func (s *NewsletterService) Enabled() bool {
+	return s.mailer != nil
+}
+
+// Rebuild 重新读取已发布的文章（恢复备份后调用）
+func (s *NewsletterService) Rebuild() error {
+	return s.published.load(s.posts)
+}
+
+// ReadLocked 在禁止写入订阅者与通知的状态下执行 fn（用于备份）
+func (s *NewsletterService) ReadLocked(fn func() error) error {
+	return s.repo.ReadLocked(fn)
+}
+
+// WriteLocked 在禁止写入的状态下执行 fn（fn 可直接替换数据文件），返回前重新加载订阅者与通知（用于恢复备份）。
+// 已发布的文章由调用方在文章恢复完成后调用 Rebuild 重新读取
+func (s *NewsletterService) WriteLocked(fn func() error) error {
+	return s.repo.WriteLocked(fn)
+}
+
+// Subscribe 提交订阅并发送确认邮件。已确认的邮箱不会重复发送，也不会返回错误（避免暴露订阅者）。
+// 频率限制等反垃圾检查由调用方在提交前完成
+func (s *NewsletterService) Subscribe(email string) error {
+	if s.mailer == nil {
+		return ErrNewsletterDisabled
+	}
+	email = domain.NormalizeEmail(email)
+	now := s.now()
+
+	confirmToken, err := newNewsletterToken()
+	if err != nil {
+		return err
+	}
+	subscriber, err := s.repo.FindSubscriber(email)
+	switch {
+	case err == repository.ErrSubscriberNotFound:
+		unsubscribeToken, err := newNewsletterToken()
+		if err != nil {
+			return err
+		}
+		if subscriber, err = domain.NewSubscriber(email, confirmToken, unsubscribeToken); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	case subscriber.IsConfirmed():
+		return nil
+	default:
+		// 重新提交时换发确认链接并重新计算有效期
+		subscriber.ConfirmToken = confirmToken
+	}
+	subscriber.CreatedAt = now
+
+	if err := s.repo.SaveSubscriber(subscriber); err != nil {
+		return err
+	}
+	return s.mailer.Send(s.confirmationMessage(subscriber))
+}
+
+// Confirm 通过确认链接中的令牌确认订阅
+func (s *NewsletterService) Confirm(token string) (*domain.Subscriber, error) {
+	subscriber, err := s.repo.FindSubscriberByToken(token)
+	if err == repository.ErrSubscriberNotFound {
+		return nil, ErrInvalidNewsletterToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	if subscriber.ConfirmToken != token || now.Sub(subscriber.CreatedAt) > NewsletterConfirmTTL {
+		return nil, ErrInvalidNewsletterToken
+	}
+
+	subscriber.Confirm(now)
+	if err := s.repo.SaveSubscriber(subscriber); err != nil {
+		return nil, err
+	}
+	return subscriber, nil
+}
+
+// Unsubscribe 通过退订链接中的令牌退订（删除订阅者）
+func (s *NewsletterService) Unsubscribe(token string) (*domain.Subscriber, error) {
+	subscriber, err := s.repo.FindSubscriberByToken(token)
+	if err == repository.ErrSubscriberNotFound {
+		return nil, ErrInvalidNewsletterToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if subscriber.UnsubscribeToken != token {
+		return nil, ErrInvalidNewsletterToken
+	}
+
+	if err := s.repo.DeleteSubscriber(subscriber.Email); err != nil {
+		return nil, err
+	}
+	return subscriber, nil
+}
+
+// Subscribers 列出全部订阅者
+func (s *NewsletterService) Subscribers() ([]*domain.Subscriber, error) {
+	return s.repo.ListSubscribers()
+}
+
+// Remove 由管理员删除订阅者
+func (s *NewsletterService) Remove(email string) error {
+	return s.repo.DeleteSubscriber(domain.NormalizeEmail(email))
+}
+
+// Issues 列出全部通知及其发送进度（按创建时间倒序）
+func (s *NewsletterService) Issues() ([]*domain.NewsletterIssue, error) {
+	return s.repo.ListIssues()
+}
+
+// PruneExpired 删除确认链接已过期的待确认订阅者，返回删除的数量
+func (s *NewsletterService) PruneExpired() (int, error) {
+	subscribers, err := s.repo.ListSubscribers()
+	if err != nil {
+		return 0, err
+	}
+	now := s.now()
+	pruned := 0
+	for _, subscriber := range subscribers {
+		if subscriber.IsConfirmed() || now.Sub(subscriber.CreatedAt) <= NewsletterConfirmTTL {
+			continue
+		}
+		if err := s.repo.DeleteSubscriber(subscriber.Email); err != nil {
+			return pruned, err
+		}
+		pruned++
+	}
+	return pruned, nil
+}
+
+// SendDue 发送一批待发送的邮件（最多 BatchSize 封，先发较早的通知），返回发送的数量
+func (s *NewsletterService) SendDue() (int, error) {
+	if s.mailer == nil {
+		return 0, nil
+	}
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
+	issues, err := s.repo.ListIssues()
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for i := len(issues) - 1; i >= 0 && sent < s.opts.BatchSize; i-- {
+		issue := issues[i]
+		if issue.Done() {
+			continue
+		}
+		n, err := s.sendIssue(issue, s.opts.BatchSize-sent)
+		sent += n
+		if err != nil {
+			return sent, err
+		}
+	}
+	return sent, nil
+}
+
+// Start 启动后台发送：每隔 interval 清理过期的订阅并发送一批邮件，以此限制发送速率。onError 接收仓库错误
+func (s *NewsletterService) Start(interval time.Duration, onError func(error)) {
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+			case <-s.stop:
+				return
+			}
+			if _, err := s.PruneExpired(); err != nil && onError != nil {
+				onError(err)
+			}
+			if _, err := s.SendDue(); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}()
+}
+
+// Close 停止后台发送（未发送的邮件保留在通知中，下次启动后继续）
+func (s *NewsletterService) Close() error {
+	if s.stop == nil {
+		return nil
+	}
+	s.stopOnce.Do(func() {
+		close(s.stop)
+		<-s.done
+	})
+	return nil
+}
+
+// PostSaved 实现 PostObserver：文章首次发布时为已确认的订阅者创建通知（每篇文章只通知一次）
+func (s *NewsletterService) PostSaved(post *domain.Post) {
+	_, wasPublished := s.published.saved(post)
+	if s.mailer == nil || !post.IsPublished() || wasPublished {
+		return
+	}
+	now := s.now()
+	if post.PublishedAt == nil || now.Sub(*post.PublishedAt) > newsletterMaxPostAge {
+		return
+	}
+	if _, err := s.repo.FindIssue(post.ID); err != repository.ErrIssueNotFound {
+		return
+	}
+
+	subscribers, err := s.repo.ListSubscribers()
+	if err != nil {
+		return
+	}
+	issue := &domain.NewsletterIssue{PostID: post.ID, Title: post.Title, CreatedAt: now}
+	for _, subscriber := range subscribers {
+		if subscriber.IsConfirmed() {
+			issue.Pending = append(issue.Pending, subscriber.Email)
+		}
+	}
+	if issue.Done() {
+		issue.CompletedAt = now
+	}
+	_ = s.repo.SaveIssue(issue)
+}
+
+// PostDeleted 实现 PostObserver：文章被删除时取消尚未发送完的通知
+func (s *NewsletterService) PostDeleted(id string) {
+	s.published.deleted(id)
+
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
+	issue, err := s.repo.FindIssue(id)
+	if err != nil || issue.Done() {
+		return
+	}
+	issue.Pending = nil
+	issue.Canceled = true
+	issue.CompletedAt = s.now()
+	_ = s.repo.SaveIssue(issue)
+}
+
+// sendIssue 发送通知中至多 limit 封邮件，每封发送后保存进度（调用方需持有 sendMu）
+func (s *NewsletterService) sendIssue(issue *domain.NewsletterIssue, limit int) (int, error) {
+	post, err := s.posts.FindByID(issue.PostID)
+	if err == repository.ErrPostNotFound {
+		issue.Pending = nil
+		issue.Canceled = true
+		issue.CompletedAt = s.now()
+		return 0, s.repo.SaveIssue(issue)
+	}
+	if err != nil {
+		return 0, err
+	}
+	content := s.renderPost(post)
+
+	sent := 0
+	for !issue.Done() && sent < limit {
+		email := issue.Pending[0]
+		issue.Pending = issue.Pending[1:]
+
+		// 创建通知后退订的不再发送
+		subscriber, err := s.repo.FindSubscriber(email)
+		if err == nil && subscriber.IsConfirmed() {
+			if err := s.mailer.Send(content.message(subscriber, s.unsubscribeURL(subscriber))); err != nil {
+				issue.Failed++
+				issue.LastError = truncateError(err.Error())
+			} else {
+				issue.Sent++
+			}
+			sent++
+		}
+
+		if issue.Done() {
+			issue.CompletedAt = s.now()
+		}
+		if err := s.repo.SaveIssue(issue); err != nil {
+			return sent, err
+		}
+	}
+	return sent, nil
+}
+
+// newsletterContent 一篇文章的邮件内容，退订链接按订阅者填入
+type newsletterContent struct {
+	subject string
+	html    string // 含 {{unsubscribe}} 占位
+	text    string // 含 {{unsubscribe}} 占位
+}
+
+const unsubscribePlaceholder = "{{unsubscribe}}"
+
+// message 生成发给订阅者的邮件
+func (c *newsletterContent) message(subscriber *domain.Subscriber, unsubscribeURL string) *mail.Message {
+	return &mail.Message{
+		To:      subscriber.Email,
+		Subject: c.subject,
+		HTML:    strings.ReplaceAll(c.html, unsubscribePlaceholder, html.EscapeString(unsubscribeURL)),
+		Text:    strings.ReplaceAll(c.text, unsubscribePlaceholder, unsubscribeURL),
+		Headers: map[string]string{
+			"List-Unsubscribe":      "<" + unsubscribeURL + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
+	}
+}
+
+// relativeLinkRegex 匹配站内相对地址（以单个 "/" 开头）的链接与图片
+var relativeLinkRegex = regexp.MustCompile(`(href|src)="/([^/"][^"]*)?"`)
+
+// renderPost 由 markdown.Parse 渲染文章的 HTML 与纯文本邮件，站内相对地址改为绝对地址
+func (s *NewsletterService) renderPost(post *domain.Post) *newsletterContent {
+	postURL := s.opts.SiteURL + PostPath(post.Slug.String())
+	body := relativeLinkRegex.ReplaceAllString(markdown.Parse(post.Content).HTML, `$1="`+s.opts.SiteURL+`/$2"`)
+	siteName := html.EscapeString(s.opts.SiteName)
+
+	var h strings.Builder
+	h.WriteString(`<!DOCTYPE html><html><body style="font-family:sans-serif;max-width:640px;margin:0 auto;line-height:1.6">`)
+	fmt.Fprintf(&h, `<p style="color:#888">%s</p>`, siteName)
+	fmt.Fprintf(&h, `<h1><a href="%s" style="color:inherit">%s</a></h1>`, html.EscapeString(postURL), html.EscapeString(post.Title))
+	h.WriteString(body)
+	fmt.Fprintf(&h, `<p><a href="%s">Read on %s</a></p>`, html.EscapeString(postURL), siteName)
+	fmt.Fprintf(&h, `<hr><p style="color:#888;font-size:12px">You are receiving this because you subscribed to %s. <a href="%s">Unsubscribe</a></p>`, siteName, unsubscribePlaceholder)
+	h.WriteString(`</body></html>`)
+
+	var t strings.Builder
+	fmt.Fprintf(&t, "%s\n\n", post.Title)
+	fmt.Fprintf(&t, "%s\n\n", markdown.PlainText(post.Content))
+	fmt.Fprintf(&t, "Read on %s: %s\n\n", s.opts.SiteName, postURL)
+	fmt.Fprintf(&t, "--\nUnsubscribe: %s\n", unsubscribePlaceholder)
+
+	return &newsletterContent{subject: post.Title, html: h.String(), text: t.String()}
+}
+
+// confirmationMessage 生成确认订阅的邮件
+func (s *NewsletterService) confirmationMessage(subscriber *domain.Subscriber) *mail.Message {
+	confirmURL := s.opts.SiteURL + newsletterConfirmPath + "?token=" + url.QueryEscape(subscriber.ConfirmToken)
+	siteName := html.EscapeString(s.opts.SiteName)
+
+	return &mail.Message{
+		To:      subscriber.Email,
+		Subject: "Confirm your subscription to " + s.opts.SiteName,
+		HTML: fmt.Sprintf(`<!DOCTYPE html><html><body style="font-family:sans-serif">`+
+			`<p>Please confirm that you want to receive new posts from %s by email:</p>`+
+			`<p><a href="%s">Confirm subscription</a></p>`+
+			`<p style="color:#888;font-size:12px">The link expires in 48 hours. If you did not subscribe, ignore this email.</p>`+
+			`</body></html>`, siteName, html.EscapeString(confirmURL)),
+		Text: fmt.Sprintf("Please confirm that you want to receive new posts from %s by email:\n\n%s\n\n"+
+			"The link expires in 48 hours. If you did not subscribe, ignore this email.\n", s.opts.SiteName, confirmURL),
+	}
+}
+
+// unsubscribeURL 返回订阅者的一键退订地址
+func (s *NewsletterService) unsubscribeURL(subscriber *domain.Subscriber) string {
+	return s.opts.SiteURL + newsletterUnsubscribePath + "?token=" + url.QueryEscape(subscriber.UnsubscribeToken)
+}
+
+// newNewsletterToken 生成确认或退订链接中的随机令牌
+func newNewsletterToken() (string, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/domain/valueobject"
+	"github.com/next-ai-ventus/server/internal/mail"
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+// recordingMailer 记录发送的邮件
+type recordingMailer struct {
+	mu       sync.Mutex
+	messages []*mail.Message
+}
+
+func (m *recordingMailer) Send(msg *mail.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+func (m *recordingMailer) sent() []*mail.Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*mail.Message{}, m.messages...)
+}
+
+// linkToken 从邮件正文中取出 path 链接的令牌
+func linkToken(t *testing.T, msg *mail.Message, path string) string {
+	t.Helper()
+	start := strings.Index(msg.Text, path+"?token=")
+	if start < 0 {
+		t.Fatalf("message to %s has no %s link:\n%s", msg.To, path, msg.Text)
+	}
+	link := strings.Fields(msg.Text[start:])[0]
+	u, _ := url.Parse(link)
+	return u.Query().Get("token")
+}
+
+func setupNewsletterService(t *testing.T) (*NewsletterService, *PostService, *recordingMailer) {
+	t.Helper()
+
+	postService, repo := setupTestServices()
+	mailer := &recordingMailer{}
+	newsletter, err := NewNewsletterService(repository.NewMemoryNewsletterRepository(), repo, mailer, NewsletterOptions{
+		SiteName:  "Test Blog",
+		SiteURL:   "https://blog.example.com/",
+		BatchSize: 2,
+	})
+	if err != nil {
+		t.Fatalf("NewNewsletterService() error = %v", err)
+	}
+	postService.AddObserver(newsletter)
+	return newsletter, postService, mailer
+}
+
+// subscribeConfirmed 订阅并点击确认链接
+func subscribeConfirmed(t *testing.T, newsletter *NewsletterService, mailer *recordingMailer, email string) {
+	t.Helper()
+	if err := newsletter.Subscribe(email); err != nil {
+		t.Fatalf("Subscribe(%s) error = %v", email, err)
+	}
+	messages := mailer.sent()
+	if _, err := newsletter.Confirm(linkToken(t, messages[len(messages)-1], newsletterConfirmPath)); err != nil {
+		t.Fatalf("Confirm(%s) error = %v", email, err)
+	}
+}
+
+func TestNewsletterService_DoubleOptIn(t *testing.T) {
+	newsletter, _, mailer := setupNewsletterService(t)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	newsletter.now = func() time.Time { return now }
+
+	if err := newsletter.Subscribe(" Reader@Example.com "); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	messages := mailer.sent()
+	if len(messages) != 1 || messages[0].To != "reader@example.com" {
+		t.Fatalf("sent %v, want one confirmation to reader@example.com", messages)
+	}
+	token := linkToken(t, messages[0], newsletterConfirmPath)
+	if !strings.HasPrefix(messages[0].Text[strings.Index(messages[0].Text, "https://"):], "https://blog.example.com/api/") {
+		t.Errorf("confirmation link should be absolute:\n%s", messages[0].Text)
+	}
+
+	subscribers, _ := newsletter.Subscribers()
+	if len(subscribers) != 1 || subscribers[0].IsConfirmed() {
+		t.Fatalf("Subscribers() = %+v, want one pending", subscribers)
+	}
+
+	subscriber, err := newsletter.Confirm(token)
+	if err != nil || !subscriber.IsConfirmed() {
+		t.Fatalf("Confirm() = %+v, %v", subscriber, err)
+	}
+	if _, err := newsletter.Confirm(token); err != ErrInvalidNewsletterToken {
+		t.Errorf("Confirm() twice error = %v, want ErrInvalidNewsletterToken", err)
+	}
+
+	// 已确认的邮箱再次订阅不发送邮件
+	if err := newsletter.Subscribe("reader@example.com"); err != nil || len(mailer.sent()) != 1 {
+		t.Errorf("Subscribe() confirmed = %v, sent %d, want no new mail", err, len(mailer.sent()))
+	}
+
+	// 确认链接过期后无效，并在清理时删除
+	newsletter.Subscribe("late@example.com")
+	messages = mailer.sent()
+	lateToken := linkToken(t, messages[len(messages)-1], newsletterConfirmPath)
+	now = now.Add(NewsletterConfirmTTL + time.Minute)
+	if _, err := newsletter.Confirm(lateToken); err != ErrInvalidNewsletterToken {
+		t.Errorf("Confirm() expired error = %v, want ErrInvalidNewsletterToken", err)
+	}
+	if pruned, _ := newsletter.PruneExpired(); pruned != 1 {
+		t.Errorf("PruneExpired() = %d, want 1", pruned)
+	}
+	if subscribers, _ := newsletter.Subscribers(); len(subscribers) != 1 {
+		t.Errorf("Subscribers() after prune = %d, want 1", len(subscribers))
+	}
+
+	if err := newsletter.Subscribe("not-an-email"); err != domain.ErrInvalidSubscriberEmail {
+		t.Errorf("Subscribe(invalid) error = %v, want ErrInvalidSubscriberEmail", err)
+	}
+}
+
+func TestNewsletterService_SendThrottled(t *testing.T) {
+	newsletter, postService, mailer := setupNewsletterService(t)
+	for i := 0; i < 3; i++ {
+		subscribeConfirmed(t, newsletter, mailer, fmt.Sprintf("reader%d@example.com", i))
+	}
+	newsletter.Subscribe("pending@example.com") // 未确认，不会收到
+	confirmations := len(mailer.sent())
+
+	post, _ := postService.CreatePost(CreatePostInput{Title: "Hello", Content: "Some **bold** text and [a file](/uploads/a.pdf)"})
+	published := "published"
+	post, _ = postService.UpdatePost(post.ID, UpdatePostInput{Status: &published}, post.Version)
+
+	// 每批最多 2 封
+	if n, err := newsletter.SendDue(); err != nil || n != 2 {
+		t.Fatalf("first SendDue() = %d, %v, want 2", n, err)
+	}
+	if n, _ := newsletter.SendDue(); n != 1 {
+		t.Fatalf("second SendDue() = %d, want 1", n)
+	}
+	if n, _ := newsletter.SendDue(); n != 0 {
+		t.Errorf("third SendDue() = %d, want 0", n)
+	}
+
+	issues, _ := newsletter.Issues()
+	if len(issues) != 1 || !issues[0].Done() || issues[0].Sent != 3 || issues[0].CompletedAt.IsZero() {
+		t.Fatalf("Issues() = %+v, want one completed issue with 3 sent", issues)
+	}
+
+	digests := mailer.sent()[confirmations:]
+	if len(digests) != 3 {
+		t.Fatalf("sent %d digests, want 3", len(digests))
+	}
+	msg := digests[0]
+	if msg.Subject != "Hello" || !strings.Contains(msg.HTML, "<strong>bold</strong>") || !strings.Contains(msg.HTML, `href="https://blog.example.com/uploads/a.pdf"`) {
+		t.Errorf("digest HTML = %s", msg.HTML)
+	}
+	if !strings.Contains(msg.Text, "https://blog.example.com"+PostPath(post.Slug.String())) {
+		t.Errorf("digest text missing post link:\n%s", msg.Text)
+	}
+	unsubscribe := msg.Headers["List-Unsubscribe"]
+	if !strings.HasPrefix(unsubscribe, "<https://blog.example.com"+newsletterUnsubscribePath+"?token=") || msg.Headers["List-Unsubscribe-Post"] != "List-Unsubscribe=One-Click" {
+		t.Errorf("digest headers = %v", msg.Headers)
+	}
+
+	// 一键退订
+	subscriber, err := newsletter.Unsubscribe(linkToken(t, msg, newsletterUnsubscribePath))
+	if err != nil || subscriber.Email != msg.To {
+		t.Fatalf("Unsubscribe() = %+v, %v", subscriber, err)
+	}
+	if _, err := newsletter.Unsubscribe(linkToken(t, msg, newsletterUnsubscribePath)); err != ErrInvalidNewsletterToken {
+		t.Errorf("Unsubscribe() twice error = %v, want ErrInvalidNewsletterToken", err)
+	}
+
+	// 取消发布后重新发布不会再次发送
+	draft := "draft"
+	post, _ = postService.UpdatePost(post.ID, UpdatePostInput{Status: &draft}, post.Version)
+	postService.UpdatePost(post.ID, UpdatePostInput{Status: &published}, post.Version)
+	if issues, _ := newsletter.Issues(); len(issues) != 1 {
+		t.Errorf("Issues() after republish = %d, want 1", len(issues))
+	}
+}
+
+func TestNewsletterService_SkipsAndCancels(t *testing.T) {
+	newsletter, postService, mailer := setupNewsletterService(t)
+	subscribeConfirmed(t, newsletter, mailer, "a@example.com")
+	subscribeConfirmed(t, newsletter, mailer, "b@example.com")
+	subscribeConfirmed(t, newsletter, mailer, "c@example.com")
+
+	// 导入的旧文章不发送
+	slug, _ := valueobject.NewSlug("old")
+	postService.ImportPost(ImportPostInput{Title: "Old", Content: "Old post", Slug: slug, Date: time.Now().AddDate(-1, 0, 0)})
+	if issues, _ := newsletter.Issues(); len(issues) != 0 {
+		t.Fatalf("Issues() after import = %d, want 0", len(issues))
+	}
+
+	post, _ := postService.CreatePost(CreatePostInput{Title: "New", Content: "Content"})
+	published := "published"
+	postService.UpdatePost(post.ID, UpdatePostInput{Status: &published}, post.Version)
+
+	// 创建通知后退订的订阅者不再收到
+	subscribers, _ := newsletter.Subscribers()
+	newsletter.Unsubscribe(subscribers[0].UnsubscribeToken)
+
+	if n, _ := newsletter.SendDue(); n != 2 {
+		t.Errorf("SendDue() = %d, want 2 (unsubscribed reader skipped)", n)
+	}
+
+	// 发送完成前删除文章时取消通知
+	other, _ := postService.CreatePost(CreatePostInput{Title: "Other", Content: "Content"})
+	postService.UpdatePost(other.ID, UpdatePostInput{Status: &published}, other.Version)
+	postService.DeletePost(other.ID)
+	if n, _ := newsletter.SendDue(); n != 0 {
+		t.Errorf("SendDue() after delete = %d, want 0", n)
+	}
+	issues, _ := newsletter.Issues()
+	if len(issues) != 2 || !issues[0].Canceled || issues[0].Sent != 0 {
+		t.Errorf("Issues() = %+v, want the deleted post's issue canceled", issues)
+	}
+}
+
+func TestNewsletterService_Disabled(t *testing.T) {
+	_, repo := setupTestServices()
+	disabled, _ := NewNewsletterService(repository.NewMemoryNewsletterRepository(), repo, nil, NewsletterOptions{})
+	if err := disabled.Subscribe("a@example.com"); err != ErrNewsletterDisabled {
+		t.Errorf("Subscribe() without mailer error = %v, want ErrNewsletterDisabled", err)
+	}
+}
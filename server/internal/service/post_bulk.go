@@ -0,0 +1,272 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/domain/valueobject"
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+var (
+	ErrInvalidBulkRequest = errors.New("invalid bulk request")
+	ErrBulkAborted        = errors.New("not applied: another post in the atomic batch failed")
+)
+
+// MaxBulkItems 单次批量操作最多处理的文章数
+const MaxBulkItems = 200
+
+// BulkAction 批量操作类型
+type BulkAction string
+
+const (
+	BulkPublish     BulkAction = "publish"
+	BulkUnpublish   BulkAction = "unpublish"
+	BulkDelete      BulkAction = "delete"
+	BulkAddTags     BulkAction = "addTags"
+	BulkRemoveTags  BulkAction = "removeTags"
+	BulkSetCategory BulkAction = "setCategory"
+)
+
+// BulkItem 批量操作的一篇文章及其期望版本（乐观锁）
+type BulkItem struct {
+	ID      string
+	Version int
+}
+
+// BulkInput 批量操作输入
+type BulkInput struct {
+	Action   BulkAction
+	Items    []BulkItem
+	Tags     []string // addTags / removeTags
+	Category string   // setCategory，为空表示清除分类
+	Atomic   bool     // 全部成功或全部不生效（需要仓库支持 BatchWriter）
+	Editor   string   // 操作者用户名（版本化仓库用作提交作者）
+}
+
+// BulkItemResult 单篇文章的处理结果
+type BulkItemResult struct {
+	ID      string
+	Version int   // 成功后的版本（删除时为 0）
+	Err     error // 为 nil 表示成功
+}
+
+// BulkResult 批量操作结果，Items 与输入顺序一致
+type BulkResult struct {
+	Items     []BulkItemResult
+	Succeeded int
+	Failed    int
+}
+
+// bulkChange 单篇文章准备好的修改
+type bulkChange struct {
+	post   *domain.Post // 修改后的文章（删除时为原文章）
+	delete bool
+}
+
+// BulkUpdate 对多篇文章执行同一操作。
+// 默认逐篇处理并分别报告结果；Atomic 为 true 时先校验全部文章，再由仓库一次性写入
+func (s *PostService) BulkUpdate(input BulkInput) (*BulkResult, error) {
+	if err := validateBulkInput(input); err != nil {
+		return nil, err
+	}
+	tags, err := s.parseTags(input.Tags)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBulkRequest, err)
+	}
+
+	var writer repository.BatchWriter
+	if input.Atomic {
+		var ok bool
+		if writer, ok = s.repo.(repository.BatchWriter); !ok {
+			return nil, repository.ErrBatchUnsupported
+		}
+	}
+
+	result := &BulkResult{Items: make([]BulkItemResult, len(input.Items))}
+	changes := make([]*bulkChange, len(input.Items))
+	for i, item := range input.Items {
+		result.Items[i].ID = item.ID
+		changes[i], result.Items[i].Err = s.prepareBulkChange(input, tags, item)
+	}
+
+	if input.Atomic {
+		s.applyBulkAtomic(writer, input, changes, result)
+	} else {
+		for i, change := range changes {
+			if change != nil {
+				result.Items[i].Err = s.applyBulkChange(change, input.Editor)
+			}
+		}
+	}
+
+	for i, change := range changes {
+		if result.Items[i].Err != nil {
+			result.Failed++
+			continue
+		}
+		result.Succeeded++
+		if !change.delete {
+			result.Items[i].Version = change.post.Version
+		}
+	}
+	return result, nil
+}
+
+// validateBulkInput 校验操作类型与文章列表（不允许重复的文章）
+func validateBulkInput(input BulkInput) error {
+	switch input.Action {
+	case BulkPublish, BulkUnpublish, BulkDelete, BulkSetCategory:
+	case BulkAddTags, BulkRemoveTags:
+		if len(input.Tags) == 0 {
+			return fmt.Errorf("%w: no tags", ErrInvalidBulkRequest)
+		}
+	default:
+		return fmt.Errorf("%w: unknown action %q", ErrInvalidBulkRequest, input.Action)
+	}
+
+	if len(input.Items) == 0 || len(input.Items) > MaxBulkItems {
+		return fmt.Errorf("%w: between 1 and %d posts required", ErrInvalidBulkRequest, MaxBulkItems)
+	}
+	seen := make(map[string]bool, len(input.Items))
+	for _, item := range input.Items {
+		if item.ID == "" || seen[item.ID] {
+			return fmt.Errorf("%w: empty or duplicate post id %q", ErrInvalidBulkRequest, item.ID)
+		}
+		seen[item.ID] = true
+	}
+	return nil
+}
+
+// prepareBulkChange 读取文章、检查版本并在内存中应用操作（不写入仓库）
+func (s *PostService) prepareBulkChange(input BulkInput, tags []valueobject.Tag, item BulkItem) (*bulkChange, error) {
+	post, err := s.repo.FindByID(item.ID)
+	if err != nil {
+		return nil, err
+	}
+	if post.Version != item.Version {
+		return nil, ErrVersionConflict
+	}
+
+	switch input.Action {
+	case BulkPublish:
+		err = post.Publish()
+	case BulkUnpublish:
+		err = post.Unpublish()
+	case BulkDelete:
+		return &bulkChange{post: post, delete: true}, nil
+	case BulkAddTags:
+		post.UpdateTags(addTags(post.Tags, tags))
+	case BulkRemoveTags:
+		post.UpdateTags(removeTags(post.Tags, tags))
+	case BulkSetCategory:
+		post.SetCategory(input.Category)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &bulkChange{post: post}, nil
+}
+
+// applyBulkChange 写入单篇文章的修改并通知观察者
+func (s *PostService) applyBulkChange(change *bulkChange, editor string) error {
+	if change.delete {
+		return s.DeletePostAs(change.post.ID, editor)
+	}
+	if err := s.save(change.post, editor); err != nil {
+		return err
+	}
+	s.notifySaved(change.post)
+	return nil
+}
+
+// applyBulkAtomic 任一文章准备失败时不写入任何修改，否则由仓库一次性写入全部修改。
+// 期望版本随批次交给仓库，在仓库锁内再次检查，准备之后的并发修改同样会使批次失败
+func (s *PostService) applyBulkAtomic(writer repository.BatchWriter, input BulkInput, changes []*bulkChange, result *BulkResult) {
+	for _, item := range result.Items {
+		if item.Err != nil {
+			for i := range result.Items {
+				if result.Items[i].Err == nil {
+					result.Items[i].Err = ErrBulkAborted
+				}
+			}
+			return
+		}
+	}
+
+	batch := repository.Batch{Editor: input.Editor, Versions: make(map[string]int, len(input.Items))}
+	for i, change := range changes {
+		batch.Versions[change.post.ID] = input.Items[i].Version
+		if change.delete {
+			batch.Delete = append(batch.Delete, change.post.ID)
+		} else {
+			batch.Save = append(batch.Save, change.post)
+		}
+	}
+
+	if err := writer.ApplyBatch(batch); err != nil {
+		for i := range result.Items {
+			result.Items[i].Err = err
+		}
+		if errors.Is(err, repository.ErrVersionConflict) {
+			s.markBulkConflicts(input.Items, result)
+		}
+		return
+	}
+
+	for _, change := range changes {
+		if change.delete {
+			s.notifyDeleted(change.post.ID)
+		} else {
+			s.notifySaved(change.post)
+		}
+	}
+}
+
+// markBulkConflicts 批次因版本冲突失败时，重新读取文章，
+// 版本已变化的标记为 ErrVersionConflict，其余标记为 ErrBulkAborted
+func (s *PostService) markBulkConflicts(items []BulkItem, result *BulkResult) {
+	for i, item := range items {
+		post, err := s.repo.FindByID(item.ID)
+		switch {
+		case err != nil:
+			result.Items[i].Err = err
+		case post.Version != item.Version:
+			result.Items[i].Err = ErrVersionConflict
+		default:
+			result.Items[i].Err = ErrBulkAborted
+		}
+	}
+}
+
+// addTags 追加文章尚未包含的标签
+func addTags(current, tags []valueobject.Tag) []valueobject.Tag {
+	result := append([]valueobject.Tag{}, current...)
+	for _, tag := range tags {
+		if !containsTag(result, tag) {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+// removeTags 去掉指定标签
+func removeTags(current, tags []valueobject.Tag) []valueobject.Tag {
+	result := make([]valueobject.Tag, 0, len(current))
+	for _, tag := range current {
+		if !containsTag(tags, tag) {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+func containsTag(tags []valueobject.Tag, tag valueobject.Tag) bool {
+	for _, t := range tags {
+		if t.String() == tag.String() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+// createBulkPosts 创建测试文章，返回批量操作条目
+func createBulkPosts(t *testing.T, service *PostService, titles ...string) []BulkItem {
+	t.Helper()
+	items := make([]BulkItem, len(titles))
+	for i, title := range titles {
+		post, err := service.CreatePost(CreatePostInput{Title: title, Content: "Content of " + title, Tags: []string{"go"}})
+		if err != nil {
+			t.Fatalf("CreatePost() error = %v", err)
+		}
+		items[i] = BulkItem{ID: post.ID, Version: post.Version}
+	}
+	return items
+}
+
+func TestPostService_BulkUpdate(t *testing.T) {
+	t.Run("reports each post", func(t *testing.T) {
+		service, _ := setupTestServices()
+		items := createBulkPosts(t, service, "First", "Second", "Third")
+		items[1].Version = 99
+		items = append(items, BulkItem{ID: "missing", Version: 1})
+
+		result, err := service.BulkUpdate(BulkInput{Action: BulkPublish, Items: items})
+		if err != nil {
+			t.Fatalf("BulkUpdate() error = %v", err)
+		}
+		if result.Succeeded != 2 || result.Failed != 2 {
+			t.Errorf("Succeeded = %d, Failed = %d, want 2, 2", result.Succeeded, result.Failed)
+		}
+		if !errors.Is(result.Items[1].Err, ErrVersionConflict) {
+			t.Errorf("Items[1].Err = %v, want ErrVersionConflict", result.Items[1].Err)
+		}
+		if !errors.Is(result.Items[3].Err, repository.ErrPostNotFound) {
+			t.Errorf("Items[3].Err = %v, want ErrPostNotFound", result.Items[3].Err)
+		}
+
+		post, _ := service.GetPost(items[0].ID)
+		if !post.IsPublished() || result.Items[0].Version != post.Version {
+			t.Errorf("first post published = %v, version = %d, result version = %d", post.IsPublished(), post.Version, result.Items[0].Version)
+		}
+		if post, _ := service.GetPost(items[1].ID); post.IsPublished() {
+			t.Error("post with stale version was published")
+		}
+	})
+
+	t.Run("atomic batch applies nothing on failure", func(t *testing.T) {
+		service, _ := setupTestServices()
+		items := createBulkPosts(t, service, "First", "Second")
+		items[1].Version = 99
+
+		result, err := service.BulkUpdate(BulkInput{Action: BulkDelete, Items: items, Atomic: true})
+		if err != nil {
+			t.Fatalf("BulkUpdate() error = %v", err)
+		}
+		if !errors.Is(result.Items[0].Err, ErrBulkAborted) || !errors.Is(result.Items[1].Err, ErrVersionConflict) {
+			t.Errorf("errors = %v, %v", result.Items[0].Err, result.Items[1].Err)
+		}
+		if _, err := service.GetPost(items[0].ID); err != nil {
+			t.Errorf("post deleted by failed atomic batch: %v", err)
+		}
+
+		items[1].Version = 1
+		result, err = service.BulkUpdate(BulkInput{Action: BulkDelete, Items: items, Atomic: true})
+		if err != nil || result.Succeeded != 2 {
+			t.Fatalf("BulkUpdate() = %+v, %v", result, err)
+		}
+		if total, _, _, _ := service.GetStats(); total != 0 {
+			t.Errorf("total = %d, want 0", total)
+		}
+	})
+
+	t.Run("tags and category", func(t *testing.T) {
+		service, _ := setupTestServices()
+		items := createBulkPosts(t, service, "First")
+
+		result, err := service.BulkUpdate(BulkInput{Action: BulkAddTags, Items: items, Tags: []string{"go", "web"}})
+		if err != nil || result.Failed != 0 {
+			t.Fatalf("BulkUpdate(addTags) = %+v, %v", result, err)
+		}
+		items[0].Version = result.Items[0].Version
+
+		result, err = service.BulkUpdate(BulkInput{Action: BulkRemoveTags, Items: items, Tags: []string{"go"}})
+		if err != nil || result.Failed != 0 {
+			t.Fatalf("BulkUpdate(removeTags) = %+v, %v", result, err)
+		}
+		items[0].Version = result.Items[0].Version
+
+		result, err = service.BulkUpdate(BulkInput{Action: BulkSetCategory, Items: items, Category: " notes "})
+		if err != nil || result.Failed != 0 {
+			t.Fatalf("BulkUpdate(setCategory) = %+v, %v", result, err)
+		}
+
+		post, _ := service.GetPost(items[0].ID)
+		if got := post.GetTagNames(); len(got) != 1 || got[0] != "web" {
+			t.Errorf("tags = %v, want [web]", got)
+		}
+		if post.Category != "notes" {
+			t.Errorf("Category = %q, want notes", post.Category)
+		}
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		service, _ := setupTestServices()
+		items := createBulkPosts(t, service, "First")
+
+		inputs := []BulkInput{
+			{Action: "archive", Items: items},
+			{Action: BulkPublish},
+			{Action: BulkPublish, Items: append(items, items[0])},
+			{Action: BulkAddTags, Items: items},
+		}
+		for _, input := range inputs {
+			if _, err := service.BulkUpdate(input); !errors.Is(err, ErrInvalidBulkRequest) {
+				t.Errorf("BulkUpdate(%+v) error = %v, want ErrInvalidBulkRequest", input, err)
+			}
+		}
+	})
+}
+
+// noBatchRepository 隐藏 BatchWriter 的仓库
+type noBatchRepository struct {
+	repository.PostRepository
+}
+
+func TestPostService_BulkUpdateAtomicUnsupported(t *testing.T) {
+	service := NewPostService(noBatchRepository{repository.NewMemoryPostRepository()}, nil)
+	_, err := service.BulkUpdate(BulkInput{Action: BulkPublish, Items: []BulkItem{{ID: "a", Version: 1}}, Atomic: true})
+	if !errors.Is(err, repository.ErrBatchUnsupported) {
+		t.Errorf("BulkUpdate() error = %v, want ErrBatchUnsupported", err)
+	}
+}
+
+// racingRepository 在批量写入前修改一篇文章，模拟准备与写入之间的并发更新
+type racingRepository struct {
+	*repository.MemoryPostRepository
+	id string
+}
+
+func (r *racingRepository) ApplyBatch(batch repository.Batch) error {
+	post, err := r.FindByID(r.id)
+	if err != nil {
+		return err
+	}
+	post.SetCategory("concurrent")
+	if err := r.Save(post); err != nil {
+		return err
+	}
+	return r.MemoryPostRepository.ApplyBatch(batch)
+}
+
+func TestPostService_BulkUpdateAtomicChecksVersionsInRepository(t *testing.T) {
+	repo := &racingRepository{MemoryPostRepository: repository.NewMemoryPostRepository()}
+	service := NewPostService(repo, NewSlugService(repo))
+	items := createBulkPosts(t, service, "First", "Second")
+	repo.id = items[1].ID
+
+	result, err := service.BulkUpdate(BulkInput{Action: BulkPublish, Items: items, Atomic: true})
+	if err != nil {
+		t.Fatalf("BulkUpdate() error = %v", err)
+	}
+	if !errors.Is(result.Items[0].Err, ErrBulkAborted) || !errors.Is(result.Items[1].Err, ErrVersionConflict) {
+		t.Errorf("errors = %v, %v", result.Items[0].Err, result.Items[1].Err)
+	}
+	if post, _ := service.GetPost(items[0].ID); post.IsPublished() {
+		t.Error("post published by a batch that failed the version check")
+	}
+}
@@ -13,6 +13,7 @@ import (
 var (
 	ErrVersionConflict = errors.New("version conflict: post has been modified")
 	ErrUnauthorized    = errors.New("unauthorized")
+	ErrPostExists      = errors.New("post already exists")
 )
 
 // CreatePostInput 创建文章输入
@@ -20,20 +21,48 @@ type CreatePostInput struct {
 	Title   string
 	Content string
 	Tags    []string
+	Editor  string // 操作者用户名（版本化仓库用作提交作者）
 }
 
 // UpdatePostInput 更新文章输入
 type UpdatePostInput struct {
-	Title   *string
-	Content *string
-	Tags    []string
-	Status  *string
+	Title          *string
+	Content        *string
+	Tags           []string
+	Status         *string
+	Category       *string
+	CommentsClosed *bool
+	Editor         string // 操作者用户名（版本化仓库用作提交作者）
+}
+
+// ImportPostInput 导入文章输入（保留源站点的时间与状态）
+type ImportPostInput struct {
+	Title     string
+	Slug      valueobject.Slug // 已通过 SlugService.ResolveSlug 解析的唯一 slug
+	Content   string
+	Excerpt   string // 为空时根据正文生成
+	Tags      []string
+	Cover     string
+	Draft     bool
+	Date      time.Time // 发布（草稿为创建）时间，为零时取当前时间
+	UpdatedAt time.Time // 为零时与 Date 相同
+	Editor    string    // 操作者用户名（版本化仓库用作提交作者）
+}
+
+// PostObserver 文章变更观察者，用于增量维护搜索索引等派生数据
+type PostObserver interface {
+	// PostSaved 文章创建或更新成功后调用
+	PostSaved(post *domain.Post)
+
+	// PostDeleted 文章删除成功后调用
+	PostDeleted(id string)
 }
 
 // PostService 文章应用服务
 type PostService struct {
 	repo        repository.PostRepository
 	slugService *SlugService
+	observers   []PostObserver
 }
 
 // NewPostService 创建文章服务
@@ -44,6 +73,11 @@ func NewPostService(repo repository.PostRepository, slugService *SlugService) *P
 	}
 }
 
+// AddObserver 注册文章变更观察者（应在启动阶段调用）
+func (s *PostService) AddObserver(observer PostObserver) {
+	s.observers = append(s.observers, observer)
+}
+
 // CreatePost 创建文章
 func (s *PostService) CreatePost(input CreatePostInput) (*domain.Post, error) {
 	// 验证输入
@@ -77,9 +111,63 @@ func (s *PostService) CreatePost(input CreatePostInput) (*domain.Post, error) {
 	}
 
 	// 保存
-	if err := s.repo.Save(post); err != nil {
+	if err := s.save(post, input.Editor); err != nil {
+		return nil, fmt.Errorf("save post failed: %w", err)
+	}
+	s.notifySaved(post)
+
+	return post, nil
+}
+
+// ImportPost 保存从其他站点导入的文章，ID 按原发布时间生成
+func (s *PostService) ImportPost(input ImportPostInput) (*domain.Post, error) {
+	if input.Title == "" {
+		return nil, domain.ErrEmptyTitle
+	}
+	if input.Content == "" {
+		return nil, domain.ErrEmptyContent
+	}
+
+	date := input.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+	updatedAt := input.UpdatedAt
+	if updatedAt.IsZero() || updatedAt.Before(date) {
+		updatedAt = date
+	}
+
+	id := fmt.Sprintf("%d-%02d-%s", date.Year(), date.Month(), input.Slug.String())
+	if _, err := s.repo.FindByID(id); err == nil {
+		return nil, ErrPostExists
+	} else if err != repository.ErrPostNotFound {
+		return nil, err
+	}
+
+	tags, err := s.parseTags(input.Tags)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tags: %w", err)
+	}
+
+	post, err := domain.NewPost(id, input.Title, input.Slug, input.Content, tags)
+	if err != nil {
+		return nil, err
+	}
+	if input.Excerpt != "" {
+		post.Excerpt = input.Excerpt
+	}
+	post.Cover = input.Cover
+	post.CreatedAt = date
+	post.UpdatedAt = updatedAt
+	if !input.Draft {
+		post.Status = valueobject.StatusPublished
+		post.PublishedAt = &date
+	}
+
+	if err := s.save(post, input.Editor); err != nil {
 		return nil, fmt.Errorf("save post failed: %w", err)
 	}
+	s.notifySaved(post)
 
 	return post, nil
 }
@@ -128,6 +216,16 @@ func (s *PostService) UpdatePost(id string, input UpdatePostInput, expectedVersi
 		post.UpdateTags(tags)
 	}
 
+	// 更新分类
+	if input.Category != nil {
+		post.SetCategory(*input.Category)
+	}
+
+	// 开启或关闭评论
+	if input.CommentsClosed != nil && *input.CommentsClosed != post.CommentsClosed {
+		post.SetCommentsClosed(*input.CommentsClosed)
+	}
+
 	// 更新状态
 	if input.Status != nil {
 		switch *input.Status {
@@ -145,21 +243,38 @@ func (s *PostService) UpdatePost(id string, input UpdatePostInput, expectedVersi
 	}
 
 	// 保存
-	if err := s.repo.Save(post); err != nil {
+	if err := s.save(post, input.Editor); err != nil {
 		return nil, fmt.Errorf("save post failed: %w", err)
 	}
+	s.notifySaved(post)
 
 	return post, nil
 }
 
 // DeletePost 删除文章
 func (s *PostService) DeletePost(id string) error {
+	return s.DeletePostAs(id, "")
+}
+
+// DeletePostAs 以指定编辑者身份删除文章
+func (s *PostService) DeletePostAs(id, editor string) error {
 	// 检查文章是否存在
 	if _, err := s.repo.FindByID(id); err != nil {
 		return err
 	}
 
-	return s.repo.Delete(id)
+	var err error
+	if writer, ok := s.repo.(repository.AuthoredWriter); ok && editor != "" {
+		err = writer.DeleteAs(id, editor)
+	} else {
+		err = s.repo.Delete(id)
+	}
+	if err != nil {
+		return err
+	}
+	s.notifyDeleted(id)
+
+	return nil
 }
 
 // GetPost 获取文章
@@ -202,6 +317,64 @@ func (s *PostService) GetAllTags() ([]string, error) {
 	return s.repo.FindAllTags()
 }
 
+// GetHistory 获取文章的历史版本列表
+func (s *PostService) GetHistory(id string) ([]repository.Revision, error) {
+	versioned, ok := s.repo.(repository.VersionedRepository)
+	if !ok {
+		return nil, repository.ErrHistoryUnsupported
+	}
+	return versioned.History(id)
+}
+
+// GetRevision 获取文章的指定历史版本
+func (s *PostService) GetRevision(id, hash string) (*domain.Post, error) {
+	versioned, ok := s.repo.(repository.VersionedRepository)
+	if !ok {
+		return nil, repository.ErrHistoryUnsupported
+	}
+	return versioned.FindRevision(id, hash)
+}
+
+// RebuildIndex 让仓库重新读取存储并重建索引（用于手动修改内容目录之后）
+func (s *PostService) RebuildIndex() error {
+	rebuilder, ok := s.repo.(repository.IndexRebuilder)
+	if !ok {
+		return repository.ErrRebuildUnsupported
+	}
+	return rebuilder.RebuildIndex()
+}
+
+// CheckContent 检查存储内容的完整性，opts.Fix 为 true 时执行安全修复
+func (s *PostService) CheckContent(opts repository.CheckOptions) (*repository.CheckReport, error) {
+	checker, ok := s.repo.(repository.ContentChecker)
+	if !ok {
+		return nil, repository.ErrCheckUnsupported
+	}
+	return checker.CheckContent(opts)
+}
+
+// save 保存文章，仓库支持时记录编辑者
+func (s *PostService) save(post *domain.Post, editor string) error {
+	if writer, ok := s.repo.(repository.AuthoredWriter); ok && editor != "" {
+		return writer.SaveAs(post, editor)
+	}
+	return s.repo.Save(post)
+}
+
+// notifySaved 通知观察者文章已保存
+func (s *PostService) notifySaved(post *domain.Post) {
+	for _, observer := range s.observers {
+		observer.PostSaved(post)
+	}
+}
+
+// notifyDeleted 通知观察者文章已删除
+func (s *PostService) notifyDeleted(id string) {
+	for _, observer := range s.observers {
+		observer.PostDeleted(id)
+	}
+}
+
 // parseTags 解析标签字符串
 func (s *PostService) parseTags(tagNames []string) ([]valueobject.Tag, error) {
 	tags := make([]valueobject.Tag, 0, len(tagNames))
@@ -2,8 +2,10 @@ package service
 
 import (
 	"testing"
+	"time"
 
 	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/domain/valueobject"
 	"github.com/next-ai-ventus/server/internal/repository"
 )
 
@@ -348,3 +350,71 @@ func TestPostService_GetAllTags(t *testing.T) {
 		t.Errorf("len(tags) = %d, want 3", len(tags))
 	}
 }
+
+func TestPostService_HistoryUnsupported(t *testing.T) {
+	service, _ := setupTestServices()
+
+	if _, err := service.GetHistory("any"); err != repository.ErrHistoryUnsupported {
+		t.Errorf("GetHistory() error = %v, want ErrHistoryUnsupported", err)
+	}
+	if _, err := service.GetRevision("any", "hash"); err != repository.ErrHistoryUnsupported {
+		t.Errorf("GetRevision() error = %v, want ErrHistoryUnsupported", err)
+	}
+}
+
+func TestPostService_RebuildIndexUnsupported(t *testing.T) {
+	service, _ := setupTestServices()
+
+	if err := service.RebuildIndex(); err != repository.ErrRebuildUnsupported {
+		t.Errorf("RebuildIndex() error = %v, want ErrRebuildUnsupported", err)
+	}
+}
+
+func TestPostService_CheckContentUnsupported(t *testing.T) {
+	service, _ := setupTestServices()
+
+	if _, err := service.CheckContent(repository.CheckOptions{}); err != repository.ErrCheckUnsupported {
+		t.Errorf("CheckContent() error = %v, want ErrCheckUnsupported", err)
+	}
+}
+
+func TestPostService_ImportPost(t *testing.T) {
+	service, _ := setupTestServices()
+	slug, _ := valueobject.NewSlug("old-post")
+	date := time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	post, err := service.ImportPost(ImportPostInput{
+		Title:   "Old Post",
+		Slug:    slug,
+		Content: "Imported content",
+		Excerpt: "Custom excerpt",
+		Tags:    []string{"go"},
+		Date:    date,
+	})
+	if err != nil {
+		t.Fatalf("ImportPost() error = %v", err)
+	}
+
+	if post.ID != "2019-07-old-post" {
+		t.Errorf("ID = %q, want 2019-07-old-post", post.ID)
+	}
+	if !post.IsPublished() || post.PublishedAt == nil || !post.PublishedAt.Equal(date) {
+		t.Errorf("post should be published at %v, got %v", date, post.PublishedAt)
+	}
+	if !post.CreatedAt.Equal(date) || !post.UpdatedAt.Equal(date) {
+		t.Errorf("CreatedAt = %v, UpdatedAt = %v, want %v", post.CreatedAt, post.UpdatedAt, date)
+	}
+	if post.Excerpt != "Custom excerpt" {
+		t.Errorf("Excerpt = %q, want Custom excerpt", post.Excerpt)
+	}
+
+	if _, err := service.ImportPost(ImportPostInput{Title: "Again", Slug: slug, Content: "x", Date: date}); err != ErrPostExists {
+		t.Errorf("ImportPost() with existing ID error = %v, want ErrPostExists", err)
+	}
+
+	draftSlug, _ := valueobject.NewSlug("draft")
+	draft, err := service.ImportPost(ImportPostInput{Title: "Draft", Slug: draftSlug, Content: "x", Draft: true, Date: date})
+	if err != nil || draft.IsPublished() || draft.PublishedAt != nil {
+		t.Errorf("ImportPost(draft) = %+v, %v", draft, err)
+	}
+}
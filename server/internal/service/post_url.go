@@ -0,0 +1,30 @@
+package service
+
+import (
+	"net/url"
+	"strings"
+)
+
+// postPagePath 前端文章页，通过 slug 参数加载文章
+const postPagePath = "/pages/post/index.html"
+
+// PostPath 返回文章的前台地址（BFF 模块、重定向与邮件中的文章链接统一由此生成）
+func PostPath(slug string) string {
+	return postPagePath + "?slug=" + url.QueryEscape(slug)
+}
+
+// PostSlugFromPath 解析前台文章地址中的 slug，兼容正文中手写的 /post/<slug>。
+// 不是文章地址时返回 false
+func PostSlugFromPath(u *url.URL) (string, bool) {
+	slug := ""
+	switch {
+	case u.Path == postPagePath:
+		slug = u.Query().Get("slug")
+	case strings.HasPrefix(u.Path, "/post/"):
+		slug = strings.Trim(strings.TrimPrefix(u.Path, "/post/"), "/")
+	}
+	if slug == "" || strings.Contains(slug, "/") {
+		return "", false
+	}
+	return slug, true
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestPostSlugFromPath(t *testing.T) {
+	tests := []struct {
+		path   string
+		want   string
+		wantOK bool
+	}{
+		{PostPath("hello-world"), "hello-world", true},
+		{"/post/hello-world/", "hello-world", true},
+		{"/post/hello-world?from=home#top", "hello-world", true},
+		{"/post/a/b", "", false},
+		{"/pages/post/index.html", "", false},
+		{"/about", "", false},
+	}
+	for _, tt := range tests {
+		u, _ := url.Parse(tt.path)
+		got, ok := PostSlugFromPath(u)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("PostSlugFromPath(%q) = %q, %v, want %q, %v", tt.path, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
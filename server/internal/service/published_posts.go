@@ -0,0 +1,60 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+// publishedPosts 记录已发布文章的 Slug，供观察者区分首次发布与修改已发布的文章
+type publishedPosts struct {
+	mu    sync.Mutex
+	slugs map[string]string // 文章 ID -> Slug
+}
+
+// load 从仓库重新读取已发布的文章
+func (p *publishedPosts) load(posts repository.PostRepository) error {
+	slugs := make(map[string]string)
+	for page := 1; ; page++ {
+		result, err := posts.FindAll(repository.ListOptions{Page: page, PageSize: indexPageSize, Status: "published"})
+		if err != nil {
+			return err
+		}
+		for _, item := range result.Items {
+			slugs[item.ID] = item.Slug.String()
+		}
+		if page >= result.TotalPages {
+			break
+		}
+	}
+
+	p.mu.Lock()
+	p.slugs = slugs
+	p.mu.Unlock()
+	return nil
+}
+
+// saved 记录文章保存后的状态，返回保存前是否已发布及当时的 Slug
+func (p *publishedPosts) saved(post *domain.Post) (previousSlug string, wasPublished bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	previousSlug, wasPublished = p.slugs[post.ID]
+	if post.IsPublished() {
+		p.slugs[post.ID] = post.Slug.String()
+	} else {
+		delete(p.slugs, post.ID)
+	}
+	return previousSlug, wasPublished
+}
+
+// deleted 移除被删除的文章，返回删除前是否已发布及其 Slug
+func (p *publishedPosts) deleted(id string) (slug string, wasPublished bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	slug, wasPublished = p.slugs[id]
+	delete(p.slugs, id)
+	return slug, wasPublished
+}
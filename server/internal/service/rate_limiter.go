@@ -0,0 +1,50 @@
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+// rateLimiter 滑动窗口计数器：按键统计时间窗口内的事件次数
+type rateLimiter struct {
+	window time.Duration
+
+	mu   sync.Mutex
+	hits map[string][]time.Time // 键 -> 窗口内的事件时间
+}
+
+// newRateLimiter 创建滑动窗口计数器
+func newRateLimiter(window time.Duration) *rateLimiter {
+	return &rateLimiter{
+		window: window,
+		hits:   make(map[string][]time.Time),
+	}
+}
+
+// Hit 记录一次事件，返回窗口内（含本次）的次数
+func (l *rateLimiter) Hit(key string, now time.Time) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+	l.hits[key] = append(l.hits[key], now)
+	return len(l.hits[key])
+}
+
+// sweep 清理窗口外的事件（调用方需持有锁）
+func (l *rateLimiter) sweep(now time.Time) {
+	cutoff := now.Add(-l.window)
+	for key, times := range l.hits {
+		kept := times[:0]
+		for _, t := range times {
+			if t.After(cutoff) {
+				kept = append(kept, t)
+			}
+		}
+		if len(kept) == 0 {
+			delete(l.hits, key)
+		} else {
+			l.hits[key] = kept
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/domain/valueobject"
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+var ErrReactionRateLimited = errors.New("too many reactions")
+
+// 表情回应的频率限制：同一 IP 在窗口内最多回应的次数（不区分文章）
+const (
+	reactionRateLimit  = 30
+	reactionRateWindow = 10 * time.Minute
+)
+
+// ReactionCount 一种表情回应及其数量
+type ReactionCount struct {
+	Reaction string `json:"reaction"`
+	Emoji    string `json:"emoji"`
+	Count    int    `json:"count"`
+}
+
+// ReactionService 读者表情回应：无需登录，同一访客对同一篇文章的每种表情只计一次。
+// 访客以密钥派生的 HMAC（IP + User-Agent）标识，原始 IP 不会保存；
+// 密钥固定时重启后仍能去重
+type ReactionService struct {
+	repo    repository.ReactionRepository
+	key     []byte
+	limiter *rateLimiter
+	now     func() time.Time
+
+	mu        sync.Mutex
+	reactions map[string]*repository.PostReactions
+}
+
+// NewReactionService 创建表情回应服务并加载已保存的回应。secret 为访客标识的密钥
+func NewReactionService(repo repository.ReactionRepository, secret []byte) (*ReactionService, error) {
+	reactions, err := repo.LoadReactions()
+	if err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("reactions"))
+	return &ReactionService{
+		repo:      repo,
+		key:       mac.Sum(nil),
+		limiter:   newRateLimiter(reactionRateWindow),
+		now:       time.Now,
+		reactions: reactions,
+	}, nil
+}
+
+// React 记录访客对文章的表情回应，返回回应后的统计与本次是否计数（重复回应不计数）
+func (s *ReactionService) React(postID string, reaction valueobject.Reaction, ip, userAgent string) ([]ReactionCount, bool, error) {
+	if s.limiter.Hit(ip, s.now()) > reactionRateLimit {
+		return nil, false, ErrReactionRateLimited
+	}
+	visitor := s.visitorKey(ip, userAgent)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.reactions[postID]
+	if !ok {
+		current = repository.NewPostReactions()
+	}
+	for _, existing := range current.Visitors[visitor] {
+		if existing == reaction.String() {
+			return reactionCounts(current), false, nil
+		}
+	}
+
+	// 先写入仓库，成功后再替换内存中的数据
+	next := current.Clone()
+	next.Counts[reaction.String()]++
+	next.Visitors[visitor] = append(next.Visitors[visitor], reaction.String())
+	if err := s.repo.SaveReactions(postID, next); err != nil {
+		return nil, false, err
+	}
+	s.reactions[postID] = next
+	return reactionCounts(next), true, nil
+}
+
+// Counts 返回文章各表情回应的数量（按固定顺序，包含数量为 0 的表情）
+func (s *ReactionService) Counts(postID string) []ReactionCount {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return reactionCounts(s.reactions[postID])
+}
+
+// ReadLocked 在禁止写入表情回应的状态下执行 fn（用于备份）
+func (s *ReactionService) ReadLocked(fn func() error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.repo.ReadLocked(fn)
+}
+
+// WriteLocked 在禁止写入表情回应的状态下执行 fn（fn 可直接替换回应文件），返回前从仓库重新加载全部回应（用于恢复备份）
+func (s *ReactionService) WriteLocked(fn func() error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fnErr := s.repo.WriteLocked(fn)
+	reactions, err := s.repo.LoadReactions()
+	if err != nil {
+		return err
+	}
+	s.reactions = reactions
+	return fnErr
+}
+
+// PostSaved 实现 PostObserver，文章保存不影响表情回应
+func (s *ReactionService) PostSaved(post *domain.Post) {}
+
+// PostDeleted 实现 PostObserver，文章删除时一并删除其表情回应
+func (s *ReactionService) PostDeleted(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.repo.DeleteReactions(id); err == nil {
+		delete(s.reactions, id)
+	}
+}
+
+// visitorKey 计算访客标识
+func (s *ReactionService) visitorKey(ip, userAgent string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(ip + "\n" + userAgent))
+	return hex.EncodeToString(mac.Sum(nil))[:32]
+}
+
+// reactionCounts 按 ValidReactions 的顺序列出各表情的数量
+func reactionCounts(reactions *repository.PostReactions) []ReactionCount {
+	counts := make([]ReactionCount, 0, len(valueobject.ValidReactions))
+	for _, reaction := range valueobject.ValidReactions {
+		count := 0
+		if reactions != nil {
+			count = reactions.Counts[reaction.String()]
+		}
+		counts = append(counts, ReactionCount{
+			Reaction: reaction.String(),
+			Emoji:    reaction.Emoji(),
+			Count:    count,
+		})
+	}
+	return counts
+}
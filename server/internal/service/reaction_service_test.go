@@ -0,0 +1,105 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/domain/valueobject"
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+// countOf 返回统计中某种表情的数量
+func countOf(counts []ReactionCount, reaction valueobject.Reaction) int {
+	for _, c := range counts {
+		if c.Reaction == reaction.String() {
+			return c.Count
+		}
+	}
+	return -1
+}
+
+func TestReactionService_ReactDedup(t *testing.T) {
+	repo := repository.NewMemoryReactionRepository()
+	service, err := NewReactionService(repo, []byte("secret"))
+	if err != nil {
+		t.Fatalf("NewReactionService() error = %v", err)
+	}
+
+	if _, added, _ := service.React("p1", valueobject.ReactionLike, "10.0.0.1", testUA); !added {
+		t.Error("first reaction should be counted")
+	}
+	if _, added, _ := service.React("p1", valueobject.ReactionLike, "10.0.0.1", testUA); added {
+		t.Error("repeated reaction from the same visitor should not be counted")
+	}
+	service.React("p1", valueobject.ReactionClap, "10.0.0.1", testUA)
+	counts, added, _ := service.React("p1", valueobject.ReactionLike, "10.0.0.2", testUA)
+	if !added || countOf(counts, valueobject.ReactionLike) != 2 || countOf(counts, valueobject.ReactionClap) != 1 {
+		t.Errorf("React() = %v, %v, want like 2 and clap 1", counts, added)
+	}
+	if len(counts) != len(valueobject.ValidReactions) || counts[0].Emoji == "" {
+		t.Errorf("counts should list every reaction with its emoji, got %v", counts)
+	}
+
+	// 使用相同密钥重新创建服务后仍能去重
+	reloaded, _ := NewReactionService(repo, []byte("secret"))
+	if _, added, _ := reloaded.React("p1", valueobject.ReactionLike, "10.0.0.1", testUA); added {
+		t.Error("reaction should stay deduplicated after restart")
+	}
+	if got := countOf(reloaded.Counts("p1"), valueobject.ReactionLike); got != 2 {
+		t.Errorf("Counts() after restart like = %d, want 2", got)
+	}
+
+	reloaded.PostDeleted("p1")
+	if got := countOf(reloaded.Counts("p1"), valueobject.ReactionLike); got != 0 {
+		t.Errorf("Counts() after PostDeleted like = %d, want 0", got)
+	}
+}
+
+func TestReactionService_RateLimit(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	service, _ := NewReactionService(repository.NewMemoryReactionRepository(), []byte("secret"))
+	service.now = func() time.Time { return now }
+
+	for i := 0; i < reactionRateLimit; i++ {
+		if _, _, err := service.React(fmt.Sprintf("p%d", i), valueobject.ReactionLike, "10.0.0.1", testUA); err != nil {
+			t.Fatalf("React() #%d error = %v", i, err)
+		}
+	}
+	if _, _, err := service.React("extra", valueobject.ReactionLike, "10.0.0.1", testUA); err != ErrReactionRateLimited {
+		t.Errorf("React() over limit error = %v, want ErrReactionRateLimited", err)
+	}
+	if _, _, err := service.React("extra", valueobject.ReactionLike, "10.0.0.2", testUA); err != nil {
+		t.Errorf("React() from another IP error = %v", err)
+	}
+
+	now = now.Add(reactionRateWindow)
+	if _, _, err := service.React("extra", valueobject.ReactionLike, "10.0.0.1", testUA); err != nil {
+		t.Errorf("React() after window error = %v", err)
+	}
+}
+
+func TestReactionService_WriteLocked(t *testing.T) {
+	repo := repository.NewMemoryReactionRepository()
+	service, err := NewReactionService(repo, []byte("secret"))
+	if err != nil {
+		t.Fatalf("NewReactionService() error = %v", err)
+	}
+	service.React("p1", valueobject.ReactionLike, "10.0.0.1", testUA)
+
+	// 模拟恢复备份：仓库中的回应被替换
+	restored := repository.NewPostReactions()
+	restored.Counts[valueobject.ReactionClap.String()] = 3
+	repo.DeleteReactions("p1")
+	repo.SaveReactions("p2", restored)
+	if err := service.WriteLocked(func() error { return nil }); err != nil {
+		t.Fatalf("WriteLocked() error = %v", err)
+	}
+
+	if got := countOf(service.Counts("p1"), valueobject.ReactionLike); got != 0 {
+		t.Errorf("Counts(p1) after reload like = %d, want 0", got)
+	}
+	if got := countOf(service.Counts("p2"), valueobject.ReactionClap); got != 3 {
+		t.Errorf("Counts(p2) after reload clap = %d, want 3", got)
+	}
+}
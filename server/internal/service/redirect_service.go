@@ -0,0 +1,81 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+// RedirectService 旧地址（如 WordPress 固定链接）到文章的重定向
+type RedirectService struct {
+	repo     repository.RedirectRepository
+	postRepo repository.PostRepository
+}
+
+// NewRedirectService 创建重定向服务
+func NewRedirectService(repo repository.RedirectRepository, postRepo repository.PostRepository) *RedirectService {
+	return &RedirectService{
+		repo:     repo,
+		postRepo: postRepo,
+	}
+}
+
+// Add 添加从 from 到文章的重定向，from 可以是完整 URL 或路径
+func (s *RedirectService) Add(from, postID string) (*domain.Redirect, error) {
+	path, err := domain.NormalizeRedirectPath(from)
+	if err != nil {
+		return nil, err
+	}
+
+	redirect := &domain.Redirect{
+		From:      path,
+		PostID:    postID,
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.Save(redirect); err != nil {
+		return nil, err
+	}
+	return redirect, nil
+}
+
+// Resolve 查找旧地址对应的已发布文章，找不到时返回 repository.ErrRedirectNotFound
+func (s *RedirectService) Resolve(from string) (*domain.Post, error) {
+	path, err := domain.NormalizeRedirectPath(from)
+	if err != nil {
+		return nil, repository.ErrRedirectNotFound
+	}
+
+	redirect, err := s.repo.Find(path)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := s.postRepo.FindByID(redirect.PostID)
+	if errors.Is(err, repository.ErrPostNotFound) {
+		return nil, repository.ErrRedirectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !post.IsPublished() {
+		return nil, repository.ErrRedirectNotFound
+	}
+	return post, nil
+}
+
+// ListForPost 返回指向某篇文章的所有重定向
+func (s *RedirectService) ListForPost(postID string) ([]*domain.Redirect, error) {
+	return s.repo.FindByPost(postID)
+}
+
+// ReadLocked 在禁止修改重定向的状态下执行 fn（用于备份）
+func (s *RedirectService) ReadLocked(fn func() error) error {
+	return s.repo.ReadLocked(fn)
+}
+
+// WriteLocked 在禁止修改重定向的状态下执行 fn（fn 可直接替换重定向文件），返回前重新加载重定向表（用于恢复备份）
+func (s *RedirectService) WriteLocked(fn func() error) error {
+	return s.repo.WriteLocked(fn)
+}
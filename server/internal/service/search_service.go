@@ -0,0 +1,450 @@
+package service
+
+import (
+	"errors"
+	"html"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/repository"
+	"github.com/next-ai-ventus/server/pkg/markdown"
+)
+
+// 搜索字段
+const (
+	fieldTitle = iota
+	fieldTags
+	fieldBody
+	fieldCount
+)
+
+// BM25 参数与字段权重
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+
+	snippetRunes  = 120 // 摘要片段长度（字符数）
+	snippetBefore = 30  // 命中词之前保留的字符数
+)
+
+var fieldWeights = [fieldCount]float64{
+	fieldTitle: 3.0,
+	fieldTags:  2.0,
+	fieldBody:  1.0,
+}
+
+// SearchOptions 搜索选项
+type SearchOptions struct {
+	Query         string
+	Page          int
+	PageSize      int
+	IncludeDrafts bool // 管理端可搜索草稿
+}
+
+// SearchHit 单条搜索结果
+type SearchHit struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Slug        string     `json:"slug"`
+	Status      string     `json:"status"`
+	Tags        []string   `json:"tags"`
+	Score       float64    `json:"score"`
+	Highlight   string     `json:"highlight"` // 高亮后的标题（HTML）
+	Snippet     string     `json:"snippet"`   // 高亮后的正文片段（HTML）
+	CreatedAt   time.Time  `json:"createdAt"`
+	PublishedAt *time.Time `json:"publishedAt,omitempty"`
+}
+
+// SearchResult 搜索结果
+type SearchResult struct {
+	Query      string       `json:"query"`
+	Hits       []*SearchHit `json:"hits"`
+	Total      int          `json:"total"`
+	Page       int          `json:"page"`
+	PageSize   int          `json:"pageSize"`
+	TotalPages int          `json:"totalPages"`
+}
+
+// searchDoc 索引中的文档（只保存元数据与词频统计，不保存正文）
+type searchDoc struct {
+	id          string
+	title       string
+	slug        string
+	tags        []string
+	published   bool
+	createdAt   time.Time
+	publishedAt *time.Time
+	lengths     [fieldCount]int
+	terms       []string
+}
+
+// searchIndex 倒排索引
+type searchIndex struct {
+	docs     map[string]*searchDoc                  // id -> doc
+	postings map[string]map[string]*[fieldCount]int // term -> id -> 各字段词频
+	totalLen [fieldCount]int
+}
+
+// newSearchIndex 创建空索引
+func newSearchIndex() *searchIndex {
+	return &searchIndex{
+		docs:     make(map[string]*searchDoc),
+		postings: make(map[string]map[string]*[fieldCount]int),
+	}
+}
+
+// SearchService 全文搜索服务（内存倒排索引 + BM25 排序）。
+// 正文不常驻内存，摘要片段只为当前页的结果按需从仓库加载（经仓库的 LRU 缓存）
+type SearchService struct {
+	repo  repository.PostRepository
+	mu    sync.RWMutex
+	index *searchIndex
+}
+
+// NewSearchService 创建搜索服务
+func NewSearchService(repo repository.PostRepository) *SearchService {
+	return &SearchService{
+		repo:  repo,
+		index: newSearchIndex(),
+	}
+}
+
+// Rebuild 从仓库全量重建索引
+func (s *SearchService) Rebuild() error {
+	// 列表只包含摘要，正文需逐篇加载；逐篇加入新索引后即释放，不在内存中累积
+	index := newSearchIndex()
+	for page := 1; ; page++ {
+		result, err := s.repo.FindAll(repository.ListOptions{Page: page, PageSize: 100})
+		if err != nil {
+			return err
+		}
+		for _, item := range result.Items {
+			post, err := s.repo.FindByID(item.ID)
+			if errors.Is(err, repository.ErrPostNotFound) {
+				continue // 期间被删除
+			}
+			if err != nil {
+				return err
+			}
+			index.add(post)
+		}
+		if page >= result.TotalPages {
+			break
+		}
+	}
+
+	s.mu.Lock()
+	s.index = index
+	s.mu.Unlock()
+	return nil
+}
+
+// PostSaved 实现 PostObserver，增量更新索引
+func (s *SearchService) PostSaved(post *domain.Post) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.index.remove(post.ID)
+	s.index.add(post)
+}
+
+// PostDeleted 实现 PostObserver，从索引中移除文章
+func (s *SearchService) PostDeleted(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.index.remove(id)
+}
+
+// Search 执行搜索
+func (s *SearchService) Search(opts SearchOptions) *SearchResult {
+	if opts.Page <= 0 {
+		opts.Page = 1
+	}
+	if opts.PageSize <= 0 {
+		opts.PageSize = 10
+	}
+	if opts.PageSize > 50 {
+		opts.PageSize = 50
+	}
+
+	result := &SearchResult{
+		Query:      opts.Query,
+		Hits:       []*SearchHit{},
+		Page:       opts.Page,
+		PageSize:   opts.PageSize,
+		TotalPages: 1,
+	}
+
+	queryTerms := tokenizeTerms(opts.Query)
+	if len(queryTerms) == 0 {
+		return result
+	}
+
+	termSet := make(map[string]struct{}, len(queryTerms))
+	for _, term := range queryTerms {
+		termSet[term] = struct{}{}
+	}
+
+	s.mu.RLock()
+	hits := s.searchLocked(queryTerms, termSet, opts, result)
+	s.mu.RUnlock()
+
+	// 锁外按需加载当前页的正文生成摘要片段
+	for _, hit := range hits {
+		hit.Snippet = s.snippet(hit.ID, termSet)
+	}
+	result.Hits = hits
+	return result
+}
+
+// searchLocked 计算得分并返回当前页的结果（不含摘要片段），调用方需持有读锁
+func (s *SearchService) searchLocked(queryTerms []string, termSet map[string]struct{}, opts SearchOptions, result *SearchResult) []*SearchHit {
+	docs := s.index.docs
+	scores := s.scoreLocked(queryTerms, opts.IncludeDrafts)
+
+	ids := make([]string, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if scores[ids[i]] != scores[ids[j]] {
+			return scores[ids[i]] > scores[ids[j]]
+		}
+		// 同分按创建时间倒序
+		return docs[ids[i]].createdAt.After(docs[ids[j]].createdAt)
+	})
+
+	result.Total = len(ids)
+	result.TotalPages = (result.Total + opts.PageSize - 1) / opts.PageSize
+	if result.TotalPages < 1 {
+		result.TotalPages = 1
+	}
+
+	hits := []*SearchHit{}
+	// 先比较页码再相乘，避免极大的页码使偏移量溢出
+	if opts.Page-1 >= (len(ids)+opts.PageSize-1)/opts.PageSize {
+		return hits
+	}
+	start := (opts.Page - 1) * opts.PageSize
+	end := start + opts.PageSize
+	if end > len(ids) {
+		end = len(ids)
+	}
+
+	for _, id := range ids[start:end] {
+		doc := docs[id]
+		status := "draft"
+		if doc.published {
+			status = "published"
+		}
+		hits = append(hits, &SearchHit{
+			ID:          doc.id,
+			Title:       doc.title,
+			Slug:        doc.slug,
+			Status:      status,
+			Tags:        append([]string(nil), doc.tags...),
+			Score:       math.Round(scores[id]*1000) / 1000,
+			Highlight:   highlight(doc.title, termSet),
+			CreatedAt:   doc.createdAt,
+			PublishedAt: doc.publishedAt,
+		})
+	}
+
+	return hits
+}
+
+// snippet 加载文章正文并生成摘要片段，加载失败（如期间被删除）时为空
+func (s *SearchService) snippet(id string, terms map[string]struct{}) string {
+	post, err := s.repo.FindByID(id)
+	if err != nil {
+		return ""
+	}
+	return buildSnippet(markdown.PlainText(post.Content), terms)
+}
+
+// scoreLocked 使用 BM25F 计算每篇命中文档的得分
+func (s *SearchService) scoreLocked(queryTerms []string, includeDrafts bool) map[string]float64 {
+	index := s.index
+	scores := make(map[string]float64)
+	n := float64(len(index.docs))
+	if n == 0 {
+		return scores
+	}
+
+	var avgLen [fieldCount]float64
+	for f := 0; f < fieldCount; f++ {
+		avgLen[f] = float64(index.totalLen[f]) / n
+		if avgLen[f] == 0 {
+			avgLen[f] = 1
+		}
+	}
+
+	for _, term := range queryTerms {
+		postings, ok := index.postings[term]
+		if !ok {
+			continue
+		}
+
+		df := float64(len(postings))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+
+		for id, freqs := range postings {
+			doc := index.docs[id]
+			if !includeDrafts && !doc.published {
+				continue
+			}
+
+			// 各字段按长度归一化后加权求和
+			weighted := 0.0
+			for f := 0; f < fieldCount; f++ {
+				if freqs[f] == 0 {
+					continue
+				}
+				norm := 1 - bm25B + bm25B*float64(doc.lengths[f])/avgLen[f]
+				weighted += fieldWeights[f] * float64(freqs[f]) / norm
+			}
+
+			scores[id] += idf * weighted * (bm25K1 + 1) / (weighted + bm25K1)
+		}
+	}
+
+	return scores
+}
+
+// add 将文章加入索引（正文只用于统计词频，不保存）
+func (idx *searchIndex) add(post *domain.Post) {
+	doc := &searchDoc{
+		id:          post.ID,
+		title:       post.Title,
+		slug:        post.Slug.String(),
+		tags:        post.GetTagNames(),
+		published:   post.IsPublished(),
+		createdAt:   post.CreatedAt,
+		publishedAt: post.PublishedAt,
+	}
+
+	fields := [fieldCount]string{
+		fieldTitle: doc.title,
+		fieldTags:  strings.Join(doc.tags, " "),
+		fieldBody:  markdown.PlainText(post.Content),
+	}
+
+	freqs := make(map[string]*[fieldCount]int)
+	for f, text := range fields {
+		tokens := tokenize(text)
+		doc.lengths[f] = len(tokens)
+		idx.totalLen[f] += len(tokens)
+		for _, tok := range tokens {
+			fq, ok := freqs[tok.Term]
+			if !ok {
+				fq = &[fieldCount]int{}
+				freqs[tok.Term] = fq
+				doc.terms = append(doc.terms, tok.Term)
+			}
+			fq[f]++
+		}
+	}
+
+	for term, fq := range freqs {
+		if _, ok := idx.postings[term]; !ok {
+			idx.postings[term] = make(map[string]*[fieldCount]int)
+		}
+		idx.postings[term][post.ID] = fq
+	}
+
+	idx.docs[post.ID] = doc
+}
+
+// remove 将文章移出索引
+func (idx *searchIndex) remove(id string) {
+	doc, ok := idx.docs[id]
+	if !ok {
+		return
+	}
+
+	for _, term := range doc.terms {
+		if ids, ok := idx.postings[term]; ok {
+			delete(ids, id)
+			if len(ids) == 0 {
+				delete(idx.postings, term)
+			}
+		}
+	}
+	for f := 0; f < fieldCount; f++ {
+		idx.totalLen[f] -= doc.lengths[f]
+	}
+	delete(idx.docs, id)
+}
+
+// buildSnippet 截取第一个命中词附近的正文片段并高亮
+func buildSnippet(body string, terms map[string]struct{}) string {
+	if body == "" {
+		return ""
+	}
+
+	start := 0
+	for _, tok := range tokenize(body) {
+		if _, ok := terms[tok.Term]; ok {
+			start = tok.Start
+			break
+		}
+	}
+
+	// 向前回退若干字符，保证片段有上下文
+	for i := 0; i < snippetBefore && start > 0; i++ {
+		_, size := utf8.DecodeLastRuneInString(body[:start])
+		start -= size
+	}
+
+	end := start
+	for i := 0; i < snippetRunes && end < len(body); i++ {
+		_, size := utf8.DecodeRuneInString(body[end:])
+		end += size
+	}
+
+	snippet := highlight(body[start:end], terms)
+	if start > 0 {
+		snippet = "..." + snippet
+	}
+	if end < len(body) {
+		snippet += "..."
+	}
+	return snippet
+}
+
+// highlight 对文本做 HTML 转义，并用 <mark> 包裹命中的词
+func highlight(text string, terms map[string]struct{}) string {
+	// 收集命中区间并合并重叠部分（bigram 会相互重叠）
+	type span struct{ start, end int }
+	var spans []span
+	for _, tok := range tokenize(text) {
+		if _, ok := terms[tok.Term]; !ok {
+			continue
+		}
+		if n := len(spans); n > 0 && tok.Start <= spans[n-1].end {
+			if tok.End > spans[n-1].end {
+				spans[n-1].end = tok.End
+			}
+			continue
+		}
+		spans = append(spans, span{tok.Start, tok.End})
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, sp := range spans {
+		b.WriteString(html.EscapeString(text[last:sp.start]))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(text[sp.start:sp.end]))
+		b.WriteString("</mark>")
+		last = sp.end
+	}
+	b.WriteString(html.EscapeString(text[last:]))
+	return b.String()
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"english words", "Hello, Go World!", []string{"hello", "go", "world"}},
+		{"chinese bigrams", "全文搜索", []string{"全文", "文搜", "搜索"}},
+		{"single chinese char", "好", []string{"好"}},
+		{"japanese kana", "カタカナ", []string{"カタ", "タカ", "カナ"}},
+		{"mixed", "Go语言 v1", []string{"go", "语言", "v1"}},
+		{"empty", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, tok := range tokenize(tt.text) {
+				got = append(got, tok.Term)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("tokenize(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func setupSearchServices() (*PostService, *SearchService) {
+	postService, repo := setupTestServices()
+	searchService := NewSearchService(repo)
+	postService.AddObserver(searchService)
+	return postService, searchService
+}
+
+func TestSearchService_Search(t *testing.T) {
+	postService, searchService := setupSearchServices()
+
+	goPost, _ := postService.CreatePost(CreatePostInput{
+		Title:   "Go 并发编程",
+		Content: "本文介绍 goroutine 与 channel 的用法。",
+		Tags:    []string{"go"},
+	})
+	webPost, _ := postService.CreatePost(CreatePostInput{
+		Title:   "Web 开发笔记",
+		Content: "前端框架对比，顺带提到 Go 的模板引擎。",
+	})
+	for _, p := range []string{goPost.ID, webPost.ID} {
+		status := "published"
+		post, _ := postService.GetPost(p)
+		postService.UpdatePost(p, UpdatePostInput{Status: &status}, post.Version)
+	}
+
+	t.Run("title match ranks first", func(t *testing.T) {
+		result := searchService.Search(SearchOptions{Query: "go"})
+		if result.Total != 2 {
+			t.Fatalf("Total = %d, want 2", result.Total)
+		}
+		if result.Hits[0].ID != goPost.ID {
+			t.Errorf("first hit = %s, want %s", result.Hits[0].ID, goPost.ID)
+		}
+	})
+
+	t.Run("chinese query", func(t *testing.T) {
+		result := searchService.Search(SearchOptions{Query: "并发"})
+		if result.Total != 1 || result.Hits[0].ID != goPost.ID {
+			t.Fatalf("Search(并发) = %+v", result.Hits)
+		}
+		if !strings.Contains(result.Hits[0].Highlight, "<mark>并发</mark>") {
+			t.Errorf("Highlight = %q, want marked term", result.Hits[0].Highlight)
+		}
+	})
+
+	t.Run("snippet is highlighted", func(t *testing.T) {
+		result := searchService.Search(SearchOptions{Query: "channel"})
+		if result.Total != 1 {
+			t.Fatalf("Total = %d, want 1", result.Total)
+		}
+		if !strings.Contains(result.Hits[0].Snippet, "<mark>channel</mark>") {
+			t.Errorf("Snippet = %q, want marked term", result.Hits[0].Snippet)
+		}
+	})
+
+	t.Run("chinese body", func(t *testing.T) {
+		result := searchService.Search(SearchOptions{Query: "模板引擎"})
+		if result.Total != 1 || result.Hits[0].ID != webPost.ID {
+			t.Fatalf("Search(模板引擎) = %+v", result.Hits)
+		}
+		if !strings.Contains(result.Hits[0].Snippet, "<mark>模板引擎</mark>") {
+			t.Errorf("Snippet = %q, want marked term", result.Hits[0].Snippet)
+		}
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		result := searchService.Search(SearchOptions{Query: "rust"})
+		if result.Total != 0 || len(result.Hits) != 0 {
+			t.Errorf("Total = %d, want 0", result.Total)
+		}
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		result := searchService.Search(SearchOptions{Query: "go", Page: 2, PageSize: 1})
+		if len(result.Hits) != 1 || result.TotalPages != 2 {
+			t.Errorf("page 2 hits = %d, totalPages = %d", len(result.Hits), result.TotalPages)
+		}
+
+		// 极大的页码不会使偏移量溢出
+		result = searchService.Search(SearchOptions{Query: "go", Page: 1e18, PageSize: 10})
+		if len(result.Hits) != 0 || result.Total != 2 {
+			t.Errorf("huge page hits = %d, total = %d", len(result.Hits), result.Total)
+		}
+	})
+}
+
+func TestSearchService_Drafts(t *testing.T) {
+	postService, searchService := setupSearchServices()
+
+	postService.CreatePost(CreatePostInput{Title: "Draft Post", Content: "secret content"})
+
+	if result := searchService.Search(SearchOptions{Query: "secret"}); result.Total != 0 {
+		t.Errorf("public search Total = %d, want 0", result.Total)
+	}
+	if result := searchService.Search(SearchOptions{Query: "secret", IncludeDrafts: true}); result.Total != 1 {
+		t.Errorf("admin search Total = %d, want 1", result.Total)
+	}
+}
+
+func TestSearchService_IncrementalUpdate(t *testing.T) {
+	postService, searchService := setupSearchServices()
+
+	post, _ := postService.CreatePost(CreatePostInput{Title: "Original", Content: "alpha"})
+
+	content := "beta"
+	post, err := postService.UpdatePost(post.ID, UpdatePostInput{Content: &content}, post.Version)
+	if err != nil {
+		t.Fatalf("UpdatePost() error = %v", err)
+	}
+
+	opts := SearchOptions{IncludeDrafts: true}
+	if opts.Query = "alpha"; searchService.Search(opts).Total != 0 {
+		t.Error("old content should be removed from index")
+	}
+	if opts.Query = "beta"; searchService.Search(opts).Total != 1 {
+		t.Error("new content should be indexed")
+	}
+
+	postService.DeletePost(post.ID)
+	if searchService.Search(opts).Total != 0 {
+		t.Error("deleted post should be removed from index")
+	}
+}
+
+func TestSearchService_Rebuild(t *testing.T) {
+	postService, repo := setupTestServices()
+	postService.CreatePost(CreatePostInput{Title: "Existing", Content: "indexed on rebuild"})
+
+	searchService := NewSearchService(repo)
+	if err := searchService.Rebuild(); err != nil {
+		t.Fatalf("Rebuild() error = %v", err)
+	}
+
+	result := searchService.Search(SearchOptions{Query: "rebuild", IncludeDrafts: true})
+	if result.Total != 1 {
+		t.Fatalf("Total = %d, want 1", result.Total)
+	}
+	// 正文不在索引中保存，摘要片段从仓库按需加载
+	if !strings.Contains(result.Hits[0].Snippet, "<mark>rebuild</mark>") {
+		t.Errorf("Snippet = %q, want marked term", result.Hits[0].Snippet)
+	}
+}
+
+func TestBuildSnippet_CJK(t *testing.T) {
+	body := strings.Repeat("背景介绍。", 20) + "这里讨论全文搜索的实现。"
+	got := buildSnippet(body, map[string]struct{}{"搜索": {}})
+
+	if !strings.Contains(got, "<mark>搜索</mark>") {
+		t.Errorf("buildSnippet() = %q, want marked term", got)
+	}
+	if !strings.HasPrefix(got, "...") {
+		t.Errorf("buildSnippet() = %q, want leading ellipsis", got)
+	}
+}
+
+func TestHighlight_EscapesHTML(t *testing.T) {
+	got := highlight("<b>go</b>", map[string]struct{}{"go": {}})
+	want := "&lt;b&gt;<mark>go</mark>&lt;/b&gt;"
+	if got != want {
+		t.Errorf("highlight() = %q, want %q", got, want)
+	}
+}
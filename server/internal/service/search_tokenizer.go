@@ -0,0 +1,99 @@
+package service
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// searchToken 分词结果，Start/End 为原文中的字节偏移
+type searchToken struct {
+	Term  string
+	Start int
+	End   int
+}
+
+// tokenize 对文本分词：
+//   - 英文、数字等按单词切分并转小写
+//   - 中文、日文（汉字、平假名、片假名）按二元组（bigram）切分，单字成词时保留单字
+func tokenize(text string) []searchToken {
+	var tokens []searchToken
+
+	i := 0
+	for i < len(text) {
+		r, size := utf8.DecodeRuneInString(text[i:])
+
+		switch {
+		case isCJK(r):
+			// 收集连续的 CJK 字符
+			var starts []int
+			j := i
+			for j < len(text) {
+				r2, size2 := utf8.DecodeRuneInString(text[j:])
+				if !isCJK(r2) {
+					break
+				}
+				starts = append(starts, j)
+				j += size2
+			}
+			starts = append(starts, j)
+
+			if len(starts) == 2 {
+				tokens = append(tokens, searchToken{Term: text[i:j], Start: i, End: j})
+			} else {
+				for k := 0; k+2 < len(starts); k++ {
+					tokens = append(tokens, searchToken{
+						Term:  text[starts[k]:starts[k+2]],
+						Start: starts[k],
+						End:   starts[k+2],
+					})
+				}
+			}
+			i = j
+
+		case isWordRune(r):
+			j := i
+			for j < len(text) {
+				r2, size2 := utf8.DecodeRuneInString(text[j:])
+				if !isWordRune(r2) {
+					break
+				}
+				j += size2
+			}
+			tokens = append(tokens, searchToken{Term: strings.ToLower(text[i:j]), Start: i, End: j})
+			i = j
+
+		default:
+			i += size
+		}
+	}
+
+	return tokens
+}
+
+// tokenizeTerms 分词并返回去重后的词项列表（保持出现顺序）
+func tokenizeTerms(text string) []string {
+	seen := make(map[string]struct{})
+	var terms []string
+	for _, tok := range tokenize(text) {
+		if _, ok := seen[tok.Term]; ok {
+			continue
+		}
+		seen[tok.Term] = struct{}{}
+		terms = append(terms, tok.Term)
+	}
+	return terms
+}
+
+// isCJK 判断是否为中日文字符
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) ||
+		unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) ||
+		r == 'ー'
+}
+
+// isWordRune 判断是否为单词字符（非 CJK 的字母或数字）
+func isWordRune(r rune) bool {
+	return (unicode.IsLetter(r) || unicode.IsDigit(r)) && !isCJK(r)
+}
@@ -1,6 +1,10 @@
 package service
 
 import (
+	"fmt"
+
+	"github.com/mozillazg/go-slugify"
+
 	"github.com/next-ai-ventus/server/internal/domain/valueobject"
 	"github.com/next-ai-ventus/server/internal/repository"
 )
@@ -17,24 +21,53 @@ func NewSlugService(repo repository.PostRepository) *SlugService {
 
 // GenerateUniqueSlug 根据标题生成唯一 Slug
 func (s *SlugService) GenerateUniqueSlug(title string) (valueobject.Slug, error) {
-	// 获取所有文章来收集 slug（这里可以优化，只获取 slug 列）
-	result, err := s.repo.FindAll(repository.ListOptions{
-		Page:     1,
-		PageSize: 10000,
-	})
+	slug, err := s.uniqueSlug(title, nil)
 	if err != nil {
-		// 如果获取失败，尝试无冲突生成
+		// 如果查询失败，尝试无冲突生成
 		return s.generateSlugWithoutCheck(title), nil
 	}
+	return slug, nil
+}
+
+// ResolveSlug 生成唯一 Slug：优先使用 preferred（会被规范化），为空时根据 title 生成；
+// reserved 为批量导入中已分配但尚未保存的 slug，冲突时追加序号
+func (s *SlugService) ResolveSlug(preferred, title string, reserved []string) (valueobject.Slug, error) {
+	base := preferred
+	if slugify.Slugify(base) == "" {
+		base = title
+	}
 
-	// 收集所有 slug
-	existingSlugs := make([]string, 0, len(result.Items))
-	for _, post := range result.Items {
-		existingSlugs = append(existingSlugs, post.Slug.String())
+	return s.uniqueSlug(base, reserved)
+}
+
+// uniqueSlug 依次检查 base、base-2、base-3... 是否被占用（查询仓库的 slug 索引，
+// 不列出全部文章），返回第一个未被占用的 slug
+func (s *SlugService) uniqueSlug(title string, reserved []string) (valueobject.Slug, error) {
+	taken := make(map[string]bool, len(reserved))
+	for _, slug := range reserved {
+		taken[slug] = true
+	}
+
+	base := valueobject.GenerateFromTitle(title, nil).String()
+	var occupied []string
+	candidate := base
+	for n := 2; ; n++ {
+		used := taken[candidate]
+		if !used {
+			exists, err := s.repo.Exists(candidate)
+			if err != nil {
+				return valueobject.Slug{}, err
+			}
+			used = exists
+		}
+		if !used {
+			break
+		}
+		occupied = append(occupied, candidate)
+		candidate = fmt.Sprintf("%s-%d", base, n)
 	}
 
-	// 生成唯一 slug
-	return valueobject.GenerateFromTitle(title, existingSlugs), nil
+	return valueobject.GenerateFromTitle(title, occupied), nil
 }
 
 // CheckConflict 检查 slug 是否冲突
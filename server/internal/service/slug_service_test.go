@@ -55,6 +55,39 @@ func TestSlugService_GenerateUniqueSlug(t *testing.T) {
 	})
 }
 
+func TestSlugService_ResolveSlug(t *testing.T) {
+	service, repo := setupSlugService()
+	for i, s := range []string{"hello", "hello-2"} {
+		slug, _ := valueobject.NewSlug(s)
+		post, _ := domain.NewPost(string(rune('a'+i)), "Hello", slug, "Content", nil)
+		repo.Save(post)
+	}
+
+	tests := []struct {
+		name      string
+		preferred string
+		title     string
+		reserved  []string
+		want      string
+	}{
+		{"preferred is free", "World", "Ignored", nil, "world"},
+		{"conflicts with saved posts", "hello", "", nil, "hello-3"},
+		{"conflicts with reserved slugs", "", "Hello", []string{"hello-3"}, "hello-4"},
+		{"falls back to title", "!!!", "Hello", nil, "hello-3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slug, err := service.ResolveSlug(tt.preferred, tt.title, tt.reserved)
+			if err != nil {
+				t.Fatalf("ResolveSlug() error = %v", err)
+			}
+			if slug.String() != tt.want {
+				t.Errorf("ResolveSlug() = %q, want %q", slug.String(), tt.want)
+			}
+		})
+	}
+}
+
 func TestSlugService_CheckConflict(t *testing.T) {
 	service, repo := setupSlugService()
 
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SpamCheck 反垃圾检查项，返回本项的分数与原因（未命中时分数为 0）
+type SpamCheck interface {
+	Name() string
+	Check(sub SpamSubmission) SpamScore
+}
+
+// SpamScore 单项检查的结果
+type SpamScore struct {
+	Score   int
+	Reasons []string
+}
+
+// ==================== Honeypot ====================
+
+// HoneypotCheck 蜜罐字段：表单中对用户隐藏的字段被填写时视为机器人
+type HoneypotCheck struct{}
+
+// Name 检查项名称
+func (HoneypotCheck) Name() string { return "honeypot" }
+
+// Check 蜜罐字段非空时直接达到拒绝分数
+func (HoneypotCheck) Check(sub SpamSubmission) SpamScore {
+	if strings.TrimSpace(sub.Honeypot) == "" {
+		return SpamScore{}
+	}
+	return SpamScore{Score: SpamRejectScore, Reasons: []string{"honeypot field filled"}}
+}
+
+// ==================== Form token ====================
+
+const (
+	// MinSubmitTime 从获取表单令牌到提交的最短时间，更快的提交视为机器人
+	MinSubmitTime = 3 * time.Second
+	// MaxFormTokenAge 表单令牌的有效期
+	MaxFormTokenAge = 24 * time.Hour
+)
+
+// FormTokens 签发与校验表单令牌。令牌为「签发时间.签名」，
+// 签名使用服务端密钥，不需要保存任何状态
+type FormTokens struct {
+	secret []byte
+}
+
+// NewFormTokens 使用密钥创建表单令牌签发器
+func NewFormTokens(secret []byte) *FormTokens {
+	return &FormTokens{secret: secret}
+}
+
+// Issue 签发令牌
+func (t *FormTokens) Issue(now time.Time) string {
+	issued := strconv.FormatInt(now.Unix(), 10)
+	return issued + "." + t.sign(issued)
+}
+
+// Issued 校验签名并返回令牌的签发时间
+func (t *FormTokens) Issued(token string) (time.Time, bool) {
+	issued, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(t.sign(issued))) {
+		return time.Time{}, false
+	}
+	unix, err := strconv.ParseInt(issued, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(unix, 0), true
+}
+
+// sign 计算签发时间的签名
+func (t *FormTokens) sign(issued string) string {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte(issued))
+	return hex.EncodeToString(mac.Sum(nil))[:32]
+}
+
+// FormTokenCheck 最短提交时间：缺少或伪造令牌的提交进入审核，提交过快时拒绝
+type FormTokenCheck struct {
+	Tokens *FormTokens
+}
+
+// Name 检查项名称
+func (FormTokenCheck) Name() string { return "token" }
+
+// Check 校验令牌与提交耗时
+func (c FormTokenCheck) Check(sub SpamSubmission) SpamScore {
+	if sub.Token == "" {
+		return SpamScore{Score: SpamModerateScore, Reasons: []string{"missing form token"}}
+	}
+	issued, ok := c.Tokens.Issued(sub.Token)
+	if !ok {
+		return SpamScore{Score: SpamModerateScore, Reasons: []string{"invalid form token"}}
+	}
+
+	elapsed := sub.ReceivedAt.Sub(issued)
+	switch {
+	case elapsed < MinSubmitTime:
+		return SpamScore{Score: SpamRejectScore, Reasons: []string{fmt.Sprintf("submitted %s after the form was loaded", elapsed.Round(time.Second))}}
+	case elapsed > MaxFormTokenAge:
+		return SpamScore{Score: SpamModerateScore, Reasons: []string{"form token expired"}}
+	}
+	return SpamScore{}
+}
+
+// ==================== Links ====================
+
+// linkPattern 匹配正文中的链接
+var linkPattern = regexp.MustCompile(`(?i)\bhttps?://|\bwww\.`)
+
+// LinkCountCheck 链接数量：超过 Max 个链接时进入审核，超过两倍时拒绝；作者名中出现链接时进入审核
+type LinkCountCheck struct {
+	Max int
+}
+
+// Name 检查项名称
+func (LinkCountCheck) Name() string { return "links" }
+
+// Check 统计正文与作者名中的链接
+func (c LinkCountCheck) Check(sub SpamSubmission) SpamScore {
+	var result SpamScore
+	links := len(linkPattern.FindAllStringIndex(sub.Body, -1))
+	switch {
+	case links > 2*c.Max:
+		result.Score += SpamRejectScore
+		result.Reasons = append(result.Reasons, fmt.Sprintf("%d links in body", links))
+	case links > c.Max:
+		result.Score += SpamModerateScore
+		result.Reasons = append(result.Reasons, fmt.Sprintf("%d links in body", links))
+	}
+	if linkPattern.MatchString(sub.AuthorName) {
+		result.Score += SpamModerateScore
+		result.Reasons = append(result.Reasons, "link in author name")
+	}
+	return result
+}
+
+// ==================== Keywords ====================
+
+// DefaultSpamKeywords 常见垃圾内容关键词（小写）
+var DefaultSpamKeywords = []string{
+	"viagra", "cialis", "casino", "payday loan", "porn", "escort",
+	"crypto giveaway", "buy followers", "seo services",
+}
+
+// spamKeywordScore 每个命中关键词的分数
+const spamKeywordScore = 3
+
+// KeywordCheck 关键词规则：作者名与正文中每命中一个关键词加 3 分
+type KeywordCheck struct {
+	Keywords []string // 小写
+}
+
+// Name 检查项名称
+func (KeywordCheck) Name() string { return "keywords" }
+
+// Check 匹配关键词（不区分大小写）
+func (c KeywordCheck) Check(sub SpamSubmission) SpamScore {
+	text := strings.ToLower(sub.AuthorName + "\n" + sub.Body)
+	var result SpamScore
+	for _, keyword := range c.Keywords {
+		if strings.Contains(text, keyword) {
+			result.Score += spamKeywordScore
+			result.Reasons = append(result.Reasons, fmt.Sprintf("keyword %q", keyword))
+		}
+	}
+	return result
+}
+
+// ==================== Rate limit ====================
+
+// RateLimitCheck 频率限制：同一类提交在时间窗口内按 IP 与邮箱分别计数，超出限制时拒绝。
+// 每次检查都会计数（包括被拒绝的提交）
+type RateLimitCheck struct {
+	perIP    int
+	perEmail int
+	window   time.Duration
+	limiter  *rateLimiter
+}
+
+// NewRateLimitCheck 创建频率限制，限制为 0 时不检查对应维度
+func NewRateLimitCheck(perIP, perEmail int, window time.Duration) *RateLimitCheck {
+	return &RateLimitCheck{
+		perIP:    perIP,
+		perEmail: perEmail,
+		window:   window,
+		limiter:  newRateLimiter(window),
+	}
+}
+
+// Name 检查项名称
+func (*RateLimitCheck) Name() string { return "rate" }
+
+// Check 记录本次提交并检查窗口内的次数
+func (c *RateLimitCheck) Check(sub SpamSubmission) SpamScore {
+	var result SpamScore
+	if c.perIP > 0 && sub.IP != "" {
+		if n := c.limiter.Hit(sub.Kind+"|ip|"+sub.IP, sub.ReceivedAt); n > c.perIP {
+			result.Score += SpamRejectScore
+			result.Reasons = append(result.Reasons, fmt.Sprintf("%d submissions from this IP within %s", n, c.window))
+		}
+	}
+	email := strings.ToLower(strings.TrimSpace(sub.Email))
+	if c.perEmail > 0 && email != "" {
+		if n := c.limiter.Hit(sub.Kind+"|email|"+email, sub.ReceivedAt); n > c.perEmail {
+			result.Score += SpamRejectScore
+			result.Reasons = append(result.Reasons, fmt.Sprintf("%d submissions from this email within %s", n, c.window))
+		}
+	}
+	return result
+}
+
+// ==================== Disallow list ====================
+
+// DisallowCheck 禁止列表：条目为 IP 或 CIDR 时匹配来源 IP，包含 @ 时匹配邮箱
+// （以 @ 开头时匹配整个域名），其余条目匹配作者名、邮箱与正文中的文字（不区分大小写），命中时拒绝
+type DisallowCheck struct {
+	Entries func() []string // 当前的禁止列表（小写）
+}
+
+// Name 检查项名称
+func (DisallowCheck) Name() string { return "disallow" }
+
+// Check 匹配禁止列表
+func (c DisallowCheck) Check(sub SpamSubmission) SpamScore {
+	ip := net.ParseIP(sub.IP)
+	email := strings.ToLower(strings.TrimSpace(sub.Email))
+	text := strings.ToLower(sub.AuthorName + "\n" + sub.Email + "\n" + sub.Body)
+
+	for _, entry := range c.Entries() {
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			if ip != nil && network.Contains(ip) {
+				return disallowed("ip", entry)
+			}
+			continue
+		}
+		if entryIP := net.ParseIP(entry); entryIP != nil {
+			if ip != nil && entryIP.Equal(ip) {
+				return disallowed("ip", entry)
+			}
+			continue
+		}
+		if strings.Contains(entry, "@") {
+			if email == entry || (strings.HasPrefix(entry, "@") && strings.HasSuffix(email, entry)) {
+				return disallowed("email", entry)
+			}
+			continue
+		}
+		if strings.Contains(text, entry) {
+			return disallowed("text", entry)
+		}
+	}
+	return SpamScore{}
+}
+
+// disallowed 命中禁止列表的结果
+func disallowed(kind, entry string) SpamScore {
+	return SpamScore{Score: SpamRejectScore, Reasons: []string{fmt.Sprintf("disallowed %s %q", kind, entry)}}
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+// SpamVerdict 反垃圾判定结果
+type SpamVerdict string
+
+const (
+	SpamAccept   SpamVerdict = "accept"   // 正常处理
+	SpamModerate SpamVerdict = "moderate" // 保留但需人工复查
+	SpamReject   SpamVerdict = "reject"   // 直接拒绝
+)
+
+const (
+	// SpamModerateScore 总分达到该值时进入人工复查
+	SpamModerateScore = 5
+	// SpamRejectScore 总分达到该值时拒绝
+	SpamRejectScore = 10
+)
+
+// 默认检查项的参数
+const (
+	defaultMaxLinks      = 2
+	defaultPerIPLimit    = 5
+	defaultPerEmailLimit = 3
+	defaultRateWindow    = 10 * time.Minute
+	spamExcerptLength    = 120
+)
+
+// SpamSubmission 一次公开提交（评论、联系表单、订阅等）
+type SpamSubmission struct {
+	Kind       string // 提交类型，如 comment
+	IP         string
+	UserAgent  string
+	AuthorName string
+	Email      string
+	Body       string
+	Honeypot   string    // 蜜罐字段的值，正常用户不会填写
+	Token      string    // 表单令牌（由 IssueToken 签发）
+	ReceivedAt time.Time // 为零值时使用当前时间
+}
+
+// SpamDecision 汇总判定：各检查项的分数相加后与阈值比较
+type SpamDecision struct {
+	Verdict SpamVerdict `json:"verdict"`
+	Score   int         `json:"score"`
+	Reasons []string    `json:"reasons"` // 「检查项: 原因」
+}
+
+// SpamService 公开提交的反垃圾流水线：依次执行检查项，
+// 汇总分数得出判定，并将每次判定写入记录供管理员复查
+type SpamService struct {
+	repo   repository.SpamRepository
+	tokens *FormTokens
+	ipKey  []byte // 判定记录中 IP 哈希的密钥
+	checks []SpamCheck
+	now    func() time.Time
+
+	mu       sync.RWMutex
+	disallow []string // 禁止列表缓存
+}
+
+// NewSpamService 创建反垃圾服务并加载禁止列表。
+// 默认检查项为蜜罐、最短提交时间、链接数、关键词、频率限制与禁止列表；
+// secret 用于派生表单令牌的签名密钥与记录中 IP 哈希的密钥，
+// 为空时随机生成（重启后已签发的令牌失效，记录中的 IP 哈希不再可比）
+func NewSpamService(repo repository.SpamRepository, secret []byte) (*SpamService, error) {
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+	}
+	disallow, err := repo.DisallowList()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &SpamService{
+		repo:     repo,
+		tokens:   NewFormTokens(deriveKey(secret, "form-tokens")),
+		ipKey:    deriveKey(secret, "spam-log"),
+		now:      time.Now,
+		disallow: disallow,
+	}
+	s.checks = []SpamCheck{
+		HoneypotCheck{},
+		FormTokenCheck{Tokens: s.tokens},
+		LinkCountCheck{Max: defaultMaxLinks},
+		KeywordCheck{Keywords: DefaultSpamKeywords},
+		NewRateLimitCheck(defaultPerIPLimit, defaultPerEmailLimit, defaultRateWindow),
+		DisallowCheck{Entries: s.DisallowList},
+	}
+	return s, nil
+}
+
+// AddCheck 追加检查项
+func (s *SpamService) AddCheck(check SpamCheck) {
+	s.checks = append(s.checks, check)
+}
+
+// IssueToken 签发表单令牌（表单加载时获取，提交时带回）
+func (s *SpamService) IssueToken() string {
+	return s.tokens.Issue(s.now())
+}
+
+// Evaluate 执行全部检查项并记录判定结果。记录写入失败不影响判定
+func (s *SpamService) Evaluate(sub SpamSubmission) SpamDecision {
+	if sub.ReceivedAt.IsZero() {
+		sub.ReceivedAt = s.now()
+	}
+
+	decision := SpamDecision{Reasons: []string{}}
+	for _, check := range s.checks {
+		result := check.Check(sub)
+		if result.Score <= 0 {
+			continue
+		}
+		decision.Score += result.Score
+		for _, reason := range result.Reasons {
+			decision.Reasons = append(decision.Reasons, check.Name()+": "+reason)
+		}
+	}
+	switch {
+	case decision.Score >= SpamRejectScore:
+		decision.Verdict = SpamReject
+	case decision.Score >= SpamModerateScore:
+		decision.Verdict = SpamModerate
+	default:
+		decision.Verdict = SpamAccept
+	}
+
+	_ = s.repo.AppendLog(repository.SpamLogEntry{
+		Time:      sub.ReceivedAt,
+		Kind:      sub.Kind,
+		IPHash:    s.hashIP(sub.IP),
+		EmailHash: domain.HashEmail(sub.Email),
+		Verdict:   string(decision.Verdict),
+		Score:     decision.Score,
+		Reasons:   decision.Reasons,
+		Excerpt:   spamExcerpt(sub.Body),
+	})
+	return decision
+}
+
+// Log 返回最近的判定记录（按时间倒序）
+func (s *SpamService) Log(limit int) ([]repository.SpamLogEntry, error) {
+	return s.repo.RecentLog(limit)
+}
+
+// DisallowList 返回当前的禁止列表
+func (s *SpamService) DisallowList() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]string{}, s.disallow...)
+}
+
+// SetDisallowList 覆盖保存禁止列表，返回规范化后的列表
+func (s *SpamService) SetDisallowList(entries []string) ([]string, error) {
+	if err := s.repo.SaveDisallowList(entries); err != nil {
+		return nil, err
+	}
+	saved, err := s.repo.DisallowList()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.disallow = saved
+	s.mu.Unlock()
+	return append([]string{}, saved...), nil
+}
+
+// hashIP 返回 IP 的带密钥哈希，同一 IP 的记录可相互关联但无法还原 IP
+func (s *SpamService) hashIP(ip string) string {
+	if ip == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, s.ipKey)
+	mac.Write([]byte(ip))
+	return hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// deriveKey 由 secret 派生用于 label 用途的密钥
+func deriveKey(secret []byte, label string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(label))
+	return mac.Sum(nil)
+}
+
+// ReadLocked 在禁止写入禁止列表与判定记录的状态下执行 fn（用于备份）
+func (s *SpamService) ReadLocked(fn func() error) error {
+	return s.repo.ReadLocked(fn)
+}
+
+// WriteLocked 在禁止写入的状态下执行 fn（fn 可直接替换数据文件），返回前重新加载禁止列表与判定记录（用于恢复备份）
+func (s *SpamService) WriteLocked(fn func() error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fnErr := s.repo.WriteLocked(fn)
+	disallow, err := s.repo.DisallowList()
+	if err != nil {
+		return err
+	}
+	s.disallow = disallow
+	return fnErr
+}
+
+// spamExcerpt 截取正文开头用于判定记录
+func spamExcerpt(body string) string {
+	body = strings.Join(strings.Fields(body), " ")
+	if utf8.RuneCountInString(body) <= spamExcerptLength {
+		return body
+	}
+	return string([]rune(body)[:spamExcerptLength]) + "…"
+}
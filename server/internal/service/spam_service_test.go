@@ -0,0 +1,169 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+func newTestSpamService(t *testing.T, now *time.Time) (*SpamService, *repository.MemorySpamRepository) {
+	t.Helper()
+	repo := repository.NewMemorySpamRepository()
+	service, err := NewSpamService(repo, []byte("test-secret"))
+	if err != nil {
+		t.Fatalf("NewSpamService() error = %v", err)
+	}
+	service.now = func() time.Time { return *now }
+	return service, repo
+}
+
+// validSubmission 正常用户的提交：表单加载一分钟后提交
+func validSubmission(service *SpamService, now *time.Time, ip string) SpamSubmission {
+	token := service.IssueToken()
+	*now = now.Add(time.Minute)
+	return SpamSubmission{
+		Kind: "comment", IP: ip, AuthorName: "Alice", Email: "alice@example.com",
+		Body: "Thanks for the write-up, see https://example.com/notes", Token: token,
+	}
+}
+
+func TestSpamService_Evaluate(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	service, _ := newTestSpamService(t, &now)
+
+	tests := []struct {
+		name    string
+		mutate  func(*SpamSubmission)
+		verdict SpamVerdict
+		reason  string
+	}{
+		{"clean", func(*SpamSubmission) {}, SpamAccept, ""},
+		{"honeypot", func(s *SpamSubmission) { s.Honeypot = "http://spam.example" }, SpamReject, "honeypot:"},
+		{"missing token", func(s *SpamSubmission) { s.Token = "" }, SpamModerate, "missing form token"},
+		{"forged token", func(s *SpamSubmission) { s.Token = "1717243200.deadbeef" }, SpamModerate, "invalid form token"},
+		{"too fast", func(s *SpamSubmission) { s.ReceivedAt = now.Add(-59 * time.Second) }, SpamReject, "after the form was loaded"},
+		{"many links", func(s *SpamSubmission) { s.Body = strings.Repeat("https://spam.example ", 3) }, SpamModerate, "3 links"},
+		{"link flood", func(s *SpamSubmission) { s.Body = strings.Repeat("www.spam.example ", 5) }, SpamReject, "5 links"},
+		{"keywords", func(s *SpamSubmission) { s.Body = "Best CASINO and payday loan offers" }, SpamModerate, `keyword "casino"`},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := validSubmission(service, &now, fmt.Sprintf("203.0.113.%d", i+1))
+			sub.Email = fmt.Sprintf("user%d@example.com", i)
+			tt.mutate(&sub)
+			decision := service.Evaluate(sub)
+			if decision.Verdict != tt.verdict {
+				t.Errorf("Evaluate() = %+v, want %s", decision, tt.verdict)
+			}
+			if tt.reason != "" && !strings.Contains(strings.Join(decision.Reasons, "\n"), tt.reason) {
+				t.Errorf("Evaluate() reasons = %v, want one containing %q", decision.Reasons, tt.reason)
+			}
+		})
+	}
+
+	// 每次判定都写入记录
+	entries, _ := service.Log(0)
+	if len(entries) != len(tests) || entries[0].Verdict != string(SpamModerate) || entries[0].EmailHash == "" {
+		t.Errorf("Log() = %+v, want %d entries, newest first", entries, len(tests))
+	}
+}
+
+func TestSpamService_RateLimit(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	service, _ := newTestSpamService(t, &now)
+
+	// 同一邮箱 10 分钟内最多 3 次
+	for i := 1; i <= 4; i++ {
+		sub := validSubmission(service, &now, fmt.Sprintf("203.0.113.%d", i))
+		decision := service.Evaluate(sub)
+		if want := i <= 3; (decision.Verdict == SpamAccept) != want {
+			t.Fatalf("submission %d = %+v, accepted want %v", i, decision, want)
+		}
+	}
+
+	// 窗口过后重新计数
+	now = now.Add(10 * time.Minute)
+	if decision := service.Evaluate(validSubmission(service, &now, "203.0.113.9")); decision.Verdict != SpamAccept {
+		t.Errorf("submission after window = %+v, want accept", decision)
+	}
+}
+
+func TestSpamService_DisallowList(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	service, repo := newTestSpamService(t, &now)
+
+	saved, err := service.SetDisallowList([]string{"198.51.100.0/24", "@spam.example", "Cheap Pills", " "})
+	if err != nil || len(saved) != 3 {
+		t.Fatalf("SetDisallowList() = %v, %v", saved, err)
+	}
+	if stored, _ := repo.DisallowList(); len(stored) != 3 {
+		t.Errorf("stored disallow list = %v", stored)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(*SpamSubmission)
+		reason string
+	}{
+		{"ip range", func(s *SpamSubmission) { s.IP = "198.51.100.7" }, `disallowed ip "198.51.100.0/24"`},
+		{"email domain", func(s *SpamSubmission) { s.Email = "Bob@Spam.Example" }, `disallowed email "@spam.example"`},
+		{"text", func(s *SpamSubmission) { s.Body = "cheap pills here" }, `disallowed text "cheap pills"`},
+	}
+	for _, tt := range tests {
+		sub := validSubmission(service, &now, "203.0.113.1")
+		sub.Email = tt.name + "@example.com"
+		tt.mutate(&sub)
+		decision := service.Evaluate(sub)
+		if decision.Verdict != SpamReject || !strings.Contains(strings.Join(decision.Reasons, "\n"), tt.reason) {
+			t.Errorf("%s: Evaluate() = %+v, want reject with %q", tt.name, decision, tt.reason)
+		}
+	}
+
+	// 重新创建服务时从仓库加载禁止列表
+	reloaded, _ := NewSpamService(repo, nil)
+	if list := reloaded.DisallowList(); len(list) != 3 {
+		t.Errorf("DisallowList() after reload = %v", list)
+	}
+}
+
+func TestSpamService_LogHashesIP(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	service, _ := newTestSpamService(t, &now)
+	for _, ip := range []string{"203.0.113.1", "203.0.113.1", "203.0.113.2"} {
+		service.Evaluate(validSubmission(service, &now, ip))
+	}
+
+	entries, _ := service.Log(0)
+	if len(entries) != 3 {
+		t.Fatalf("Log() = %+v, want 3 entries", entries)
+	}
+	if entries[2].IPHash == "" || entries[1].IPHash != entries[2].IPHash || entries[0].IPHash == entries[1].IPHash {
+		t.Errorf("IP hashes = %q, %q, %q, want equal only for the same IP", entries[2].IPHash, entries[1].IPHash, entries[0].IPHash)
+	}
+	if strings.Contains(entries[0].IPHash, "203.0.113") {
+		t.Errorf("IPHash = %q contains the raw IP", entries[0].IPHash)
+	}
+
+	// 不同密钥下同一 IP 的哈希不同
+	other, _ := NewSpamService(repository.NewMemorySpamRepository(), []byte("other-secret"))
+	if other.hashIP("203.0.113.2") == entries[0].IPHash {
+		t.Error("IP hash does not depend on the secret")
+	}
+}
+
+func TestSpamService_WriteLocked(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	service, repo := newTestSpamService(t, &now)
+
+	// 模拟恢复备份：仓库中的禁止列表被替换
+	repo.SaveDisallowList([]string{"casino"})
+	if err := service.WriteLocked(func() error { return nil }); err != nil {
+		t.Fatalf("WriteLocked() error = %v", err)
+	}
+	if list := service.DisallowList(); len(list) != 1 || list[0] != "casino" {
+		t.Errorf("DisallowList() after reload = %v", list)
+	}
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"math"
+	"sort"
+)
+
+// 标签云排序方式
+const (
+	TagCloudSortCount = "count" // 按文章数倒序（默认）
+	TagCloudSortName  = "name"  // 按名称
+)
+
+// defaultTagCloudLevels 默认字号分级数
+const defaultTagCloudLevels = 5
+
+// TagCloudOptions 标签云选项
+type TagCloudOptions struct {
+	Limit    int    // 最多显示的标签数（取文章数最多的前 N 个），<= 0 时不限
+	MinCount int    // 文章数少于该值的标签不显示
+	SortBy   string // TagCloudSortCount 或 TagCloudSortName
+	Levels   int    // 字号分级数，<= 0 时为 5
+}
+
+// TagCloudItem 标签云中的标签
+type TagCloudItem struct {
+	Name   string `json:"name"`
+	Count  int    `json:"count"`
+	Weight int    `json:"weight"` // 字号等级 1..Levels，按文章数的对数比例分级
+}
+
+// TagCloud 由已发布文章的标签统计生成标签云
+func (s *IndexService) TagCloud(opts TagCloudOptions) []TagCloudItem {
+	return BuildTagCloud(s.GetPublishedTagCounts(), opts)
+}
+
+// BuildTagCloud 按 MinCount 过滤并取文章数最多的 Limit 个标签，
+// 再按对数比例分级（文章数相差悬殊时，少量热门标签不会把其余标签都压到最小一级）
+func BuildTagCloud(counts []TagCount, opts TagCloudOptions) []TagCloudItem {
+	levels := opts.Levels
+	if levels <= 0 {
+		levels = defaultTagCloudLevels
+	}
+
+	selected := make([]TagCount, 0, len(counts))
+	for _, tc := range counts {
+		if tc.Count > 0 && tc.Count >= opts.MinCount {
+			selected = append(selected, tc)
+		}
+	}
+	sort.Slice(selected, func(i, j int) bool {
+		if selected[i].Count != selected[j].Count {
+			return selected[i].Count > selected[j].Count
+		}
+		return selected[i].Name < selected[j].Name
+	})
+	if opts.Limit > 0 && len(selected) > opts.Limit {
+		selected = selected[:opts.Limit]
+	}
+
+	items := make([]TagCloudItem, 0, len(selected))
+	if len(selected) == 0 {
+		return items
+	}
+
+	// 倒序排列后首尾即为最大与最小文章数
+	maxLog := math.Log(float64(selected[0].Count))
+	minLog := math.Log(float64(selected[len(selected)-1].Count))
+	for _, tc := range selected {
+		weight := (levels + 1) / 2 // 文章数都相同时取中间一级
+		if maxLog > minLog {
+			ratio := (math.Log(float64(tc.Count)) - minLog) / (maxLog - minLog)
+			weight = 1 + int(math.Round(ratio*float64(levels-1)))
+		}
+		items = append(items, TagCloudItem{Name: tc.Name, Count: tc.Count, Weight: weight})
+	}
+
+	if opts.SortBy == TagCloudSortName {
+		sort.Slice(items, func(i, j int) bool {
+			return items[i].Name < items[j].Name
+		})
+	}
+	return items
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/domain/valueobject"
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+func TestBuildTagCloud(t *testing.T) {
+	counts := []TagCount{
+		{Name: "rare", Count: 1},
+		{Name: "go", Count: 100},
+		{Name: "web", Count: 10},
+		{Name: "rust", Count: 10},
+		{Name: "misc", Count: 2},
+	}
+
+	tests := []struct {
+		name string
+		opts TagCloudOptions
+		want string
+	}{
+		{
+			name: "log scaled by popularity",
+			opts: TagCloudOptions{},
+			want: "[{go 100 5} {rust 10 3} {web 10 3} {misc 2 2} {rare 1 1}]",
+		},
+		{
+			name: "top n with min count, sorted by name",
+			opts: TagCloudOptions{Limit: 3, MinCount: 2, SortBy: TagCloudSortName},
+			want: "[{go 100 5} {rust 10 1} {web 10 1}]",
+		},
+		{
+			name: "custom levels",
+			opts: TagCloudOptions{MinCount: 10, Limit: 2, Levels: 3},
+			want: "[{go 100 3} {rust 10 1}]",
+		},
+		{
+			name: "nothing above threshold",
+			opts: TagCloudOptions{MinCount: 1000},
+			want: "[]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fmt.Sprint(BuildTagCloud(counts, tt.opts))
+			if got != tt.want {
+				t.Errorf("BuildTagCloud() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	single := BuildTagCloud([]TagCount{{Name: "go", Count: 4}}, TagCloudOptions{})
+	if len(single) != 1 || single[0].Weight != 3 {
+		t.Errorf("single tag = %v, want middle weight 3", single)
+	}
+}
+
+func TestIndexService_PublishedTagCounts(t *testing.T) {
+	repo := repository.NewMemoryPostRepository()
+	tagGo, _ := valueobject.NewTag("go")
+	tagWeb, _ := valueobject.NewTag("web")
+	date := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	published := createPublishedPostWithDate("1", "Go", "go", date)
+	published.UpdateTags([]valueobject.Tag{tagGo, tagWeb})
+	other := createPublishedPostWithDate("2", "Go 2", "go-2", date)
+	other.UpdateTags([]valueobject.Tag{tagGo})
+	draft := createTestPostWithDate("3", "Draft", "draft", date)
+	draft.UpdateTags([]valueobject.Tag{tagGo})
+	repo.Save(published)
+	repo.Save(other)
+	repo.Save(draft)
+
+	service := NewIndexService(repo, nil)
+	service.Rebuild()
+
+	// 草稿不计入
+	if got := fmt.Sprint(service.GetPublishedTagCounts()); got != "[{go 2} {web 1}]" {
+		t.Errorf("GetPublishedTagCounts() = %s, want [{go 2} {web 1}]", got)
+	}
+
+	// 增量维护：取消发布与删除后计数同步减少
+	other.Unpublish()
+	service.PostSaved(other)
+	service.PostDeleted("1")
+	if got := service.GetPublishedTagCounts(); len(got) != 0 {
+		t.Errorf("GetPublishedTagCounts() after unpublish and delete = %v, want empty", got)
+	}
+
+	draft.Publish()
+	service.PostSaved(draft)
+	if got := fmt.Sprint(service.TagCloud(TagCloudOptions{})); got != "[{go 1 3}]" {
+		t.Errorf("TagCloud() = %s, want [{go 1 3}]", got)
+	}
+}
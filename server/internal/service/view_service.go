@@ -0,0 +1,291 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+// DefaultViewWindow 同一访客重复浏览同一篇文章时，在该时间内只计一次
+const DefaultViewWindow = 30 * time.Minute
+
+// viewDayLayout 每日浏览量的日期格式
+const viewDayLayout = "2006-01-02"
+
+// botMarkers User-Agent 中包含这些片段（小写）的请求视为爬虫，不计入浏览量
+var botMarkers = []string{
+	"bot", "crawl", "spider", "slurp", "fetcher", "scraper",
+	"curl/", "wget/", "python-requests", "python-urllib", "go-http-client", "java/", "okhttp",
+	"headless", "lighthouse", "pingdom", "facebookexternalhit", "embedly", "preview",
+}
+
+// PostViews 文章与浏览量
+type PostViews struct {
+	PostID string `json:"postId"`
+	Views  int    `json:"views"`
+}
+
+// ViewService 文章浏览量统计：计数先缓存在内存中，由 Flush 定期累加到仓库。
+// 访客以「随机盐 + IP + User-Agent」的 SHA-256 标识，只用于去重，原始 IP 不会保存
+type ViewService struct {
+	repo   repository.ViewRepository
+	loc    *time.Location
+	window time.Duration
+	salt   []byte
+	now    func() time.Time
+
+	flushMu sync.Mutex // 同一时间只执行一次写入，恢复备份期间暂停写入
+	mu      sync.Mutex
+	daily   map[string]repository.DailyViews // 全部浏览量（含未写入仓库的部分）
+	totals  map[string]int                   // postID -> 总浏览量
+	pending map[string]repository.DailyViews // 未写入仓库的增量
+	seen    map[string]time.Time             // 访客哈希 + 文章 -> 上次计数时间
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewViewService 创建浏览量服务并加载已保存的浏览量。
+// loc 为站点时区（按天分桶），为 nil 时使用 UTC；window <= 0 时使用 DefaultViewWindow
+func NewViewService(repo repository.ViewRepository, loc *time.Location, window time.Duration) (*ViewService, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	if window <= 0 {
+		window = DefaultViewWindow
+	}
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	daily, err := repo.LoadViews()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ViewService{
+		repo:    repo,
+		loc:     loc,
+		window:  window,
+		salt:    salt,
+		now:     time.Now,
+		daily:   daily,
+		totals:  viewTotals(daily),
+		pending: make(map[string]repository.DailyViews),
+		seen:    make(map[string]time.Time),
+	}, nil
+}
+
+// Record 记录一次浏览，返回是否计数（爬虫与窗口内的重复浏览不计数）
+func (s *ViewService) Record(postID, ip, userAgent string) bool {
+	if postID == "" || IsBot(userAgent) {
+		return false
+	}
+	key := s.visitorKey(ip, userAgent) + ":" + postID
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if last, ok := s.seen[key]; ok && now.Sub(last) < s.window {
+		return false
+	}
+	s.seen[key] = now
+
+	day := now.In(s.loc).Format(viewDayLayout)
+	delta := map[string]repository.DailyViews{postID: {day: 1}}
+	repository.MergeViews(s.daily, delta)
+	repository.MergeViews(s.pending, delta)
+	s.totals[postID]++
+	return true
+}
+
+// ReadLocked 在暂停写入浏览量的状态下执行 fn（用于备份）
+func (s *ViewService) ReadLocked(fn func() error) error {
+	s.flushMu.Lock()
+	defer s.flushMu.Unlock()
+
+	return s.repo.ReadLocked(fn)
+}
+
+// WriteLocked 在暂停计数与写入的状态下执行 fn（fn 可直接替换浏览量文件），
+// 返回前从仓库重新加载浏览量，尚未写入的增量被丢弃（用于恢复备份）
+func (s *ViewService) WriteLocked(fn func() error) error {
+	s.flushMu.Lock()
+	defer s.flushMu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fnErr := s.repo.WriteLocked(fn)
+	daily, err := s.repo.LoadViews()
+	if err != nil {
+		return err
+	}
+	s.daily = daily
+	s.totals = viewTotals(daily)
+	s.pending = make(map[string]repository.DailyViews)
+	return fnErr
+}
+
+// Flush 将缓存的增量写入仓库，并清理已过去重窗口的访客记录；写入失败时增量保留到下次
+func (s *ViewService) Flush() error {
+	s.flushMu.Lock()
+	defer s.flushMu.Unlock()
+
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[string]repository.DailyViews)
+	now := s.now()
+	for key, last := range s.seen {
+		if now.Sub(last) >= s.window {
+			delete(s.seen, key)
+		}
+	}
+	s.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+	if err := s.repo.AddViews(pending); err != nil {
+		s.mu.Lock()
+		repository.MergeViews(s.pending, pending)
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Start 在后台每隔 interval 执行一次 Flush，onError 处理写入错误（可为 nil）
+func (s *ViewService) Start(interval time.Duration, onError func(error)) {
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := s.Flush(); err != nil && onError != nil {
+					onError(err)
+				}
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Close 停止后台写入并写入剩余的增量
+func (s *ViewService) Close() error {
+	s.stopOnce.Do(func() {
+		if s.stop != nil {
+			close(s.stop)
+			<-s.done
+		}
+	})
+	return s.Flush()
+}
+
+// Views 返回文章的总浏览量
+func (s *ViewService) Views(postID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.totals[postID]
+}
+
+// Popular 返回浏览量最多的 limit 篇文章（浏览量倒序，同量按 ID 排序）。
+// days > 0 时只统计最近 days 天（含今天），include 为 nil 时不过滤文章
+func (s *ViewService) Popular(days, limit int, include func(postID string) bool) []PostViews {
+	s.mu.Lock()
+	var result []PostViews
+	if days > 0 {
+		since := s.now().In(s.loc).AddDate(0, 0, -(days - 1)).Format(viewDayLayout)
+		for postID, buckets := range s.daily {
+			views := 0
+			for day, count := range buckets {
+				// 日期格式固定，字符串比较即时间比较
+				if day >= since {
+					views += count
+				}
+			}
+			if views > 0 {
+				result = append(result, PostViews{PostID: postID, Views: views})
+			}
+		}
+	} else {
+		for postID, views := range s.totals {
+			if views > 0 {
+				result = append(result, PostViews{PostID: postID, Views: views})
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	if include != nil {
+		filtered := result[:0]
+		for _, pv := range result {
+			if include(pv.PostID) {
+				filtered = append(filtered, pv)
+			}
+		}
+		result = filtered
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Views != result[j].Views {
+			return result[i].Views > result[j].Views
+		}
+		return result[i].PostID < result[j].PostID
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	if result == nil {
+		result = []PostViews{}
+	}
+	return result
+}
+
+// IsBot 根据 User-Agent 判断是否为爬虫或自动化客户端（空 User-Agent 也视为爬虫）
+func IsBot(userAgent string) bool {
+	ua := strings.ToLower(strings.TrimSpace(userAgent))
+	if ua == "" {
+		return true
+	}
+	for _, marker := range botMarkers {
+		if strings.Contains(ua, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// visitorKey 返回访客的匿名标识（加盐哈希，盐只保存在内存中，重启后更换）
+func (s *ViewService) visitorKey(ip, userAgent string) string {
+	h := sha256.New()
+	h.Write(s.salt)
+	h.Write([]byte(ip))
+	h.Write([]byte{0})
+	h.Write([]byte(userAgent))
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+// viewTotals 汇总每篇文章的总浏览量
+func viewTotals(daily map[string]repository.DailyViews) map[string]int {
+	totals := make(map[string]int, len(daily))
+	for postID, days := range daily {
+		for _, count := range days {
+			totals[postID] += count
+		}
+	}
+	return totals
+}
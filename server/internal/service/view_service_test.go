@@ -0,0 +1,187 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+const testUA = "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_0) AppleWebKit/605.1.15 Safari/605.1.15"
+
+// failingViewRepository 写入总是失败的浏览量仓库
+type failingViewRepository struct {
+	repository.ViewRepository
+}
+
+func (r *failingViewRepository) AddViews(map[string]repository.DailyViews) error {
+	return errors.New("disk full")
+}
+
+func newTestViewService(t *testing.T, repo repository.ViewRepository, now *time.Time) *ViewService {
+	t.Helper()
+	service, err := NewViewService(repo, time.UTC, 30*time.Minute)
+	if err != nil {
+		t.Fatalf("NewViewService() error = %v", err)
+	}
+	service.now = func() time.Time { return *now }
+	return service
+}
+
+func TestViewService_RecordDedupAndBots(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	service := newTestViewService(t, repository.NewMemoryViewRepository(), &now)
+
+	if !service.Record("1", "10.0.0.1", testUA) {
+		t.Error("first view should be counted")
+	}
+	if service.Record("1", "10.0.0.1", testUA) {
+		t.Error("repeat view within window should not be counted")
+	}
+	if !service.Record("2", "10.0.0.1", testUA) {
+		t.Error("view of another post should be counted")
+	}
+	if !service.Record("1", "10.0.0.2", testUA) {
+		t.Error("view from another visitor should be counted")
+	}
+
+	for _, ua := range []string{"", "Googlebot/2.1 (+http://www.google.com/bot.html)", "curl/8.4.0", "Mozilla/5.0 HeadlessChrome/120.0"} {
+		if service.Record("1", "10.0.0.3", ua) {
+			t.Errorf("bot user agent %q should not be counted", ua)
+		}
+	}
+
+	now = now.Add(31 * time.Minute)
+	if !service.Record("1", "10.0.0.1", testUA) {
+		t.Error("view after window should be counted")
+	}
+
+	if got := service.Views("1"); got != 3 {
+		t.Errorf("Views(1) = %d, want 3", got)
+	}
+	if got := service.Views("2"); got != 1 {
+		t.Errorf("Views(2) = %d, want 1", got)
+	}
+}
+
+func TestViewService_NoRawIP(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	service := newTestViewService(t, repository.NewMemoryViewRepository(), &now)
+
+	service.Record("1", "203.0.113.7", testUA)
+	for key := range service.seen {
+		if strings.Contains(key, "203.0.113.7") {
+			t.Errorf("visitor key %q should not contain the raw IP", key)
+		}
+	}
+}
+
+func TestViewService_FlushAndReload(t *testing.T) {
+	now := time.Date(2024, 6, 1, 23, 50, 0, 0, time.UTC)
+	repo := repository.NewMemoryViewRepository()
+	service := newTestViewService(t, repo, &now)
+
+	service.Record("1", "10.0.0.1", testUA)
+	now = now.Add(20 * time.Minute) // 次日
+	service.Record("1", "10.0.0.2", testUA)
+
+	if err := service.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	// 没有新增时再次 Flush 不重复累加
+	if err := service.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	stored, _ := repo.LoadViews()
+	if got := fmt.Sprint(stored["1"]); got != "map[2024-06-01:1 2024-06-02:1]" {
+		t.Errorf("stored views = %s", got)
+	}
+
+	reloaded := newTestViewService(t, repo, &now)
+	if got := reloaded.Views("1"); got != 2 {
+		t.Errorf("Views(1) after reload = %d, want 2", got)
+	}
+}
+
+func TestViewService_FlushFailureKeepsPending(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	repo := repository.NewMemoryViewRepository()
+	service := newTestViewService(t, &failingViewRepository{ViewRepository: repo}, &now)
+
+	service.Record("1", "10.0.0.1", testUA)
+	if err := service.Flush(); err == nil {
+		t.Fatal("Flush() should fail")
+	}
+
+	// 写入恢复后，之前的增量仍会写入
+	service.repo = repo
+	service.Record("1", "10.0.0.2", testUA)
+	if err := service.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	stored, _ := repo.LoadViews()
+	if got := stored["1"]["2024-06-01"]; got != 2 {
+		t.Errorf("stored views = %d, want 2", got)
+	}
+}
+
+func TestViewService_Popular(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	repo := repository.NewMemoryViewRepository()
+	repo.AddViews(map[string]repository.DailyViews{
+		"old":    {"2024-05-01": 50},
+		"recent": {"2024-06-09": 3, "2024-06-10": 2},
+		"mixed":  {"2024-05-01": 1, "2024-06-04": 4},
+		"draft":  {"2024-06-10": 10},
+	})
+	service := newTestViewService(t, repo, &now)
+	published := func(id string) bool { return id != "draft" }
+
+	tests := []struct {
+		name  string
+		days  int
+		limit int
+		want  string
+	}{
+		{name: "last 7 days", days: 7, limit: 10, want: "[{recent 5} {mixed 4}]"},
+		{name: "all time", days: 0, limit: 10, want: "[{old 50} {mixed 5} {recent 5}]"},
+		{name: "limited", days: 0, limit: 1, want: "[{old 50}]"},
+		{name: "today only", days: 1, limit: 10, want: "[{recent 2}]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fmt.Sprint(service.Popular(tt.days, tt.limit, published))
+			if got != tt.want {
+				t.Errorf("Popular() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestViewService_WriteLockedReplacesCounts(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	repo := repository.NewMemoryViewRepository()
+	service := newTestViewService(t, repo, &now)
+	service.Record("1", "10.0.0.1", testUA)
+
+	// 模拟恢复备份：仓库中的浏览量被替换
+	repo.AddViews(map[string]repository.DailyViews{"2": {"2024-05-01": 5}})
+	if err := service.WriteLocked(func() error { return nil }); err != nil {
+		t.Fatalf("WriteLocked() error = %v", err)
+	}
+	if got1, got2 := service.Views("1"), service.Views("2"); got1 != 0 || got2 != 5 {
+		t.Errorf("Views() after reload = %d, %d, want 0, 5", got1, got2)
+	}
+
+	// 恢复前未写入的增量被丢弃
+	if err := service.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if stored, _ := repo.LoadViews(); len(stored) != 1 {
+		t.Errorf("stored views = %v, want only the restored post", stored)
+	}
+}
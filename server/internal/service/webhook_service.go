@@ -0,0 +1,471 @@
+package service
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+// Webhook 投递参数：失败后按 1、2、4、8 分钟的间隔重试，共投递 MaxDeliveryAttempts 次
+const (
+	MaxDeliveryAttempts  = 5
+	deliveryBackoff      = time.Minute
+	deliveryTimeout      = 10 * time.Second
+	deliveryErrorLength  = 200
+	webhookSignatureName = "X-Webhook-Signature"
+)
+
+// WebhookPayload 投递的请求体
+type WebhookPayload struct {
+	Event     domain.WebhookEvent `json:"event"`
+	CreatedAt time.Time           `json:"createdAt"`
+	Data      interface{}         `json:"data"`
+}
+
+// WebhookPostData 文章事件的数据
+type WebhookPostData struct {
+	ID           string     `json:"id"`
+	Title        string     `json:"title,omitempty"`
+	Slug         string     `json:"slug"`
+	PreviousSlug string     `json:"previousSlug,omitempty"` // 修改了 Slug 时为原 Slug
+	Status       string     `json:"status,omitempty"`
+	Tags         []string   `json:"tags,omitempty"`
+	PublishedAt  *time.Time `json:"publishedAt,omitempty"`
+	UpdatedAt    *time.Time `json:"updatedAt,omitempty"`
+}
+
+// WebhookCommentData 评论事件的数据
+type WebhookCommentData struct {
+	ID         string    `json:"id"`
+	PostID     string    `json:"postId"`
+	ParentID   string    `json:"parentId,omitempty"`
+	AuthorName string    `json:"authorName"`
+	Status     string    `json:"status"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// CreateWebhookInput 注册 Webhook 输入
+type CreateWebhookInput struct {
+	URL    string
+	Secret string // 为空时自动生成
+	Events []string
+}
+
+// UpdateWebhookInput 修改 Webhook 输入，为 nil 的字段保持不变
+type UpdateWebhookInput struct {
+	URL    *string
+	Secret *string
+	Events []string
+	Active *bool
+}
+
+// WebhookService 将内容变更通知到管理员注册的地址。
+// 事件先写入投递记录（pending），由后台任务投递；请求体使用 Webhook 的密钥
+// 做 HMAC-SHA256 签名（X-Webhook-Signature: sha256=<hex>），失败时按指数退避重试
+type WebhookService struct {
+	repo   repository.WebhookRepository
+	posts  repository.PostRepository
+	client *http.Client
+	now    func() time.Time
+
+	published publishedPosts // 用于区分发布与修改
+
+	deliverMu sync.Mutex // 同一时间只执行一批投递
+
+	wake     chan struct{}
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewWebhookService 创建 Webhook 服务并读取已发布的文章
+func NewWebhookService(repo repository.WebhookRepository, posts repository.PostRepository) (*WebhookService, error) {
+	s := &WebhookService{
+		repo:   repo,
+		posts:  posts,
+		client: &http.Client{Timeout: deliveryTimeout},
+		now:    time.Now,
+		wake:   make(chan struct{}, 1),
+	}
+	if err := s.Rebuild(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Rebuild 重新读取已发布的文章
+func (s *WebhookService) Rebuild() error {
+	return s.published.load(s.posts)
+}
+
+// ReadLocked 在禁止修改 Webhook 与写入投递记录的状态下执行 fn（用于备份）
+func (s *WebhookService) ReadLocked(fn func() error) error {
+	return s.repo.ReadLocked(fn)
+}
+
+// WriteLocked 在禁止写入的状态下执行 fn（fn 可直接替换数据文件），返回前重新加载 Webhook 与投递记录（用于恢复备份）。
+// 已发布的文章由调用方在文章恢复完成后调用 Rebuild 重新读取
+func (s *WebhookService) WriteLocked(fn func() error) error {
+	return s.repo.WriteLocked(fn)
+}
+
+// List 列出全部 Webhook
+func (s *WebhookService) List() ([]*domain.Webhook, error) {
+	return s.repo.ListWebhooks()
+}
+
+// Create 注册 Webhook
+func (s *WebhookService) Create(input CreateWebhookInput) (*domain.Webhook, error) {
+	id, err := newRecordID()
+	if err != nil {
+		return nil, err
+	}
+	secret := input.Secret
+	if secret == "" {
+		if secret, err = newWebhookSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	webhook, err := domain.NewWebhook(id, input.URL, secret, webhookEvents(input.Events))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveWebhook(webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// Update 修改 Webhook
+func (s *WebhookService) Update(id string, input UpdateWebhookInput) (*domain.Webhook, error) {
+	webhook, err := s.repo.FindWebhook(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.URL != nil {
+		if err := webhook.SetURL(*input.URL); err != nil {
+			return nil, err
+		}
+	}
+	if input.Events != nil {
+		if err := webhook.SetEvents(webhookEvents(input.Events)); err != nil {
+			return nil, err
+		}
+	}
+	if input.Secret != nil && *input.Secret != "" {
+		webhook.Secret = *input.Secret
+	}
+	if input.Active != nil {
+		webhook.Active = *input.Active
+	}
+	webhook.UpdatedAt = s.now()
+
+	if err := s.repo.SaveWebhook(webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// Delete 删除 Webhook 及其投递记录
+func (s *WebhookService) Delete(id string) error {
+	return s.repo.DeleteWebhook(id)
+}
+
+// Deliveries 列出投递记录（按创建时间倒序）
+func (s *WebhookService) Deliveries(webhookID string, limit int) ([]*domain.WebhookDelivery, error) {
+	return s.repo.ListDeliveries(webhookID, limit)
+}
+
+// Redeliver 以原请求体重新投递一次，立即执行并返回新的投递记录（失败时按退避继续重试）
+func (s *WebhookService) Redeliver(deliveryID string) (*domain.WebhookDelivery, error) {
+	original, err := s.repo.FindDelivery(deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	webhook, err := s.repo.FindWebhook(original.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+	id, err := newRecordID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	delivery := &domain.WebhookDelivery{
+		ID:           id,
+		WebhookID:    webhook.ID,
+		Event:        original.Event,
+		Payload:      original.Payload,
+		Status:       domain.DeliveryPending,
+		RedeliveryOf: original.ID,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	s.deliverMu.Lock()
+	defer s.deliverMu.Unlock()
+
+	s.attempt(webhook, delivery)
+	if err := s.repo.SaveDelivery(delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// Dispatch 为订阅了事件的 Webhook 创建投递记录并唤醒后台投递
+func (s *WebhookService) Dispatch(event domain.WebhookEvent, data interface{}) error {
+	webhooks, err := s.repo.ListWebhooks()
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	var payload []byte
+	created := 0
+	for _, webhook := range webhooks {
+		if !webhook.Subscribes(event) {
+			continue
+		}
+		if payload == nil {
+			if payload, err = json.Marshal(WebhookPayload{Event: event, CreatedAt: now, Data: data}); err != nil {
+				return err
+			}
+		}
+		id, err := newRecordID()
+		if err != nil {
+			return err
+		}
+		if err := s.repo.SaveDelivery(&domain.WebhookDelivery{
+			ID:            id,
+			WebhookID:     webhook.ID,
+			Event:         event,
+			Payload:       string(payload),
+			Status:        domain.DeliveryPending,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+			NextAttemptAt: now,
+		}); err != nil {
+			return err
+		}
+		created++
+	}
+
+	if created > 0 {
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// DeliverDue 投递全部到期的记录，返回本次尝试的次数
+func (s *WebhookService) DeliverDue() (int, error) {
+	s.deliverMu.Lock()
+	defer s.deliverMu.Unlock()
+
+	due, err := s.repo.DueDeliveries(s.now())
+	if err != nil {
+		return 0, err
+	}
+	for _, delivery := range due {
+		webhook, err := s.repo.FindWebhook(delivery.WebhookID)
+		if err != nil {
+			delivery.Status = domain.DeliveryFailed
+			delivery.LastError = err.Error()
+			delivery.UpdatedAt = s.now()
+		} else {
+			s.attempt(webhook, delivery)
+		}
+		if err := s.repo.SaveDelivery(delivery); err != nil {
+			return 0, err
+		}
+	}
+	return len(due), nil
+}
+
+// Start 启动后台投递：每隔 interval 以及有新事件时投递到期的记录。onError 接收投递过程中的仓库错误
+func (s *WebhookService) Start(interval time.Duration, onError func(error)) {
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+			case <-s.wake:
+			case <-s.stop:
+				return
+			}
+			if _, err := s.DeliverDue(); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}()
+}
+
+// Close 停止后台投递（未投递的记录保留在仓库中，下次启动后继续）
+func (s *WebhookService) Close() error {
+	if s.stop == nil {
+		return nil
+	}
+	s.stopOnce.Do(func() {
+		close(s.stop)
+		<-s.done
+	})
+	return nil
+}
+
+// PostSaved 实现 PostObserver：文章发布时触发 post.published，已发布的文章被修改或取消发布时触发 post.updated
+func (s *WebhookService) PostSaved(post *domain.Post) {
+	previousSlug, wasPublished := s.published.saved(post)
+
+	event := domain.EventPostUpdated
+	switch {
+	case post.IsPublished() && !wasPublished:
+		event = domain.EventPostPublished
+	case !post.IsPublished() && !wasPublished:
+		return
+	}
+
+	updatedAt := post.UpdatedAt
+	data := WebhookPostData{
+		ID:          post.ID,
+		Title:       post.Title,
+		Slug:        post.Slug.String(),
+		Status:      post.Status.String(),
+		Tags:        post.GetTagNames(),
+		PublishedAt: post.PublishedAt,
+		UpdatedAt:   &updatedAt,
+	}
+	if wasPublished && previousSlug != data.Slug {
+		data.PreviousSlug = previousSlug
+	}
+	_ = s.Dispatch(event, data)
+}
+
+// PostDeleted 实现 PostObserver：已发布的文章被删除时触发 post.deleted
+func (s *WebhookService) PostDeleted(id string) {
+	slug, wasPublished := s.published.deleted(id)
+
+	if wasPublished {
+		_ = s.Dispatch(domain.EventPostDeleted, WebhookPostData{ID: id, Slug: slug})
+	}
+}
+
+// CommentCreated 实现 CommentObserver：收到新评论时触发 comment.created
+func (s *WebhookService) CommentCreated(comment *domain.Comment) {
+	_ = s.Dispatch(domain.EventCommentCreated, WebhookCommentData{
+		ID:         comment.ID,
+		PostID:     comment.PostID,
+		ParentID:   comment.ParentID,
+		AuthorName: comment.AuthorName,
+		Status:     comment.Status.String(),
+		CreatedAt:  comment.CreatedAt,
+	})
+}
+
+// attempt 投递一次并更新投递记录（调用方需持有 deliverMu）
+func (s *WebhookService) attempt(webhook *domain.Webhook, delivery *domain.WebhookDelivery) {
+	code, err := s.post(webhook, delivery)
+
+	delivery.Attempts++
+	delivery.ResponseCode = code
+	delivery.UpdatedAt = s.now()
+	if err == nil {
+		delivery.Status = domain.DeliverySucceeded
+		delivery.LastError = ""
+		delivery.NextAttemptAt = time.Time{}
+		return
+	}
+
+	delivery.LastError = truncateError(err.Error())
+	if delivery.Attempts >= MaxDeliveryAttempts {
+		delivery.Status = domain.DeliveryFailed
+		delivery.NextAttemptAt = time.Time{}
+		return
+	}
+	delivery.Status = domain.DeliveryPending
+	delivery.NextAttemptAt = delivery.UpdatedAt.Add(deliveryBackoff << (delivery.Attempts - 1))
+}
+
+// post 发送请求，接收方返回 2xx 时视为成功
+func (s *WebhookService) post(webhook *domain.Webhook, delivery *domain.WebhookDelivery) (int, error) {
+	if !webhook.Active {
+		return 0, fmt.Errorf("webhook is disabled")
+	}
+
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "ventus-webhook")
+	req.Header.Set("X-Webhook-Event", string(delivery.Event))
+	req.Header.Set("X-Webhook-Delivery", delivery.ID)
+	req.Header.Set(webhookSignatureName, SignWebhookPayload(webhook.Secret, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// SignWebhookPayload 计算请求体的签名，格式为 "sha256=<hex>"
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookEvents 将字符串转换为事件（校验由 domain 完成）
+func webhookEvents(raw []string) []domain.WebhookEvent {
+	events := make([]domain.WebhookEvent, 0, len(raw))
+	for _, event := range raw {
+		events = append(events, domain.WebhookEvent(event))
+	}
+	return events
+}
+
+// newWebhookSecret 生成随机签名密钥
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// truncateError 截断过长的错误信息
+func truncateError(message string) string {
+	if len(message) <= deliveryErrorLength {
+		return message
+	}
+	return message[:deliveryErrorLength] + "…"
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/domain"
+	"github.com/next-ai-ventus/server/internal/repository"
+)
+
+// webhookReceiver 记录收到的请求，status 为返回的状态码
+type webhookReceiver struct {
+	mu       sync.Mutex
+	status   int
+	requests []receivedWebhook
+}
+
+type receivedWebhook struct {
+	event     string
+	signature string
+	body      []byte
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, receivedWebhook{
+		event:     req.Header.Get("X-Webhook-Event"),
+		signature: req.Header.Get("X-Webhook-Signature"),
+		body:      body,
+	})
+	w.WriteHeader(r.status)
+}
+
+func (r *webhookReceiver) received() []receivedWebhook {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]receivedWebhook{}, r.requests...)
+}
+
+func setupWebhookService(t *testing.T, status int) (*WebhookService, *PostService, *webhookReceiver, *httptest.Server) {
+	t.Helper()
+
+	receiver := &webhookReceiver{status: status}
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	postService, repo := setupTestServices()
+	webhooks, err := NewWebhookService(repository.NewMemoryWebhookRepository(), repo)
+	if err != nil {
+		t.Fatalf("NewWebhookService() error = %v", err)
+	}
+	postService.AddObserver(webhooks)
+	return webhooks, postService, receiver, server
+}
+
+func TestWebhookService_SignedDelivery(t *testing.T) {
+	webhooks, postService, receiver, server := setupWebhookService(t, http.StatusOK)
+
+	hook, err := webhooks.Create(CreateWebhookInput{URL: server.URL, Secret: "s3cret", Events: []string{"post.published", "post.deleted"}})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	post, _ := postService.CreatePost(CreatePostInput{Title: "Hello", Content: "Content"})
+	published := "published"
+	post, _ = postService.UpdatePost(post.ID, UpdatePostInput{Status: &published}, post.Version)
+	title := "Hello again"
+	postService.UpdatePost(post.ID, UpdatePostInput{Title: &title}, post.Version) // 未订阅 post.updated
+	postService.DeletePost(post.ID)
+
+	if n, err := webhooks.DeliverDue(); err != nil || n != 2 {
+		t.Fatalf("DeliverDue() = %d, %v, want 2 deliveries", n, err)
+	}
+
+	requests := receiver.received()
+	if len(requests) != 2 || requests[0].event != "post.published" || requests[1].event != "post.deleted" {
+		t.Fatalf("received %v, want post.published then post.deleted", requests)
+	}
+	for _, req := range requests {
+		if req.signature != SignWebhookPayload(hook.Secret, req.body) {
+			t.Errorf("signature %q does not match the body", req.signature)
+		}
+	}
+
+	var payload struct {
+		Event string          `json:"event"`
+		Data  WebhookPostData `json:"data"`
+	}
+	if err := json.Unmarshal(requests[0].body, &payload); err != nil {
+		t.Fatalf("payload is not JSON: %v", err)
+	}
+	if payload.Event != "post.published" || payload.Data.ID != post.ID || payload.Data.Slug != post.Slug.String() {
+		t.Errorf("payload = %+v, want the published post", payload)
+	}
+
+	deliveries, _ := webhooks.Deliveries(hook.ID, 0)
+	for _, d := range deliveries {
+		if d.Status != domain.DeliverySucceeded || d.Attempts != 1 || d.ResponseCode != http.StatusOK {
+			t.Errorf("delivery = %+v, want succeeded after one attempt", d)
+		}
+	}
+}
+
+func TestWebhookService_EventFilter(t *testing.T) {
+	webhooks, postService, receiver, server := setupWebhookService(t, http.StatusOK)
+
+	webhooks.Create(CreateWebhookInput{URL: server.URL, Events: []string{"post.updated"}})
+	disabled, _ := webhooks.Create(CreateWebhookInput{URL: server.URL, Events: []string{"post.published", "post.updated"}})
+	inactive := false
+	webhooks.Update(disabled.ID, UpdateWebhookInput{Active: &inactive})
+
+	// 草稿的保存与删除不触发事件
+	draft, _ := postService.CreatePost(CreatePostInput{Title: "Draft", Content: "Content"})
+	postService.DeletePost(draft.ID)
+
+	post, _ := postService.CreatePost(CreatePostInput{Title: "Hello", Content: "Content"})
+	published := "published"
+	post, _ = postService.UpdatePost(post.ID, UpdatePostInput{Status: &published}, post.Version)
+	title := "Renamed"
+	postService.UpdatePost(post.ID, UpdatePostInput{Title: &title}, post.Version)
+
+	webhooks.DeliverDue()
+	requests := receiver.received()
+	if len(requests) != 1 || requests[0].event != "post.updated" {
+		t.Fatalf("received %v, want a single post.updated", requests)
+	}
+
+	if _, err := webhooks.Create(CreateWebhookInput{URL: server.URL, Events: []string{"post.exploded"}}); err != domain.ErrInvalidWebhookEvent {
+		t.Errorf("Create() with unknown event error = %v, want ErrInvalidWebhookEvent", err)
+	}
+	if _, err := webhooks.Create(CreateWebhookInput{URL: "ftp://example.com", Events: []string{"post.updated"}}); err != domain.ErrInvalidWebhookURL {
+		t.Errorf("Create() with ftp url error = %v, want ErrInvalidWebhookURL", err)
+	}
+}
+
+func TestWebhookService_RetryBackoff(t *testing.T) {
+	webhooks, _, receiver, server := setupWebhookService(t, http.StatusInternalServerError)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	webhooks.now = func() time.Time { return now }
+
+	hook, _ := webhooks.Create(CreateWebhookInput{URL: server.URL, Events: []string{"comment.created"}})
+	webhooks.CommentCreated(&domain.Comment{ID: "c1", PostID: "p1", AuthorName: "Alice"})
+
+	webhooks.DeliverDue()
+	deliveries, _ := webhooks.Deliveries(hook.ID, 0)
+	if len(deliveries) != 1 {
+		t.Fatalf("Deliveries() = %d, want 1", len(deliveries))
+	}
+	d := deliveries[0]
+	if d.Status != domain.DeliveryPending || d.Attempts != 1 || d.ResponseCode != 500 || !d.NextAttemptAt.Equal(now.Add(time.Minute)) {
+		t.Fatalf("after first failure delivery = %+v, want pending and retried in 1m", d)
+	}
+
+	// 未到重试时间不投递
+	if n, _ := webhooks.DeliverDue(); n != 0 {
+		t.Errorf("DeliverDue() before backoff = %d, want 0", n)
+	}
+
+	// 间隔依次为 1、2、4、8 分钟，第 5 次失败后不再重试
+	for attempt := 2; attempt <= MaxDeliveryAttempts; attempt++ {
+		now = now.Add(time.Minute << (attempt - 2))
+		if n, _ := webhooks.DeliverDue(); n != 1 {
+			t.Fatalf("attempt %d: DeliverDue() = %d, want 1", attempt, n)
+		}
+	}
+	deliveries, _ = webhooks.Deliveries(hook.ID, 0)
+	if d := deliveries[0]; d.Status != domain.DeliveryFailed || d.Attempts != MaxDeliveryAttempts {
+		t.Errorf("delivery = %+v, want failed after %d attempts", d, MaxDeliveryAttempts)
+	}
+	now = now.Add(time.Hour)
+	if n, _ := webhooks.DeliverDue(); n != 0 {
+		t.Errorf("DeliverDue() after failure = %d, want 0", n)
+	}
+	if got := len(receiver.received()); got != MaxDeliveryAttempts {
+		t.Errorf("receiver got %d requests, want %d", got, MaxDeliveryAttempts)
+	}
+}
+
+func TestWebhookService_Redeliver(t *testing.T) {
+	webhooks, _, receiver, server := setupWebhookService(t, http.StatusBadGateway)
+
+	hook, _ := webhooks.Create(CreateWebhookInput{URL: server.URL, Events: []string{"comment.created"}})
+	webhooks.CommentCreated(&domain.Comment{ID: "c1", PostID: "p1", AuthorName: "Alice"})
+	webhooks.DeliverDue()
+	deliveries, _ := webhooks.Deliveries(hook.ID, 0)
+	original := deliveries[0]
+
+	receiver.mu.Lock()
+	receiver.status = http.StatusNoContent
+	receiver.mu.Unlock()
+
+	redelivered, err := webhooks.Redeliver(original.ID)
+	if err != nil {
+		t.Fatalf("Redeliver() error = %v", err)
+	}
+	if redelivered.Status != domain.DeliverySucceeded || redelivered.RedeliveryOf != original.ID || redelivered.Payload != original.Payload {
+		t.Errorf("Redeliver() = %+v, want a succeeded copy of %s", redelivered, original.ID)
+	}
+
+	requests := receiver.received()
+	if len(requests) != 2 || string(requests[0].body) != string(requests[1].body) {
+		t.Errorf("redelivery should resend the original body, got %d requests", len(requests))
+	}
+	if deliveries, _ := webhooks.Deliveries(hook.ID, 0); len(deliveries) != 2 || deliveries[0].ID != redelivered.ID {
+		t.Errorf("Deliveries() should list the redelivery first, got %v", deliveries)
+	}
+
+	if _, err := webhooks.Redeliver("missing"); err != repository.ErrDeliveryNotFound {
+		t.Errorf("Redeliver(missing) error = %v, want ErrDeliveryNotFound", err)
+	}
+}
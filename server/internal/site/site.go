@@ -0,0 +1,283 @@
+package site
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/next-ai-ventus/server/internal/mail"
+	"github.com/next-ai-ventus/server/internal/storage"
+)
+
+var ErrInvalidConfig = errors.New("invalid site config")
+
+// DefaultSiteName 未配置站点名称时使用的名称
+const DefaultSiteName = "Ventus Blog"
+
+// idRegex 站点 ID 只允许小写字母、数字与连字符
+var idRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Config 站点配置文件（SITES_CONFIG）
+type Config struct {
+	Sites []*Definition `json:"sites"`
+	// TrustedProxies 可信反向代理的 IP 或网段，只采信来自这些地址的 X-Forwarded-For；
+	// 为空时不信任任何代理，客户端 IP 取连接地址
+	TrustedProxies []string `json:"trustedProxies"`
+}
+
+// Definition 单个站点的定义
+type Definition struct {
+	ID          string         `json:"id"`
+	Hosts       []string       `json:"hosts"`       // 匹配的 Host（不含端口），为空表示不限
+	PathPrefix  string         `json:"pathPrefix"`  // 路径前缀（如 "/blog"），为空表示不限
+	URL         string         `json:"url"`         // 站点对外地址（如 https://blog.example.com），用于邮件中的链接
+	ContentPath string         `json:"contentPath"` // 文章目录
+	UploadsPath string         `json:"uploadsPath"` // 上传目录，默认 ./storage/<id>/uploads（本地存储时使用）
+	Storage     storage.Config `json:"storage"`     // 上传文件的存储，默认本地目录 UploadsPath
+	Mail        mail.Config    `json:"mail"`        // 邮件发送（新文章通知），默认不发送
+	JWTSecret   string         `json:"jwtSecret"`   // 默认使用 JWT_SECRET
+	JWTAudience string         `json:"jwtAudience"` // 令牌的 aud，默认为站点 ID；其他站点签发的令牌不被接受
+	Users       []User         `json:"users"`
+	Settings    Settings       `json:"settings"`
+}
+
+// User 站点管理员账号
+type User struct {
+	Username     string `json:"username"`
+	Password     string `json:"password,omitempty"`     // 明文密码
+	PasswordHash string `json:"passwordHash,omitempty"` // bcrypt 哈希（优先使用）
+}
+
+// Settings 站点设置
+type Settings struct {
+	Name        string `json:"name"`
+	Logo        string `json:"logo"`
+	Description string `json:"description"`
+	TimeZone    string `json:"timeZone"` // IANA 时区（如 "Asia/Shanghai"），用于归档与日期显示，默认 UTC
+}
+
+// Location 返回站点时区，未配置或无法识别时为 UTC（配置经 Validate 校验后不会无法识别）
+func (s Settings) Location() *time.Location {
+	loc, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// LoadConfig 读取并校验站点配置文件，defaultSecret 用于未配置 jwtSecret 的站点
+func LoadConfig(path, defaultSecret string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read site config failed: %w", err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	if err := cfg.Validate(defaultSecret); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Validate 校验站点定义并补全默认值
+func (c *Config) Validate(defaultSecret string) error {
+	if len(c.Sites) == 0 {
+		return fmt.Errorf("%w: no sites defined", ErrInvalidConfig)
+	}
+	for _, proxy := range c.TrustedProxies {
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			return fmt.Errorf("%w: trusted proxy %q", ErrInvalidConfig, proxy)
+		}
+	}
+
+	ids := make(map[string]bool)
+	routes := make(map[string]string)  // host + prefix -> id
+	paths := make(map[string]string)   // 内容与上传目录 -> id
+	buckets := make(map[string]string) // S3 bucket 与前缀 -> id
+	for _, def := range c.Sites {
+		if !idRegex.MatchString(def.ID) {
+			return fmt.Errorf("%w: site id %q", ErrInvalidConfig, def.ID)
+		}
+		if ids[def.ID] {
+			return fmt.Errorf("%w: duplicate site id %q", ErrInvalidConfig, def.ID)
+		}
+		ids[def.ID] = true
+
+		if err := def.normalize(defaultSecret); err != nil {
+			return err
+		}
+
+		hosts := def.Hosts
+		if len(hosts) == 0 {
+			hosts = []string{""}
+		}
+		for _, host := range hosts {
+			key := host + def.PathPrefix
+			if other, ok := routes[key]; ok {
+				return fmt.Errorf("%w: sites %q and %q have the same host and path prefix", ErrInvalidConfig, other, def.ID)
+			}
+			routes[key] = def.ID
+		}
+
+		// 站点之间不能共享目录，否则索引与备份会互相覆盖
+		dirs := []string{def.ContentPath}
+		if def.Storage.Type == storage.TypeS3 {
+			s3 := def.Storage.S3
+			key := strings.TrimRight(s3.Endpoint, "/") + "/" + s3.Bucket + "/" + strings.Trim(s3.Prefix, "/")
+			if other, ok := buckets[key]; ok {
+				return fmt.Errorf("%w: sites %q and %q share storage bucket and prefix", ErrInvalidConfig, other, def.ID)
+			}
+			buckets[key] = def.ID
+		} else {
+			dirs = append(dirs, def.UploadsPath)
+		}
+		for _, dir := range dirs {
+			abs, err := filepath.Abs(dir)
+			if err != nil {
+				return fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+			}
+			if other, ok := paths[abs]; ok {
+				return fmt.Errorf("%w: sites %q and %q share directory %s", ErrInvalidConfig, other, def.ID, dir)
+			}
+			paths[abs] = def.ID
+		}
+	}
+	return nil
+}
+
+// normalize 校验单个站点并补全默认值
+func (d *Definition) normalize(defaultSecret string) error {
+	if d.ContentPath == "" {
+		return fmt.Errorf("%w: site %q has no contentPath", ErrInvalidConfig, d.ID)
+	}
+	if d.UploadsPath == "" {
+		d.UploadsPath = filepath.Join("storage", d.ID, "uploads")
+	}
+	switch d.Storage.Type {
+	case "", storage.TypeLocal:
+		d.Storage.Type = storage.TypeLocal
+	case storage.TypeS3:
+		if d.Storage.S3.Endpoint == "" || d.Storage.S3.Bucket == "" {
+			return fmt.Errorf("%w: site %q s3 storage requires endpoint and bucket", ErrInvalidConfig, d.ID)
+		}
+	default:
+		return fmt.Errorf("%w: site %q storage type %q", ErrInvalidConfig, d.ID, d.Storage.Type)
+	}
+	switch d.Mail.Type {
+	case "":
+	case mail.TypeSMTP, mail.TypeFile:
+		if d.Mail.From == "" || d.URL == "" {
+			return fmt.Errorf("%w: site %q mail requires from and url", ErrInvalidConfig, d.ID)
+		}
+		if d.Mail.Type == mail.TypeSMTP && d.Mail.SMTP.Host == "" {
+			return fmt.Errorf("%w: site %q smtp mail requires host", ErrInvalidConfig, d.ID)
+		}
+		if d.Mail.Type == mail.TypeFile && d.Mail.Dir == "" {
+			d.Mail.Dir = filepath.Join("storage", d.ID, "mail")
+		}
+	default:
+		return fmt.Errorf("%w: site %q mail type %q", ErrInvalidConfig, d.ID, d.Mail.Type)
+	}
+	d.URL = strings.TrimRight(d.URL, "/")
+	if d.JWTSecret == "" {
+		d.JWTSecret = defaultSecret
+	}
+	if d.JWTSecret == "" {
+		return fmt.Errorf("%w: site %q has no jwtSecret", ErrInvalidConfig, d.ID)
+	}
+	if d.JWTAudience == "" {
+		d.JWTAudience = d.ID
+	}
+	if d.Settings.Name == "" {
+		d.Settings.Name = DefaultSiteName
+	}
+	if _, err := time.LoadLocation(d.Settings.TimeZone); err != nil {
+		return fmt.Errorf("%w: site %q time zone %q", ErrInvalidConfig, d.ID, d.Settings.TimeZone)
+	}
+
+	if d.PathPrefix != "" {
+		prefix := "/" + strings.Trim(d.PathPrefix, "/")
+		if prefix == "/" || strings.ContainsAny(prefix, "?#") {
+			return fmt.Errorf("%w: site %q path prefix %q", ErrInvalidConfig, d.ID, d.PathPrefix)
+		}
+		d.PathPrefix = prefix
+	}
+	for i, host := range d.Hosts {
+		d.Hosts[i] = normalizeHost(host)
+		if d.Hosts[i] == "" {
+			return fmt.Errorf("%w: site %q has an empty host", ErrInvalidConfig, d.ID)
+		}
+	}
+
+	if len(d.Users) == 0 {
+		return fmt.Errorf("%w: site %q has no users", ErrInvalidConfig, d.ID)
+	}
+	for _, user := range d.Users {
+		if user.Username == "" || (user.Password == "" && user.PasswordHash == "") {
+			return fmt.Errorf("%w: site %q has a user without username or password", ErrInvalidConfig, d.ID)
+		}
+	}
+	return nil
+}
+
+// ForwardedPrefixHeader 站点路由按路径前缀分发时，记录被去掉的前缀
+const ForwardedPrefixHeader = "X-Forwarded-Prefix"
+
+// Match 按 Host 与路径查找站点，返回站点与需要去掉的路径前缀。
+// 同时匹配 Host 与前缀的站点优先，其次是只匹配 Host、只匹配前缀，最后是两者都不限的站点
+func (c *Config) Match(host, path string) (*Definition, string) {
+	host = normalizeHost(host)
+
+	var best *Definition
+	bestScore := -1
+	for _, def := range c.Sites {
+		score := 0
+		if len(def.Hosts) > 0 {
+			if !containsHost(def.Hosts, host) {
+				continue
+			}
+			score += 2
+		}
+		if def.PathPrefix != "" {
+			if path != def.PathPrefix && !strings.HasPrefix(path, def.PathPrefix+"/") {
+				continue
+			}
+			score++
+		}
+		// 同等条件下前缀更长的更具体
+		if score > bestScore || (score == bestScore && best != nil && len(def.PathPrefix) > len(best.PathPrefix)) {
+			best, bestScore = def, score
+		}
+	}
+	if best == nil {
+		return nil, ""
+	}
+	return best, best.PathPrefix
+}
+
+// normalizeHost 去掉端口并转为小写
+func normalizeHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.TrimSuffix(host, ".")
+}
+
+func containsHost(hosts []string, host string) bool {
+	for _, h := range hosts {
+		if h == host {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,214 @@
+package site
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/next-ai-ventus/server/internal/mail"
+	"github.com/next-ai-ventus/server/internal/storage"
+)
+
+func testSite(id string) *Definition {
+	return &Definition{
+		ID:          id,
+		ContentPath: filepath.Join("content", id),
+		Users:       []User{{Username: "admin", Password: "secret"}},
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sites.json")
+	data := `{"sites": [
+		{"id": "blog", "hosts": ["Blog.Example.com:8080"], "contentPath": "./content/blog",
+		 "url": "https://blog.example.com/", "mail": {"type": "file", "from": "news@example.com"},
+		 "users": [{"username": "alice", "password": "pw"}], "settings": {"name": "Alice"}},
+		{"id": "notes", "pathPrefix": "notes/", "contentPath": "./content/notes",
+		 "users": [{"username": "bob", "password": "pw"}]}
+	]}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(path, "default-secret")
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	blog, notes := cfg.Sites[0], cfg.Sites[1]
+	if blog.Hosts[0] != "blog.example.com" {
+		t.Errorf("host = %q, want blog.example.com", blog.Hosts[0])
+	}
+	if blog.Settings.Name != "Alice" || notes.Settings.Name != DefaultSiteName {
+		t.Errorf("names = %q, %q", blog.Settings.Name, notes.Settings.Name)
+	}
+	if notes.PathPrefix != "/notes" {
+		t.Errorf("prefix = %q, want /notes", notes.PathPrefix)
+	}
+	if notes.UploadsPath != filepath.Join("storage", "notes", "uploads") {
+		t.Errorf("uploads = %q", notes.UploadsPath)
+	}
+	if blog.URL != "https://blog.example.com" || blog.Mail.Dir != filepath.Join("storage", "blog", "mail") {
+		t.Errorf("url = %q, mail dir = %q", blog.URL, blog.Mail.Dir)
+	}
+	if notes.JWTSecret != "default-secret" || notes.JWTAudience != "notes" {
+		t.Errorf("secret = %q, audience = %q", notes.JWTSecret, notes.JWTAudience)
+	}
+}
+
+func TestConfig_ValidateErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		sites func() []*Definition
+	}{
+		{name: "no sites", sites: func() []*Definition { return nil }},
+		{name: "bad id", sites: func() []*Definition { return []*Definition{testSite("Bad_ID")} }},
+		{name: "duplicate id", sites: func() []*Definition {
+			b := testSite("a")
+			b.ContentPath = "other"
+			return []*Definition{testSite("a"), b}
+		}},
+		{name: "same route", sites: func() []*Definition {
+			a, b := testSite("a"), testSite("b")
+			a.Hosts, b.Hosts = []string{"example.com"}, []string{"EXAMPLE.com"}
+			return []*Definition{a, b}
+		}},
+		{name: "shared content", sites: func() []*Definition {
+			a, b := testSite("a"), testSite("b")
+			b.PathPrefix = "/b"
+			b.ContentPath = a.ContentPath
+			return []*Definition{a, b}
+		}},
+		{name: "no users", sites: func() []*Definition {
+			a := testSite("a")
+			a.Users = nil
+			return []*Definition{a}
+		}},
+		{name: "root prefix", sites: func() []*Definition {
+			a := testSite("a")
+			a.PathPrefix = "/"
+			return []*Definition{a}
+		}},
+		{name: "bad time zone", sites: func() []*Definition {
+			a := testSite("a")
+			a.Settings.TimeZone = "Mars/Olympus"
+			return []*Definition{a}
+		}},
+		{name: "unknown storage", sites: func() []*Definition {
+			a := testSite("a")
+			a.Storage.Type = "ftp"
+			return []*Definition{a}
+		}},
+		{name: "unknown mail", sites: func() []*Definition {
+			a := testSite("a")
+			a.Mail.Type = "pigeon"
+			return []*Definition{a}
+		}},
+		{name: "mail without url", sites: func() []*Definition {
+			a := testSite("a")
+			a.Mail = mail.Config{Type: mail.TypeFile, From: "news@example.com"}
+			return []*Definition{a}
+		}},
+		{name: "shared bucket", sites: func() []*Definition {
+			a, b := testSite("a"), testSite("b")
+			b.PathPrefix = "/b"
+			a.Storage = s3Storage("blog/")
+			b.Storage = s3Storage("/blog")
+			return []*Definition{a, b}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Sites: tt.sites()}
+			if err := cfg.Validate("secret"); !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("Validate() error = %v, want ErrInvalidConfig", err)
+			}
+		})
+	}
+
+	cfg := &Config{Sites: []*Definition{testSite("a")}, TrustedProxies: []string{"10.0.0.0/8", "192.0.2.1", "proxy.local"}}
+	if err := cfg.Validate("secret"); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Validate() with a host name as trusted proxy error = %v, want ErrInvalidConfig", err)
+	}
+	cfg.TrustedProxies = cfg.TrustedProxies[:2]
+	if err := cfg.Validate("secret"); err != nil {
+		t.Errorf("Validate() with valid trusted proxies error = %v", err)
+	}
+}
+
+func TestConfig_Match(t *testing.T) {
+	fallback := testSite("fallback")
+	host := testSite("host")
+	host.Hosts = []string{"a.example.com"}
+	hostPrefix := testSite("host-prefix")
+	hostPrefix.Hosts = []string{"a.example.com"}
+	hostPrefix.PathPrefix = "/docs"
+	prefix := testSite("prefix")
+	prefix.PathPrefix = "/notes"
+	nested := testSite("nested")
+	nested.PathPrefix = "/notes/old"
+
+	cfg := &Config{Sites: []*Definition{fallback, host, hostPrefix, prefix, nested}}
+	if err := cfg.Validate("secret"); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	tests := []struct {
+		host, path string
+		wantID     string
+		wantPrefix string
+	}{
+		{host: "a.example.com:443", path: "/docs/post/x", wantID: "host-prefix", wantPrefix: "/docs"},
+		{host: "A.example.com", path: "/post/x", wantID: "host"},
+		{host: "a.example.com", path: "/docsx", wantID: "host"},
+		{host: "b.example.com", path: "/notes", wantID: "prefix", wantPrefix: "/notes"},
+		{host: "b.example.com", path: "/notes/old/post/x", wantID: "nested", wantPrefix: "/notes/old"},
+		{host: "b.example.com", path: "/docs/post/x", wantID: "fallback"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host+tt.path, func(t *testing.T) {
+			def, gotPrefix := cfg.Match(tt.host, tt.path)
+			if def == nil || def.ID != tt.wantID || gotPrefix != tt.wantPrefix {
+				t.Errorf("Match() = %v, %q, want %s, %q", def, gotPrefix, tt.wantID, tt.wantPrefix)
+			}
+		})
+	}
+
+	only := &Config{Sites: []*Definition{host}}
+	if def, _ := only.Match("other.example.com", "/"); def != nil {
+		t.Errorf("Match() = %s, want no site", def.ID)
+	}
+}
+
+func TestConfig_ValidateS3Storage(t *testing.T) {
+	// S3 站点不使用本地上传目录，相同的 uploadsPath 不算冲突
+	a, b := testSite("a"), testSite("b")
+	b.PathPrefix = "/b"
+	a.UploadsPath, b.UploadsPath = "uploads", "uploads"
+	a.Storage = s3Storage("a")
+	b.Storage = s3Storage("b")
+
+	cfg := &Config{Sites: []*Definition{a, b}}
+	if err := cfg.Validate("secret"); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	c := testSite("c")
+	if err := (&Config{Sites: []*Definition{c}}).Validate("secret"); err != nil {
+		t.Fatal(err)
+	}
+	if c.Storage.Type != storage.TypeLocal {
+		t.Errorf("default storage = %q, want %q", c.Storage.Type, storage.TypeLocal)
+	}
+}
+
+func s3Storage(prefix string) storage.Config {
+	return storage.Config{Type: storage.TypeS3, S3: storage.S3Options{
+		Endpoint: "http://localhost:9000",
+		Bucket:   "media",
+		Prefix:   prefix,
+	}}
+}
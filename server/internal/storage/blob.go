@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// Info 对象的元数据
+type Info struct {
+	Key         string    `json:"key"`
+	Size        int64     `json:"size"`
+	ModTime     time.Time `json:"modTime"`
+	ContentType string    `json:"contentType"`
+}
+
+// Blob 上传文件的存储。键是以 "/" 分隔的相对路径（如 "2024/06/20240601_cover.png"），
+// 对应的访问地址为 /uploads/<key>
+type Blob interface {
+	// Put 写入对象，已存在时覆盖
+	Put(key string, r io.Reader, contentType string) error
+
+	// Get 读取对象，调用方负责关闭；不存在时返回 ErrNotFound
+	Get(key string) (io.ReadCloser, *Info, error)
+
+	// Stat 读取对象元数据；不存在时返回 ErrNotFound
+	Stat(key string) (*Info, error)
+
+	// Delete 删除对象，不存在时不报错
+	Delete(key string) error
+
+	// List 按键的字典序列出以 prefix 开头的全部对象
+	List(prefix string) ([]Info, error)
+
+	// SignedURL 返回在 expires 内可直接访问对象的地址
+	SignedURL(key string, expires time.Duration) (string, error)
+}
+
+// Redirector 读取时应重定向到 SignedURL、而不是由服务端转发内容的存储
+type Redirector interface {
+	RedirectReads() bool
+}
+
+// CleanKey 校验并规范化键：不能为空、不能以 "/" 开头、不能包含 ".." 路径段
+func CleanKey(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	clean := path.Clean(key)
+	if clean != key || clean == "." || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	return clean, nil
+}
+
+// ContentType 根据扩展名推断内容类型
+func ContentType(key string) string {
+	if ct := mime.TypeByExtension(path.Ext(key)); ct != "" {
+		return ct
+	}
+	return "application/octet-stream"
+}
@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// runBlobContract 对任意 Blob 实现运行一致性测试，blob 必须为空
+func runBlobContract(t *testing.T, blob Blob) {
+	t.Helper()
+
+	files := map[string]string{
+		"2024/06/cover.png":     "png data",
+		"2024/06/photo 1.jpg":   "jpg data",
+		"2024/07/中文.gif":        "gif data",
+		"imported/p1/image.png": "imported",
+	}
+	for key, content := range files {
+		if err := blob.Put(key, strings.NewReader(content), ""); err != nil {
+			t.Fatalf("Put(%q) error = %v", key, err)
+		}
+	}
+
+	t.Run("get", func(t *testing.T) {
+		r, info, err := blob.Get("2024/06/photo 1.jpg")
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		data, _ := io.ReadAll(r)
+		r.Close()
+		if string(data) != "jpg data" || info.Size != int64(len("jpg data")) {
+			t.Errorf("Get() = %q, size %d", data, info.Size)
+		}
+		if info.ContentType != "image/jpeg" {
+			t.Errorf("ContentType = %q, want image/jpeg", info.ContentType)
+		}
+		if _, _, err := blob.Get("2024/06/missing.png"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Get(missing) error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("stat", func(t *testing.T) {
+		info, err := blob.Stat("2024/07/中文.gif")
+		if err != nil || info.Size != int64(len("gif data")) || info.ModTime.IsZero() {
+			t.Errorf("Stat() = %+v, %v", info, err)
+		}
+		if _, err := blob.Stat("2024"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Stat(directory) error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		all, err := blob.List("")
+		if err != nil || len(all) != len(files) {
+			t.Fatalf("List() = %v, %v", all, err)
+		}
+		june, err := blob.List("2024/06/")
+		if err != nil || len(june) != 2 || june[0].Key != "2024/06/cover.png" || june[1].Key != "2024/06/photo 1.jpg" {
+			t.Errorf("List(2024/06/) = %+v, %v", june, err)
+		}
+	})
+
+	t.Run("overwrite and delete", func(t *testing.T) {
+		if err := blob.Put("2024/06/cover.png", strings.NewReader("new"), "image/png"); err != nil {
+			t.Fatalf("Put() error = %v", err)
+		}
+		if info, _ := blob.Stat("2024/06/cover.png"); info == nil || info.Size != 3 {
+			t.Errorf("Stat() after overwrite = %+v", info)
+		}
+
+		if err := blob.Delete("2024/06/cover.png"); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if err := blob.Delete("2024/06/cover.png"); err != nil {
+			t.Errorf("Delete(missing) error = %v", err)
+		}
+		if _, err := blob.Stat("2024/06/cover.png"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Stat() after delete error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("invalid keys", func(t *testing.T) {
+		for _, key := range []string{"", "/abs.png", "../escape.png", "a/../../b.png", "a//b.png"} {
+			if err := blob.Put(key, strings.NewReader("x"), ""); !errors.Is(err, ErrInvalidKey) {
+				t.Errorf("Put(%q) error = %v, want ErrInvalidKey", key, err)
+			}
+		}
+	})
+}
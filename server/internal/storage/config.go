@@ -0,0 +1,30 @@
+package storage
+
+import "fmt"
+
+// 存储类型
+const (
+	TypeLocal = "local"
+	TypeS3    = "s3"
+)
+
+// URLPrefix 上传文件对外的访问地址前缀
+const URLPrefix = "/uploads"
+
+// Config 上传文件的存储配置
+type Config struct {
+	Type string    `json:"type"` // "local"（默认）或 "s3"
+	S3   S3Options `json:"s3"`
+}
+
+// New 按配置创建存储，本地存储使用 localDir 目录
+func New(cfg Config, localDir string) (Blob, error) {
+	switch cfg.Type {
+	case "", TypeLocal:
+		return NewLocal(localDir, URLPrefix), nil
+	case TypeS3:
+		return NewS3(cfg.S3, nil)
+	default:
+		return nil, fmt.Errorf("unknown storage type %q", cfg.Type)
+	}
+}
@@ -0,0 +1,199 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// tmpPrefix 写入中的临时文件前缀，List 时跳过
+const tmpPrefix = ".tmp-"
+
+// Local 本地文件系统存储
+type Local struct {
+	dir       string
+	urlPrefix string
+}
+
+// NewLocal 创建本地存储，对象保存在 dir 下，urlPrefix 为对外访问地址前缀（如 "/uploads"）
+func NewLocal(dir, urlPrefix string) *Local {
+	return &Local{
+		dir:       dir,
+		urlPrefix: strings.TrimRight(urlPrefix, "/"),
+	}
+}
+
+// Dir 返回存储目录
+func (l *Local) Dir() string {
+	return l.dir
+}
+
+// Put 先写入临时文件再重命名，读取方不会看到写了一半的文件
+func (l *Local) Put(key string, r io.Reader, contentType string) error {
+	target, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("create directory failed: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), tmpPrefix+"*")
+	if err != nil {
+		return fmt.Errorf("create file failed: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write file failed: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write file failed: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), target)
+}
+
+// Get 打开文件；返回的 ReadCloser 是 *os.File，可用于 http.ServeContent
+func (l *Local) Get(key string) (io.ReadCloser, *Info, error) {
+	target, err := l.path(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	f, err := os.Open(target)
+	if err != nil {
+		return nil, nil, notFound(err)
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	if !stat.Mode().IsRegular() {
+		f.Close()
+		return nil, nil, ErrNotFound
+	}
+	return f, fileInfo(key, stat), nil
+}
+
+// Stat 读取文件信息
+func (l *Local) Stat(key string) (*Info, error) {
+	target, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	stat, err := os.Stat(target)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	if !stat.Mode().IsRegular() {
+		return nil, ErrNotFound
+	}
+	return fileInfo(key, stat), nil
+}
+
+// Delete 删除文件，并清理因此变空的上级目录
+func (l *Local) Delete(key string) error {
+	target, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for dir := filepath.Dir(target); dir != filepath.Clean(l.dir); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			break
+		}
+	}
+	return nil
+}
+
+// List 遍历存储目录（目录不存在时返回空列表）
+func (l *Local) List(prefix string) ([]Info, error) {
+	infos := []Info{}
+	err := filepath.WalkDir(l.dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && p == l.dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if !d.Type().IsRegular() || strings.HasPrefix(d.Name(), tmpPrefix) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(l.dir, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		stat, err := d.Info()
+		if err != nil {
+			return err
+		}
+		infos = append(infos, *fileInfo(key, stat))
+		return nil
+	})
+	return infos, err
+}
+
+// SignedURL 本地文件通过 urlPrefix 公开访问，直接返回访问地址（不会过期）
+func (l *Local) SignedURL(key string, expires time.Duration) (string, error) {
+	clean, err := CleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return l.urlPrefix + "/" + escapeKey(clean), nil
+}
+
+// path 返回键对应的文件路径
+func (l *Local) path(key string) (string, error) {
+	clean, err := CleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(clean)), nil
+}
+
+// fileInfo 由文件信息生成对象元数据
+func fileInfo(key string, stat os.FileInfo) *Info {
+	return &Info{
+		Key:         key,
+		Size:        stat.Size(),
+		ModTime:     stat.ModTime(),
+		ContentType: ContentType(key),
+	}
+}
+
+// notFound 将文件不存在转换为 ErrNotFound
+func notFound(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// escapeKey 逐段转义键，保留分隔符 "/"
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLocal_Contract(t *testing.T) {
+	runBlobContract(t, NewLocal(filepath.Join(t.TempDir(), "uploads"), "/uploads"))
+}
+
+func TestLocal_ListMissingDir(t *testing.T) {
+	blob := NewLocal(filepath.Join(t.TempDir(), "missing"), "/uploads")
+	infos, err := blob.List("")
+	if err != nil || len(infos) != 0 {
+		t.Errorf("List() = %v, %v, want empty", infos, err)
+	}
+}
+
+func TestLocal_SkipsTempFiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, tmpPrefix+"123"), []byte("partial"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	infos, _ := NewLocal(dir, "/uploads").List("")
+	if len(infos) != 0 {
+		t.Errorf("List() = %v, want temp files skipped", infos)
+	}
+}
+
+func TestLocal_SignedURL(t *testing.T) {
+	url, err := NewLocal(t.TempDir(), "/uploads/").SignedURL("2024/06/photo 1.jpg", time.Hour)
+	if err != nil || url != "/uploads/2024/06/photo%201.jpg" {
+		t.Errorf("SignedURL() = %q, %v", url, err)
+	}
+}
+
+func TestLocal_DeletePrunesEmptyDirs(t *testing.T) {
+	dir := t.TempDir()
+	blob := NewLocal(dir, "/uploads")
+	for _, key := range []string{"a/b/one.png", "a/two.png"} {
+		if err := blob.Put(key, strings.NewReader("x"), ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := blob.Delete("a/b/one.png"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "a", "b")); !os.IsNotExist(err) {
+		t.Errorf("empty directory a/b not removed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "a", "two.png")); err != nil {
+		t.Errorf("sibling removed: %v", err)
+	}
+
+	if err := blob.Delete("a/two.png"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(dir); err != nil {
+		t.Errorf("storage root removed: %v", err)
+	}
+}
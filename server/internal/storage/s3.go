@@ -0,0 +1,294 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// S3Options S3 兼容存储的配置
+type S3Options struct {
+	Endpoint  string `json:"endpoint"` // 如 https://s3.us-east-1.amazonaws.com 或 http://localhost:9000
+	Region    string `json:"region"`   // 默认 us-east-1
+	Bucket    string `json:"bucket"`
+	Prefix    string `json:"prefix"` // 对象键前缀（如 "blog/"），用于多个站点共用一个 bucket
+	AccessKey string `json:"accessKey"`
+	SecretKey string `json:"secretKey"`
+	PathStyle bool   `json:"pathStyle"` // 使用 endpoint/bucket/key 形式的地址（MinIO 等兼容存储通常需要）
+	Redirect  bool   `json:"redirect"`  // 访问 /uploads/ 时重定向到签名地址，而不是由服务端转发
+}
+
+// S3 S3 兼容的对象存储（使用 Signature V4 签名）
+type S3 struct {
+	opts     S3Options
+	endpoint *url.URL
+	signer   signer
+	client   *http.Client
+}
+
+// NewS3 创建 S3 存储，client 为 nil 时使用 http.DefaultClient
+func NewS3(opts S3Options, client *http.Client) (*S3, error) {
+	if opts.Endpoint == "" || opts.Bucket == "" {
+		return nil, fmt.Errorf("s3 storage requires endpoint and bucket")
+	}
+	endpoint, err := url.Parse(opts.Endpoint)
+	if err != nil || endpoint.Host == "" || (endpoint.Scheme != "http" && endpoint.Scheme != "https") {
+		return nil, fmt.Errorf("invalid s3 endpoint %q", opts.Endpoint)
+	}
+	if opts.Region == "" {
+		opts.Region = "us-east-1"
+	}
+	if opts.Prefix != "" {
+		opts.Prefix = strings.Trim(opts.Prefix, "/") + "/"
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &S3{
+		opts:     opts,
+		endpoint: endpoint,
+		signer: signer{
+			accessKey: opts.AccessKey,
+			secretKey: opts.SecretKey,
+			region:    opts.Region,
+			service:   "s3",
+		},
+		client: client,
+	}, nil
+}
+
+// RedirectReads 实现 Redirector
+func (s *S3) RedirectReads() bool {
+	return s.opts.Redirect
+}
+
+// Put 上传对象（内容先读入内存以计算签名所需的 SHA-256）
+func (s *S3) Put(key string, r io.Reader, contentType string) error {
+	u, err := s.objectURL(key)
+	if err != nil {
+		return err
+	}
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if contentType == "" {
+		contentType = ContentType(key)
+	}
+
+	req, err := http.NewRequest(http.MethodPut, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := s.do(req, body)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Get 下载对象
+func (s *S3) Get(key string) (io.ReadCloser, *Info, error) {
+	u, err := s.objectURL(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := s.do(req, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.Body, headerInfo(key, resp), nil
+}
+
+// Stat 使用 HEAD 读取对象元数据
+func (s *S3) Stat(key string) (*Info, error) {
+	u, err := s.objectURL(key)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodHead, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(req, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return headerInfo(key, resp), nil
+}
+
+// Delete 删除对象（S3 对不存在的对象同样返回成功）
+func (s *S3) Delete(key string) error {
+	u, err := s.objectURL(key)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodDelete, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// listResult ListObjectsV2 的响应
+type listResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List 使用 ListObjectsV2 分页列出对象
+func (s *S3) List(prefix string) ([]Info, error) {
+	infos := []Info{}
+	token := ""
+	for {
+		u := s.bucketURL()
+		query := url.Values{}
+		query.Set("list-type", "2")
+		query.Set("prefix", s.opts.Prefix+prefix)
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		u.RawQuery = query.Encode()
+
+		req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := s.do(req, nil)
+		if err != nil {
+			return nil, err
+		}
+		var result listResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("parse s3 list response failed: %w", err)
+		}
+
+		for _, item := range result.Contents {
+			key := strings.TrimPrefix(item.Key, s.opts.Prefix)
+			infos = append(infos, Info{
+				Key:         key,
+				Size:        item.Size,
+				ModTime:     item.LastModified,
+				ContentType: ContentType(key),
+			})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return infos, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// SignedURL 生成预签名的 GET 地址
+func (s *S3) SignedURL(key string, expires time.Duration) (string, error) {
+	u, err := s.objectURL(key)
+	if err != nil {
+		return "", err
+	}
+	return s.signer.presign(http.MethodGet, u, expires, time.Now()), nil
+}
+
+// bucketURL 返回 bucket 的地址
+func (s *S3) bucketURL() *url.URL {
+	u := *s.endpoint
+	base := strings.TrimRight(u.Path, "/")
+	if s.opts.PathStyle {
+		u.Path = base + "/" + s.opts.Bucket + "/"
+	} else {
+		u.Host = s.opts.Bucket + "." + u.Host
+		u.Path = base + "/"
+	}
+	u.RawPath = ""
+	return &u
+}
+
+// objectURL 返回对象的地址（键逐段转义）
+func (s *S3) objectURL(key string) (*url.URL, error) {
+	clean, err := CleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+	u := s.bucketURL()
+	name := s.opts.Prefix + clean
+	u.Path += name
+	u.RawPath = uriEncodePath(u.Path)
+	return u, nil
+}
+
+// s3Error S3 错误响应
+type s3Error struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// do 签名并发送请求；非 2xx 响应转换为错误（404 为 ErrNotFound）
+func (s *S3) do(req *http.Request, body []byte) (*http.Response, error) {
+	sum := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(sum[:])
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	s.signer.sign(req, payloadHash, time.Now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("s3 request failed: %w", err)
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	var e s3Error
+	if data, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024)); len(data) > 0 {
+		_ = xml.Unmarshal(data, &e)
+	}
+	if e.Code == "" {
+		e.Code = resp.Status
+	}
+	return nil, fmt.Errorf("s3 %s %s: %s %s", req.Method, req.URL.Path, e.Code, e.Message)
+}
+
+// headerInfo 从响应头读取对象元数据
+func headerInfo(key string, resp *http.Response) *Info {
+	info := &Info{Key: key, Size: resp.ContentLength, ContentType: resp.Header.Get("Content-Type")}
+	if size, err := strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64); err == nil {
+		info.Size = size
+	}
+	if t, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		info.ModTime = t
+	}
+	if info.ContentType == "" {
+		info.ContentType = ContentType(key)
+	}
+	return info
+}
@@ -0,0 +1,258 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3 本地的 S3 替身：只实现路径风格的对象读写与 ListObjectsV2，并校验每个请求的签名
+type fakeS3 struct {
+	t       *testing.T
+	bucket  string
+	signer  signer
+	pageLen int // 每页最多返回的对象数，用于测试分页
+
+	mu      sync.Mutex
+	objects map[string]fakeObject
+}
+
+type fakeObject struct {
+	data        []byte
+	contentType string
+	modTime     time.Time
+}
+
+func newFakeS3(t *testing.T, bucket string) (*fakeS3, *httptest.Server) {
+	f := &fakeS3{
+		t:       t,
+		bucket:  bucket,
+		signer:  signer{accessKey: "test-key", secretKey: "test-secret", region: "us-east-1", service: "s3"},
+		pageLen: 2,
+		objects: make(map[string]fakeObject),
+	}
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+	return f, server
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := f.verify(r); err != nil {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprintf(w, "<Error><Code>SignatureDoesNotMatch</Code><Message>%s</Message></Error>", err)
+		return
+	}
+
+	key := strings.TrimPrefix(r.URL.Path, "/"+f.bucket+"/")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && key == "":
+		f.list(w, r)
+	case r.Method == http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		f.objects[key] = fakeObject{data: data, contentType: r.Header.Get("Content-Type"), modTime: time.Now()}
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		obj, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, "<Error><Code>NoSuchKey</Code></Error>")
+			return
+		}
+		w.Header().Set("Content-Type", obj.contentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(obj.data)))
+		w.Header().Set("Last-Modified", obj.modTime.UTC().Format(http.TimeFormat))
+		if r.Method == http.MethodGet {
+			w.Write(obj.data)
+		}
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// list 实现 ListObjectsV2（continuation-token 为上一页最后一个键）
+func (f *fakeS3) list(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	prefix, after := query.Get("prefix"), query.Get("continuation-token")
+
+	var keys []string
+	for key := range f.objects {
+		if strings.HasPrefix(key, prefix) && key > after {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString("<ListBucketResult>")
+	truncated := len(keys) > f.pageLen
+	if truncated {
+		keys = keys[:f.pageLen]
+		fmt.Fprintf(&b, "<IsTruncated>true</IsTruncated><NextContinuationToken>%s</NextContinuationToken>", xmlEscape(keys[len(keys)-1]))
+	}
+	for _, key := range keys {
+		obj := f.objects[key]
+		fmt.Fprintf(&b, "<Contents><Key>%s</Key><Size>%d</Size><LastModified>%s</LastModified></Contents>",
+			xmlEscape(key), len(obj.data), obj.modTime.UTC().Format(time.RFC3339))
+	}
+	b.WriteString("</ListBucketResult>")
+	w.Write([]byte(b.String()))
+}
+
+// verify 按收到的请求重新计算签名并比较
+func (f *fakeS3) verify(r *http.Request) error {
+	if r.URL.Query().Get("X-Amz-Signature") != "" {
+		return f.verifyPresigned(r)
+	}
+
+	date, err := time.Parse(amzDateFormat, r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return fmt.Errorf("missing X-Amz-Date")
+	}
+	body, _ := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(strings.NewReader(string(body)))
+	sum := sha256.Sum256(body)
+	if hash := hex.EncodeToString(sum[:]); r.Header.Get("X-Amz-Content-Sha256") != hash {
+		return fmt.Errorf("payload hash mismatch")
+	}
+
+	check, _ := http.NewRequest(r.Method, "http://"+r.Host+r.URL.RequestURI(), nil)
+	for name, values := range r.Header {
+		lower := strings.ToLower(name)
+		if strings.HasPrefix(lower, "x-amz-") || lower == "content-type" {
+			check.Header[name] = values
+		}
+	}
+	f.signer.sign(check, r.Header.Get("X-Amz-Content-Sha256"), date)
+	if got, want := r.Header.Get("Authorization"), check.Header.Get("Authorization"); got != want {
+		return fmt.Errorf("authorization mismatch")
+	}
+	return nil
+}
+
+// verifyPresigned 校验预签名地址的签名与有效期
+func (f *fakeS3) verifyPresigned(r *http.Request) error {
+	query := r.URL.Query()
+	date, err := time.Parse(amzDateFormat, query.Get("X-Amz-Date"))
+	if err != nil {
+		return fmt.Errorf("missing X-Amz-Date")
+	}
+	expires, _ := strconv.Atoi(query.Get("X-Amz-Expires"))
+	if time.Now().After(date.Add(time.Duration(expires) * time.Second)) {
+		return fmt.Errorf("request has expired")
+	}
+
+	u := &url.URL{Scheme: "http", Host: r.Host, Path: r.URL.Path, RawPath: r.URL.RawPath}
+	want, _ := url.Parse(f.signer.presign(r.Method, u, time.Duration(expires)*time.Second, date))
+	if want.Query().Get("X-Amz-Signature") != query.Get("X-Amz-Signature") {
+		return fmt.Errorf("presigned signature mismatch")
+	}
+	return nil
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func newTestS3(t *testing.T, prefix string) (*S3, *fakeS3) {
+	fake, server := newFakeS3(t, "media")
+	blob, err := NewS3(S3Options{
+		Endpoint:  server.URL,
+		Bucket:    "media",
+		Prefix:    prefix,
+		AccessKey: "test-key",
+		SecretKey: "test-secret",
+		PathStyle: true,
+	}, server.Client())
+	if err != nil {
+		t.Fatalf("NewS3() error = %v", err)
+	}
+	return blob, fake
+}
+
+func TestS3_Contract(t *testing.T) {
+	blob, _ := newTestS3(t, "")
+	runBlobContract(t, blob)
+}
+
+func TestS3_Prefix(t *testing.T) {
+	blob, fake := newTestS3(t, "/site-a/")
+	if err := blob.Put("2024/06/a.png", strings.NewReader("a"), ""); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if _, ok := fake.objects["site-a/2024/06/a.png"]; !ok {
+		t.Errorf("objects = %v, want key under site-a/", fake.objects)
+	}
+	infos, err := blob.List("")
+	if err != nil || len(infos) != 1 || infos[0].Key != "2024/06/a.png" {
+		t.Errorf("List() = %+v, %v", infos, err)
+	}
+}
+
+func TestS3_SignedURL(t *testing.T) {
+	blob, _ := newTestS3(t, "")
+	if err := blob.Put("2024/06/photo 1.jpg", strings.NewReader("jpg"), ""); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	signed, err := blob.SignedURL("2024/06/photo 1.jpg", time.Minute)
+	if err != nil {
+		t.Fatalf("SignedURL() error = %v", err)
+	}
+	resp, err := http.Get(signed)
+	if err != nil {
+		t.Fatalf("GET signed URL error = %v", err)
+	}
+	data, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(data) != "jpg" {
+		t.Errorf("GET signed URL = %d %q", resp.StatusCode, data)
+	}
+
+	// 篡改后的地址被拒绝
+	resp, err = http.Get(strings.Replace(signed, "photo%201.jpg", "other.jpg", 1))
+	if err != nil {
+		t.Fatalf("GET tampered URL error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("GET tampered URL status = %d, want 403", resp.StatusCode)
+	}
+}
+
+func TestS3_WrongCredentials(t *testing.T) {
+	_, server := newFakeS3(t, "media")
+	blob, _ := NewS3(S3Options{Endpoint: server.URL, Bucket: "media", AccessKey: "test-key", SecretKey: "wrong", PathStyle: true}, server.Client())
+	err := blob.Put("a.png", strings.NewReader("a"), "")
+	if err == nil || !strings.Contains(err.Error(), "SignatureDoesNotMatch") {
+		t.Errorf("Put() error = %v, want SignatureDoesNotMatch", err)
+	}
+}
+
+func TestS3_VirtualHostedURL(t *testing.T) {
+	blob, err := NewS3(S3Options{Endpoint: "https://s3.example.com", Bucket: "media", Prefix: "blog"}, nil)
+	if err != nil {
+		t.Fatalf("NewS3() error = %v", err)
+	}
+	u, _ := blob.objectURL("2024/06/photo 1.jpg")
+	if got := u.String(); got != "https://media.s3.example.com/blog/2024/06/photo%201.jpg" {
+		t.Errorf("objectURL() = %s", got)
+	}
+}
@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// AWS Signature V4 测试套件中的 get-vanilla 用例
+func TestSigner_GetVanilla(t *testing.T) {
+	s := signer{
+		accessKey: "AKIDEXAMPLE",
+		secretKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		region:    "us-east-1",
+		service:   "service",
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	now := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+
+	s.sign(req, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", now)
+
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+		"SignedHeaders=host;x-amz-date, " +
+		"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("Authorization =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestURIEncode(t *testing.T) {
+	tests := map[string]string{
+		"photo 1.png":  "photo%201.png",
+		"a+b=c&d":      "a%2Bb%3Dc%26d",
+		"中文.png":       "%E4%B8%AD%E6%96%87.png",
+		"safe-_.~Name": "safe-_.~Name",
+	}
+	for in, want := range tests {
+		if got := uriEncode(in); got != want {
+			t.Errorf("uriEncode(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,360 @@
+package markdown
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	blankLinesRegex = regexp.MustCompile(`\n{3,}`)
+	spacesRegex     = regexp.MustCompile(`[ \t\r\f]+`)
+)
+
+// FromHTML 将 HTML 转换为 Markdown（用于导入其他博客系统的文章）。
+// 支持段落、标题、强调、链接、图片、列表、引用、代码块与表格；
+// 脚本、样式与注释会被丢弃，嵌入的视频等转换为链接。
+func FromHTML(source string) (string, error) {
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(source), body)
+	if err != nil {
+		return "", fmt.Errorf("parse html failed: %w", err)
+	}
+
+	c := &htmlConverter{}
+	for _, node := range nodes {
+		c.block(node)
+	}
+
+	result := blankLinesRegex.ReplaceAllString(c.out.String(), "\n\n")
+	return strings.TrimSpace(result), nil
+}
+
+// htmlConverter HTML 到 Markdown 的转换状态
+type htmlConverter struct {
+	out strings.Builder
+}
+
+// block 转换块级上下文中的节点
+func (c *htmlConverter) block(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		c.out.WriteString(normalizeText(n.Data))
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.Script, atom.Style, atom.Noscript:
+	case atom.P, atom.Div, atom.Section, atom.Article, atom.Header, atom.Footer, atom.Main, atom.Figure, atom.Aside:
+		c.paragraph()
+		c.children(n)
+		c.paragraph()
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		level := int(n.Data[1] - '0')
+		c.paragraph()
+		c.out.WriteString(strings.Repeat("#", level) + " " + singleLine(inlineText(n)))
+		c.paragraph()
+	case atom.Figcaption:
+		c.paragraph()
+		if text := singleLine(inlineText(n)); text != "" {
+			c.out.WriteString("*" + text + "*")
+		}
+		c.paragraph()
+	case atom.Hr:
+		c.paragraph()
+		c.out.WriteString("---")
+		c.paragraph()
+	case atom.Ul, atom.Ol:
+		c.paragraph()
+		c.out.WriteString(listMarkdown(n, 0))
+		c.paragraph()
+	case atom.Blockquote:
+		inner := &htmlConverter{}
+		inner.children(n)
+		text := strings.TrimSpace(blankLinesRegex.ReplaceAllString(inner.out.String(), "\n\n"))
+		c.paragraph()
+		for i, line := range strings.Split(text, "\n") {
+			if i > 0 {
+				c.out.WriteString("\n")
+			}
+			c.out.WriteString(strings.TrimRight("> "+line, " "))
+		}
+		c.paragraph()
+	case atom.Pre:
+		c.paragraph()
+		c.out.WriteString("```" + codeLanguage(n) + "\n")
+		c.out.WriteString(strings.TrimRight(textContent(n), "\n"))
+		c.out.WriteString("\n```")
+		c.paragraph()
+	case atom.Table:
+		c.paragraph()
+		c.out.WriteString(tableMarkdown(n))
+		c.paragraph()
+	default:
+		c.out.WriteString(inlineMarkdown(n))
+	}
+}
+
+// children 依次转换子节点
+func (c *htmlConverter) children(n *html.Node) {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		c.block(child)
+	}
+}
+
+// paragraph 结束当前段落（保证后面有一个空行）
+func (c *htmlConverter) paragraph() {
+	text := c.out.String()
+	if text == "" {
+		return
+	}
+	trimmed := strings.TrimRight(text, " \n")
+	c.out.Reset()
+	c.out.WriteString(trimmed)
+	c.out.WriteString("\n\n")
+}
+
+// inlineText 转换行内内容
+func inlineText(n *html.Node) string {
+	var b strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		b.WriteString(inlineMarkdown(child))
+	}
+	return b.String()
+}
+
+// inlineMarkdown 转换行内节点
+func inlineMarkdown(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		return normalizeText(n.Data)
+	case html.ElementNode:
+	default:
+		return ""
+	}
+
+	switch n.DataAtom {
+	case atom.Script, atom.Style, atom.Noscript:
+		return ""
+	case atom.Br:
+		return "  \n"
+	case atom.Strong, atom.B:
+		return wrapInline(inlineText(n), "**")
+	case atom.Em, atom.I:
+		return wrapInline(inlineText(n), "*")
+	case atom.Del, atom.S, atom.Strike:
+		return wrapInline(inlineText(n), "~~")
+	case atom.Code, atom.Kbd:
+		text := textContent(n)
+		if text == "" {
+			return ""
+		}
+		if strings.Contains(text, "`") {
+			return "`` " + text + " ``"
+		}
+		return "`" + text + "`"
+	case atom.A:
+		text := strings.TrimSpace(inlineText(n))
+		href := attr(n, "href")
+		if href == "" || text == "" {
+			return text
+		}
+		return "[" + text + "](" + href + titleSuffix(n) + ")"
+	case atom.Img:
+		src := attr(n, "src")
+		if src == "" {
+			return ""
+		}
+		return "![" + attr(n, "alt") + "](" + src + titleSuffix(n) + ")"
+	case atom.Iframe, atom.Video, atom.Audio, atom.Embed:
+		src := attr(n, "src")
+		if src == "" {
+			if source := findElement(n, atom.Source); source != nil {
+				src = attr(source, "src")
+			}
+		}
+		if src == "" {
+			return ""
+		}
+		return "[" + src + "](" + src + ")"
+	default:
+		return inlineText(n)
+	}
+}
+
+// listMarkdown 转换列表，depth 为嵌套层级
+func listMarkdown(list *html.Node, depth int) string {
+	var b strings.Builder
+	indent := strings.Repeat("  ", depth)
+	index := 1
+	for item := list.FirstChild; item != nil; item = item.NextSibling {
+		if item.Type != html.ElementNode || item.DataAtom != atom.Li {
+			continue
+		}
+
+		marker := "- "
+		if list.DataAtom == atom.Ol {
+			marker = fmt.Sprintf("%d. ", index)
+			index++
+		}
+
+		var text strings.Builder
+		var nested strings.Builder
+		for child := item.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type == html.ElementNode && (child.DataAtom == atom.Ul || child.DataAtom == atom.Ol) {
+				nested.WriteString(listMarkdown(child, depth+1))
+				continue
+			}
+			text.WriteString(inlineMarkdown(child))
+		}
+
+		b.WriteString(indent + marker + singleLine(text.String()) + "\n")
+		b.WriteString(nested.String())
+	}
+	return b.String()
+}
+
+// tableMarkdown 转换表格，第一行作为表头
+func tableMarkdown(table *html.Node) string {
+	var rows [][]string
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode {
+				continue
+			}
+			if child.DataAtom == atom.Tr {
+				var cells []string
+				for cell := child.FirstChild; cell != nil; cell = cell.NextSibling {
+					if cell.Type == html.ElementNode && (cell.DataAtom == atom.Td || cell.DataAtom == atom.Th) {
+						cells = append(cells, strings.ReplaceAll(singleLine(inlineText(cell)), "|", `\|`))
+					}
+				}
+				rows = append(rows, cells)
+				continue
+			}
+			walk(child)
+		}
+	}
+	walk(table)
+	if len(rows) == 0 {
+		return ""
+	}
+
+	columns := 0
+	for _, row := range rows {
+		if len(row) > columns {
+			columns = len(row)
+		}
+	}
+
+	var b strings.Builder
+	writeRow := func(cells []string) {
+		for len(cells) < columns {
+			cells = append(cells, "")
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	writeRow(rows[0])
+	separator := make([]string, columns)
+	for i := range separator {
+		separator[i] = "---"
+	}
+	writeRow(separator)
+	for _, row := range rows[1:] {
+		writeRow(row)
+	}
+	return b.String()
+}
+
+// codeLanguage 从 pre 或其中 code 的 class（language-xxx / lang-xxx）读取语言
+func codeLanguage(pre *html.Node) string {
+	nodes := []*html.Node{pre}
+	if code := findElement(pre, atom.Code); code != nil {
+		nodes = append(nodes, code)
+	}
+	for _, n := range nodes {
+		for _, class := range strings.Fields(attr(n, "class")) {
+			for _, prefix := range []string{"language-", "lang-"} {
+				if strings.HasPrefix(class, prefix) {
+					return strings.TrimPrefix(class, prefix)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// textContent 返回节点内的原始文本
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var b strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && child.DataAtom == atom.Br {
+			b.WriteString("\n")
+			continue
+		}
+		b.WriteString(textContent(child))
+	}
+	return b.String()
+}
+
+// findElement 查找第一个指定类型的子孙元素
+func findElement(n *html.Node, a atom.Atom) *html.Node {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && child.DataAtom == a {
+			return child
+		}
+		if found := findElement(child, a); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// attr 读取属性值
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return strings.TrimSpace(a.Val)
+		}
+	}
+	return ""
+}
+
+// titleSuffix 返回链接或图片的 title 部分
+func titleSuffix(n *html.Node) string {
+	title := attr(n, "title")
+	if title == "" {
+		return ""
+	}
+	return ` "` + strings.ReplaceAll(title, `"`, `'`) + `"`
+}
+
+// wrapInline 用标记包裹行内文本，标记放在首尾空白之内
+func wrapInline(text, mark string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	start := strings.Index(text, trimmed)
+	return text[:start] + mark + trimmed + mark + text[start+len(trimmed):]
+}
+
+// normalizeText 合并文本中的空格与制表符（保留换行，WordPress 用空行分段）
+func normalizeText(text string) string {
+	return spacesRegex.ReplaceAllString(text, " ")
+}
+
+// singleLine 将文本合并为一行
+func singleLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
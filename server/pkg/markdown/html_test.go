@@ -0,0 +1,76 @@
+package markdown
+
+import "testing"
+
+func TestFromHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "paragraphs and inline",
+			html: `<p>Hello <strong>bold</strong> and <em>italic</em> with <code>x := 1</code>.</p><p>Second <a href="https://example.com" title="Ex">link</a></p>`,
+			want: "Hello **bold** and *italic* with `x := 1`.\n\nSecond [link](https://example.com \"Ex\")",
+		},
+		{
+			name: "wordpress autop text",
+			html: "First paragraph\nsame paragraph\n\nSecond paragraph",
+			want: "First paragraph\nsame paragraph\n\nSecond paragraph",
+		},
+		{
+			name: "headings and image",
+			html: `<h2>Title</h2><p><img src="/a.png" alt="A"></p>`,
+			want: "## Title\n\n![A](/a.png)",
+		},
+		{
+			name: "nested lists",
+			html: `<ul><li>One</li><li>Two<ol><li>A</li><li>B</li></ol></li></ul>`,
+			want: "- One\n- Two\n  1. A\n  2. B",
+		},
+		{
+			name: "blockquote",
+			html: `<blockquote><p>Quote one</p><p>Quote two</p></blockquote>`,
+			want: "> Quote one\n>\n> Quote two",
+		},
+		{
+			name: "code block",
+			html: `<pre class="wp-block-code"><code class="language-go">func main() {
+	fmt.Println("&lt;hi&gt;")
+}</code></pre>`,
+			want: "```go\nfunc main() {\n\tfmt.Println(\"<hi>\")\n}\n```",
+		},
+		{
+			name: "table",
+			html: `<table><thead><tr><th>Name</th><th>Value</th></tr></thead><tbody><tr><td>a|b</td><td>1</td></tr></tbody></table>`,
+			want: "| Name | Value |\n| --- | --- |\n| a\\|b | 1 |",
+		},
+		{
+			name: "drops scripts and comments",
+			html: `<!-- wp:paragraph --><p>Kept</p><!-- /wp:paragraph --><script>alert(1)</script>`,
+			want: "Kept",
+		},
+		{
+			name: "embeds become links",
+			html: `<iframe src="https://www.youtube.com/embed/x"></iframe>`,
+			want: "[https://www.youtube.com/embed/x](https://www.youtube.com/embed/x)",
+		},
+		{
+			name: "line breaks",
+			html: `<p>Line one<br>Line two</p>`,
+			want: "Line one  \nLine two",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromHTML(tt.html)
+			if err != nil {
+				t.Fatalf("FromHTML() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FromHTML() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Link 正文中的链接或图片引用
+type Link struct {
+	URL   string
+	Text  string // 链接文字或图片的 alt
+	Image bool
+	Line  int // 所在行（从 1 开始）
+}
+
+// linkRefRegex 匹配 [text](url) 与 ![alt](url)，地址可用 <> 包裹，其后可带 "title"
+var linkRefRegex = regexp.MustCompile(`(!?)\[([^\]]*)\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+
+// inlineCodeRegex 匹配行内代码
+var inlineCodeRegex = regexp.MustCompile("`[^`]*`")
+
+// ExtractLinks 按出现顺序提取正文中的链接与图片，代码块与行内代码中的内容不计入
+func ExtractLinks(content string) []Link {
+	var links []Link
+	inCodeBlock := false
+	for i, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if inCodeBlock {
+			continue
+		}
+
+		line = inlineCodeRegex.ReplaceAllString(line, "")
+		for _, m := range linkRefRegex.FindAllStringSubmatch(line, -1) {
+			links = append(links, Link{
+				URL:   m[3],
+				Text:  m[2],
+				Image: m[1] == "!",
+				Line:  i + 1,
+			})
+		}
+	}
+	return links
+}
@@ -0,0 +1,31 @@
+package markdown
+
+import "testing"
+
+func TestExtractLinks(t *testing.T) {
+	content := "Intro [first](/post/hello) and ![logo](/uploads/logo.png \"Logo\").\n" +
+		"\n" +
+		"```\n" +
+		"[ignored](/post/in-code)\n" +
+		"```\n" +
+		"Inline `[skip](/post/inline)` and [wrapped](<https://example.com/a>)\n"
+
+	links := ExtractLinks(content)
+	want := []Link{
+		{URL: "/post/hello", Text: "first", Line: 1},
+		{URL: "/uploads/logo.png", Text: "logo", Image: true, Line: 1},
+		{URL: "https://example.com/a", Text: "wrapped", Line: 6},
+	}
+	if len(links) != len(want) {
+		t.Fatalf("ExtractLinks() = %+v, want %d links", links, len(want))
+	}
+	for i, link := range links {
+		if link != want[i] {
+			t.Errorf("links[%d] = %+v, want %+v", i, link, want[i])
+		}
+	}
+
+	if got := Parse(content).Links; len(got) != len(want) {
+		t.Errorf("Parse().Links = %d, want %d", len(got), len(want))
+	}
+}
@@ -19,6 +19,7 @@ type Result struct {
 	TOC       []*TOCItem
 	Excerpt   string
 	WordCount int
+	Links     []Link // 链接与图片引用
 }
 
 // Parse 解析 Markdown
@@ -36,9 +37,19 @@ func Parse(content string) *Result {
 	// HTML 渲染（简化实现，实际使用 markdown 库）
 	result.HTML = renderToHTML(content)
 
+	// 链接与图片引用（用于站内链接图）
+	result.Links = ExtractLinks(content)
+
 	return result
 }
 
+// RenderSafe 将用户提交的 Markdown（如评论）渲染为可直接嵌入页面的 HTML：
+// 原始 HTML 一律转义，不生成标题锚点，链接只保留 http、https 与 mailto，
+// 并带有 rel="nofollow ugc noopener"
+func RenderSafe(content string) string {
+	return renderHTML(content, true)
+}
+
 // ExtractTOC 从 Markdown 提取目录
 func ExtractTOC(content string) []*TOCItem {
 	var items []*TOCItem
@@ -125,8 +136,23 @@ func generateAnchor(text string) string {
 	return result.String()
 }
 
+// PlainText 提取完整纯文本（不截断，用于搜索索引等场景）
+func PlainText(content string) string {
+	return stripMarkdown(content)
+}
+
 // extractPlainText 提取纯文本
 func extractPlainText(content string, maxLen int) string {
+	content = stripMarkdown(content)
+
+	if len(content) > maxLen {
+		return content[:maxLen] + "..."
+	}
+	return content
+}
+
+// stripMarkdown 移除代码块与 Markdown 标记，并合并空白
+func stripMarkdown(content string) string {
 	// 移除代码块
 	content = removeCodeBlocks(content)
 
@@ -141,12 +167,7 @@ func extractPlainText(content string, maxLen int) string {
 	content = strings.ReplaceAll(content, ")", "")
 
 	// 合并空白
-	content = strings.Join(strings.Fields(content), " ")
-
-	if len(content) > maxLen {
-		return content[:maxLen] + "..."
-	}
-	return content
+	return strings.Join(strings.Fields(content), " ")
 }
 
 // removeCodeBlocks 移除代码块
@@ -190,6 +211,11 @@ func countWords(content string) int {
 
 // renderToHTML 渲染为 HTML（简化实现）
 func renderToHTML(content string) string {
+	return renderHTML(content, false)
+}
+
+// renderHTML 渲染为 HTML，safe 为 true 时按 RenderSafe 的规则处理不可信内容
+func renderHTML(content string, safe bool) string {
 	var result strings.Builder
 	lines := strings.Split(content, "\n")
 	inCodeBlock := false
@@ -228,8 +254,8 @@ func renderToHTML(content string) string {
 			continue
 		}
 
-		// 标题
-		if strings.HasPrefix(trimmed, "#") {
+		// 标题（不可信内容按普通段落处理，避免与页面锚点冲突）
+		if !safe && strings.HasPrefix(trimmed, "#") {
 			level := 0
 			for level < len(trimmed) && trimmed[level] == '#' {
 				level++
@@ -263,7 +289,7 @@ func renderToHTML(content string) string {
 			text := strings.TrimPrefix(trimmed, "- ")
 			text = strings.TrimPrefix(text, "* ")
 			result.WriteString("<li>")
-			result.WriteString(renderInline(escapeHTML(text)))
+			result.WriteString(renderInline(escapeHTML(text), safe))
 			result.WriteString("</li>\n")
 			continue
 		}
@@ -281,7 +307,7 @@ func renderToHTML(content string) string {
 			}
 			text := orderedListRegex.ReplaceAllString(trimmed, "")
 			result.WriteString("<li>")
-			result.WriteString(renderInline(escapeHTML(text)))
+			result.WriteString(renderInline(escapeHTML(text), safe))
 			result.WriteString("</li>\n")
 			continue
 		}
@@ -293,7 +319,7 @@ func renderToHTML(content string) string {
 			listType = ""
 		}
 		result.WriteString("<p>")
-		result.WriteString(renderInline(escapeHTML(trimmed)))
+		result.WriteString(renderInline(escapeHTML(trimmed), safe))
 		result.WriteString("</p>\n")
 
 		// 段落间添加空行（除了最后一行）
@@ -319,8 +345,8 @@ func escapeHTML(text string) string {
 	return text
 }
 
-// renderInline 行内元素渲染（粗体、斜体、代码、链接）
-func renderInline(text string) string {
+// renderInline 行内元素渲染（粗体、斜体、代码、链接），text 需已转义
+func renderInline(text string, safe bool) string {
 	// 粗体 **text**
 	boldRegex := regexp.MustCompile(`\*\*(.+?)\*\*`)
 	text = boldRegex.ReplaceAllString(text, "<strong>$1</strong>")
@@ -335,7 +361,20 @@ func renderInline(text string) string {
 
 	// 链接 [text](url)
 	linkRegex := regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`)
-	text = linkRegex.ReplaceAllString(text, `<a href="$2">$1</a>`)
+	if !safe {
+		return linkRegex.ReplaceAllString(text, `<a href="$2">$1</a>`)
+	}
+	return linkRegex.ReplaceAllStringFunc(text, func(link string) string {
+		m := linkRegex.FindStringSubmatch(link)
+		if !safeLinkURL(m[2]) {
+			return m[1]
+		}
+		return `<a href="` + strings.TrimSpace(m[2]) + `" rel="nofollow ugc noopener">` + m[1] + `</a>`
+	})
+}
 
-	return text
+// safeLinkURL 判断链接地址是否可以出现在不可信内容中
+func safeLinkURL(url string) bool {
+	url = strings.ToLower(strings.TrimSpace(url))
+	return strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://") || strings.HasPrefix(url, "mailto:")
 }
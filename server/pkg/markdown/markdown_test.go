@@ -190,6 +190,36 @@ func TestRenderToHTML(t *testing.T) {
 	})
 }
 
+func TestRenderSafe(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		notWant string
+	}{
+		{"escape html", "<img src=x onerror=alert(1)>", "&lt;img src=x onerror=alert(1)&gt;", "<img"},
+		{"http link", "[site](https://example.com)", `<a href="https://example.com" rel="nofollow ugc noopener">site</a>`, ""},
+		{"mailto link", "[mail](mailto:a@example.com)", `href="mailto:a@example.com"`, ""},
+		{"javascript link", "[click](javascript:alert%281%29)", "<p>click</p>", "<a"},
+		{"data link", "[click](DATA:text/html;base64,xx)", "<p>click</p>", "href"},
+		{"quote in link", `[x](https://e.com/" onmouseover="alert(1))`, "&quot; onmouseover=&quot;", `" onmouseover="`},
+		{"no heading anchors", "# Title", "<p># Title</p>", "<h1"},
+		{"inline markup", "**bold** and `code`", "<strong>bold</strong> and <code>code</code>", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html := RenderSafe(tt.content)
+			if !strings.Contains(html, tt.want) {
+				t.Errorf("RenderSafe(%q) = %q, should contain %q", tt.content, html, tt.want)
+			}
+			if tt.notWant != "" && strings.Contains(html, tt.notWant) {
+				t.Errorf("RenderSafe(%q) = %q, should not contain %q", tt.content, html, tt.notWant)
+			}
+		})
+	}
+}
+
 func TestParse(t *testing.T) {
 	content := `# Hello World
 